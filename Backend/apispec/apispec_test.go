@@ -0,0 +1,96 @@
+package apispec
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testDoc = `{
+  "paths": {
+    "/api/v1/stocks/{id}/notes": {
+      "post": {
+        "parameters": [
+          {"name": "id", "in": "path", "type": "integer", "required": true},
+          {"name": "format", "in": "query", "type": "string", "enum": ["csv", "xlsx"]},
+          {"name": "request", "in": "body", "required": true, "schema": {"$ref": "#/definitions/validators.NoteRequest"}}
+        ],
+        "responses": {
+          "201": {"schema": {"type": "object", "additionalProperties": true}},
+          "400": {"schema": {"type": "object", "additionalProperties": true}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "validators.NoteRequest": {
+      "type": "object",
+      "required": ["body"],
+      "properties": {
+        "body": {"type": "string"},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+// TestValidateRequest checks parameter and body validation of a documented route
+func TestValidateRequest(t *testing.T) {
+	spec, err := Load([]byte(testDoc))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	op := spec.Operation("POST", "/api/v1/stocks/:id/notes")
+	if op == nil {
+		t.Fatal("Operation() = nil, want the documented route")
+	}
+	if spec.Operation("GET", "/api/v1/stocks/:id/notes") != nil {
+		t.Error("Operation() found an undocumented method")
+	}
+
+	testCases := []struct {
+		name   string
+		target string
+		id     string
+		body   string
+		want   []string
+	}{
+		{name: "valid", target: "/?format=csv", id: "7", body: `{"body": "hi", "tags": ["a"]}`},
+		{name: "bad path and query", target: "/?format=pdf", id: "x", body: `{"body": "hi"}`, want: []string{"path parameter id", "query parameter format"}},
+		{name: "missing body", target: "/", id: "7", want: []string{"request body is required"}},
+		{name: "bad body", target: "/", id: "7", body: `{"tags": [1]}`, want: []string{"body.body is required", "body.tags[0] must be a string"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := op.ValidateRequest(httptest.NewRequest("POST", tc.target, nil), map[string]string{"id": tc.id}, []byte(tc.body))
+			if len(violations) != len(tc.want) {
+				t.Fatalf("ValidateRequest() = %v, want %d violations", violations, len(tc.want))
+			}
+			for i, want := range tc.want {
+				if !strings.Contains(violations[i], want) {
+					t.Errorf("violation %d = %q, want it to mention %q", i, violations[i], want)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateResponse checks documented status codes and JSON bodies
+func TestValidateResponse(t *testing.T) {
+	spec, err := Load([]byte(testDoc))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	op := spec.Operation("POST", "/api/v1/stocks/:id/notes")
+
+	if violations := op.ValidateResponse(201, "application/json; charset=utf-8", []byte(`{"data": {}}`)); len(violations) != 0 {
+		t.Errorf("ValidateResponse(201) = %v, want none", violations)
+	}
+	if violations := op.ValidateResponse(404, "application/json", []byte(`{}`)); len(violations) != 1 || !strings.Contains(violations[0], "not documented") {
+		t.Errorf("ValidateResponse(404) = %v, want undocumented status", violations)
+	}
+	if violations := op.ValidateResponse(400, "text/plain", []byte(`oops`)); len(violations) != 1 {
+		t.Errorf("ValidateResponse(400, text/plain) = %v, want a content type violation", violations)
+	}
+}
@@ -0,0 +1,95 @@
+// Package apispec checks HTTP traffic against the Swagger 2.0 document generated by swag, so drift
+// between the swagger annotations and what the handlers actually accept and return shows up while
+// developing. It covers the subset of the format swag emits: typed path/query/header parameters,
+// JSON bodies described by definitions, and the documented status codes of each operation.
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Spec is a decoded Swagger 2.0 document
+type Spec struct {
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions"`
+}
+
+// Operation is one documented method of a path
+type Operation struct {
+	Summary    string              `json:"summary"`
+	Parameters []Parameter         `json:"parameters"`
+	Responses  map[string]Response `json:"responses"`
+
+	spec *Spec
+}
+
+// Parameter is a path, query, header or body parameter
+type Parameter struct {
+	Name     string   `json:"name"`
+	In       string   `json:"in"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum"`
+	Items    *Schema  `json:"items"`
+	Schema   *Schema  `json:"schema"`
+}
+
+// Response is a documented response of an operation
+type Response struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of a Swagger schema object used by the generated document
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Items      *Schema            `json:"items"`
+	Enum       []string           `json:"enum"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+}
+
+// Load decodes a Swagger 2.0 JSON document
+func Load(doc []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI document: %w", err)
+	}
+	for _, methods := range spec.Paths {
+		for _, op := range methods {
+			op.spec = &spec
+		}
+	}
+	return &spec, nil
+}
+
+// Operation returns the documented operation of a gin route (e.g. GET /api/v1/stocks/:id), or
+// nil when the route is not in the document
+func (s *Spec) Operation(method, route string) *Operation {
+	methods, ok := s.Paths[specPath(route)]
+	if !ok {
+		return nil
+	}
+	return methods[strings.ToLower(method)]
+}
+
+// specPath converts gin path parameters (:id, *path) to the document form ({id})
+func specPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// resolve follows a definition reference
+func (s *Spec) resolve(schema *Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	return s.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+}
@@ -0,0 +1,203 @@
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidateRequest checks the parameters and JSON body of a request against the operation and
+// returns one message per violation. pathParams are the values of the route's path parameters.
+func (op *Operation) ValidateRequest(r *http.Request, pathParams map[string]string, body []byte) []string {
+	var violations []string
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			violations = append(violations, checkParameter(p, []string{pathParams[p.Name]})...)
+		case "query":
+			violations = append(violations, checkParameter(p, query[p.Name])...)
+		case "header":
+			violations = append(violations, checkParameter(p, r.Header.Values(p.Name))...)
+		case "body":
+			violations = append(violations, op.checkBody(p, body)...)
+		}
+	}
+	return violations
+}
+
+// ValidateResponse checks that the status is documented for the operation and that a documented
+// JSON body is a JSON document of the documented shape
+func (op *Operation) ValidateResponse(status int, contentType string, body []byte) []string {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	switch {
+	case !ok && status == http.StatusNotModified:
+		// Conditional GETs answer 304 on any cacheable route
+		return nil
+	case !ok && (status == http.StatusUnauthorized || status == http.StatusForbidden):
+		// Role checks can reject a request on any API route
+		return nil
+	case !ok:
+		return []string{fmt.Sprintf("response status %d is not documented (documented: %s)", status, strings.Join(op.statuses(), ", "))}
+	case response.Schema == nil || response.Schema.Type == "file" || len(body) == 0:
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "application/json" {
+		return []string{fmt.Sprintf("response status %d has content type %q, documented as JSON", status, contentType)}
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("response status %d body is not valid JSON: %v", status, err)}
+	}
+	return op.spec.checkValue("response", op.spec.resolve(response.Schema), value)
+}
+
+// statuses lists the documented status codes of the operation
+func (op *Operation) statuses() []string {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// checkParameter checks the values of a non-body parameter
+func checkParameter(p Parameter, values []string) []string {
+	if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+		if p.Required {
+			return []string{fmt.Sprintf("%s parameter %s is required", p.In, p.Name)}
+		}
+		return nil
+	}
+
+	itemType, enum := p.Type, p.Enum
+	if p.Type == "array" && p.Items != nil {
+		itemType, enum = p.Items.Type, p.Items.Enum
+	} else {
+		values = values[:1]
+	}
+	var violations []string
+	for _, value := range values {
+		if msg := checkScalar(itemType, enum, value); msg != "" {
+			violations = append(violations, fmt.Sprintf("%s parameter %s: %s", p.In, p.Name, msg))
+		}
+	}
+	return violations
+}
+
+// checkScalar checks a string-encoded parameter value against its type and enumeration
+func checkScalar(typ string, enum []string, value string) string {
+	switch typ {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Sprintf("%q is not an integer", value)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Sprintf("%q is not a boolean", value)
+		}
+	}
+	if len(enum) > 0 && !contains(enum, value) {
+		return fmt.Sprintf("%q is not one of %v", value, enum)
+	}
+	return ""
+}
+
+// checkBody checks a JSON request body against the body parameter's schema
+func (op *Operation) checkBody(p Parameter, body []byte) []string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if p.Required {
+			return []string{"request body is required"}
+		}
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("request body is not valid JSON: %v", err)}
+	}
+	return op.spec.checkValue("body", op.spec.resolve(p.Schema), value)
+}
+
+// checkValue checks a decoded JSON value against a schema; path locates the value in messages
+func (s *Spec) checkValue(path string, schema *Schema, value interface{}) []string {
+	if schema == nil || value == nil {
+		return nil
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object", path)}
+		}
+		var violations []string
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				violations = append(violations, fmt.Sprintf("%s.%s is required", path, name))
+			}
+		}
+		for name, property := range schema.Properties {
+			if field, ok := object[name]; ok {
+				violations = append(violations, s.checkValue(path+"."+name, s.resolve(property), field)...)
+			}
+		}
+		sort.Strings(violations)
+		return violations
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an array", path)}
+		}
+		var violations []string
+		for i, item := range items {
+			violations = append(violations, s.checkValue(fmt.Sprintf("%s[%d]", path, i), s.resolve(schema.Items), item)...)
+		}
+		return violations
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be a string", path)}
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, text) {
+			return []string{fmt.Sprintf("%s: %q is not one of %v", path, text, schema.Enum)}
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return []string{fmt.Sprintf("%s must be an integer", path)}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("%s must be a number", path)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("%s must be a boolean", path)}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
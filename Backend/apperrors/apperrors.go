@@ -0,0 +1,187 @@
+// Package apperrors defines the application's typed errors and their HTTP status mapping.
+// Layers return failures wrapped with a Kind (NotFound, Validation, ...) up the call chain, and
+// the HTTP layer maps them to a status code in one place without inspecting error messages.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// Kind classifies an application error
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindValidation
+	KindConflict
+	KindUpstream
+	KindUnauthorized
+	KindTooManyRequests
+	KindUnavailable
+	KindPreconditionFailed
+)
+
+// Sentinels for errors.Is checks against a kind, e.g. errors.Is(err, apperrors.ErrNotFound)
+var (
+	ErrInternal           = &Error{Kind: KindInternal}
+	ErrNotFound           = &Error{Kind: KindNotFound}
+	ErrValidation         = &Error{Kind: KindValidation}
+	ErrConflict           = &Error{Kind: KindConflict}
+	ErrUpstream           = &Error{Kind: KindUpstream}
+	ErrUnauthorized       = &Error{Kind: KindUnauthorized}
+	ErrTooManyRequests    = &Error{Kind: KindTooManyRequests}
+	ErrUnavailable        = &Error{Kind: KindUnavailable}
+	ErrPreconditionFailed = &Error{Kind: KindPreconditionFailed}
+)
+
+// Error is an application error carrying a Kind, a message and an optional cause
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+}
+
+// Error returns the message followed by the cause, if any
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the kind sentinels (errors with neither message nor cause)
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Err == nil && t.Kind == e.Kind
+}
+
+// New creates an error of the given kind
+func New(kind Kind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound creates a KindNotFound error
+func NotFound(format string, args ...interface{}) error {
+	return New(KindNotFound, format, args...)
+}
+
+// Validation creates a KindValidation error
+func Validation(format string, args ...interface{}) error {
+	return New(KindValidation, format, args...)
+}
+
+// Conflict creates a KindConflict error
+func Conflict(format string, args ...interface{}) error {
+	return New(KindConflict, format, args...)
+}
+
+// Upstream creates a KindUpstream error
+func Upstream(format string, args ...interface{}) error {
+	return New(KindUpstream, format, args...)
+}
+
+// PreconditionFailed creates a KindPreconditionFailed error
+func PreconditionFailed(format string, args ...interface{}) error {
+	return New(KindPreconditionFailed, format, args...)
+}
+
+// Internal creates a KindInternal error
+func Internal(format string, args ...interface{}) error {
+	return New(KindInternal, format, args...)
+}
+
+// Wrap adds message to err, keeping its kind; it returns nil when err is nil
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: KindOf(err), Message: message, Err: err}
+}
+
+// WrapAs adds message to err and sets its kind; it returns nil when err is nil
+func WrapAs(err error, kind Kind, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// KindOf classifies err: typed errors report their kind, well-known GORM and validator errors
+// are mapped, and any other error is internal
+func KindOf(err error) Kind {
+	var appErr *Error
+	var validationErrs validator.ValidationErrors
+	switch {
+	case err == nil:
+		return KindInternal
+	case errors.As(err, &appErr):
+		return appErr.Kind
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return KindNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return KindConflict
+	case errors.Is(err, gorm.ErrInvalidData), errors.Is(err, gorm.ErrInvalidTransaction), errors.As(err, &validationErrs):
+		return KindValidation
+	}
+	return KindInternal
+}
+
+// HTTPStatus returns the HTTP status code for err
+func HTTPStatus(err error) int {
+	switch KindOf(err) {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindConflict:
+		return http.StatusConflict
+	case KindUpstream:
+		return http.StatusBadGateway
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusInternalServerError
+}
+
+// Title returns the short error label used in the "error" field of responses
+func Title(err error) string {
+	switch KindOf(err) {
+	case KindNotFound:
+		return "Resource not found"
+	case KindValidation:
+		return "Invalid request"
+	case KindConflict:
+		return "Conflict"
+	case KindUpstream:
+		return "Upstream service error"
+	case KindUnauthorized:
+		return "Unauthorized"
+	case KindTooManyRequests:
+		return "Too many requests"
+	case KindUnavailable:
+		return "Service unavailable"
+	case KindPreconditionFailed:
+		return "Precondition failed"
+	}
+	return "Internal server error"
+}
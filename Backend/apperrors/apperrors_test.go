@@ -0,0 +1,54 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// TestHTTPStatus checks kind detection through wrapping; untyped errors are internal whatever
+// their message says
+func TestHTTPStatus(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "typed not found", err: NotFound("stock with ID %d not found", 7), want: http.StatusNotFound},
+		{name: "wrapped keeps kind", err: Wrap(fmt.Errorf("lookup: %w", Conflict("ticker exists")), "failed to create stock"), want: http.StatusConflict},
+		{name: "wrap as overrides", err: WrapAs(errors.New("boom"), KindUpstream, "failed to fetch"), want: http.StatusBadGateway},
+		{name: "too many requests", err: fmt.Errorf("extract: %w", New(KindTooManyRequests, "daily quota exhausted")), want: http.StatusTooManyRequests},
+		{name: "unavailable", err: Wrap(New(KindUnavailable, "database is not connected"), "failed to list stocks"), want: http.StatusServiceUnavailable},
+		{name: "precondition failed", err: Wrap(PreconditionFailed("stock 7 was modified"), "failed to update stock"), want: http.StatusPreconditionFailed},
+		{name: "gorm not found", err: fmt.Errorf("query: %w", gorm.ErrRecordNotFound), want: http.StatusNotFound},
+		{name: "gorm duplicated key", err: fmt.Errorf("create: %w", gorm.ErrDuplicatedKey), want: http.StatusConflict},
+		{name: "untyped invalid message", err: errors.New("invalid sort column: foo"), want: http.StatusInternalServerError},
+		{name: "untyped not found message", err: errors.New("stock not found"), want: http.StatusInternalServerError},
+		{name: "untyped other", err: errors.New("connection reset"), want: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HTTPStatus(tc.err); got != tc.want {
+				t.Errorf("HTTPStatus(%v) = %d, want %d", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestSentinels checks errors.Is against the kind sentinels
+func TestSentinels(t *testing.T) {
+	err := Wrap(NotFound("note %d not found", 3), "failed to update note")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected %v to match ErrNotFound", err)
+	}
+	if errors.Is(err, ErrValidation) {
+		t.Errorf("did not expect %v to match ErrValidation", err)
+	}
+	if got := err.Error(); got != "failed to update note: note 3 not found" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"dataextractor/models"
+)
+
+// Roles, from least to most privileged; each role is granted everything the ones before it are
+const (
+	RoleReader = "reader" // read endpoints
+	RoleWriter = "writer" // creating, updating and deleting records
+	RoleAdmin  = "admin"  // operations that wipe, bulk-load or extract the database
+)
+
+// roleRanks orders the roles; unknown roles rank 0 and satisfy nothing
+var roleRanks = map[string]int{RoleReader: 1, RoleWriter: 2, RoleAdmin: 3}
+
+// IsRole reports whether role is one of the known roles
+func IsRole(role string) bool {
+	return roleRanks[role] > 0
+}
+
+// HasRole reports whether a caller with role is granted required
+func HasRole(role, required string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[required]
+}
+
+// Credential errors
+var (
+	ErrUnsupportedAuthorization = errors.New("unsupported Authorization scheme, expected Bearer")
+	ErrUnknownAPIKey            = errors.New("unknown API key")
+)
+
+// Authenticate checks the Authorization header value (a bearer token signed with jwtSecret) or
+// else the API key against apiKeys (key to role). Both results are empty when the request carries
+// neither, or when the matching mechanism is not configured.
+func Authenticate(authorization, apiKey, jwtSecret string, apiKeys map[string]string, now time.Time) (actor, role string, err error) {
+	if authorization != "" && jwtSecret != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok {
+			return "", "", ErrUnsupportedAuthorization
+		}
+		claims, err := Verify(strings.TrimSpace(token), jwtSecret, now)
+		if err != nil {
+			return "", "", err
+		}
+		return claims.Subject, claims.Role, nil
+	}
+
+	if key := strings.TrimSpace(apiKey); key != "" && len(apiKeys) > 0 {
+		for candidate, role := range apiKeys {
+			if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+				// Identify the key by a fingerprint prefix so the key itself never reaches created_by
+				return "apikey:" + models.APIKeyFingerprint(key)[:12], role, nil
+			}
+		}
+		return "", "", ErrUnknownAPIKey
+	}
+	return "", "", nil
+}
@@ -0,0 +1,111 @@
+// Package auth verifies the credentials API callers authenticate with and ranks their roles.
+// Tokens are HS256-signed JWTs whose sub claim names the caller and whose role claim carries the
+// caller's role; API keys map to a role in the configuration.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Token verification errors
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported token algorithm, expected HS256")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotYetValid = errors.New("token not valid yet")
+)
+
+// clockSkew tolerates small clock differences with the token issuer on exp and nbf
+const clockSkew = 30 * time.Second
+
+// Claims are the token claims the API uses
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Sign issues an HS256 token for claims (used by tests and tooling that mint tokens)
+func Sign(claims Claims, secret string) (string, error) {
+	head, err := json.Marshal(header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encode(head) + "." + encode(payload)
+	return signingInput + "." + encode(signature(signingInput, secret)), nil
+}
+
+// Verify checks the signature and validity window of an HS256 token at now and returns its claims
+func Verify(token, secret string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+
+	var head header
+	if err := decode(parts[0], &head); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if head.Alg != "HS256" {
+		return Claims{}, ErrUnsupportedAlg
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if !hmac.Equal(sig, signature(parts[0]+"."+parts[1], secret)) {
+		return Claims{}, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decode(parts[1], &claims); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if claims.ExpiresAt != 0 && now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return Claims{}, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return Claims{}, ErrTokenNotYetValid
+	}
+	return claims, nil
+}
+
+// signature computes the HMAC-SHA256 of the signing input
+func signature(signingInput, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// encode base64url-encodes a token segment without padding
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decode unmarshals a base64url-encoded JSON token segment
+func decode(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
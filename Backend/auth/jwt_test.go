@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestVerify checks signature, algorithm and validity window handling
+func TestVerify(t *testing.T) {
+	const secret = "test-secret"
+	now := time.Unix(1_700_000_000, 0)
+
+	valid, err := Sign(Claims{Subject: "alice", Role: "writer", ExpiresAt: now.Add(time.Hour).Unix()}, secret)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	expired, _ := Sign(Claims{Subject: "alice", ExpiresAt: now.Add(-time.Hour).Unix()}, secret)
+	early, _ := Sign(Claims{Subject: "alice", NotBefore: now.Add(time.Hour).Unix()}, secret)
+	parts := strings.Split(valid, ".")
+	unsigned := encode([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+
+	claims, err := Verify(valid, secret, now)
+	if err != nil {
+		t.Fatalf("Verify(valid) error = %v", err)
+	}
+	if claims.Subject != "alice" || claims.Role != "writer" {
+		t.Errorf("Verify(valid) = %+v", claims)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		secret string
+		want   error
+	}{
+		{"wrong secret", valid, "other", ErrInvalidSignature},
+		{"tampered payload", parts[0] + "." + encode([]byte(`{"sub":"mallory","role":"admin"}`)) + "." + parts[2], secret, ErrInvalidSignature},
+		{"expired", expired, secret, ErrTokenExpired},
+		{"not yet valid", early, secret, ErrTokenNotYetValid},
+		{"alg none", unsigned, secret, ErrUnsupportedAlg},
+		{"two segments", parts[0] + "." + parts[1], secret, ErrMalformedToken},
+	}
+	for _, tt := range tests {
+		if _, err := Verify(tt.token, tt.secret, now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Verify() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package auth
+
+import "strings"
+
+// AccessPolicy is the minimum role required to read and to write in a route group
+type AccessPolicy struct {
+	Read  string
+	Write string
+}
+
+// defaultAccessPolicy applies to route groups without an entry in AUTH_GROUP_ROLES
+var defaultAccessPolicy = AccessPolicy{Read: RoleReader, Write: RoleWriter}
+
+// AccessPolicies builds the per-group policies from AUTH_GROUP_ROLES ("read:write" by group name,
+// or a single role for both), on top of the built-in ones in defaults. Entries naming unknown roles
+// are ignored.
+func AccessPolicies(groupRoles map[string]string, defaults map[string]AccessPolicy) map[string]AccessPolicy {
+	policies := make(map[string]AccessPolicy, len(defaults)+len(groupRoles))
+	for group, policy := range defaults {
+		policies[group] = policy
+	}
+	for group, roles := range groupRoles {
+		read, write, found := strings.Cut(roles, ":")
+		if !found {
+			write = read
+		}
+		read, write = strings.TrimSpace(read), strings.TrimSpace(write)
+		if !IsRole(read) || !IsRole(write) {
+			continue
+		}
+		policies[group] = AccessPolicy{Read: read, Write: write}
+	}
+	return policies
+}
+
+// PolicyFor returns the policy of group, reader:writer when it has none
+func PolicyFor(policies map[string]AccessPolicy, group string) AccessPolicy {
+	if policy, ok := policies[group]; ok {
+		return policy
+	}
+	return defaultAccessPolicy
+}
@@ -0,0 +1,157 @@
+// Package client is a Go client of the Stock Data Extractor API. The operations and request
+// models in client_gen.go are generated from the OpenAPI document by cmd/sdkgen; this file holds
+// the transport they share.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Response is the decoded JSON envelope of an API response ({"data", "count", "message", ...})
+type Response map[string]interface{}
+
+// APIError is a non-2xx response; Title and Details come from the {"error", "details"} envelope
+type APIError struct {
+	StatusCode int
+	Title      string `json:"error"`
+	Details    string `json:"details"`
+}
+
+// Error returns the status code with the error envelope
+func (e *APIError) Error() string {
+	if e.Details != "" {
+		return fmt.Sprintf("API request failed with status %d: %s: %s", e.StatusCode, e.Title, e.Details)
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Title)
+}
+
+// Client calls the API at BaseURL
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Header is sent with every request (e.g. Authorization or X-API-Key credentials, X-Actor)
+	Header http.Header
+}
+
+// New creates a client for the API at baseURL (e.g. http://localhost:8887)
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Header:     http.Header{},
+	}
+}
+
+// File is a file uploaded in a multipart/form-data request
+type File struct {
+	Name    string
+	Content io.Reader
+}
+
+// formFiles are the file fields of a multipart/form-data request body
+type formFiles map[string]File
+
+// encode writes the files, in field name order, as a multipart/form-data body
+func (f formFiles) encode() (io.Reader, string, error) {
+	fields := make([]string, 0, len(f))
+	for field := range f {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, field := range fields {
+		part, err := writer.CreateFormFile(field, f[field].Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, f[field].Content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, writer.FormDataContentType(), nil
+}
+
+// do sends a request and decodes the response into out (*Response or *[]byte)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body interface{}, out interface{}) error {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	contentType := ""
+	switch body := body.(type) {
+	case nil:
+	case formFiles:
+		payload, formType, err := body.encode()
+		if err != nil {
+			return fmt.Errorf("failed to encode request form: %w", err)
+		}
+		reader, contentType = payload, formType
+	default:
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader, contentType = bytes.NewReader(payload), "application/json"
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	for _, h := range []http.Header{c.Header, header} {
+		for key, values := range h {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Title == "" {
+			apiErr.Title = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	switch out := out.(type) {
+	case *[]byte:
+		*out, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+	case *Response:
+		if resp.StatusCode == http.StatusNoContent {
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
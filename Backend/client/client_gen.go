@@ -0,0 +1,2060 @@
+// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BatchCreateResult is a request model of the API
+type BatchCreateResult struct {
+	Created *int              `json:"created,omitempty"`
+	Failed  *int              `json:"failed,omitempty"`
+	Results []BatchItemResult `json:"results,omitempty"`
+}
+
+// BatchItemResult is a request model of the API
+type BatchItemResult struct {
+	Data    *StockDataPoint `json:"data,omitempty"`
+	Error   *string         `json:"error,omitempty"`
+	Index   *int            `json:"index,omitempty"`
+	Success *bool           `json:"success,omitempty"`
+}
+
+// BulkClusterAssignmentRequest is a request model of the API
+type BulkClusterAssignmentRequest struct {
+	Cluster int      `json:"cluster"`
+	Reason  string   `json:"reason"`
+	Tickers []string `json:"tickers"`
+}
+
+// ClusterAssignmentRequest is a request model of the API
+type ClusterAssignmentRequest struct {
+	Cluster int    `json:"cluster"`
+	Reason  string `json:"reason"`
+}
+
+// DataMeta is a request model of the API
+type DataMeta struct {
+	DataAsOf        *string  `json:"data_as_of,omitempty"`
+	DatasetVersion  *int     `json:"dataset_version,omitempty"`
+	LastExtractedAt *string  `json:"last_extracted_at,omitempty"`
+	LastImportedAt  *string  `json:"last_imported_at,omitempty"`
+	Stale           *bool    `json:"stale,omitempty"`
+	StaleAfterHours *float64 `json:"stale_after_hours,omitempty"`
+	StalenessHours  *float64 `json:"staleness_hours,omitempty"`
+	TotalRows       *int     `json:"total_rows,omitempty"`
+}
+
+// DatabaseStats is a request model of the API
+type DatabaseStats struct {
+	ClusterCounts   map[string]interface{} `json:"cluster_counts,omitempty"`
+	TotalRecords    *int                   `json:"total_records,omitempty"`
+	UniqueCompanies *int                   `json:"unique_companies,omitempty"`
+	UniqueTickers   *int                   `json:"unique_tickers,omitempty"`
+}
+
+// DefaultWeightProfile is a request model of the API
+type DefaultWeightProfile struct {
+	DefaultWeight *float64               `json:"default_weight,omitempty"`
+	Weights       map[string]interface{} `json:"weights,omitempty"`
+}
+
+// FilterRequest is a request model of the API
+type FilterRequest struct {
+	Aggregate        *bool           `json:"aggregate,omitempty"`
+	Contributions    *bool           `json:"contributions,omitempty"`
+	GroupingColumn   *string         `json:"grouping_column,omitempty"`
+	GroupingValue    *string         `json:"grouping_value,omitempty"`
+	MaxFinalScore    *float64        `json:"max_final_score,omitempty"`
+	MaxLastClose     *float64        `json:"max_last_close,omitempty"`
+	MaxTargetDelta   *float64        `json:"max_target_delta,omitempty"`
+	MaxTargetFrom    *float64        `json:"max_target_from,omitempty"`
+	MaxTargetTo      *float64        `json:"max_target_to,omitempty"`
+	MinFinalScore    *float64        `json:"min_final_score,omitempty"`
+	MinLastClose     *float64        `json:"min_last_close,omitempty"`
+	MinTargetDelta   *float64        `json:"min_target_delta,omitempty"`
+	MinTargetFrom    *float64        `json:"min_target_from,omitempty"`
+	MinTargetTo      *float64        `json:"min_target_to,omitempty"`
+	NumericalWeights []WeightRequest `json:"numerical_weights,omitempty"`
+	Order            *string         `json:"order,omitempty"`
+	Page             *int            `json:"page,omitempty"`
+	PerPage          *int            `json:"per_page,omitempty"`
+	RatingWeights    []WeightRequest `json:"rating_weights,omitempty"`
+	Relations        *string         `json:"relations,omitempty"`
+	SortBy           *string         `json:"sort_by,omitempty"`
+	Tags             []string        `json:"tags,omitempty"`
+}
+
+// GraphQLRequest is a request model of the API
+type GraphQLRequest struct {
+	OperationName *string                `json:"operationName,omitempty"`
+	Query         *string                `json:"query,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Note is a request model of the API
+type Note struct {
+	Author    *string `json:"author,omitempty"`
+	Body      *string `json:"body,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	ID        *int    `json:"id,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+}
+
+// NoteRequest is a request model of the API
+type NoteRequest struct {
+	Author *string `json:"author,omitempty"`
+	Body   string  `json:"body"`
+}
+
+// NotificationTestRequest is a request model of the API
+type NotificationTestRequest struct {
+	Channel *string `json:"channel,omitempty"`
+}
+
+// NumericalIndicator is a request model of the API
+type NumericalIndicator struct {
+	CreatedAt *string  `json:"created_at,omitempty"`
+	CreatedBy *string  `json:"created_by,omitempty"`
+	Name      *string  `json:"name,omitempty"`
+	NormValue *float64 `json:"norm_value,omitempty"`
+	UpdatedAt *string  `json:"updated_at,omitempty"`
+	UpdatedBy *string  `json:"updated_by,omitempty"`
+	UUID      *string  `json:"uuid,omitempty"`
+	Value     *float64 `json:"value,omitempty"`
+}
+
+// NumericalIndicatorRequest is a request model of the API
+type NumericalIndicatorRequest struct {
+	Name      string  `json:"name"`
+	NormValue float64 `json:"norm_value"`
+	Value     float64 `json:"value"`
+}
+
+// NumericalIndicatorUpdateRequest is a request model of the API
+type NumericalIndicatorUpdateRequest struct {
+	NormValue float64 `json:"norm_value"`
+	Value     float64 `json:"value"`
+}
+
+// PreferencesRequest is a request model of the API
+type PreferencesRequest struct {
+	DefaultCluster   *int            `json:"default_cluster,omitempty"`
+	NumericalWeights []WeightRequest `json:"numerical_weights,omitempty"`
+	RatingWeights    []WeightRequest `json:"rating_weights,omitempty"`
+}
+
+// RatingRubricRequest is a request model of the API
+type RatingRubricRequest struct {
+	Kind      string  `json:"kind"`
+	NormScore float64 `json:"norm_score"`
+	Score     float64 `json:"score"`
+	Term      string  `json:"term"`
+}
+
+// RatingSentiment is a request model of the API
+type RatingSentiment struct {
+	CreatedAt       *string  `json:"created_at,omitempty"`
+	CreatedBy       *string  `json:"created_by,omitempty"`
+	Name            *string  `json:"name,omitempty"`
+	NormRatingScore *float64 `json:"norm_rating_score,omitempty"`
+	Rating          *string  `json:"rating,omitempty"`
+	RatingScore     *float64 `json:"rating_score,omitempty"`
+	UpdatedAt       *string  `json:"updated_at,omitempty"`
+	UpdatedBy       *string  `json:"updated_by,omitempty"`
+	UUID            *string  `json:"uuid,omitempty"`
+}
+
+// RatingSentimentRequest is a request model of the API
+type RatingSentimentRequest struct {
+	Name            string  `json:"name"`
+	NormRatingScore float64 `json:"norm_rating_score"`
+	Rating          string  `json:"rating"`
+	RatingScore     float64 `json:"rating_score"`
+}
+
+// RatingSentimentUpdateRequest is a request model of the API
+type RatingSentimentUpdateRequest struct {
+	NormRatingScore float64 `json:"norm_rating_score"`
+	Rating          string  `json:"rating"`
+	RatingScore     float64 `json:"rating_score"`
+}
+
+// ScoringConfigDocument is a request model of the API
+type ScoringConfigDocument struct {
+	DefaultWeights *DefaultWeightProfile  `json:"default_weights,omitempty"`
+	ExportedAt     *string                `json:"exported_at,omitempty"`
+	Indicators     *ScoringIndicators     `json:"indicators,omitempty"`
+	RatingRubric   []RatingRubricRequest  `json:"rating_rubric,omitempty"`
+	Version        *int                   `json:"version,omitempty"`
+	WeightProfiles []WeightProfileRequest `json:"weight_profiles,omitempty"`
+}
+
+// ScoringConfigRequest is a request model of the API
+type ScoringConfigRequest struct {
+	Indicators     *ScoringIndicators     `json:"indicators,omitempty"`
+	RatingRubric   []RatingRubricRequest  `json:"rating_rubric,omitempty"`
+	Version        int                    `json:"version"`
+	WeightProfiles []WeightProfileRequest `json:"weight_profiles,omitempty"`
+}
+
+// ScoringIndicators is a request model of the API
+type ScoringIndicators struct {
+	Numerical []string `json:"numerical,omitempty"`
+	Rating    []string `json:"rating,omitempty"`
+}
+
+// StockCreateRequest is a request model of the API
+type StockCreateRequest struct {
+	Action              *string                     `json:"action,omitempty"`
+	Brokerage           *string                     `json:"brokerage,omitempty"`
+	Cluster             *int                        `json:"cluster,omitempty"`
+	Company             string                      `json:"company"`
+	Date                string                      `json:"date"`
+	LastClose           *float64                    `json:"last_close,omitempty"`
+	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators,omitempty"`
+	RatingFrom          *string                     `json:"rating_from,omitempty"`
+	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments,omitempty"`
+	RatingTo            *string                     `json:"rating_to,omitempty"`
+	TargetDelta         *float64                    `json:"target_delta,omitempty"`
+	TargetFrom          *float64                    `json:"target_from,omitempty"`
+	TargetTo            *float64                    `json:"target_to,omitempty"`
+	Ticker              string                      `json:"ticker"`
+}
+
+// StockDataPoint is a request model of the API
+type StockDataPoint struct {
+	Action              *string                `json:"action,omitempty"`
+	Brokerage           *string                `json:"brokerage,omitempty"`
+	Cluster             *int                   `json:"cluster,omitempty"`
+	Company             *string                `json:"company,omitempty"`
+	Contributions       map[string]interface{} `json:"contributions,omitempty"`
+	CreatedAt           *string                `json:"created_at,omitempty"`
+	CreatedBy           *string                `json:"created_by,omitempty"`
+	DatasetVersionID    *int                   `json:"dataset_version_id,omitempty"`
+	Date                *string                `json:"date,omitempty"`
+	FinalScore          *float64               `json:"final_score,omitempty"`
+	LastClose           *float64               `json:"last_close,omitempty"`
+	Notes               []Note                 `json:"notes,omitempty"`
+	NumericalIndicators []NumericalIndicator   `json:"numerical_indicators,omitempty"`
+	RatingFrom          *string                `json:"rating_from,omitempty"`
+	RatingSentiments    []RatingSentiment      `json:"rating_sentiments,omitempty"`
+	RatingTo            *string                `json:"rating_to,omitempty"`
+	Tags                []Tag                  `json:"tags,omitempty"`
+	TargetDelta         *float64               `json:"target_delta,omitempty"`
+	TargetFrom          *float64               `json:"target_from,omitempty"`
+	TargetTo            *float64               `json:"target_to,omitempty"`
+	Ticker              *string                `json:"ticker,omitempty"`
+	UpdatedAt           *string                `json:"updated_at,omitempty"`
+	UpdatedBy           *string                `json:"updated_by,omitempty"`
+	UUID                *string                `json:"uuid,omitempty"`
+	WeightedScore       *float64               `json:"weighted_score,omitempty"`
+}
+
+// StockExtractRequest is a request model of the API
+type StockExtractRequest struct {
+	MaxPages int `json:"max_pages"`
+}
+
+// StockUpdateRequest is a request model of the API
+type StockUpdateRequest struct {
+	Action              *string                     `json:"action,omitempty"`
+	Brokerage           *string                     `json:"brokerage,omitempty"`
+	Cluster             *int                        `json:"cluster,omitempty"`
+	Company             *string                     `json:"company,omitempty"`
+	Date                *string                     `json:"date,omitempty"`
+	ID                  int                         `json:"id"`
+	LastClose           *float64                    `json:"last_close,omitempty"`
+	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators,omitempty"`
+	RatingFrom          *string                     `json:"rating_from,omitempty"`
+	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments,omitempty"`
+	RatingTo            *string                     `json:"rating_to,omitempty"`
+	TargetDelta         *float64                    `json:"target_delta,omitempty"`
+	TargetFrom          *float64                    `json:"target_from,omitempty"`
+	TargetTo            *float64                    `json:"target_to,omitempty"`
+	Ticker              *string                     `json:"ticker,omitempty"`
+}
+
+// Tag is a request model of the API
+type Tag struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	ID        *int    `json:"id,omitempty"`
+	Name      *string `json:"name,omitempty"`
+}
+
+// TagRequest is a request model of the API
+type TagRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// TickerStats is a request model of the API
+type TickerStats struct {
+	Count        *int    `json:"count,omitempty"`
+	EarliestTime *string `json:"earliest_time,omitempty"`
+	LatestTime   *string `json:"latest_time,omitempty"`
+	Ticker       *string `json:"ticker,omitempty"`
+}
+
+// WebhookSubscriptionRequest is a request model of the API
+type WebhookSubscriptionRequest struct {
+	Events []string `json:"events"`
+	URL    string   `json:"url"`
+}
+
+// WeightProfileRequest is a request model of the API
+type WeightProfileRequest struct {
+	DefaultCluster   *int            `json:"default_cluster,omitempty"`
+	NumericalWeights []WeightRequest `json:"numerical_weights,omitempty"`
+	RatingWeights    []WeightRequest `json:"rating_weights,omitempty"`
+	UserID           string          `json:"user_id"`
+}
+
+// WeightRequest is a request model of the API
+type WeightRequest struct {
+	IndicatorName string   `json:"indicator_name"`
+	Weight        *float64 `json:"weight,omitempty"`
+}
+
+// GetAdminDiagnostics calls GET /api/v1/admin/diagnostics: Get database diagnostics
+func (c *Client) GetAdminDiagnostics(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/admin/diagnostics", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetAdminUsageParams holds the parameters of GetAdminUsage
+type GetAdminUsageParams struct {
+	// Number of days to report, 1-90 (default: 7)
+	Days *int
+	// Busiest endpoints listed per client, 1-50 (default: 5)
+	Top *int
+}
+
+// GetAdminUsage calls GET /api/v1/admin/usage: Get API usage per client
+func (c *Client) GetAdminUsage(ctx context.Context, params GetAdminUsageParams) (Response, error) {
+	query := url.Values{}
+	if params.Days != nil {
+		query.Set("days", fmt.Sprint(*params.Days))
+	}
+	if params.Top != nil {
+		query.Set("top", fmt.Sprint(*params.Top))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/admin/usage", query, nil, nil, &out)
+	return out, err
+}
+
+// PostArchiveParams holds the parameters of PostArchive
+type PostArchiveParams struct {
+	// Archive records dated before this day (YYYY-MM-DD)
+	Before *string
+	// Archive records of this cluster
+	Cluster *int
+	// Archive records last written by this dataset version
+	Dataset *int
+	// Archive records last written by a dataset version older than this one
+	DatasetBefore *int
+}
+
+// PostArchive calls POST /api/v1/archive: Archive old data points
+func (c *Client) PostArchive(ctx context.Context, params PostArchiveParams) (Response, error) {
+	query := url.Values{}
+	if params.Before != nil {
+		query.Set("before", fmt.Sprint(*params.Before))
+	}
+	if params.Cluster != nil {
+		query.Set("cluster", fmt.Sprint(*params.Cluster))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	if params.DatasetBefore != nil {
+		query.Set("dataset_before", fmt.Sprint(*params.DatasetBefore))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/archive", query, nil, nil, &out)
+	return out, err
+}
+
+// GetArchiveByTickerParams holds the parameters of GetArchiveByTicker
+type GetArchiveByTickerParams struct {
+	// Stock ticker symbol
+	Ticker string
+}
+
+// GetArchiveByTicker calls GET /api/v1/archive/{ticker}: Get the archived records of a ticker
+func (c *Client) GetArchiveByTicker(ctx context.Context, params GetArchiveByTickerParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/archive/"+url.PathEscape(fmt.Sprint(params.Ticker)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetDatasets calls GET /api/v1/datasets: List dataset versions
+func (c *Client) GetDatasets(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/datasets", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostDatasetsByVersionRollbackParams holds the parameters of PostDatasetsByVersionRollback
+type PostDatasetsByVersionRollbackParams struct {
+	// Dataset version ID to roll back to
+	Version int
+}
+
+// PostDatasetsByVersionRollback calls POST /api/v1/datasets/{version}/rollback: Roll back to a dataset version
+func (c *Client) PostDatasetsByVersionRollback(ctx context.Context, params PostDatasetsByVersionRollbackParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/datasets/"+url.PathEscape(fmt.Sprint(params.Version))+"/rollback", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostExportsParams holds the parameters of PostExports
+type PostExportsParams struct {
+	// Export format: csv | xlsx | ndjson (default: csv)
+	Format *string
+}
+
+// PostExports calls POST /api/v1/exports: Start an export job
+func (c *Client) PostExports(ctx context.Context, params PostExportsParams) (Response, error) {
+	query := url.Values{}
+	if params.Format != nil {
+		query.Set("format", fmt.Sprint(*params.Format))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/exports", query, nil, nil, &out)
+	return out, err
+}
+
+// GetExportsByIDParams holds the parameters of GetExportsByID
+type GetExportsByIDParams struct {
+	// Export job ID
+	ID int
+}
+
+// GetExportsByID calls GET /api/v1/exports/{id}: Get an export job
+func (c *Client) GetExportsByID(ctx context.Context, params GetExportsByIDParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/exports/"+url.PathEscape(fmt.Sprint(params.ID)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetExportsByIDDownloadParams holds the parameters of GetExportsByIDDownload
+type GetExportsByIDDownloadParams struct {
+	// Export job ID
+	ID int
+	// Link expiry (unix seconds)
+	Expires int
+	// Link signature
+	Signature string
+}
+
+// GetExportsByIDDownload calls GET /api/v1/exports/{id}/download: Download an export file
+func (c *Client) GetExportsByIDDownload(ctx context.Context, params GetExportsByIDDownloadParams) ([]byte, error) {
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(params.Expires))
+	query.Set("signature", fmt.Sprint(params.Signature))
+	var out []byte
+	err := c.do(ctx, http.MethodGet, "/api/v1/exports/"+url.PathEscape(fmt.Sprint(params.ID))+"/download", query, nil, nil, &out)
+	return out, err
+}
+
+// GetGraphqlParams holds the parameters of GetGraphql
+type GetGraphqlParams struct {
+	// GraphQL query
+	Query string
+	// JSON-encoded query variables
+	Variables *string
+	// Operation to run when the query defines several
+	Operationname *string
+}
+
+// GetGraphql calls GET /api/v1/graphql: Run a GraphQL query
+func (c *Client) GetGraphql(ctx context.Context, params GetGraphqlParams) (Response, error) {
+	query := url.Values{}
+	query.Set("query", fmt.Sprint(params.Query))
+	if params.Variables != nil {
+		query.Set("variables", fmt.Sprint(*params.Variables))
+	}
+	if params.Operationname != nil {
+		query.Set("operationName", fmt.Sprint(*params.Operationname))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/graphql", query, nil, nil, &out)
+	return out, err
+}
+
+// PostGraphqlParams holds the parameters of PostGraphql
+type PostGraphqlParams struct {
+	Body *GraphQLRequest
+}
+
+// PostGraphql calls POST /api/v1/graphql: Run a GraphQL query
+func (c *Client) PostGraphql(ctx context.Context, params PostGraphqlParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/graphql", nil, nil, body, &out)
+	return out, err
+}
+
+// GetJobsByIDParams holds the parameters of GetJobsByID
+type GetJobsByIDParams struct {
+	// Job ID
+	ID int
+}
+
+// GetJobsByID calls GET /api/v1/jobs/{id}: Get a background job
+func (c *Client) GetJobsByID(ctx context.Context, params GetJobsByIDParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/jobs/"+url.PathEscape(fmt.Sprint(params.ID)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetMePreferences calls GET /api/v1/me/preferences: Get my dashboard preferences
+func (c *Client) GetMePreferences(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/me/preferences", nil, nil, nil, &out)
+	return out, err
+}
+
+// PutMePreferencesParams holds the parameters of PutMePreferences
+type PutMePreferencesParams struct {
+	Body *PreferencesRequest
+}
+
+// PutMePreferences calls PUT /api/v1/me/preferences: Save my dashboard preferences
+func (c *Client) PutMePreferences(ctx context.Context, params PutMePreferencesParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/me/preferences", nil, nil, body, &out)
+	return out, err
+}
+
+// PostNotificationsTestParams holds the parameters of PostNotificationsTest
+type PostNotificationsTestParams struct {
+	Body *NotificationTestRequest
+}
+
+// PostNotificationsTest calls POST /api/v1/notifications/test: Send a test notification
+func (c *Client) PostNotificationsTest(ctx context.Context, params PostNotificationsTestParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/notifications/test", nil, nil, body, &out)
+	return out, err
+}
+
+// GetOpenapiJSON calls GET /api/v1/openapi.json: Get the OpenAPI document
+func (c *Client) GetOpenapiJSON(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/openapi.json", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRatingRubricParams holds the parameters of GetRatingRubric
+type GetRatingRubricParams struct {
+	// Only entries of this kind (rating or action)
+	Kind *string
+}
+
+// GetRatingRubric calls GET /api/v1/rating-rubric: List the rating rubric
+func (c *Client) GetRatingRubric(ctx context.Context, params GetRatingRubricParams) (Response, error) {
+	query := url.Values{}
+	if params.Kind != nil {
+		query.Set("kind", fmt.Sprint(*params.Kind))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/rating-rubric", query, nil, nil, &out)
+	return out, err
+}
+
+// PostRatingRubricParams holds the parameters of PostRatingRubric
+type PostRatingRubricParams struct {
+	Body *RatingRubricRequest
+}
+
+// PostRatingRubric calls POST /api/v1/rating-rubric: Add a rubric term
+func (c *Client) PostRatingRubric(ctx context.Context, params PostRatingRubricParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/rating-rubric", nil, nil, body, &out)
+	return out, err
+}
+
+// PutRatingRubricByIDParams holds the parameters of PutRatingRubricByID
+type PutRatingRubricByIDParams struct {
+	// Rubric entry ID
+	ID   int
+	Body *RatingRubricRequest
+}
+
+// PutRatingRubricByID calls PUT /api/v1/rating-rubric/{id}: Edit a rubric term
+func (c *Client) PutRatingRubricByID(ctx context.Context, params PutRatingRubricByIDParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/rating-rubric/"+url.PathEscape(fmt.Sprint(params.ID)), nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteRatingRubricByIDParams holds the parameters of DeleteRatingRubricByID
+type DeleteRatingRubricByIDParams struct {
+	// Rubric entry ID
+	ID int
+}
+
+// DeleteRatingRubricByID calls DELETE /api/v1/rating-rubric/{id}: Delete a rubric term
+func (c *Client) DeleteRatingRubricByID(ctx context.Context, params DeleteRatingRubricByIDParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/rating-rubric/"+url.PathEscape(fmt.Sprint(params.ID)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetSchema calls GET /api/v1/schema: Get JSON Schemas for request bodies
+func (c *Client) GetSchema(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/schema", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetScoringConfig calls GET /api/v1/scoring-config: Export the scoring configuration
+func (c *Client) GetScoringConfig(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/scoring-config", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostScoringConfigImportParams holds the parameters of PostScoringConfigImport
+type PostScoringConfigImportParams struct {
+	// Delete rubric entries and weight profiles missing from the document (default: false)
+	Replace *bool
+	Body    *ScoringConfigRequest
+}
+
+// PostScoringConfigImport calls POST /api/v1/scoring-config/import: Import a scoring configuration
+func (c *Client) PostScoringConfigImport(ctx context.Context, params PostScoringConfigImportParams) (Response, error) {
+	query := url.Values{}
+	if params.Replace != nil {
+		query.Set("replace", fmt.Sprint(*params.Replace))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/scoring-config/import", query, nil, body, &out)
+	return out, err
+}
+
+// GetSearchParams holds the parameters of GetSearch
+type GetSearchParams struct {
+	// Search text
+	Q string
+	// Maximum number of results, 1-50 (default: 10)
+	Limit *int
+}
+
+// GetSearch calls GET /api/v1/search: Search tickers and companies
+func (c *Client) GetSearch(ctx context.Context, params GetSearchParams) (Response, error) {
+	query := url.Values{}
+	query.Set("q", fmt.Sprint(params.Q))
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/search", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocks calls GET /api/v1/stocks: Get all stocks
+func (c *Client) GetStocks(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksParams holds the parameters of PostStocks
+type PostStocksParams struct {
+	Body *StockCreateRequest
+}
+
+// PostStocks calls POST /api/v1/stocks: Create a new stock
+func (c *Client) PostStocks(ctx context.Context, params PostStocksParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks", nil, nil, body, &out)
+	return out, err
+}
+
+// GetStocksActionByActionParams holds the parameters of GetStocksActionByAction
+type GetStocksActionByActionParams struct {
+	// Action value
+	Action string
+	// Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+	// Page number (default: 1)
+	Page *int
+	// Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)
+	PerPage *int
+	// Only include data points last written by this dataset version
+	Dataset *int
+}
+
+// GetStocksActionByAction calls GET /api/v1/stocks/action/{action}: Get stocks by action
+func (c *Client) GetStocksActionByAction(ctx context.Context, params GetStocksActionByActionParams) (Response, error) {
+	query := url.Values{}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	if params.Page != nil {
+		query.Set("page", fmt.Sprint(*params.Page))
+	}
+	if params.PerPage != nil {
+		query.Set("per_page", fmt.Sprint(*params.PerPage))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/action/"+url.PathEscape(fmt.Sprint(params.Action)), query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksActions calls GET /api/v1/stocks/actions: Get unique actions
+func (c *Client) GetStocksActions(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/actions", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksAnalyticsDispersionParams holds the parameters of GetStocksAnalyticsDispersion
+type GetStocksAnalyticsDispersionParams struct {
+	// Restrict to one cluster (default: all clusters)
+	Cluster *int
+}
+
+// GetStocksAnalyticsDispersion calls GET /api/v1/stocks/analytics/dispersion: Get per-cluster dispersion statistics
+func (c *Client) GetStocksAnalyticsDispersion(ctx context.Context, params GetStocksAnalyticsDispersionParams) (Response, error) {
+	query := url.Values{}
+	if params.Cluster != nil {
+		query.Set("cluster", fmt.Sprint(*params.Cluster))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/analytics/dispersion", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksAnalyticsHeatmapParams holds the parameters of GetStocksAnalyticsHeatmap
+type GetStocksAnalyticsHeatmapParams struct {
+	// Column crossed with cluster: action | rating_to (default: action)
+	Dimension *string
+}
+
+// GetStocksAnalyticsHeatmap calls GET /api/v1/stocks/analytics/heatmap: Get the cluster heatmap
+func (c *Client) GetStocksAnalyticsHeatmap(ctx context.Context, params GetStocksAnalyticsHeatmapParams) (Response, error) {
+	query := url.Values{}
+	if params.Dimension != nil {
+		query.Set("dimension", fmt.Sprint(*params.Dimension))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/analytics/heatmap", query, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksBatchParams holds the parameters of PostStocksBatch
+type PostStocksBatchParams struct {
+	Body []StockCreateRequest
+}
+
+// PostStocksBatch calls POST /api/v1/stocks/batch: Create stocks in bulk
+func (c *Client) PostStocksBatch(ctx context.Context, params PostStocksBatchParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/batch", nil, nil, body, &out)
+	return out, err
+}
+
+// PutStocksClusterParams holds the parameters of PutStocksCluster
+type PutStocksClusterParams struct {
+	Body *BulkClusterAssignmentRequest
+}
+
+// PutStocksCluster calls PUT /api/v1/stocks/cluster: Move tickers to a cluster
+func (c *Client) PutStocksCluster(ctx context.Context, params PutStocksClusterParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/cluster", nil, nil, body, &out)
+	return out, err
+}
+
+// GetStocksClusterByClusterParams holds the parameters of GetStocksClusterByCluster
+type GetStocksClusterByClusterParams struct {
+	// Cluster id
+	Cluster int
+	// Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+	// Page number (default: 1)
+	Page *int
+	// Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)
+	PerPage *int
+	// Only include data points last written by this dataset version
+	Dataset *int
+}
+
+// GetStocksClusterByCluster calls GET /api/v1/stocks/cluster/{cluster}: Get stocks by cluster
+func (c *Client) GetStocksClusterByCluster(ctx context.Context, params GetStocksClusterByClusterParams) (Response, error) {
+	query := url.Values{}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	if params.Page != nil {
+		query.Set("page", fmt.Sprint(*params.Page))
+	}
+	if params.PerPage != nil {
+		query.Set("per_page", fmt.Sprint(*params.PerPage))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster)), query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksClusterByClusterFilterParams holds the parameters of GetStocksClusterByClusterFilter
+type GetStocksClusterByClusterFilterParams struct {
+	// Cluster id
+	Cluster int
+	// Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected). Note: company and date are excluded.
+	GroupingColumn *string
+	// Grouping value to filter by (required if grouping_column is not none)
+	GroupingValue *string
+	// Sort by column: ticker | action | date | company | target_to | target_from | rating_to | rating_from | final_score | weighted_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+	// Page number (default: 1)
+	Page *int
+	// Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)
+	PerPage *int
+	// JSON array of numerical weights: [{\
+	NumericalWeights *string
+	// JSON array of rating weights: [{\
+	RatingWeights *string
+	// Only include stocks carrying any of these tags
+	Tags []string
+	// Only include stocks with final_score at least this value
+	MinFinalScore *float64
+	// Only include stocks with final_score at most this value
+	MaxFinalScore *float64
+	// Only include stocks with target_to at least this value
+	MinTargetTo *float64
+	// Only include stocks with target_to at most this value
+	MaxTargetTo *float64
+	// Only include stocks with target_from at least this value
+	MinTargetFrom *float64
+	// Only include stocks with target_from at most this value
+	MaxTargetFrom *float64
+	// Only include stocks with target_delta at least this value
+	MinTargetDelta *float64
+	// Only include stocks with target_delta at most this value
+	MaxTargetDelta *float64
+	// Only include stocks with last_close at least this value
+	MinLastClose *float64
+	// Only include stocks with last_close at most this value
+	MaxLastClose *float64
+	// Child rows loaded per stock: full | scoring (only name and normalized score/value) | none (default: full)
+	Relations *string
+	// With weights, add a contributions object to each row mapping every weighted name to weight x normalized value; the values add up to weighted_score (default: false)
+	Contributions *bool
+	// Return one record per grouping value (group_value, count, avg_final_score, avg_weighted_score) instead of rows; requires grouping_column (default: false)
+	Aggregate *bool
+	// Admin only: return the EXPLAIN ANALYZE plan of the page query (data.sql, data.plan) instead of rows; not combinable with aggregate (default: false)
+	Explain *bool
+}
+
+// GetStocksClusterByClusterFilter calls GET /api/v1/stocks/cluster/{cluster}/filter: Filter stocks by cluster with grouping, pagination, sorting, and weighted scoring
+func (c *Client) GetStocksClusterByClusterFilter(ctx context.Context, params GetStocksClusterByClusterFilterParams) (Response, error) {
+	query := url.Values{}
+	if params.GroupingColumn != nil {
+		query.Set("grouping_column", fmt.Sprint(*params.GroupingColumn))
+	}
+	if params.GroupingValue != nil {
+		query.Set("grouping_value", fmt.Sprint(*params.GroupingValue))
+	}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	if params.Page != nil {
+		query.Set("page", fmt.Sprint(*params.Page))
+	}
+	if params.PerPage != nil {
+		query.Set("per_page", fmt.Sprint(*params.PerPage))
+	}
+	if params.NumericalWeights != nil {
+		query.Set("numerical_weights", fmt.Sprint(*params.NumericalWeights))
+	}
+	if params.RatingWeights != nil {
+		query.Set("rating_weights", fmt.Sprint(*params.RatingWeights))
+	}
+	for _, value := range params.Tags {
+		query.Add("tags", fmt.Sprint(value))
+	}
+	if params.MinFinalScore != nil {
+		query.Set("min_final_score", fmt.Sprint(*params.MinFinalScore))
+	}
+	if params.MaxFinalScore != nil {
+		query.Set("max_final_score", fmt.Sprint(*params.MaxFinalScore))
+	}
+	if params.MinTargetTo != nil {
+		query.Set("min_target_to", fmt.Sprint(*params.MinTargetTo))
+	}
+	if params.MaxTargetTo != nil {
+		query.Set("max_target_to", fmt.Sprint(*params.MaxTargetTo))
+	}
+	if params.MinTargetFrom != nil {
+		query.Set("min_target_from", fmt.Sprint(*params.MinTargetFrom))
+	}
+	if params.MaxTargetFrom != nil {
+		query.Set("max_target_from", fmt.Sprint(*params.MaxTargetFrom))
+	}
+	if params.MinTargetDelta != nil {
+		query.Set("min_target_delta", fmt.Sprint(*params.MinTargetDelta))
+	}
+	if params.MaxTargetDelta != nil {
+		query.Set("max_target_delta", fmt.Sprint(*params.MaxTargetDelta))
+	}
+	if params.MinLastClose != nil {
+		query.Set("min_last_close", fmt.Sprint(*params.MinLastClose))
+	}
+	if params.MaxLastClose != nil {
+		query.Set("max_last_close", fmt.Sprint(*params.MaxLastClose))
+	}
+	if params.Relations != nil {
+		query.Set("relations", fmt.Sprint(*params.Relations))
+	}
+	if params.Contributions != nil {
+		query.Set("contributions", fmt.Sprint(*params.Contributions))
+	}
+	if params.Aggregate != nil {
+		query.Set("aggregate", fmt.Sprint(*params.Aggregate))
+	}
+	if params.Explain != nil {
+		query.Set("explain", fmt.Sprint(*params.Explain))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster))+"/filter", query, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksClusterByClusterFilterParams holds the parameters of PostStocksClusterByClusterFilter
+type PostStocksClusterByClusterFilterParams struct {
+	// Cluster id
+	Cluster int
+	// Admin only: return the EXPLAIN ANALYZE plan of the page query instead of rows (default: false)
+	Explain *bool
+	Body    *FilterRequest
+}
+
+// PostStocksClusterByClusterFilter calls POST /api/v1/stocks/cluster/{cluster}/filter: Filter stocks by cluster (JSON body variant)
+func (c *Client) PostStocksClusterByClusterFilter(ctx context.Context, params PostStocksClusterByClusterFilterParams) (Response, error) {
+	query := url.Values{}
+	if params.Explain != nil {
+		query.Set("explain", fmt.Sprint(*params.Explain))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster))+"/filter", query, nil, body, &out)
+	return out, err
+}
+
+// GetStocksClusterByClusterFilterExportParams holds the parameters of GetStocksClusterByClusterFilterExport
+type GetStocksClusterByClusterFilterExportParams struct {
+	// Cluster id
+	Cluster int
+	// Export format: csv | xlsx (default: csv)
+	Format *string
+	// Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected)
+	GroupingColumn *string
+	// Grouping value to filter by (required if grouping_column is not none)
+	GroupingValue *string
+	// Sort by column; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+	// JSON array of numerical weights: [{\
+	NumericalWeights *string
+	// JSON array of rating weights: [{\
+	RatingWeights *string
+	// Only include stocks carrying any of these tags
+	Tags []string
+	// Only include stocks with final_score at least this value
+	MinFinalScore *float64
+	// Only include stocks with final_score at most this value
+	MaxFinalScore *float64
+	// Only include stocks with target_to at least this value
+	MinTargetTo *float64
+	// Only include stocks with target_to at most this value
+	MaxTargetTo *float64
+	// Only include stocks with target_from at least this value
+	MinTargetFrom *float64
+	// Only include stocks with target_from at most this value
+	MaxTargetFrom *float64
+	// Only include stocks with target_delta at least this value
+	MinTargetDelta *float64
+	// Only include stocks with target_delta at most this value
+	MaxTargetDelta *float64
+	// Only include stocks with last_close at least this value
+	MinLastClose *float64
+	// Only include stocks with last_close at most this value
+	MaxLastClose *float64
+}
+
+// GetStocksClusterByClusterFilterExport calls GET /api/v1/stocks/cluster/{cluster}/filter/export: Export the filtered result set
+func (c *Client) GetStocksClusterByClusterFilterExport(ctx context.Context, params GetStocksClusterByClusterFilterExportParams) ([]byte, error) {
+	query := url.Values{}
+	if params.Format != nil {
+		query.Set("format", fmt.Sprint(*params.Format))
+	}
+	if params.GroupingColumn != nil {
+		query.Set("grouping_column", fmt.Sprint(*params.GroupingColumn))
+	}
+	if params.GroupingValue != nil {
+		query.Set("grouping_value", fmt.Sprint(*params.GroupingValue))
+	}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	if params.NumericalWeights != nil {
+		query.Set("numerical_weights", fmt.Sprint(*params.NumericalWeights))
+	}
+	if params.RatingWeights != nil {
+		query.Set("rating_weights", fmt.Sprint(*params.RatingWeights))
+	}
+	for _, value := range params.Tags {
+		query.Add("tags", fmt.Sprint(value))
+	}
+	if params.MinFinalScore != nil {
+		query.Set("min_final_score", fmt.Sprint(*params.MinFinalScore))
+	}
+	if params.MaxFinalScore != nil {
+		query.Set("max_final_score", fmt.Sprint(*params.MaxFinalScore))
+	}
+	if params.MinTargetTo != nil {
+		query.Set("min_target_to", fmt.Sprint(*params.MinTargetTo))
+	}
+	if params.MaxTargetTo != nil {
+		query.Set("max_target_to", fmt.Sprint(*params.MaxTargetTo))
+	}
+	if params.MinTargetFrom != nil {
+		query.Set("min_target_from", fmt.Sprint(*params.MinTargetFrom))
+	}
+	if params.MaxTargetFrom != nil {
+		query.Set("max_target_from", fmt.Sprint(*params.MaxTargetFrom))
+	}
+	if params.MinTargetDelta != nil {
+		query.Set("min_target_delta", fmt.Sprint(*params.MinTargetDelta))
+	}
+	if params.MaxTargetDelta != nil {
+		query.Set("max_target_delta", fmt.Sprint(*params.MaxTargetDelta))
+	}
+	if params.MinLastClose != nil {
+		query.Set("min_last_close", fmt.Sprint(*params.MinLastClose))
+	}
+	if params.MaxLastClose != nil {
+		query.Set("max_last_close", fmt.Sprint(*params.MaxLastClose))
+	}
+	var out []byte
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster))+"/filter/export", query, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksClusterByClusterFilterExportParams holds the parameters of PostStocksClusterByClusterFilterExport
+type PostStocksClusterByClusterFilterExportParams struct {
+	// Cluster id
+	Cluster int
+	// Export format: csv | xlsx (default: csv)
+	Format *string
+	Body   *FilterRequest
+}
+
+// PostStocksClusterByClusterFilterExport calls POST /api/v1/stocks/cluster/{cluster}/filter/export: Export the filtered result set (JSON body variant)
+func (c *Client) PostStocksClusterByClusterFilterExport(ctx context.Context, params PostStocksClusterByClusterFilterExportParams) ([]byte, error) {
+	query := url.Values{}
+	if params.Format != nil {
+		query.Set("format", fmt.Sprint(*params.Format))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out []byte
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster))+"/filter/export", query, nil, body, &out)
+	return out, err
+}
+
+// GetStocksClusterByClusterUniqueByColumnNameParams holds the parameters of GetStocksClusterByClusterUniqueByColumnName
+type GetStocksClusterByClusterUniqueByColumnNameParams struct {
+	// Cluster id
+	Cluster int
+	// Column name: action | rating_to | rating_from
+	ColumnName string
+}
+
+// GetStocksClusterByClusterUniqueByColumnName calls GET /api/v1/stocks/cluster/{cluster}/unique/{column_name}: Get unique values for a specified column filtered by cluster
+func (c *Client) GetStocksClusterByClusterUniqueByColumnName(ctx context.Context, params GetStocksClusterByClusterUniqueByColumnNameParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/cluster/"+url.PathEscape(fmt.Sprint(params.Cluster))+"/unique/"+url.PathEscape(fmt.Sprint(params.ColumnName)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksClusters calls GET /api/v1/stocks/clusters: Get unique clusters
+func (c *Client) GetStocksClusters(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/clusters", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksClustersCentroids calls GET /api/v1/stocks/clusters/centroids: Get cluster centroids
+func (c *Client) GetStocksClustersCentroids(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/clusters/centroids", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksClustersCentroids calls POST /api/v1/stocks/clusters/centroids: Recompute cluster centroids
+func (c *Client) PostStocksClustersCentroids(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/clusters/centroids", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksCompanies calls GET /api/v1/stocks/companies: Get unique companies
+func (c *Client) GetStocksCompanies(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/companies", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksCompanyByCompanyParams holds the parameters of GetStocksCompanyByCompany
+type GetStocksCompanyByCompanyParams struct {
+	// Company name
+	Company string
+	// Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+	// Page number (default: 1)
+	Page *int
+	// Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)
+	PerPage *int
+	// Only include data points last written by this dataset version
+	Dataset *int
+}
+
+// GetStocksCompanyByCompany calls GET /api/v1/stocks/company/{company}: Get stocks by company
+func (c *Client) GetStocksCompanyByCompany(ctx context.Context, params GetStocksCompanyByCompanyParams) (Response, error) {
+	query := url.Values{}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	if params.Page != nil {
+		query.Set("page", fmt.Sprint(*params.Page))
+	}
+	if params.PerPage != nil {
+		query.Set("per_page", fmt.Sprint(*params.PerPage))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/company/"+url.PathEscape(fmt.Sprint(params.Company)), query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksDatabaseStats calls GET /api/v1/stocks/database/stats: Get database statistics
+func (c *Client) GetStocksDatabaseStats(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/database/stats", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksDictionary calls GET /api/v1/stocks/dictionary: Get the data dictionary
+func (c *Client) GetStocksDictionary(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/dictionary", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksEnums calls GET /api/v1/stocks/enums: Get allowed action and rating values
+func (c *Client) GetStocksEnums(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/enums", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksExportParams holds the parameters of GetStocksExport
+type GetStocksExportParams struct {
+	// Export format: csv | xlsx | ndjson (default: csv)
+	Format *string
+	// Only export stocks of this cluster
+	Cluster *int
+	// Only export stocks of this dataset version
+	Dataset *int
+	// Only export stocks recorded on or after this date (YYYY-MM-DD)
+	From *string
+	// Only export stocks recorded on or before this date (YYYY-MM-DD)
+	To *string
+}
+
+// GetStocksExport calls GET /api/v1/stocks/export: Export every stock
+func (c *Client) GetStocksExport(ctx context.Context, params GetStocksExportParams) ([]byte, error) {
+	query := url.Values{}
+	if params.Format != nil {
+		query.Set("format", fmt.Sprint(*params.Format))
+	}
+	if params.Cluster != nil {
+		query.Set("cluster", fmt.Sprint(*params.Cluster))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	if params.From != nil {
+		query.Set("from", fmt.Sprint(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", fmt.Sprint(*params.To))
+	}
+	var out []byte
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/export", query, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksExtractParams holds the parameters of PostStocksExtract
+type PostStocksExtractParams struct {
+	Body *StockExtractRequest
+}
+
+// PostStocksExtract calls POST /api/v1/stocks/extract: Extract data from API
+func (c *Client) PostStocksExtract(ctx context.Context, params PostStocksExtractParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/extract", nil, nil, body, &out)
+	return out, err
+}
+
+// GetStocksExtractBudget calls GET /api/v1/stocks/extract/budget: Get the daily upstream request budget
+func (c *Client) GetStocksExtractBudget(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/extract/budget", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksExtractPagesParams holds the parameters of GetStocksExtractPages
+type GetStocksExtractPagesParams struct {
+	// Only include entries with this status: success | error | retried
+	Status *string
+	// Page number (default: 1)
+	Page *int
+	// Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)
+	PerPage *int
+	// Sort by column: recorded_at | page_number; a comma-separated list of column [asc|desc] keys (e.g. page_number asc, recorded_at desc) sorts by each in turn, keys without a direction use order (default: recorded_at)
+	SortBy *string
+	// Sort order: asc | desc (default: desc)
+	Order *string
+}
+
+// GetStocksExtractPages calls GET /api/v1/stocks/extract/pages: List extraction page-key history
+func (c *Client) GetStocksExtractPages(ctx context.Context, params GetStocksExtractPagesParams) (Response, error) {
+	query := url.Values{}
+	if params.Status != nil {
+		query.Set("status", fmt.Sprint(*params.Status))
+	}
+	if params.Page != nil {
+		query.Set("page", fmt.Sprint(*params.Page))
+	}
+	if params.PerPage != nil {
+		query.Set("per_page", fmt.Sprint(*params.PerPage))
+	}
+	if params.SortBy != nil {
+		query.Set("sort_by", fmt.Sprint(*params.SortBy))
+	}
+	if params.Order != nil {
+		query.Set("order", fmt.Sprint(*params.Order))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/extract/pages", query, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksExtractRetryFailed calls POST /api/v1/stocks/extract/retry-failed: Replay failed extraction pages
+func (c *Client) PostStocksExtractRetryFailed(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/extract/retry-failed", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksExtractByJobIDEventsParams holds the parameters of GetStocksExtractByJobIDEvents
+type GetStocksExtractByJobIDEventsParams struct {
+	// Extraction job ID
+	JobID int
+}
+
+// GetStocksExtractByJobIDEvents calls GET /api/v1/stocks/extract/{job_id}/events: Stream the progress of an extraction job
+func (c *Client) GetStocksExtractByJobIDEvents(ctx context.Context, params GetStocksExtractByJobIDEventsParams) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/extract/"+url.PathEscape(fmt.Sprint(params.JobID))+"/events", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksImportParams holds the parameters of PostStocksImport
+type PostStocksImportParams struct {
+	// CSV file
+	File File
+	// Import even if this exact file was imported before (default: false)
+	Force *bool
+}
+
+// PostStocksImport calls POST /api/v1/stocks/import: Import stock data from an uploaded CSV
+func (c *Client) PostStocksImport(ctx context.Context, params PostStocksImportParams) (Response, error) {
+	query := url.Values{}
+	if params.Force != nil {
+		query.Set("force", fmt.Sprint(*params.Force))
+	}
+	form := formFiles{}
+	form["file"] = params.File
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/import", query, nil, form, &out)
+	return out, err
+}
+
+// PostStocksImportEnrichedParams holds the parameters of PostStocksImportEnriched
+type PostStocksImportEnrichedParams struct {
+	// Import even if this exact file was imported before (default: false)
+	Force *bool
+}
+
+// PostStocksImportEnriched calls POST /api/v1/stocks/import-enriched: Import enriched stock data from default CSV
+func (c *Client) PostStocksImportEnriched(ctx context.Context, params PostStocksImportEnrichedParams) (Response, error) {
+	query := url.Values{}
+	if params.Force != nil {
+		query.Set("force", fmt.Sprint(*params.Force))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/import-enriched", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksIntegrity calls GET /api/v1/stocks/integrity: Check referential integrity
+func (c *Client) GetStocksIntegrity(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/integrity", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksMeta calls GET /api/v1/stocks/meta: Get data freshness
+func (c *Client) GetStocksMeta(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/meta", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksMoversParams holds the parameters of GetStocksMovers
+type GetStocksMoversParams struct {
+	// Metric to rank by: target_delta | final_score (default: target_delta)
+	Metric *string
+	// up (largest positive) | down (largest negative) (default: up)
+	Direction *string
+	// Earliest date (YYYY-MM-DD, inclusive)
+	From *string
+	// Latest date (YYYY-MM-DD, inclusive)
+	To *string
+	// Number of stocks to return, 1-100 (default: 20)
+	Limit *int
+}
+
+// GetStocksMovers calls GET /api/v1/stocks/movers: Get the top movers
+func (c *Client) GetStocksMovers(ctx context.Context, params GetStocksMoversParams) (Response, error) {
+	query := url.Values{}
+	if params.Metric != nil {
+		query.Set("metric", fmt.Sprint(*params.Metric))
+	}
+	if params.Direction != nil {
+		query.Set("direction", fmt.Sprint(*params.Direction))
+	}
+	if params.From != nil {
+		query.Set("from", fmt.Sprint(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", fmt.Sprint(*params.To))
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/movers", query, nil, nil, &out)
+	return out, err
+}
+
+// DeleteStocksOrphansParams holds the parameters of DeleteStocksOrphans
+type DeleteStocksOrphansParams struct {
+	// Also delete the stocks without sentiments or without indicators (default: false)
+	Incomplete *bool
+	// Only count the rows and issue a confirmation token (default: false)
+	DryRun *bool
+	// Token from the dry run (required unless dry_run=true)
+	XConfirmationToken *string
+}
+
+// DeleteStocksOrphans calls DELETE /api/v1/stocks/orphans: Delete orphaned sentiments and indicators
+func (c *Client) DeleteStocksOrphans(ctx context.Context, params DeleteStocksOrphansParams) (Response, error) {
+	query := url.Values{}
+	if params.Incomplete != nil {
+		query.Set("incomplete", fmt.Sprint(*params.Incomplete))
+	}
+	if params.DryRun != nil {
+		query.Set("dry_run", fmt.Sprint(*params.DryRun))
+	}
+	header := http.Header{}
+	if params.XConfirmationToken != nil {
+		header.Set("X-Confirmation-Token", fmt.Sprint(*params.XConfirmationToken))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/orphans", query, header, nil, &out)
+	return out, err
+}
+
+// DeleteStocksPurgeParams holds the parameters of DeleteStocksPurge
+type DeleteStocksPurgeParams struct {
+	// Cluster id
+	Cluster *int
+	// Dataset version ID
+	Dataset *int
+	// Earliest record date, inclusive (YYYY-MM-DD)
+	From *string
+	// Latest record date, inclusive (YYYY-MM-DD)
+	To *string
+	// Only count the matching rows and issue a confirmation token (default: false)
+	DryRun *bool
+	// Token from the dry run (required unless dry_run=true)
+	XConfirmationToken *string
+}
+
+// DeleteStocksPurge calls DELETE /api/v1/stocks/purge: Delete the stocks in a cluster, dataset version or date range
+func (c *Client) DeleteStocksPurge(ctx context.Context, params DeleteStocksPurgeParams) (Response, error) {
+	query := url.Values{}
+	if params.Cluster != nil {
+		query.Set("cluster", fmt.Sprint(*params.Cluster))
+	}
+	if params.Dataset != nil {
+		query.Set("dataset", fmt.Sprint(*params.Dataset))
+	}
+	if params.From != nil {
+		query.Set("from", fmt.Sprint(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", fmt.Sprint(*params.To))
+	}
+	if params.DryRun != nil {
+		query.Set("dry_run", fmt.Sprint(*params.DryRun))
+	}
+	header := http.Header{}
+	if params.XConfirmationToken != nil {
+		header.Set("X-Confirmation-Token", fmt.Sprint(*params.XConfirmationToken))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/purge", query, header, nil, &out)
+	return out, err
+}
+
+// GetStocksStatsByTickerParams holds the parameters of GetStocksStatsByTicker
+type GetStocksStatsByTickerParams struct {
+	// Stock ticker symbol
+	Ticker string
+}
+
+// GetStocksStatsByTicker calls GET /api/v1/stocks/stats/{ticker}: Get stock statistics by ticker
+func (c *Client) GetStocksStatsByTicker(ctx context.Context, params GetStocksStatsByTickerParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/stats/"+url.PathEscape(fmt.Sprint(params.Ticker)), nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteStocksTablesParams holds the parameters of DeleteStocksTables
+type DeleteStocksTablesParams struct {
+	// Only count the rows and issue a confirmation token (default: false)
+	DryRun *bool
+	// Token from the dry run (required unless dry_run=true)
+	XConfirmationToken *string
+}
+
+// DeleteStocksTables calls DELETE /api/v1/stocks/tables: Empty all tables
+func (c *Client) DeleteStocksTables(ctx context.Context, params DeleteStocksTablesParams) (Response, error) {
+	query := url.Values{}
+	if params.DryRun != nil {
+		query.Set("dry_run", fmt.Sprint(*params.DryRun))
+	}
+	header := http.Header{}
+	if params.XConfirmationToken != nil {
+		header.Set("X-Confirmation-Token", fmt.Sprint(*params.XConfirmationToken))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/tables", query, header, nil, &out)
+	return out, err
+}
+
+// GetStocksTags calls GET /api/v1/stocks/tags: Get tags
+func (c *Client) GetStocksTags(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/tags", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksTickerByTickerParams holds the parameters of GetStocksTickerByTicker
+type GetStocksTickerByTickerParams struct {
+	// Stock ticker symbol
+	Ticker string
+}
+
+// GetStocksTickerByTicker calls GET /api/v1/stocks/ticker/{ticker}: Get stock by ticker
+func (c *Client) GetStocksTickerByTicker(ctx context.Context, params GetStocksTickerByTickerParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/ticker/"+url.PathEscape(fmt.Sprint(params.Ticker)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksTickerByTickerConsensusParams holds the parameters of GetStocksTickerByTickerConsensus
+type GetStocksTickerByTickerConsensusParams struct {
+	// Stock ticker symbol
+	Ticker string
+}
+
+// GetStocksTickerByTickerConsensus calls GET /api/v1/stocks/ticker/{ticker}/consensus: Get the brokerage consensus for a ticker
+func (c *Client) GetStocksTickerByTickerConsensus(ctx context.Context, params GetStocksTickerByTickerConsensusParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/ticker/"+url.PathEscape(fmt.Sprint(params.Ticker))+"/consensus", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksTickerByTickerHistoryParams holds the parameters of GetStocksTickerByTickerHistory
+type GetStocksTickerByTickerHistoryParams struct {
+	// Stock ticker symbol
+	Ticker string
+	// Earliest date (YYYY-MM-DD, inclusive)
+	From *string
+	// Latest date (YYYY-MM-DD, inclusive)
+	To *string
+}
+
+// GetStocksTickerByTickerHistory calls GET /api/v1/stocks/ticker/{ticker}/history: Get a ticker's score history
+func (c *Client) GetStocksTickerByTickerHistory(ctx context.Context, params GetStocksTickerByTickerHistoryParams) (Response, error) {
+	query := url.Values{}
+	if params.From != nil {
+		query.Set("from", fmt.Sprint(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", fmt.Sprint(*params.To))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/ticker/"+url.PathEscape(fmt.Sprint(params.Ticker))+"/history", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksTickerByTickerMovingAveragesParams holds the parameters of GetStocksTickerByTickerMovingAverages
+type GetStocksTickerByTickerMovingAveragesParams struct {
+	// Stock ticker symbol
+	Ticker string
+	// Comma-separated indicator names (default: all indicators)
+	Indicators *string
+}
+
+// GetStocksTickerByTickerMovingAverages calls GET /api/v1/stocks/ticker/{ticker}/moving-averages: Get rolling means of a ticker's indicators
+func (c *Client) GetStocksTickerByTickerMovingAverages(ctx context.Context, params GetStocksTickerByTickerMovingAveragesParams) (Response, error) {
+	query := url.Values{}
+	if params.Indicators != nil {
+		query.Set("indicators", fmt.Sprint(*params.Indicators))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/ticker/"+url.PathEscape(fmt.Sprint(params.Ticker))+"/moving-averages", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksTickerByTickerSimilarParams holds the parameters of GetStocksTickerByTickerSimilar
+type GetStocksTickerByTickerSimilarParams struct {
+	// Stock ticker symbol
+	Ticker string
+	// Number of neighbors (default: 10, max: 100)
+	Limit *int
+}
+
+// GetStocksTickerByTickerSimilar calls GET /api/v1/stocks/ticker/{ticker}/similar: Find comparable stocks
+func (c *Client) GetStocksTickerByTickerSimilar(ctx context.Context, params GetStocksTickerByTickerSimilarParams) (Response, error) {
+	query := url.Values{}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/ticker/"+url.PathEscape(fmt.Sprint(params.Ticker))+"/similar", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksByIDParams holds the parameters of GetStocksByID
+type GetStocksByIDParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Related resources to embed: notes
+	Include *string
+}
+
+// GetStocksByID calls GET /api/v1/stocks/{id}: Get stock by ID
+func (c *Client) GetStocksByID(ctx context.Context, params GetStocksByIDParams) (Response, error) {
+	query := url.Values{}
+	if params.Include != nil {
+		query.Set("include", fmt.Sprint(*params.Include))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID)), query, nil, nil, &out)
+	return out, err
+}
+
+// PutStocksByIDParams holds the parameters of PutStocksByID
+type PutStocksByIDParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// ETag the stock must still have
+	IfMatch *string
+	// HTTP date the stock must not have been modified after
+	IfUnmodifiedSince *string
+	Body              *StockUpdateRequest
+}
+
+// PutStocksByID calls PUT /api/v1/stocks/{id}: Update stock by ID
+func (c *Client) PutStocksByID(ctx context.Context, params PutStocksByIDParams) (Response, error) {
+	header := http.Header{}
+	if params.IfMatch != nil {
+		header.Set("If-Match", fmt.Sprint(*params.IfMatch))
+	}
+	if params.IfUnmodifiedSince != nil {
+		header.Set("If-Unmodified-Since", fmt.Sprint(*params.IfUnmodifiedSince))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID)), nil, header, body, &out)
+	return out, err
+}
+
+// DeleteStocksByIDParams holds the parameters of DeleteStocksByID
+type DeleteStocksByIDParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// ETag the stock must still have
+	IfMatch *string
+	// HTTP date the stock must not have been modified after
+	IfUnmodifiedSince *string
+}
+
+// DeleteStocksByID calls DELETE /api/v1/stocks/{id}: Delete stock by ID
+func (c *Client) DeleteStocksByID(ctx context.Context, params DeleteStocksByIDParams) (Response, error) {
+	header := http.Header{}
+	if params.IfMatch != nil {
+		header.Set("If-Match", fmt.Sprint(*params.IfMatch))
+	}
+	if params.IfUnmodifiedSince != nil {
+		header.Set("If-Unmodified-Since", fmt.Sprint(*params.IfUnmodifiedSince))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID)), nil, header, nil, &out)
+	return out, err
+}
+
+// PutStocksByIDClusterParams holds the parameters of PutStocksByIDCluster
+type PutStocksByIDClusterParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID   string
+	Body *ClusterAssignmentRequest
+}
+
+// PutStocksByIDCluster calls PUT /api/v1/stocks/{id}/cluster: Override a stock's cluster
+func (c *Client) PutStocksByIDCluster(ctx context.Context, params PutStocksByIDClusterParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/cluster", nil, nil, body, &out)
+	return out, err
+}
+
+// GetStocksByIDClusterHistoryParams holds the parameters of GetStocksByIDClusterHistory
+type GetStocksByIDClusterHistoryParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+}
+
+// GetStocksByIDClusterHistory calls GET /api/v1/stocks/{id}/cluster/history: Get a stock's cluster override history
+func (c *Client) GetStocksByIDClusterHistory(ctx context.Context, params GetStocksByIDClusterHistoryParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/cluster/history", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksByIDIndicatorsParams holds the parameters of GetStocksByIDIndicators
+type GetStocksByIDIndicatorsParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+}
+
+// GetStocksByIDIndicators calls GET /api/v1/stocks/{id}/indicators: List stock indicators
+func (c *Client) GetStocksByIDIndicators(ctx context.Context, params GetStocksByIDIndicatorsParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/indicators", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksByIDIndicatorsParams holds the parameters of PostStocksByIDIndicators
+type PostStocksByIDIndicatorsParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID   string
+	Body *NumericalIndicatorRequest
+}
+
+// PostStocksByIDIndicators calls POST /api/v1/stocks/{id}/indicators: Add a stock indicator
+func (c *Client) PostStocksByIDIndicators(ctx context.Context, params PostStocksByIDIndicatorsParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/indicators", nil, nil, body, &out)
+	return out, err
+}
+
+// PutStocksByIDIndicatorsByNameParams holds the parameters of PutStocksByIDIndicatorsByName
+type PutStocksByIDIndicatorsByNameParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Indicator name
+	Name string
+	Body *NumericalIndicatorUpdateRequest
+}
+
+// PutStocksByIDIndicatorsByName calls PUT /api/v1/stocks/{id}/indicators/{name}: Correct a stock indicator
+func (c *Client) PutStocksByIDIndicatorsByName(ctx context.Context, params PutStocksByIDIndicatorsByNameParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/indicators/"+url.PathEscape(fmt.Sprint(params.Name)), nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteStocksByIDIndicatorsByNameParams holds the parameters of DeleteStocksByIDIndicatorsByName
+type DeleteStocksByIDIndicatorsByNameParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Indicator name
+	Name string
+}
+
+// DeleteStocksByIDIndicatorsByName calls DELETE /api/v1/stocks/{id}/indicators/{name}: Delete a stock indicator
+func (c *Client) DeleteStocksByIDIndicatorsByName(ctx context.Context, params DeleteStocksByIDIndicatorsByNameParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/indicators/"+url.PathEscape(fmt.Sprint(params.Name)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksByIDNotesParams holds the parameters of GetStocksByIDNotes
+type GetStocksByIDNotesParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+}
+
+// GetStocksByIDNotes calls GET /api/v1/stocks/{id}/notes: List stock notes
+func (c *Client) GetStocksByIDNotes(ctx context.Context, params GetStocksByIDNotesParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/notes", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksByIDNotesParams holds the parameters of PostStocksByIDNotes
+type PostStocksByIDNotesParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID   string
+	Body *NoteRequest
+}
+
+// PostStocksByIDNotes calls POST /api/v1/stocks/{id}/notes: Add a stock note
+func (c *Client) PostStocksByIDNotes(ctx context.Context, params PostStocksByIDNotesParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/notes", nil, nil, body, &out)
+	return out, err
+}
+
+// PutStocksByIDNotesByNoteIDParams holds the parameters of PutStocksByIDNotesByNoteID
+type PutStocksByIDNotesByNoteIDParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Note ID
+	NoteID int
+	Body   *NoteRequest
+}
+
+// PutStocksByIDNotesByNoteID calls PUT /api/v1/stocks/{id}/notes/{note_id}: Edit a stock note
+func (c *Client) PutStocksByIDNotesByNoteID(ctx context.Context, params PutStocksByIDNotesByNoteIDParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/notes/"+url.PathEscape(fmt.Sprint(params.NoteID)), nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteStocksByIDNotesByNoteIDParams holds the parameters of DeleteStocksByIDNotesByNoteID
+type DeleteStocksByIDNotesByNoteIDParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Note ID
+	NoteID int
+}
+
+// DeleteStocksByIDNotesByNoteID calls DELETE /api/v1/stocks/{id}/notes/{note_id}: Delete a stock note
+func (c *Client) DeleteStocksByIDNotesByNoteID(ctx context.Context, params DeleteStocksByIDNotesByNoteIDParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/notes/"+url.PathEscape(fmt.Sprint(params.NoteID)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksByIDPercentileParams holds the parameters of GetStocksByIDPercentile
+type GetStocksByIDPercentileParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Cluster to rank against (default: the stock's own cluster)
+	Cluster *int
+}
+
+// GetStocksByIDPercentile calls GET /api/v1/stocks/{id}/percentile: Get a stock's percentile ranks
+func (c *Client) GetStocksByIDPercentile(ctx context.Context, params GetStocksByIDPercentileParams) (Response, error) {
+	query := url.Values{}
+	if params.Cluster != nil {
+		query.Set("cluster", fmt.Sprint(*params.Cluster))
+	}
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/percentile", query, nil, nil, &out)
+	return out, err
+}
+
+// GetStocksByIDSentimentsParams holds the parameters of GetStocksByIDSentiments
+type GetStocksByIDSentimentsParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+}
+
+// GetStocksByIDSentiments calls GET /api/v1/stocks/{id}/sentiments: List stock sentiments
+func (c *Client) GetStocksByIDSentiments(ctx context.Context, params GetStocksByIDSentimentsParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/sentiments", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksByIDSentimentsParams holds the parameters of PostStocksByIDSentiments
+type PostStocksByIDSentimentsParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID   string
+	Body *RatingSentimentRequest
+}
+
+// PostStocksByIDSentiments calls POST /api/v1/stocks/{id}/sentiments: Add a stock sentiment
+func (c *Client) PostStocksByIDSentiments(ctx context.Context, params PostStocksByIDSentimentsParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/sentiments", nil, nil, body, &out)
+	return out, err
+}
+
+// PutStocksByIDSentimentsByNameParams holds the parameters of PutStocksByIDSentimentsByName
+type PutStocksByIDSentimentsByNameParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Sentiment name
+	Name string
+	Body *RatingSentimentUpdateRequest
+}
+
+// PutStocksByIDSentimentsByName calls PUT /api/v1/stocks/{id}/sentiments/{name}: Correct a stock sentiment
+func (c *Client) PutStocksByIDSentimentsByName(ctx context.Context, params PutStocksByIDSentimentsByNameParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPut, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/sentiments/"+url.PathEscape(fmt.Sprint(params.Name)), nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteStocksByIDSentimentsByNameParams holds the parameters of DeleteStocksByIDSentimentsByName
+type DeleteStocksByIDSentimentsByNameParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Sentiment name
+	Name string
+}
+
+// DeleteStocksByIDSentimentsByName calls DELETE /api/v1/stocks/{id}/sentiments/{name}: Delete a stock sentiment
+func (c *Client) DeleteStocksByIDSentimentsByName(ctx context.Context, params DeleteStocksByIDSentimentsByNameParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/sentiments/"+url.PathEscape(fmt.Sprint(params.Name)), nil, nil, nil, &out)
+	return out, err
+}
+
+// PostStocksByIDTagsParams holds the parameters of PostStocksByIDTags
+type PostStocksByIDTagsParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID   string
+	Body *TagRequest
+}
+
+// PostStocksByIDTags calls POST /api/v1/stocks/{id}/tags: Tag a stock
+func (c *Client) PostStocksByIDTags(ctx context.Context, params PostStocksByIDTagsParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/tags", nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteStocksByIDTagsByTagParams holds the parameters of DeleteStocksByIDTagsByTag
+type DeleteStocksByIDTagsByTagParams struct {
+	// Stock UUID (or numeric ID during the transition period)
+	ID string
+	// Tag name
+	Tag string
+}
+
+// DeleteStocksByIDTagsByTag calls DELETE /api/v1/stocks/{id}/tags/{tag}: Untag a stock
+func (c *Client) DeleteStocksByIDTagsByTag(ctx context.Context, params DeleteStocksByIDTagsByTagParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/stocks/"+url.PathEscape(fmt.Sprint(params.ID))+"/tags/"+url.PathEscape(fmt.Sprint(params.Tag)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetWebhooks calls GET /api/v1/webhooks: List webhook subscriptions
+func (c *Client) GetWebhooks(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/webhooks", nil, nil, nil, &out)
+	return out, err
+}
+
+// PostWebhooksParams holds the parameters of PostWebhooks
+type PostWebhooksParams struct {
+	Body *WebhookSubscriptionRequest
+}
+
+// PostWebhooks calls POST /api/v1/webhooks: Subscribe a URL to webhook events
+func (c *Client) PostWebhooks(ctx context.Context, params PostWebhooksParams) (Response, error) {
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	var out Response
+	err := c.do(ctx, http.MethodPost, "/api/v1/webhooks", nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteWebhooksByIDParams holds the parameters of DeleteWebhooksByID
+type DeleteWebhooksByIDParams struct {
+	// Subscription ID
+	ID int
+}
+
+// DeleteWebhooksByID calls DELETE /api/v1/webhooks/{id}: Delete a webhook subscription
+func (c *Client) DeleteWebhooksByID(ctx context.Context, params DeleteWebhooksByIDParams) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodDelete, "/api/v1/webhooks/"+url.PathEscape(fmt.Sprint(params.ID)), nil, nil, nil, &out)
+	return out, err
+}
+
+// GetWs calls GET /api/v1/ws: Stream stock changes over a WebSocket
+func (c *Client) GetWs(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/api/v1/ws", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHealth calls GET /health: Health check
+func (c *Client) GetHealth(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/health", nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHealthReady calls GET /health/ready: Readiness check
+func (c *Client) GetHealthReady(ctx context.Context) (Response, error) {
+	var out Response
+	err := c.do(ctx, http.MethodGet, "/health/ready", nil, nil, nil, &out)
+	return out, err
+}
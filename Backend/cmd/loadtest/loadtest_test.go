@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dataextractor/client"
+)
+
+// TestParseMix checks weights, implicit weights and rejected entries
+func TestParseMix(t *testing.T) {
+	mix, err := parseMix("filter=6, search ,stats=0")
+	if err != nil {
+		t.Fatalf("parseMix() error = %v", err)
+	}
+	if mix.total != 7 || len(mix.scenarios) != 2 || mix.scenarios[1].name != "search" {
+		t.Errorf("parseMix() = %+v", mix)
+	}
+
+	for _, spec := range []string{"bogus=1", "filter=-1", "filter=x", "stats=0", ""} {
+		if _, err := parseMix(spec); err == nil {
+			t.Errorf("parseMix(%q) error = nil", spec)
+		}
+	}
+}
+
+// TestPercentile checks nearest-rank percentiles
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	sortDurations(latencies)
+
+	for p, want := range map[float64]time.Duration{50: 50 * time.Millisecond, 99: 99 * time.Millisecond, 100: 100 * time.Millisecond, 0: time.Millisecond} {
+		if got := percentile(latencies, p); got != want {
+			t.Errorf("percentile(%g) = %s, want %s", p, got, want)
+		}
+	}
+	if got := percentile(nil, 99); got != 0 {
+		t.Errorf("percentile(nil) = %s, want 0", got)
+	}
+}
+
+// TestRun drives a short run against a stub server and checks the report and thresholds
+func TestRun(t *testing.T) {
+	var searches atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/stocks/clusters":
+			w.Write([]byte(`{"data":[0,1]}`))
+		case strings.HasSuffix(r.URL.Path, "/filter"):
+			w.Write([]byte(`{"data":[{"ticker":"AAPL"},{"ticker":"MSFT"}]}`))
+		case r.URL.Path == "/api/v1/search":
+			searches.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Internal server error"}`))
+		default:
+			w.Write([]byte(`{"data":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	api := client.New(server.URL)
+	data, err := discover(context.Background(), api)
+	if err != nil {
+		t.Fatalf("discover() error = %v", err)
+	}
+	if len(data.clusters) != 2 || len(data.tickers) != 2 {
+		t.Fatalf("discover() = %+v", data)
+	}
+
+	mix, _ := parseMix("filter=1,search=1,stats=1")
+	rec := newRecorder(time.Now())
+	run(context.Background(), api, mix, data, rec, runOptions{
+		rps:         200,
+		duration:    300 * time.Millisecond,
+		concurrency: 8,
+		rng:         rand.New(rand.NewSource(1)),
+	})
+
+	s := rec.summarize()
+	total := s.total()
+	if total.requests == 0 || total.failed != int(searches.Load()) {
+		t.Fatalf("total = %+v, searches = %d", total, searches.Load())
+	}
+	var out strings.Builder
+	s.print(&out)
+	if !strings.Contains(out.String(), "search: ") || !strings.Contains(out.String(), "failed with 500") {
+		t.Errorf("report missing the error breakdown:\n%s", out.String())
+	}
+	if failures := s.check(0, 0.01); len(failures) != 1 || !strings.Contains(failures[0], "error rate") {
+		t.Errorf("check() = %v, want the error rate failure", failures)
+	}
+	if failures := s.check(time.Nanosecond, 1); len(failures) != 1 || !strings.Contains(failures[0], "p99") {
+		t.Errorf("check() = %v, want the p99 failure", failures)
+	}
+}
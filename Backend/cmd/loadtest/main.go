@@ -0,0 +1,146 @@
+// Command loadtest replays a weighted mix of read requests (cluster filters, search and stats)
+// against a running instance at a fixed rate and reports latency percentiles per scenario, to
+// catch performance regressions in the repository layer before a release. Run it from Backend:
+//
+//	go run ./cmd/loadtest -base http://localhost:8887 -rps 50 -duration 2m -mix filter=6,search=3,stats=1
+//
+// Requests are sent open-loop: one is started every 1/rps whether or not earlier ones finished, so
+// a slow server shows up as latency instead of a lower request rate. Ticks that find -concurrency
+// requests in flight are counted as dropped. Long -duration values turn it into a soak test, with
+// a progress line every -report-interval. The command exits with status 1 when the overall p99
+// exceeds -max-p99 or the error rate exceeds -max-error-rate.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"dataextractor/client"
+)
+
+func main() {
+	base := flag.String("base", "http://localhost:8887", "base URL of the instance under test")
+	rps := flag.Float64("rps", 20, "target requests per second")
+	duration := flag.Duration("duration", time.Minute, "how long to send requests (hours for a soak test)")
+	warmup := flag.Duration("warmup", 5*time.Second, "initial period whose requests are left out of the results")
+	mixFlag := flag.String("mix", "filter=6,search=3,stats=1", "scenario weights as name=weight pairs ("+scenarioNames()+")")
+	concurrency := flag.Int("concurrency", 64, "maximum requests in flight")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of a single request")
+	reportInterval := flag.Duration("report-interval", 10*time.Second, "interval between progress lines (0 disables them)")
+	apiKey := flag.String("api-key", "", "X-API-Key sent with every request")
+	token := flag.String("token", "", "bearer token sent with every request")
+	maxP99 := flag.Duration("max-p99", 0, "fail when the overall p99 latency exceeds this (0 disables the check)")
+	maxErrorRate := flag.Float64("max-error-rate", 0.01, "fail when the share of failed requests exceeds this")
+	seed := flag.Int64("seed", 1, "seed of the request mix and parameters, for repeatable runs")
+	flag.Parse()
+
+	if *rps <= 0 || *concurrency <= 0 {
+		log.Fatal("-rps and -concurrency must be positive")
+	}
+	mix, err := parseMix(*mixFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	api := client.New(*base)
+	api.HTTPClient.Timeout = *timeout
+	if *apiKey != "" {
+		api.Header.Set("X-API-Key", *apiKey)
+	}
+	if *token != "" {
+		api.Header.Set("Authorization", "Bearer "+*token)
+	}
+
+	// Stop early on Ctrl-C and still report what was measured
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	data, err := discover(ctx, api)
+	if err != nil {
+		log.Fatalf("Failed to discover test data from %s: %v", *base, err)
+	}
+	log.Printf("Testing %s at %.1f rps for %s (%d clusters, %d tickers)", *base, *rps, *duration, len(data.clusters), len(data.tickers))
+
+	rec := newRecorder(time.Now().Add(*warmup))
+	run(ctx, api, mix, data, rec, runOptions{
+		rps:            *rps,
+		duration:       *duration,
+		concurrency:    *concurrency,
+		reportInterval: *reportInterval,
+		rng:            rand.New(rand.NewSource(*seed)),
+	})
+
+	summary := rec.summarize()
+	summary.print(os.Stdout)
+	if failures := summary.check(*maxP99, *maxErrorRate); len(failures) > 0 {
+		for _, failure := range failures {
+			log.Printf("FAIL: %s", failure)
+		}
+		os.Exit(1)
+	}
+}
+
+// runOptions pace a run
+type runOptions struct {
+	rps            float64
+	duration       time.Duration
+	concurrency    int
+	reportInterval time.Duration
+	rng            *rand.Rand
+}
+
+// run starts one request every 1/rps until the duration is over or ctx is cancelled, then waits
+// for the requests in flight
+func run(ctx context.Context, api *client.Client, mix *scenarioMix, data testData, rec *recorder, opts runOptions) {
+	ctx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+
+	pace := time.NewTicker(time.Duration(float64(time.Second) / opts.rps))
+	defer pace.Stop()
+	var progress <-chan time.Time
+	if opts.reportInterval > 0 {
+		ticker := time.NewTicker(opts.reportInterval)
+		defer ticker.Stop()
+		progress = ticker.C
+	}
+
+	started := time.Now()
+	inFlight := make(chan struct{}, opts.concurrency)
+	var wg sync.WaitGroup
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-progress:
+			log.Printf("%s elapsed: %s", time.Since(started).Round(time.Second), rec.progress())
+		case <-pace.C:
+			// Parameters are drawn here because rand.Rand is not safe for concurrent use
+			sc := mix.pick(opts.rng)
+			call := sc.build(opts.rng, data)
+
+			select {
+			case inFlight <- struct{}{}:
+			default:
+				rec.drop(sc.name)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-inFlight }()
+				// Requests outlive the run's deadline so the last ones are not reported as failures
+				start := time.Now()
+				err := call(context.WithoutCancel(ctx), api)
+				rec.record(sc.name, start, time.Since(start), err)
+			}()
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"dataextractor/client"
+)
+
+// reportedPercentiles are the latency percentiles in the summary
+var reportedPercentiles = []float64{50, 90, 95, 99}
+
+// scenarioResults are the measurements of one scenario
+type scenarioResults struct {
+	latencies []time.Duration
+	errors    map[string]int // by status code, or "transport" for requests without a response
+	dropped   int
+}
+
+// recorder collects the results of concurrent requests; requests started before measureFrom
+// (the warm-up) are not recorded
+type recorder struct {
+	measureFrom time.Time
+
+	mu        sync.Mutex
+	scenarios map[string]*scenarioResults
+	first     time.Time
+	last      time.Time
+}
+
+// newRecorder creates a recorder that measures requests started from measureFrom on
+func newRecorder(measureFrom time.Time) *recorder {
+	return &recorder{measureFrom: measureFrom, scenarios: make(map[string]*scenarioResults)}
+}
+
+// results returns the entry of scenario; callers hold mu
+func (r *recorder) results(scenario string) *scenarioResults {
+	results, ok := r.scenarios[scenario]
+	if !ok {
+		results = &scenarioResults{errors: make(map[string]int)}
+		r.scenarios[scenario] = results
+	}
+	return results
+}
+
+// record adds a request of scenario started at start that took latency and failed with err
+func (r *recorder) record(scenario string, start time.Time, latency time.Duration, err error) {
+	if start.Before(r.measureFrom) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := r.results(scenario)
+	results.latencies = append(results.latencies, latency)
+	if err != nil {
+		results.errors[errorClass(err)]++
+	}
+	if r.first.IsZero() || start.Before(r.first) {
+		r.first = start
+	}
+	if end := start.Add(latency); end.After(r.last) {
+		r.last = end
+	}
+}
+
+// drop counts a request of scenario that was not sent because too many were in flight
+func (r *recorder) drop(scenario string) {
+	if time.Now().Before(r.measureFrom) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results(scenario).dropped++
+}
+
+// errorClass labels a failed request by its status code
+func errorClass(err error) string {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return strconv.Itoa(apiErr.StatusCode)
+	}
+	return "transport"
+}
+
+// progress summarizes the results so far in one line
+func (r *recorder) progress() string {
+	r.mu.Lock()
+	var all []time.Duration
+	failed, dropped := 0, 0
+	for _, results := range r.scenarios {
+		all = append(all, results.latencies...)
+		for _, count := range results.errors {
+			failed += count
+		}
+		dropped += results.dropped
+	}
+	r.mu.Unlock()
+
+	sortDurations(all)
+	return fmt.Sprintf("%d requests, %d errors, %d dropped, p50 %s, p99 %s",
+		len(all), failed, dropped, percentile(all, 50), percentile(all, 99))
+}
+
+// summaryRow is the result line of one scenario or of the whole run
+type summaryRow struct {
+	name        string
+	requests    int
+	failed      int
+	dropped     int
+	errors      map[string]int
+	percentiles []time.Duration
+	max         time.Duration
+}
+
+// summary is the final report
+type summary struct {
+	elapsed time.Duration
+	rows    []summaryRow // one per scenario in name order, then the total
+}
+
+// summarize computes the per-scenario and overall figures
+func (r *recorder) summarize() summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.scenarios))
+	for name := range r.scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows []summaryRow
+	total := &scenarioResults{errors: make(map[string]int)}
+	for _, name := range names {
+		results := r.scenarios[name]
+		rows = append(rows, summarizeResults(name, results))
+		total.latencies = append(total.latencies, results.latencies...)
+		for class, count := range results.errors {
+			total.errors[class] += count
+		}
+		total.dropped += results.dropped
+	}
+	rows = append(rows, summarizeResults("total", total))
+	return summary{elapsed: r.last.Sub(r.first), rows: rows}
+}
+
+// summarizeResults computes the row of one set of results
+func summarizeResults(name string, results *scenarioResults) summaryRow {
+	latencies := append([]time.Duration(nil), results.latencies...)
+	sortDurations(latencies)
+
+	row := summaryRow{name: name, requests: len(latencies), dropped: results.dropped, errors: results.errors}
+	for _, count := range results.errors {
+		row.failed += count
+	}
+	for _, p := range reportedPercentiles {
+		row.percentiles = append(row.percentiles, percentile(latencies, p))
+	}
+	if len(latencies) > 0 {
+		row.max = latencies[len(latencies)-1]
+	}
+	return row
+}
+
+// total returns the overall row
+func (s summary) total() summaryRow {
+	return s.rows[len(s.rows)-1]
+}
+
+// print writes the report as a table followed by the error breakdown
+func (s summary) print(w io.Writer) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(table, "scenario\trequests\terrors\tdropped\trps\t")
+	for _, p := range reportedPercentiles {
+		fmt.Fprintf(table, "p%g\t", p)
+	}
+	fmt.Fprint(table, "max\t\n")
+	for _, row := range s.rows {
+		rate := 0.0
+		if s.elapsed > 0 {
+			rate = float64(row.requests) / s.elapsed.Seconds()
+		}
+		fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%.1f\t", row.name, row.requests, row.failed, row.dropped, rate)
+		for _, latency := range row.percentiles {
+			fmt.Fprintf(table, "%s\t", formatLatency(latency))
+		}
+		fmt.Fprintf(table, "%s\t\n", formatLatency(row.max))
+	}
+	table.Flush()
+
+	for _, row := range s.rows[:len(s.rows)-1] {
+		classes := make([]string, 0, len(row.errors))
+		for class := range row.errors {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			fmt.Fprintf(w, "%s: %d requests failed with %s\n", row.name, row.errors[class], class)
+		}
+	}
+}
+
+// check returns the thresholds the run missed; maxP99 0 disables the latency check
+func (s summary) check(maxP99 time.Duration, maxErrorRate float64) []string {
+	var failures []string
+	total := s.total()
+	if total.requests == 0 {
+		return []string{"no requests were measured (is the duration longer than the warm-up?)"}
+	}
+	p99 := total.percentiles[len(reportedPercentiles)-1]
+	if maxP99 > 0 && p99 > maxP99 {
+		failures = append(failures, fmt.Sprintf("p99 latency %s exceeds %s", formatLatency(p99), maxP99))
+	}
+	if rate := float64(total.failed) / float64(total.requests); rate > maxErrorRate {
+		failures = append(failures, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%", rate*100, maxErrorRate*100))
+	}
+	return failures
+}
+
+// sortDurations sorts latencies in ascending order
+func sortDurations(latencies []time.Duration) {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted latencies (0 when empty)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// formatLatency rounds a latency for display
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
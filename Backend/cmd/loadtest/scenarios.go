@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dataextractor/client"
+)
+
+// testData holds the clusters and tickers requests are drawn from, discovered from the instance so
+// the queries hit real rows
+type testData struct {
+	clusters []int
+	tickers  []string
+}
+
+// discoveryPageSize is the number of stocks read to collect tickers
+const discoveryPageSize = 100
+
+// discover reads the clusters and a page of tickers from the instance under test
+func discover(ctx context.Context, api *client.Client) (testData, error) {
+	var data testData
+
+	clusters, err := api.GetStocksClusters(ctx)
+	if err != nil {
+		return data, err
+	}
+	items, _ := clusters["data"].([]interface{})
+	for _, item := range items {
+		if cluster, ok := item.(float64); ok {
+			data.clusters = append(data.clusters, int(cluster))
+		}
+	}
+	if len(data.clusters) == 0 {
+		data.clusters = []int{0}
+	}
+
+	perPage := discoveryPageSize
+	page, err := api.GetStocksClusterByClusterFilter(ctx, client.GetStocksClusterByClusterFilterParams{
+		Cluster: data.clusters[0],
+		PerPage: &perPage,
+	})
+	if err != nil {
+		return data, err
+	}
+	stocks, _ := page["data"].([]interface{})
+	seen := make(map[string]bool)
+	for _, stock := range stocks {
+		fields, _ := stock.(map[string]interface{})
+		if ticker, _ := fields["ticker"].(string); ticker != "" && !seen[ticker] {
+			seen[ticker] = true
+			data.tickers = append(data.tickers, ticker)
+		}
+	}
+	if len(data.tickers) == 0 {
+		// An empty database still exercises the query paths
+		data.tickers = []string{"AAPL", "MSFT", "NVDA"}
+	}
+	return data, nil
+}
+
+// apiCall is one prepared request
+type apiCall func(ctx context.Context, api *client.Client) error
+
+// scenario builds requests of one kind with randomized parameters
+type scenario struct {
+	name  string
+	build func(rng *rand.Rand, data testData) apiCall
+}
+
+// filterSortColumns are the sort orders exercised by the filter scenario
+var filterSortColumns = []string{"date", "final_score", "ticker", "final_score desc, date desc"}
+
+// scenarios are the request kinds a mix can name
+var scenarios = map[string]scenario{
+	// Cluster filter pages with varying sort order and page, the heaviest repository query
+	"filter": {name: "filter", build: func(rng *rand.Rand, data testData) apiCall {
+		params := client.GetStocksClusterByClusterFilterParams{
+			Cluster: data.clusters[rng.Intn(len(data.clusters))],
+			SortBy:  &filterSortColumns[rng.Intn(len(filterSortColumns))],
+		}
+		page := 1 + rng.Intn(5)
+		params.Page = &page
+		return func(ctx context.Context, api *client.Client) error {
+			_, err := api.GetStocksClusterByClusterFilter(ctx, params)
+			return err
+		}
+	}},
+	// Omnibox search with a one to three letter ticker prefix
+	"search": {name: "search", build: func(rng *rand.Rand, data testData) apiCall {
+		ticker := data.tickers[rng.Intn(len(data.tickers))]
+		params := client.GetSearchParams{Q: ticker[:1+rng.Intn(min(3, len(ticker)))]}
+		return func(ctx context.Context, api *client.Client) error {
+			_, err := api.GetSearch(ctx, params)
+			return err
+		}
+	}},
+	// Database-wide and per-ticker statistics
+	"stats": {name: "stats", build: func(rng *rand.Rand, data testData) apiCall {
+		if rng.Intn(2) == 0 {
+			return func(ctx context.Context, api *client.Client) error {
+				_, err := api.GetStocksDatabaseStats(ctx)
+				return err
+			}
+		}
+		params := client.GetStocksStatsByTickerParams{Ticker: data.tickers[rng.Intn(len(data.tickers))]}
+		return func(ctx context.Context, api *client.Client) error {
+			_, err := api.GetStocksStatsByTicker(ctx, params)
+			return err
+		}
+	}},
+}
+
+// scenarioNames lists the scenarios for the usage text
+func scenarioNames() string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// scenarioMix picks scenarios in proportion to their weights
+type scenarioMix struct {
+	scenarios []scenario
+	weights   []int
+	total     int
+}
+
+// parseMix parses name=weight pairs (e.g. "filter=6,search=3,stats=1"); a name without a weight
+// counts once, and weight 0 leaves the scenario out
+func parseMix(spec string) (*scenarioMix, error) {
+	mix := &scenarioMix{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		weight := 1
+		if found {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight in mix entry %q", item)
+			}
+		}
+		sc, ok := scenarios[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, scenarioNames())
+		}
+		if weight == 0 {
+			continue
+		}
+		mix.scenarios = append(mix.scenarios, sc)
+		mix.weights = append(mix.weights, weight)
+		mix.total += weight
+	}
+	if mix.total == 0 {
+		return nil, fmt.Errorf("the mix %q selects no scenario", spec)
+	}
+	return mix, nil
+}
+
+// pick draws a scenario
+func (m *scenarioMix) pick(rng *rand.Rand) scenario {
+	n := rng.Intn(m.total)
+	for i, weight := range m.weights {
+		if n < weight {
+			return m.scenarios[i]
+		}
+		n -= weight
+	}
+	return m.scenarios[len(m.scenarios)-1]
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// generateGo renders the operations and models of the Go client package; the transport lives
+// in the hand-written client.go of the same package
+func generateGo(s *spec, pkg string) ([]byte, error) {
+	ops, err := s.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+
+	for _, def := range s.definitions() {
+		fmt.Fprintf(&b, "// %s is a request model of the API\n", def.Name)
+		fmt.Fprintf(&b, "type %s struct {\n", def.Name)
+		for _, name := range sortedProperties(def.Schema) {
+			prop := def.Schema.Properties[name]
+			required := isRequired(def.Schema, name)
+			tag := name
+			if !required {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", pascal(name, true), goFieldType(prop, required), tag)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, op := range ops {
+		if hasParams(op) {
+			fmt.Fprintf(&b, "// %sParams holds the parameters of %s\n", op.GoName, op.GoName)
+			fmt.Fprintf(&b, "type %sParams struct {\n", op.GoName)
+			for _, p := range op.Params {
+				if p.Description != "" {
+					fmt.Fprintf(&b, "\t// %s\n", p.Description)
+				}
+				fmt.Fprintf(&b, "\t%s %s\n", goParamName(p), goFieldType(&schema{Type: p.Type, Items: p.Items}, p.Required))
+			}
+			if op.Body != nil {
+				fmt.Fprintf(&b, "\tBody %s\n", goFieldType(op.Body.Schema, false))
+			}
+			b.WriteString("}\n\n")
+		}
+		writeGoMethod(&b, op)
+	}
+
+	// Only import what the generated code uses
+	var header strings.Builder
+	header.WriteString("// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&header, "package %s\n\nimport (\n", pkg)
+	for _, imp := range []struct{ path, use string }{{"context", "context."}, {"fmt", "fmt."}, {"net/http", "http."}, {"net/url", "url."}} {
+		if strings.Contains(b.String(), imp.use) {
+			fmt.Fprintf(&header, "\t%q\n", imp.path)
+		}
+	}
+	header.WriteString(")\n\n")
+
+	src, err := format.Source([]byte(header.String() + b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("generated Go client does not compile: %w", err)
+	}
+	return src, nil
+}
+
+// writeGoMethod renders one client method
+func writeGoMethod(b *strings.Builder, op operation) {
+	result := "Response"
+	if op.Binary {
+		result = "[]byte"
+	}
+	args := "ctx context.Context"
+	if hasParams(op) {
+		args += fmt.Sprintf(", params %sParams", op.GoName)
+	}
+
+	fmt.Fprintf(b, "// %s calls %s %s: %s\n", op.GoName, op.Method, op.Path, strings.TrimSpace(op.Summary))
+	fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", op.GoName, args, result)
+
+	query, header := "nil", "nil"
+	if writeGoValues(b, op, "query") {
+		query = "query"
+	}
+	if writeGoValues(b, op, "header") {
+		header = "header"
+	}
+	body := "nil"
+	if op.Body != nil {
+		b.WriteString("\tvar body interface{}\n\tif params.Body != nil {\n\t\tbody = params.Body\n\t}\n")
+		body = "body"
+	}
+	if writeGoForm(b, op) {
+		body = "form"
+	}
+
+	fmt.Fprintf(b, "\tvar out %s\n", result)
+	fmt.Fprintf(b, "\terr := c.do(ctx, http.Method%s, %s, %s, %s, %s, &out)\n", pascal(strings.ToLower(op.Method), false), goPath(op), query, header, body)
+	b.WriteString("\treturn out, err\n}\n\n")
+}
+
+// writeGoValues renders the url.Values (query) or http.Header (header) of the parameters
+// located in "in" and reports whether there were any
+func writeGoValues(b *strings.Builder, op operation, in string) bool {
+	var params []specParameter
+	for _, p := range op.Params {
+		if p.In == in {
+			params = append(params, p)
+		}
+	}
+	if len(params) == 0 {
+		return false
+	}
+
+	if in == "query" {
+		b.WriteString("\tquery := url.Values{}\n")
+	} else {
+		b.WriteString("\theader := http.Header{}\n")
+	}
+	for _, p := range params {
+		field := "params." + goParamName(p)
+		switch {
+		case p.Type == "array":
+			fmt.Fprintf(b, "\tfor _, value := range %s {\n\t\t%s.Add(%q, fmt.Sprint(value))\n\t}\n", field, in, p.Name)
+		case p.Required:
+			fmt.Fprintf(b, "\t%s.Set(%q, fmt.Sprint(%s))\n", in, p.Name, field)
+		default:
+			fmt.Fprintf(b, "\tif %s != nil {\n\t\t%s.Set(%q, fmt.Sprint(*%s))\n\t}\n", field, in, p.Name, field)
+		}
+	}
+	return true
+}
+
+// writeGoForm renders the formFiles of the multipart (formData) file parameters and reports
+// whether there were any
+func writeGoForm(b *strings.Builder, op operation) bool {
+	var params []specParameter
+	for _, p := range op.Params {
+		if p.In == "formData" {
+			params = append(params, p)
+		}
+	}
+	if len(params) == 0 {
+		return false
+	}
+
+	b.WriteString("\tform := formFiles{}\n")
+	for _, p := range params {
+		field := "params." + goParamName(p)
+		if p.Required {
+			fmt.Fprintf(b, "\tform[%q] = %s\n", p.Name, field)
+		} else {
+			fmt.Fprintf(b, "\tif %s != nil {\n\t\tform[%q] = *%s\n\t}\n", field, p.Name, field)
+		}
+	}
+	return true
+}
+
+// goPath renders the request path expression with the escaped path parameters
+func goPath(op operation) string {
+	path := op.Path
+	for _, p := range op.Params {
+		if p.In == "path" {
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", `" + url.PathEscape(fmt.Sprint(params.`+goParamName(p)+`)) + "`)
+		}
+	}
+	return strings.TrimSuffix(`"`+path+`"`, ` + ""`)
+}
+
+// goParamName is the Params field of a parameter
+func goParamName(p specParameter) string {
+	return pascal(strings.ToLower(p.Name), true)
+}
+
+// goFieldType maps a schema to a Go type; optional scalars and models are pointers so that
+// they can be left out
+func goFieldType(sch *schema, required bool) string {
+	if sch == nil {
+		return "interface{}"
+	}
+	var t string
+	switch {
+	case sch.Ref != "":
+		t = typeName(sch.Ref)
+	case sch.Type == "file":
+		t = "File"
+	case sch.Type == "string":
+		t = "string"
+	case sch.Type == "integer":
+		t = "int"
+	case sch.Type == "number":
+		t = "float64"
+	case sch.Type == "boolean":
+		t = "bool"
+	case sch.Type == "array":
+		return "[]" + goFieldType(sch.Items, true)
+	case sch.Type == "object":
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+	if required {
+		return t
+	}
+	return "*" + t
+}
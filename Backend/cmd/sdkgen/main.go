@@ -0,0 +1,63 @@
+// Command sdkgen generates the API clients from the OpenAPI (Swagger 2.0) document produced by
+// swag: a typed TypeScript fetch client for the frontend and the Go client package. Run it from
+// Backend after regenerating the docs (go generate does both):
+//
+//	go run ./cmd/sdkgen
+//
+// With -check it only reports whether the committed clients match the document.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "docs/v1/v1_swagger.json", "OpenAPI document")
+	tsOut := flag.String("ts", "../UI/vue-project/src/services/generated/apiClient.ts", "TypeScript client output")
+	goOut := flag.String("go", "client/client_gen.go", "Go client output")
+	check := flag.Bool("check", false, "fail when the outputs are out of date instead of writing them")
+	flag.Parse()
+
+	outputs, err := generate(*specPath, *tsOut, *goOut)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for path, content := range outputs {
+		if *check {
+			current, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(current, content) {
+				log.Fatalf("%s is out of date; run go run ./cmd/sdkgen", path)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+}
+
+// generate renders both clients from the document at specPath, keyed by output path
+func generate(specPath, tsOut, goOut string) (map[string][]byte, error) {
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := generateTypeScript(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TypeScript client: %w", err)
+	}
+	goSrc, err := generateGo(s, filepath.Base(filepath.Dir(goOut)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Go client: %w", err)
+	}
+	return map[string][]byte{tsOut: []byte(ts), goOut: goSrc}, nil
+}
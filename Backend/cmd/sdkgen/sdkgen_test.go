@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestClientsUpToDate fails when the committed clients no longer match the OpenAPI document, so
+// an API change cannot land without regenerating them (go run ./cmd/sdkgen from Backend)
+func TestClientsUpToDate(t *testing.T) {
+	outputs, err := generate("../../docs/v1/v1_swagger.json", "../../../UI/vue-project/src/services/generated/apiClient.ts", "../../client/client_gen.go")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	for path, want := range outputs {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go run ./cmd/sdkgen from Backend", path)
+		}
+	}
+}
+
+// TestOperationName checks the names derived from method and path
+func TestOperationName(t *testing.T) {
+	testCases := []struct {
+		method, path, want, wantGo string
+	}{
+		{method: "get", path: "/api/v1/stocks", want: "getStocks", wantGo: "GetStocks"},
+		{method: "get", path: "/api/v1/stocks/{id}/notes", want: "getStocksByIdNotes", wantGo: "GetStocksByIDNotes"},
+		{method: "delete", path: "/api/v1/stocks/{id}/notes/{note_id}", want: "deleteStocksByIdNotesByNoteId", wantGo: "DeleteStocksByIDNotesByNoteID"},
+		{method: "post", path: "/api/v1/stocks/import-enriched", want: "postStocksImportEnriched", wantGo: "PostStocksImportEnriched"},
+	}
+
+	for _, tc := range testCases {
+		if got := operationName(tc.method, tc.path, false); got != tc.want {
+			t.Errorf("operationName(%s %s) = %s, want %s", tc.method, tc.path, got, tc.want)
+		}
+		if got := operationName(tc.method, tc.path, true); got != tc.wantGo {
+			t.Errorf("operationName(%s %s, go) = %s, want %s", tc.method, tc.path, got, tc.wantGo)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// apiPrefix is stripped from paths when deriving operation names
+const apiPrefix = "/api/v1"
+
+// spec is the subset of a Swagger 2.0 document the generator reads
+type spec struct {
+	Paths       map[string]map[string]specOperation `json:"paths"`
+	Definitions map[string]*schema                  `json:"definitions"`
+}
+
+type specOperation struct {
+	OperationID string                  `json:"operationId"`
+	Summary     string                  `json:"summary"`
+	Parameters  []specParameter         `json:"parameters"`
+	Responses   map[string]specResponse `json:"responses"`
+}
+
+type specParameter struct {
+	Name        string   `json:"name"`
+	In          string   `json:"in"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+	Items       *schema  `json:"items"`
+	Enum        []string `json:"enum"`
+	Schema      *schema  `json:"schema"`
+}
+
+type specResponse struct {
+	Schema *schema `json:"schema"`
+}
+
+// schema is a Swagger schema object; enums are only generated for strings
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Items      *schema            `json:"items"`
+	Enum       []string           `json:"enum"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+}
+
+// operation is an API operation in the shape both emitters consume
+type operation struct {
+	Name    string // lowerCamel, e.g. getStocksById
+	GoName  string // exported Go name, e.g. GetStocksByID
+	Method  string // upper case HTTP method
+	Path    string
+	Summary string
+	Params  []specParameter // path, query and header parameters
+	Body    *specParameter
+	Binary  bool // the success response is a file
+}
+
+// definition is a named request/response model
+type definition struct {
+	Name   string
+	Schema *schema
+}
+
+// loadSpec reads and decodes a Swagger JSON document from a file, or from a running server when
+// path is an http(s) URL (e.g. http://localhost:8887/api/v1/openapi.json)
+func loadSpec(path string) (*spec, error) {
+	data, err := readSpec(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode spec %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func readSpec(path string) ([]byte, error) {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		return os.ReadFile(path)
+	}
+	resp, err := http.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// operations returns the operations of the spec ordered by path then method
+func (s *spec) operations() ([]operation, error) {
+	var ops []operation
+	seen := map[string]string{}
+	for path, methods := range s.Paths {
+		for method, op := range methods {
+			name, goName := op.OperationID, pascal(op.OperationID, true)
+			if name == "" {
+				name, goName = operationName(method, path, false), operationName(method, path, true)
+			}
+			if previous, ok := seen[name]; ok {
+				return nil, fmt.Errorf("operation name %s of %s %s clashes with %s", name, strings.ToUpper(method), path, previous)
+			}
+			seen[name] = strings.ToUpper(method) + " " + path
+
+			o := operation{Name: name, GoName: goName, Method: strings.ToUpper(method), Path: path, Summary: op.Summary}
+			for _, p := range op.Parameters {
+				if p.In == "body" {
+					body := p
+					o.Body = &body
+					continue
+				}
+				o.Params = append(o.Params, p)
+			}
+			for code, resp := range op.Responses {
+				if strings.HasPrefix(code, "2") && resp.Schema != nil && resp.Schema.Type == "file" {
+					o.Binary = true
+				}
+			}
+			ops = append(ops, o)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return methodOrder(ops[i].Method) < methodOrder(ops[j].Method)
+	})
+	return ops, nil
+}
+
+// definitions returns the models of the spec ordered by name
+func (s *spec) definitions() []definition {
+	defs := make([]definition, 0, len(s.Definitions))
+	for name, sch := range s.Definitions {
+		defs = append(defs, definition{Name: typeName(name), Schema: sch})
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+func methodOrder(method string) int {
+	switch method {
+	case "GET":
+		return 0
+	case "POST":
+		return 1
+	case "PUT":
+		return 2
+	case "PATCH":
+		return 3
+	}
+	return 4
+}
+
+// operationName derives a name from the method and path: static segments are appended in
+// PascalCase and path parameters as By<Param>, e.g. GET /api/v1/stocks/{id}/notes -> getStocksByIdNotes.
+// The Go form is exported and writes initialisms in upper case (GetStocksByIDNotes).
+func operationName(method, path string, goName bool) string {
+	var b strings.Builder
+	if goName {
+		b.WriteString(pascal(strings.ToLower(method), false))
+	} else {
+		b.WriteString(strings.ToLower(method))
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(path, apiPrefix), "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") {
+			b.WriteString("By")
+			segment = strings.Trim(segment, "{}")
+		}
+		b.WriteString(pascal(segment, goName))
+	}
+	return b.String()
+}
+
+// typeName strips the Go package qualifier from a definition name or reference
+func typeName(ref string) string {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// goInitialisms are the words written in upper case in Go identifiers
+var goInitialisms = map[string]bool{"id": true, "url": true, "uuid": true, "api": true, "json": true, "http": true}
+
+// pascal joins the words of s (split on '-', '_', '.' and spaces) in PascalCase, writing Go
+// initialisms in upper case when initialisms is set
+func pascal(s string, initialisms bool) string {
+	words := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' || r == '.' || r == ' ' })
+	var b strings.Builder
+	for _, word := range words {
+		if initialisms && goInitialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// isRequired reports whether property is listed in the required properties of a schema
+func isRequired(sch *schema, property string) bool {
+	for _, name := range sch.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedProperties returns the property names of a schema in alphabetical order
+func sortedProperties(sch *schema) []string {
+	names := make([]string, 0, len(sch.Properties))
+	for name := range sch.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
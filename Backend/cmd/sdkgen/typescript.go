@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// identifier matches property names usable unquoted in TypeScript
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsRuntime is the fetch wrapper shared by the generated methods
+const tsRuntime = `/** JSON response envelope ({ data, count, message, ... }) */
+export type ApiResponse = Record<string, unknown>
+
+/** Error response of the API; body is the { error, details } envelope when the server sent one */
+export class ApiError extends Error {
+  readonly status: number
+  readonly body: unknown
+
+  constructor(status: number, statusText: string, body: unknown) {
+    super(` + "`API request failed: ${status} ${statusText}`" + `)
+    this.name = 'ApiError'
+    this.status = status
+    this.body = body
+  }
+}
+
+export interface ApiClientOptions {
+  /** Backend origin, e.g. http://localhost:8887 */
+  baseUrl: string
+  /** Headers sent with every request (e.g. Authorization or X-API-Key credentials, X-Actor) */
+  headers?: Record<string, string>
+  fetch?: typeof fetch
+}
+
+type QueryValue = string | number | boolean | string[] | undefined | null
+
+interface RequestOptions {
+  query?: Record<string, QueryValue>
+  headers?: Record<string, string | undefined>
+  body?: unknown
+  /** multipart/form-data fields (file uploads); sent instead of body */
+  form?: Record<string, Blob | string | undefined>
+  binary?: boolean
+}
+
+export class ApiClient {
+  private readonly baseUrl: string
+  private readonly headers: Record<string, string>
+  private readonly fetchFn: typeof fetch
+
+  constructor(options: ApiClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, '')
+    this.headers = options.headers ?? {}
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis)
+  }
+
+  private async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const search = new URLSearchParams()
+    for (const [key, value] of Object.entries(options.query ?? {})) {
+      if (value === undefined || value === null || value === '') {
+        continue
+      }
+      if (Array.isArray(value)) {
+        value.forEach((item) => search.append(key, item))
+      } else {
+        search.set(key, String(value))
+      }
+    }
+    const headers: Record<string, string> = { ...this.headers }
+    for (const [key, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined) {
+        headers[key] = value
+      }
+    }
+    let body: BodyInit | undefined
+    if (options.form !== undefined) {
+      // fetch sets the multipart Content-Type with its boundary
+      const form = new FormData()
+      for (const [key, value] of Object.entries(options.form)) {
+        if (value !== undefined) {
+          form.append(key, value)
+        }
+      }
+      body = form
+    } else if (options.body !== undefined) {
+      headers['Content-Type'] = 'application/json'
+      body = JSON.stringify(options.body)
+    }
+
+    const qs = search.toString()
+    const response = await this.fetchFn(` + "`${this.baseUrl}${path}${qs ? `?${qs}` : ''}`" + `, {
+      method,
+      headers,
+      body,
+    })
+    if (!response.ok) {
+      const body = await response.json().catch(() => undefined)
+      throw new ApiError(response.status, response.statusText, body)
+    }
+    if (options.binary) {
+      return (await response.blob()) as T
+    }
+    if (response.status === 204) {
+      return undefined as T
+    }
+    return (await response.json()) as T
+  }
+`
+
+// generateTypeScript renders the typed fetch client
+func generateTypeScript(s *spec) (string, error) {
+	ops, err := s.operations()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.\n")
+	b.WriteString("// Regenerate with `npm run generate:client` (or `go generate` in Backend).\n\n")
+
+	for _, def := range s.definitions() {
+		fmt.Fprintf(&b, "export interface %s {\n", def.Name)
+		for _, name := range sortedProperties(def.Schema) {
+			optional := "?"
+			if isRequired(def.Schema, name) {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s\n", tsProperty(name), optional, tsType(def.Schema.Properties[name]))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, op := range ops {
+		if !hasParams(op) {
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %sParams {\n", pascal(op.Name, false))
+		for _, p := range op.Params {
+			if p.Description != "" {
+				fmt.Fprintf(&b, "  /** %s */\n", strings.ReplaceAll(p.Description, "*/", "*\\/"))
+			}
+			optional := "?"
+			if p.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s\n", tsParamName(p), optional, tsParamType(p))
+		}
+		if op.Body != nil {
+			optional := "?"
+			if op.Body.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  body%s: %s\n", optional, tsType(op.Body.Schema))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString(tsRuntime)
+	for _, op := range ops {
+		b.WriteString("\n")
+		writeTSMethod(&b, op)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// writeTSMethod renders one client method
+func writeTSMethod(b *strings.Builder, op operation) {
+	result := "ApiResponse"
+	if op.Binary {
+		result = "Blob"
+	}
+
+	fmt.Fprintf(b, "  /** %s (%s %s) */\n", strings.ReplaceAll(strings.TrimSpace(op.Summary), "*/", "*\\/"), op.Method, op.Path)
+	switch {
+	case !hasParams(op):
+		fmt.Fprintf(b, "  %s(): Promise<%s> {\n", op.Name, result)
+	case allOptional(op):
+		fmt.Fprintf(b, "  %s(params: %sParams = {}): Promise<%s> {\n", op.Name, pascal(op.Name, false), result)
+	default:
+		fmt.Fprintf(b, "  %s(params: %sParams): Promise<%s> {\n", op.Name, pascal(op.Name, false), result)
+	}
+
+	var options []string
+	if query := tsParamMap(op, "query"); query != "" {
+		options = append(options, "query: "+query)
+	}
+	if headers := tsParamMap(op, "header"); headers != "" {
+		options = append(options, "headers: "+headers)
+	}
+	if form := tsParamMap(op, "formData"); form != "" {
+		options = append(options, "form: "+form)
+	}
+	if op.Body != nil {
+		options = append(options, "body: params.body")
+	}
+	if op.Binary {
+		options = append(options, "binary: true")
+	}
+
+	path := tsPath(op)
+	if len(options) == 0 {
+		fmt.Fprintf(b, "    return this.request<%s>('%s', %s)\n", result, op.Method, path)
+	} else {
+		fmt.Fprintf(b, "    return this.request<%s>('%s', %s, {\n", result, op.Method, path)
+		for _, option := range options {
+			fmt.Fprintf(b, "      %s,\n", option)
+		}
+		b.WriteString("    })\n")
+	}
+	b.WriteString("  }\n")
+}
+
+// tsPath renders the request path, interpolating the encoded path parameters
+func tsPath(op operation) string {
+	if !strings.Contains(op.Path, "{") {
+		return "'" + op.Path + "'"
+	}
+	path := op.Path
+	for _, p := range op.Params {
+		if p.In == "path" {
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", "${encodeURIComponent(String(params."+tsParamName(p)+"))}")
+		}
+	}
+	return "`" + path + "`"
+}
+
+// tsParamMap renders the object literal of the parameters located in "in", one entry per line
+// (indented as a request option) when there are several
+func tsParamMap(op operation, in string) string {
+	var entries []string
+	for _, p := range op.Params {
+		if p.In == in {
+			entries = append(entries, fmt.Sprintf("%s: params.%s", tsProperty(p.Name), tsParamName(p)))
+		}
+	}
+	switch len(entries) {
+	case 0:
+		return ""
+	case 1:
+		return "{ " + entries[0] + " }"
+	}
+	return "{\n        " + strings.Join(entries, ",\n        ") + ",\n      }"
+}
+
+func hasParams(op operation) bool {
+	return len(op.Params) > 0 || op.Body != nil
+}
+
+func allOptional(op operation) bool {
+	for _, p := range op.Params {
+		if p.Required {
+			return false
+		}
+	}
+	return op.Body == nil || !op.Body.Required
+}
+
+// tsParamName is the params property of a parameter; header names become lowerCamel
+func tsParamName(p specParameter) string {
+	if identifier.MatchString(p.Name) {
+		return p.Name
+	}
+	name := pascal(strings.ToLower(p.Name), false)
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// tsProperty quotes property names that are not identifiers
+func tsProperty(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	return "'" + name + "'"
+}
+
+func tsParamType(p specParameter) string {
+	return tsType(&schema{Type: p.Type, Items: p.Items, Enum: p.Enum})
+}
+
+// tsType maps a schema to a TypeScript type
+func tsType(sch *schema) string {
+	if sch == nil {
+		return "unknown"
+	}
+	if sch.Ref != "" {
+		return typeName(sch.Ref)
+	}
+	switch sch.Type {
+	case "file":
+		return "Blob"
+	case "string":
+		if len(sch.Enum) > 0 {
+			values := make([]string, len(sch.Enum))
+			for i, value := range sch.Enum {
+				values[i] = "'" + value + "'"
+			}
+			return strings.Join(values, " | ")
+		}
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := tsType(sch.Items)
+		if strings.Contains(item, "|") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"dataextractor/repository"
+	"dataextractor/validators"
 )
 
 func main() {
@@ -39,7 +40,7 @@ func main() {
 
 	// Check what actions exist in cluster 0 specifically
 	fmt.Println("=== Checking actions in cluster 0 ===")
-	cluster0Stocks, err := repo.GetStocksByCluster(0)
+	cluster0Stocks, _, err := repo.GetStocksByCluster(0, repository.ListOptions{})
 	if err != nil {
 		log.Printf("Warning: Could not get stocks for cluster 0: %v", err)
 	} else {
@@ -72,7 +73,7 @@ func main() {
 		{
 			name:           "Basic test - cluster only",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "desc",
@@ -92,7 +93,7 @@ func main() {
 		{
 			name:           "Test with numerical weights",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "asc",
@@ -106,7 +107,7 @@ func main() {
 		{
 			name:           "Test with rating weights",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "desc",
@@ -151,6 +152,9 @@ func main() {
 			tc.perPage,
 			tc.numericalWeights,
 			tc.ratingWeights,
+			nil,
+			nil,
+			repository.PreloadFull,
 		)
 
 		latency := time.Since(startTime)
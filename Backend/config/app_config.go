@@ -1,9 +1,11 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,10 +24,40 @@ type AppConfig struct {
 	// CockroachDB Configuration
 	CockroachDB CockroachDBConfig
 
+	// HTTP Server Configuration
+	Server ServerConfig
+
+	// Authentication and Role Configuration
+	Auth AuthConfig
+
+	// Scoring Configuration
+	Scoring ScoringConfig
+
+	// Validation Configuration
+	Validation ValidationConfig
+
+	// Import Configuration
+	Import ImportConfig
+
+	// Export Job Configuration
+	Export ExportConfig
+
+	// Archival Job Configuration
+	Archive ArchiveConfig
+
+	// HTTP Caching Configuration
+	Cache CacheConfig
+
+	// Notification Configuration
+	Notifications NotificationConfig
+
 	// Application Settings
 	AppEnv      string
 	AppDebug    bool
 	AppLogLevel string
+
+	// Log line encoding: json (one object per line) or text
+	AppLogFormat string
 }
 
 // DatabaseConfig holds database configuration
@@ -37,6 +69,194 @@ type DatabaseConfig struct {
 	DBName   string
 	SSLMode  string
 	LogLevel string
+
+	// Accept legacy numeric IDs alongside UUIDs in API paths (UUID transition period)
+	AcceptNumericIDs bool
+	// Key stock_data_points, rating_sentiments and numerical_indicators by UUID instead of ID
+	UUIDPrimaryKeys bool
+
+	// GORM session tuning: cache prepared statements, skip the implicit transaction around single
+	// writes (multi-statement writes open their own), and the row count per multi-row INSERT.
+	// PrepareStmt and SkipDefaultTransaction are opt-in until they are benchmarked; the weighted
+	// filter embeds its weights in the SQL text, so each weight combination is another cached statement.
+	PrepareStmt            bool
+	SkipDefaultTransaction bool
+	CreateBatchSize        int
+
+	// Read replica connection string; when set, read-only statements use the replica pool unless they
+	// run in a transaction or a write request, and everything else uses the primary
+	ReplicaDSN string
+
+	// Startup connection: attempts before the server starts in degraded mode (and keeps reconnecting
+	// in the background), and the initial and maximum wait between attempts (doubled each time)
+	ConnectAttempts   int
+	ConnectBackoff    time.Duration
+	ConnectMaxBackoff time.Duration
+
+	// Record per-method call counts, errors and latencies of the repository, served on /metrics
+	Metrics bool
+}
+
+// AuthConfig holds how callers authenticate and the roles route groups require
+type AuthConfig struct {
+	// HS256 secret of "Authorization: Bearer" tokens, whose sub claim is the actor and role claim the
+	// role; empty disables bearer tokens
+	JWTSecret string
+
+	// API keys accepted in X-API-Key and the role each grants; without any, X-API-Key only
+	// identifies the client for usage metering
+	APIKeys map[string]string
+
+	// Role of requests without credentials; empty requires every API request to authenticate
+	AnonymousRole string
+
+	// Roles required to read (GET/HEAD) and to write in a route group, as "read:write" by group
+	// name (the path segment after /api/v1), overriding the reader:writer default
+	GroupRoles map[string]string
+}
+
+// ServerConfig holds HTTP server and request handling configuration
+type ServerConfig struct {
+	// Request body limits (bytes); ImportMaxBodyBytes applies to the batch create and CSV upload endpoints
+	MaxBodyBytes       int64
+	ImportMaxBodyBytes int64
+
+	// JSON decoding guards
+	MaxJSONDepth int
+	StrictJSON   bool
+
+	// Access log filtering: skipped paths are never logged, sampled routes are logged at LogSampleRate (0-1)
+	LogSkipPaths     []string
+	LogSampledRoutes []string
+	LogSampleRate    float64
+
+	// Directory holding the built frontend (e.g. UI/vue-project/dist); empty disables static serving
+	StaticDir string
+
+	// Honor the X-Actor and X-Role headers for write attribution and role checks (only behind a
+	// trusted authenticating proxy)
+	TrustActorHeader bool
+
+	// Page size applied to list endpoints when per_page is omitted, and the largest per_page accepted
+	DefaultPerPage int
+	MaxPerPage     int
+
+	// Signing key and lifetime of the confirmation tokens returned by destructive dry runs; an empty
+	// secret is replaced by a random per-process key (tokens then do not survive restarts)
+	ConfirmationSecret string
+	ConfirmationTTL    time.Duration
+
+	// Check requests and responses against the OpenAPI document: off, log (report drift in the
+	// log) or enforce (also reject non-conforming requests). Always off when APP_ENV=production.
+	SpecValidation string
+
+	// Meter requests per client (X-API-Key fingerprint) and route into client_usage, writing the
+	// aggregated counters at most once per UsageFlushInterval
+	UsageMetering      bool
+	UsageFlushInterval time.Duration
+
+	// Port of the gRPC server started alongside the HTTP server; empty disables it
+	GRPCPort string
+}
+
+// ScoringConfig holds weighted-score configuration
+type ScoringConfig struct {
+	// Inclusive range accepted for each indicator/sentiment weight
+	MinWeight float64
+	MaxWeight float64
+
+	// Scale weights so they sum to 1 before scoring
+	NormalizeWeights bool
+
+	// Default weight profile used to recompute final_score when a stock is written through the API:
+	// per-name weights, with DefaultWeight for every indicator/sentiment not listed
+	DefaultWeight  float64
+	DefaultWeights map[string]float64
+
+	// Recompute final_score from the stored indicators and sentiments on API creates and updates
+	RecalculateOnWrite bool
+}
+
+// ValidationConfig holds input validation configuration
+type ValidationConfig struct {
+	// Allowed action and rating (rating_to/rating_from) values; empty lists are seeded from the data
+	AllowedActions []string
+	AllowedRatings []string
+
+	// Date sanity bounds: records may not predate MinDate or lie more than MaxFutureSkew in the future.
+	// StrictDates rejects out-of-range dates; otherwise they are accepted with a warning.
+	MinDate       time.Time
+	MaxFutureSkew time.Duration
+	StrictDates   bool
+
+	// Timezone used for imported timestamps that carry no zone (IANA name, e.g. America/New_York)
+	DefaultLocation *time.Location
+}
+
+// ImportConfig holds CSV import and API extraction configuration
+type ImportConfig struct {
+	// Field delimiter: auto (detect from the header), ",", ";", "|" or tab
+	CSVDelimiter string
+	// Tolerate stray quotes in fields (common in hand-edited exports)
+	CSVLazyQuotes bool
+	// How long extraction page-key history is kept; older entries are pruned after each run (0 keeps everything)
+	PageHistoryRetention time.Duration
+	// Upstream requests allowed per API key and UTC day; extractions that could exceed it are refused (0 = unlimited)
+	DailyRequestQuota int
+	// Upstream provider whose registered item transformer maps extracted items (see data_extractor.RegisterTransformer)
+	Provider string
+	// Data is reported stale this long after the last import or extraction (0 never reports it stale)
+	StaleAfter time.Duration
+}
+
+// ExportConfig holds the asynchronous export job configuration
+type ExportConfig struct {
+	// Local spool directory the export files are written to
+	SpoolDir string
+	// How long a finished export (file and job record) is kept; expired jobs are pruned when a new export starts
+	Retention time.Duration
+	// Lifetime of a signed download URL
+	URLTTL time.Duration
+}
+
+// ArchiveConfig holds the archival job configuration
+type ArchiveConfig struct {
+	// Records dated further back than MaxAge are archived by a run without explicit criteria;
+	// 0 requires every run to state its criteria
+	MaxAge time.Duration
+	// Records moved per transaction
+	BatchSize int
+}
+
+// CacheConfig holds Cache-Control max-age values for cacheable read endpoints; 0 makes clients
+// revalidate (Last-Modified/ETag) on every use. With Warmup the unique values and cluster summaries
+// are also precomputed into memory at startup and after imports, and served from there for the
+// same max-age.
+type CacheConfig struct {
+	UniqueValuesTTL time.Duration
+	StatsTTL        time.Duration
+	ClustersTTL     time.Duration
+	Warmup          bool
+}
+
+// NotificationConfig holds the SMTP and Slack channels used for alerts and job failures.
+// A channel is enabled when its host (SMTP) or webhook URL (Slack) is set.
+type NotificationConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+
+	SlackWebhookURL string
+
+	// Upper bound for delivering one message on one channel (and one webhook callback)
+	Timeout time.Duration
+
+	// How far a webhook timestamp may be from the consumer's clock before the delivery is
+	// rejected; advertised to subscribers with the verification scheme
+	WebhookTolerance time.Duration
 }
 
 // CockroachDBConfig holds CockroachDB-specific configuration
@@ -88,7 +308,7 @@ type CockroachDBConfig struct {
 func LoadConfig() *AppConfig {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: .env file not found: %v", err)
+		slog.Warn(".env file not found", "error", err)
 	}
 
 	return &AppConfig{
@@ -107,6 +327,20 @@ func LoadConfig() *AppConfig {
 			DBName:   getEnv("DB_NAME", "stock_data"),
 			SSLMode:  getEnv("DB_SSLMODE", "require"),
 			LogLevel: getEnv("DB_LOG_LEVEL", "info"),
+
+			AcceptNumericIDs: getEnvAsBool("DB_ACCEPT_NUMERIC_IDS", true),
+			UUIDPrimaryKeys:  getEnvAsBool("DB_UUID_PRIMARY_KEYS", false),
+
+			PrepareStmt:            getEnvAsBool("DB_PREPARE_STMT", false),
+			SkipDefaultTransaction: getEnvAsBool("DB_SKIP_DEFAULT_TRANSACTION", false),
+			CreateBatchSize:        getEnvAsInt("DB_CREATE_BATCH_SIZE", 500),
+
+			ReplicaDSN: getEnv("DB_REPLICA_DSN", ""),
+
+			ConnectAttempts:   getEnvAsInt("DB_CONNECT_ATTEMPTS", 5),
+			ConnectBackoff:    getEnvAsDuration("DB_CONNECT_BACKOFF", time.Second),
+			ConnectMaxBackoff: getEnvAsDuration("DB_CONNECT_MAX_BACKOFF", 30*time.Second),
+			Metrics:           getEnvAsBool("DB_METRICS", true),
 		},
 
 		// CockroachDB Configuration
@@ -154,10 +388,111 @@ func LoadConfig() *AppConfig {
 			ProfilingEnabled: getEnvAsBool("COCKROACH_PROFILING_ENABLED", false),
 		},
 
+		// HTTP Server Configuration
+		Server: ServerConfig{
+			MaxBodyBytes:       getEnvAsInt64("SERVER_MAX_BODY_BYTES", 1048576),
+			ImportMaxBodyBytes: getEnvAsInt64("SERVER_IMPORT_MAX_BODY_BYTES", 33554432),
+			MaxJSONDepth:       getEnvAsInt("SERVER_MAX_JSON_DEPTH", 32),
+			StrictJSON:         getEnvAsBool("SERVER_STRICT_JSON", false),
+			LogSkipPaths:       getEnvAsSlice("SERVER_LOG_SKIP_PATHS", []string{"/health", "/health/ready", "/metrics"}),
+			LogSampledRoutes:   getEnvAsSlice("SERVER_LOG_SAMPLED_ROUTES", nil),
+			LogSampleRate:      getEnvAsFloat64("SERVER_LOG_SAMPLE_RATE", 1),
+			StaticDir:          getEnv("SERVER_STATIC_DIR", ""),
+			TrustActorHeader:   getEnvAsBool("SERVER_TRUST_ACTOR_HEADER", false),
+			DefaultPerPage:     getEnvAsInt("SERVER_DEFAULT_PER_PAGE", 20),
+			MaxPerPage:         getEnvAsInt("SERVER_MAX_PER_PAGE", 200),
+			ConfirmationSecret: getEnv("SERVER_CONFIRMATION_SECRET", ""),
+			ConfirmationTTL:    getEnvAsDuration("SERVER_CONFIRMATION_TTL", 5*time.Minute),
+			SpecValidation:     getEnv("SERVER_SPEC_VALIDATION", "off"),
+			UsageMetering:      getEnvAsBool("SERVER_USAGE_METERING", true),
+			UsageFlushInterval: getEnvAsDuration("SERVER_USAGE_FLUSH_INTERVAL", 30*time.Second),
+			GRPCPort:           getEnv("SERVER_GRPC_PORT", "9090"),
+		},
+
+		// Authentication and Role Configuration
+		Auth: AuthConfig{
+			JWTSecret:     getEnv("AUTH_JWT_SECRET", ""),
+			APIKeys:       getEnvAsStringMap("AUTH_API_KEYS"),
+			AnonymousRole: getEnv("AUTH_ANONYMOUS_ROLE", "writer"),
+			GroupRoles:    getEnvAsStringMap("AUTH_GROUP_ROLES"),
+		},
+
+		// Scoring Configuration
+		Scoring: ScoringConfig{
+			MinWeight:        getEnvAsFloat64("SCORING_MIN_WEIGHT", 0),
+			MaxWeight:        getEnvAsFloat64("SCORING_MAX_WEIGHT", 10),
+			NormalizeWeights: getEnvAsBool("SCORING_NORMALIZE_WEIGHTS", false),
+
+			DefaultWeight:      getEnvAsFloat64("SCORING_DEFAULT_WEIGHT", 1),
+			DefaultWeights:     getEnvAsWeightMap("SCORING_DEFAULT_WEIGHTS"),
+			RecalculateOnWrite: getEnvAsBool("SCORING_RECALCULATE_ON_WRITE", true),
+		},
+
+		// Validation Configuration
+		Validation: ValidationConfig{
+			AllowedActions: getEnvAsSlice("VALIDATION_ALLOWED_ACTIONS", nil),
+			AllowedRatings: getEnvAsSlice("VALIDATION_ALLOWED_RATINGS", nil),
+			MinDate:        getEnvAsDate("VALIDATION_MIN_DATE", time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+			MaxFutureSkew:  getEnvAsDuration("VALIDATION_MAX_FUTURE_SKEW", 24*time.Hour),
+			StrictDates:    getEnvAsBool("VALIDATION_STRICT_DATES", true),
+
+			DefaultLocation: getEnvAsLocation("VALIDATION_DEFAULT_TIMEZONE", time.UTC),
+		},
+
+		// Import Configuration
+		Import: ImportConfig{
+			CSVDelimiter:  getEnv("IMPORT_CSV_DELIMITER", "auto"),
+			CSVLazyQuotes: getEnvAsBool("IMPORT_CSV_LAZY_QUOTES", false),
+
+			PageHistoryRetention: getEnvAsDuration("EXTRACT_PAGE_HISTORY_RETENTION", 30*24*time.Hour),
+			DailyRequestQuota:    getEnvAsInt("EXTRACT_DAILY_REQUEST_QUOTA", 0),
+			Provider:             getEnv("EXTRACT_PROVIDER", "swechallenge"),
+			StaleAfter:           getEnvAsDuration("EXTRACT_STALE_AFTER", 48*time.Hour),
+		},
+
+		// Export Job Configuration
+		Export: ExportConfig{
+			SpoolDir:  getEnv("EXPORT_SPOOL_DIR", "./exports"),
+			Retention: getEnvAsDuration("EXPORT_RETENTION", 24*time.Hour),
+			URLTTL:    getEnvAsDuration("EXPORT_URL_TTL", 15*time.Minute),
+		},
+
+		// Archival Job Configuration
+		Archive: ArchiveConfig{
+			MaxAge:    getEnvAsDuration("ARCHIVE_MAX_AGE", 0),
+			BatchSize: getEnvAsInt("ARCHIVE_BATCH_SIZE", 500),
+		},
+
+		// HTTP Caching Configuration
+		Cache: CacheConfig{
+			UniqueValuesTTL: getEnvAsDuration("CACHE_UNIQUE_VALUES_TTL", 5*time.Minute),
+			StatsTTL:        getEnvAsDuration("CACHE_STATS_TTL", time.Minute),
+			ClustersTTL:     getEnvAsDuration("CACHE_CLUSTERS_TTL", time.Minute),
+			Warmup:          getEnvAsBool("CACHE_WARMUP", false),
+		},
+
+		// Notification Configuration
+		Notifications: NotificationConfig{
+			SMTPHost:     getEnv("NOTIFY_SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("NOTIFY_SMTP_PORT", 587),
+			SMTPUsername: getEnv("NOTIFY_SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("NOTIFY_SMTP_PASSWORD", ""),
+			SMTPFrom:     getEnv("NOTIFY_SMTP_FROM", ""),
+			SMTPTo:       getEnvAsSlice("NOTIFY_SMTP_TO", nil),
+
+			SlackWebhookURL: getEnv("NOTIFY_SLACK_WEBHOOK_URL", ""),
+
+			Timeout: getEnvAsDuration("NOTIFY_TIMEOUT", 10*time.Second),
+
+			WebhookTolerance: getEnvAsDuration("NOTIFY_WEBHOOK_TOLERANCE", 5*time.Minute),
+		},
+
 		// Application Settings
 		AppEnv:      getEnv("APP_ENV", "development"),
 		AppDebug:    getEnvAsBool("APP_DEBUG", true),
 		AppLogLevel: getEnv("APP_LOG_LEVEL", "info"),
+
+		AppLogFormat: getEnv("APP_LOG_FORMAT", "json"),
 	}
 }
 
@@ -208,3 +543,80 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvAsSlice gets a comma-separated environment variable as a string slice with a default value
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return defaultValue
+}
+
+// getEnvAsWeightMap parses a comma-separated list of name:weight pairs (e.g. "atr:2,obv:0.5");
+// names are lower-cased and malformed entries are skipped
+func getEnvAsWeightMap(key string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, item := range getEnvAsSlice(key, nil) {
+		name, value, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		weights[strings.ToLower(strings.TrimSpace(name))] = weight
+	}
+	return weights
+}
+
+// getEnvAsStringMap parses a comma-separated list of key=value pairs (e.g. "stocks=reader:admin"),
+// splitting at the last "=" so keys may contain base64 padding; malformed entries are skipped
+func getEnvAsStringMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, item := range getEnvAsSlice(key, nil) {
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			continue
+		}
+		values[strings.TrimSpace(item[:i])] = strings.TrimSpace(item[i+1:])
+	}
+	return values
+}
+
+// getEnvAsDuration gets an environment variable as a time.Duration with a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsDate gets an environment variable as a YYYY-MM-DD date (UTC) with a default value
+func getEnvAsDate(key string, defaultValue time.Time) time.Time {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.Parse("2006-01-02", value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsLocation gets an environment variable as an IANA time zone with a default value
+func getEnvAsLocation(key string, defaultValue *time.Location) *time.Location {
+	if value := os.Getenv(key); value != "" {
+		if loc, err := time.LoadLocation(value); err == nil {
+			return loc
+		}
+		slog.Warn("Invalid time zone, using the default", "key", key, "value", value, "default", defaultValue)
+	}
+	return defaultValue
+}
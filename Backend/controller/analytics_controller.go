@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dataextractor/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPercentiles handles GET /stocks/:id/percentile
+// @Summary Get a stock's percentile ranks
+// @Description Where a stock's final_score and each normalized indicator sit percentile-wise (0-100) within a cluster, computed with SQL window functions
+// @Tags analytics
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param cluster query int false "Cluster to rank against (default: the stock's own cluster)"
+// @Success 200 {object} map[string]interface{} "Percentile ranks"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID or cluster"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/percentile [get]
+func (sc *StockController) GetPercentiles(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var cluster *int
+	if clusterStr := c.Query("cluster"); clusterStr != "" {
+		value, err := strconv.Atoi(clusterStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid cluster parameter",
+				"details": "Cluster must be an integer",
+			})
+			return
+		}
+		cluster = &value
+	}
+
+	percentiles, err := sc.stockService.GetPercentiles(id, cluster)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": percentiles,
+	})
+}
+
+// GetMovingAverages handles GET /stocks/ticker/:ticker/moving-averages
+// @Summary Get rolling means of a ticker's indicators
+// @Description Rolling 7/30/90 day means of selected indicators over the ticker's indicator history, computed with SQL window functions. Series are keyed by indicator name
+// @Tags analytics
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol"
+// @Param indicators query string false "Comma-separated indicator names (default: all indicators)"
+// @Success 200 {object} map[string]interface{} "Moving averages by indicator"
+// @Failure 400 {object} map[string]interface{} "Invalid ticker or indicator"
+// @Failure 500 {object} map[string]interface{} "Failed to compute moving averages"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/ticker/{ticker}/moving-averages [get]
+func (sc *StockController) GetMovingAverages(c *gin.Context) {
+	ticker := c.Param("ticker")
+
+	var indicators []string
+	for _, name := range strings.Split(c.Query("indicators"), ",") {
+		if name = strings.TrimSpace(strings.ToLower(name)); name != "" {
+			indicators = append(indicators, name)
+		}
+	}
+
+	series, err := sc.stockService.GetMovingAverages(ticker, indicators)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker":  ticker,
+		"windows": repository.MovingAverageWindows,
+		"data":    series,
+	})
+}
+
+// GetSimilarStocks handles GET /stocks/ticker/:ticker/similar
+// @Summary Find comparable stocks
+// @Description Nearest neighbors of a ticker within its cluster by cosine distance over normalized indicator vectors, closest first
+// @Tags analytics
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol"
+// @Param limit query int false "Number of neighbors (default: 10, max: 100)"
+// @Success 200 {object} map[string]interface{} "Similar stocks"
+// @Failure 400 {object} map[string]interface{} "Invalid ticker or limit"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/ticker/{ticker}/similar [get]
+func (sc *StockController) GetSimilarStocks(c *gin.Context) {
+	ticker := c.Param("ticker")
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid limit parameter",
+				"details": "Limit must be an integer",
+			})
+			return
+		}
+		limit = value
+	}
+
+	neighbors, err := sc.stockService.GetSimilarStocks(ticker, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker": ticker,
+		"data":   neighbors,
+		"count":  len(neighbors),
+	})
+}
+
+// GetClusterHeatmap handles GET /stocks/analytics/heatmap
+// @Summary Get the cluster heatmap
+// @Description Matrix of stock counts and average final scores for cluster vs action (or rating_to), computed with one GROUP BY query
+// @Tags analytics
+// @Produce json
+// @Param dimension query string false "Column crossed with cluster: action | rating_to (default: action)"
+// @Success 200 {object} map[string]interface{} "Heatmap matrix"
+// @Failure 400 {object} map[string]interface{} "Invalid dimension"
+// @Failure 500 {object} map[string]interface{} "Failed to build heatmap"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/analytics/heatmap [get]
+func (sc *StockController) GetClusterHeatmap(c *gin.Context) {
+	heatmap, err := sc.stockService.GetClusterHeatmap(c.Query("dimension"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": heatmap,
+	})
+}
+
+// GetClusterDispersion handles GET /stocks/analytics/dispersion
+// @Summary Get per-cluster dispersion statistics
+// @Description Mean, median, sample standard deviation, quartiles and interquartile range of target_delta and final_score per cluster, so averages can be read together with their spread
+// @Tags analytics
+// @Produce json
+// @Param cluster query int false "Restrict to one cluster (default: all clusters)"
+// @Success 200 {object} map[string]interface{} "Dispersion statistics by cluster"
+// @Failure 400 {object} map[string]interface{} "Invalid cluster"
+// @Failure 404 {object} map[string]interface{} "Cluster has no stocks"
+// @Failure 500 {object} map[string]interface{} "Failed to compute dispersion"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/analytics/dispersion [get]
+func (sc *StockController) GetClusterDispersion(c *gin.Context) {
+	var cluster *int
+	if clusterStr := c.Query("cluster"); clusterStr != "" {
+		value, err := strconv.Atoi(clusterStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid cluster parameter",
+				"details": "Cluster must be an integer",
+			})
+			return
+		}
+		cluster = &value
+	}
+
+	dispersions, err := sc.stockService.GetClusterDispersion(cluster)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  dispersions,
+		"count": len(dispersions),
+	})
+}
+
+// GetTopMovers handles GET /stocks/movers
+// @Summary Get the top movers
+// @Description Stocks with the largest positive (up) or negative (down) target change, optionally limited to records dated within a window
+// @Tags analytics
+// @Produce json
+// @Param metric query string false "Metric to rank by: target_delta | final_score (default: target_delta)"
+// @Param direction query string false "up (largest positive) | down (largest negative) (default: up)"
+// @Param from query string false "Earliest date (YYYY-MM-DD, inclusive)"
+// @Param to query string false "Latest date (YYYY-MM-DD, inclusive)"
+// @Param limit query int false "Number of stocks to return, 1-100 (default: 20)"
+// @Success 200 {object} map[string]interface{} "Top movers"
+// @Failure 400 {object} map[string]interface{} "Invalid metric, direction, date window or limit"
+// @Failure 500 {object} map[string]interface{} "Failed to get top movers"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/movers [get]
+func (sc *StockController) GetTopMovers(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid limit parameter",
+				"details": "Limit must be an integer",
+			})
+			return
+		}
+		limit = value
+	}
+
+	movers, err := sc.stockService.GetTopMovers(c.Query("metric"), c.Query("direction"), c.Query("from"), c.Query("to"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  movers,
+		"count": len(movers),
+	})
+}
+
+// GetConsensus handles GET /stocks/ticker/:ticker/consensus
+// @Summary Get the brokerage consensus for a ticker
+// @Description Takes each brokerage's latest rating of the ticker from its history and aggregates them (grouped SQL) into Buy/Hold/Sell counts and a mean price target
+// @Tags analytics
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol"
+// @Success 200 {object} map[string]interface{} "Consensus"
+// @Failure 400 {object} map[string]interface{} "Invalid ticker"
+// @Failure 404 {object} map[string]interface{} "No ratings recorded for ticker"
+// @Failure 500 {object} map[string]interface{} "Failed to get consensus"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/ticker/{ticker}/consensus [get]
+func (sc *StockController) GetConsensus(c *gin.Context) {
+	consensus, err := sc.stockService.GetConsensus(c.Param("ticker"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": consensus,
+	})
+}
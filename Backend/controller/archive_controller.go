@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartArchive handles POST /archive
+// @Summary Archive old data points
+// @Description Start a background job that moves the data points dated before a day, in a cluster, and/or last written by a dataset version (dataset) or by versions older than one (dataset_before, superseded imports) from the hot table to the stock_archive table. Criteria are combined with AND; without any, records older than ARCHIVE_MAX_AGE are archived. Records move ARCHIVE_BATCH_SIZE at a time, each batch copied with its sentiments, indicators and tags and deleted in one transaction; ticker history snapshots are kept. GET /jobs/{id} reports the batches (pages_processed) and records (items_written) archived. Requires the admin role.
+// @Tags archive
+// @Produce json
+// @Param before query string false "Archive records dated before this day (YYYY-MM-DD)"
+// @Param cluster query int false "Archive records of this cluster"
+// @Param dataset query int false "Archive records last written by this dataset version"
+// @Param dataset_before query int false "Archive records last written by a dataset version older than this one"
+// @Success 202 {object} map[string]interface{} "Archival job queued"
+// @Failure 400 {object} map[string]interface{} "Invalid or missing criteria"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to start the archival"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/archive [post]
+func (sc *StockController) StartArchive(c *gin.Context) {
+	cluster, dataset, ok := bindClusterAndDataset(c)
+	if !ok {
+		return
+	}
+	var datasetBefore uint
+	if datasetStr := c.Query("dataset_before"); datasetStr != "" {
+		value, err := strconv.ParseUint(datasetStr, 10, 32)
+		if err != nil || value == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid dataset_before parameter",
+				"details": "dataset_before must be a positive dataset version ID",
+			})
+			return
+		}
+		datasetBefore = uint(value)
+	}
+
+	// The job outlives the request, but keeps its actor
+	job, err := sc.stockService.WithContext(context.WithoutCancel(c.Request.Context())).StartArchive(c.Query("before"), cluster, dataset, datasetBefore)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to start archival"))
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Archival job queued",
+		"data":    job,
+	})
+}
+
+// GetArchivedStocks handles GET /archive/:ticker
+// @Summary Get the archived records of a ticker
+// @Description Records of a ticker moved to the archive, most recently archived first (at most 100). Each entry carries the record as it was archived, with its sentiments, indicators and tags, in payload.
+// @Tags archive
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol"
+// @Success 200 {object} map[string]interface{} "Archived records"
+// @Failure 400 {object} map[string]interface{} "Invalid ticker"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/archive/{ticker} [get]
+func (sc *StockController) GetArchivedStocks(c *gin.Context) {
+	ticker := c.Param("ticker")
+	archived, err := sc.stockService.WithContext(c.Request.Context()).GetArchivedStocks(ticker)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get archived records"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker": ticker,
+		"data":   archived,
+		"count":  len(archived),
+	})
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReassignCluster handles PUT /stocks/:id/cluster
+// @Summary Override a stock's cluster
+// @Description Manually move a stock to another cluster. The change is recorded in the cluster audit trail with the given reason
+// @Tags clusters
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param request body validators.ClusterAssignmentRequest true "Target cluster and reason"
+// @Success 200 {object} map[string]interface{} "Cluster reassigned"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/cluster [put]
+func (sc *StockController) ReassignCluster(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.ClusterAssignmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	assignments, err := sc.stockService.WithContext(c.Request.Context()).ReassignCluster(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to reassign cluster"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Cluster reassigned successfully",
+		"data":    assignments,
+		"changed": len(assignments),
+	})
+}
+
+// ReassignClusters handles PUT /stocks/cluster
+// @Summary Move tickers to a cluster
+// @Description Bulk variant of the cluster override: moves every listed ticker to the target cluster in one transaction, recording an audit entry per changed stock. Unknown tickers abort the whole move
+// @Tags clusters
+// @Accept json
+// @Produce json
+// @Param request body validators.BulkClusterAssignmentRequest true "Tickers, target cluster and reason"
+// @Success 200 {object} map[string]interface{} "Clusters reassigned"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Unknown tickers"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/cluster [put]
+func (sc *StockController) ReassignClusters(c *gin.Context) {
+	var request validators.BulkClusterAssignmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	assignments, err := sc.stockService.WithContext(c.Request.Context()).ReassignClusters(&request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to reassign clusters"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Clusters reassigned successfully",
+		"data":    assignments,
+		"changed": len(assignments),
+	})
+}
+
+// GetClusterAssignments handles GET /stocks/:id/cluster/history
+// @Summary Get a stock's cluster override history
+// @Description Audit trail of manual cluster overrides for a stock, newest first
+// @Tags clusters
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Success 200 {object} map[string]interface{} "Cluster overrides"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/cluster/history [get]
+func (sc *StockController) GetClusterAssignments(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := sc.stockService.GetClusterAssignments(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get cluster history"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  assignments,
+		"count": len(assignments),
+	})
+}
+
+// GetCentroids handles GET /stocks/clusters/centroids
+// @Summary Get cluster centroids
+// @Description Stored centroid of every cluster: the mean normalized value of each indicator over the cluster's stocks. New stocks without a cluster are assigned to the nearest centroid
+// @Tags clusters
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Centroid coordinates ordered by cluster and indicator"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/clusters/centroids [get]
+func (sc *StockController) GetCentroids(c *gin.Context) {
+	centroids, err := sc.stockService.GetCentroids()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get cluster centroids"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  centroids,
+		"count": len(centroids),
+	})
+}
+
+// RecomputeCentroids handles POST /stocks/clusters/centroids
+// @Summary Recompute cluster centroids
+// @Description Recompute the centroid of every cluster from the current stocks (noise points excluded). Imports do this automatically; call it after manual cluster overrides
+// @Tags clusters
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Recomputed centroid coordinates"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/clusters/centroids [post]
+func (sc *StockController) RecomputeCentroids(c *gin.Context) {
+	centroids, err := sc.stockService.WithContext(c.Request.Context()).RecomputeCentroids()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to recompute cluster centroids"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  centroids,
+		"count": len(centroids),
+	})
+}
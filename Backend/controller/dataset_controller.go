@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDatasetVersions handles GET /datasets
+// @Summary List dataset versions
+// @Description Lists the import runs, newest first. Every file import creates a dataset version whose ID is stored on the rows it wrote; listing endpoints accept ?dataset= to pin results to a version.
+// @Tags datasets
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Dataset versions"
+// @Failure 500 {object} map[string]interface{} "Failed to get dataset versions"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/datasets [get]
+func (sc *StockController) GetDatasetVersions(c *gin.Context) {
+	versions, err := sc.stockService.WithContext(c.Request.Context()).GetDatasetVersions()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  versions,
+		"count": len(versions),
+	})
+}
+
+// RollbackDataset handles POST /datasets/:version/rollback
+// @Summary Roll back to a dataset version
+// @Description Reverts every data point written by a newer import to its state as of the given version, deleting data points that did not exist yet. Newer versions are marked rolled_back. Rows created through the API are not affected.
+// @Tags datasets
+// @Produce json
+// @Param version path int true "Dataset version ID to roll back to"
+// @Success 200 {object} map[string]interface{} "Rollback summary"
+// @Failure 400 {object} map[string]interface{} "Invalid version or version not complete"
+// @Failure 404 {object} map[string]interface{} "Dataset version not found"
+// @Failure 500 {object} map[string]interface{} "Failed to roll back"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/datasets/{version}/rollback [post]
+func (sc *StockController) RollbackDataset(c *gin.Context) {
+	version, err := strconv.ParseUint(c.Param("version"), 10, 32)
+	if err != nil || version == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid dataset version",
+			"details": "Version must be a positive integer",
+		})
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).RollbackDataset(uint(version))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Dataset rolled back successfully",
+		"data":    result,
+	})
+}
@@ -0,0 +1,399 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dataextractor/db_populate"
+	"dataextractor/logging"
+	"dataextractor/models"
+	"dataextractor/utils"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportColumns is the header row of the stock fields; exportRow must produce values in the same order
+var exportColumns = []string{
+	"uuid", "ticker", "company", "action", "date", "cluster",
+	"target_from", "target_to", "target_delta", "last_close",
+	"rating_from", "rating_to", "brokerage", "final_score", "weighted_score",
+}
+
+// exportRow flattens a stock into the exportColumns layout
+func exportRow(stock models.StockDataPoint) []string {
+	weightedScore := ""
+	if stock.WeightedScore != nil {
+		weightedScore = formatExportFloat(*stock.WeightedScore)
+	}
+	return []string{
+		stock.UUID,
+		stock.Ticker,
+		stock.Company,
+		stock.Action,
+		stock.Date.UTC().Format(time.RFC3339),
+		strconv.Itoa(stock.Cluster),
+		formatExportFloat(stock.TargetFrom),
+		formatExportFloat(stock.TargetTo),
+		formatExportFloat(stock.TargetDelta),
+		formatExportFloat(stock.LastClose),
+		stock.RatingFrom,
+		stock.RatingTo,
+		stock.Brokerage,
+		formatExportFloat(stock.FinalScore),
+		weightedScore,
+	}
+}
+
+func formatExportFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// exportLayout is the header row of a tabular export and the function flattening a stock into it
+type exportLayout struct {
+	columns []string
+	row     func(stock models.StockDataPoint) []string
+}
+
+// basicExportLayout holds the stock fields only; filter exports load no relations
+var basicExportLayout = exportLayout{columns: exportColumns, row: exportRow}
+
+// enrichedExportLayout appends the score columns of every rating sentiment and the value and
+// normalized value columns of every numerical indicator to exportColumns, named as the CSV import
+// expects them so an export can be loaded back unchanged
+var enrichedExportLayout = newEnrichedExportLayout()
+
+func newEnrichedExportLayout() exportLayout {
+	columns := append([]string{}, exportColumns...)
+	for _, name := range models.RatingSentimentNames {
+		score, norm := db_populate.RatingScoreColumns(name)
+		columns = append(columns, score, norm)
+	}
+	// Indicators mirroring a stock field (target_to, last_close, ...) share its column
+	var indicatorColumns []string
+	for _, name := range models.NumericalIndicatorNames {
+		if !containsString(exportColumns, name) {
+			indicatorColumns = append(indicatorColumns, name)
+		}
+	}
+	columns = append(columns, indicatorColumns...)
+	for _, name := range models.NumericalIndicatorNames {
+		columns = append(columns, "norm_"+name)
+	}
+
+	return exportLayout{columns: columns, row: func(stock models.StockDataPoint) []string {
+		row := exportRow(stock)
+		sentiments := make(map[string]models.RatingSentiment, len(stock.RatingSentiments))
+		for _, sentiment := range stock.RatingSentiments {
+			sentiments[sentiment.Name] = sentiment
+		}
+		for _, name := range models.RatingSentimentNames {
+			sentiment, ok := sentiments[name]
+			if !ok {
+				row = append(row, "", "")
+				continue
+			}
+			row = append(row, formatExportFloat(sentiment.RatingScore), formatExportFloat(sentiment.NormRatingScore))
+		}
+
+		// Missing indicators are left empty, which the import reads as absent
+		indicators := make(map[string]models.NumericalIndicator, len(stock.NumericalIndicators))
+		for _, indicator := range stock.NumericalIndicators {
+			indicators[indicator.Name] = indicator
+		}
+		for _, name := range indicatorColumns {
+			value := ""
+			if indicator, ok := indicators[name]; ok {
+				value = formatExportFloat(indicator.Value)
+			}
+			row = append(row, value)
+		}
+		for _, name := range models.NumericalIndicatorNames {
+			value := ""
+			if indicator, ok := indicators[name]; ok {
+				value = formatExportFloat(indicator.NormValue)
+			}
+			row = append(row, value)
+		}
+		return row
+	}}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rowWriter is the common surface of the CSV and XLSX export encoders
+type rowWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// csvRowWriter adapts encoding/csv to rowWriter
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(values []string) error {
+	return c.w.Write(values)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// stockWriter encodes stocks into an export stream
+type stockWriter interface {
+	Write(stock models.StockDataPoint) error
+	Close() error
+}
+
+// tableWriter writes stocks as rows of its layout (csv, xlsx)
+type tableWriter struct {
+	rows   rowWriter
+	layout exportLayout
+}
+
+func (t *tableWriter) Write(stock models.StockDataPoint) error {
+	return t.rows.WriteRow(t.layout.row(stock))
+}
+
+func (t *tableWriter) Close() error {
+	return t.rows.Close()
+}
+
+// ndjsonWriter writes each stock, relations included, as one JSON line
+type ndjsonWriter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(stock models.StockDataPoint) error {
+	return n.enc.Encode(stock)
+}
+
+func (n *ndjsonWriter) Close() error {
+	if f, ok := n.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// exportContentTypes maps each export format to its Content-Type
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"ndjson": "application/x-ndjson",
+}
+
+// newStockWriter creates the encoder of format (csv, xlsx or ndjson) over w, writing the header
+// row of layout for the tabular formats
+func newStockWriter(w io.Writer, format, sheetName string, layout exportLayout) (stockWriter, error) {
+	var rows rowWriter
+	switch format {
+	case "ndjson":
+		return &ndjsonWriter{w: w, enc: json.NewEncoder(w)}, nil
+	case "xlsx":
+		xw, err := utils.NewXLSXWriter(w, sheetName)
+		if err != nil {
+			return nil, err
+		}
+		rows = xw
+	default:
+		rows = &csvRowWriter{w: csv.NewWriter(w)}
+	}
+	return &tableWriter{rows: rows, layout: layout}, rows.WriteRow(layout.columns)
+}
+
+// ExportFilterByClusterGrouped handles GET /stocks/cluster/:cluster/filter/export
+// @Summary Export the filtered result set
+// @Description Streams every page of the filter endpoint's result set (same grouping, tags, sort, and weights) as a CSV or XLSX download, so the file matches exactly what the user sees.
+// @Tags stocks
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param cluster path int true "Cluster id"
+// @Param format query string false "Export format: csv | xlsx (default: csv)"
+// @Param grouping_column query string false "Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected)"
+// @Param grouping_value query string false "Grouping value to filter by (required if grouping_column is not none)"
+// @Param sort_by query string false "Sort by column; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)"
+// @Param order query string false "Sort order: asc | desc (default: desc)"
+// @Param numerical_weights query string false "JSON array of numerical weights: [{\"indicator_name\":\"atr\",\"weight\":0.5}]"
+// @Param rating_weights query string false "JSON array of rating weights: [{\"indicator_name\":\"action\",\"weight\":0.7}]"
+// @Param tags query []string false "Only include stocks carrying any of these tags" collectionFormat(multi)
+// @Param min_final_score query number false "Only include stocks with final_score at least this value"
+// @Param max_final_score query number false "Only include stocks with final_score at most this value"
+// @Param min_target_to query number false "Only include stocks with target_to at least this value"
+// @Param max_target_to query number false "Only include stocks with target_to at most this value"
+// @Param min_target_from query number false "Only include stocks with target_from at least this value"
+// @Param max_target_from query number false "Only include stocks with target_from at most this value"
+// @Param min_target_delta query number false "Only include stocks with target_delta at least this value"
+// @Param max_target_delta query number false "Only include stocks with target_delta at most this value"
+// @Param min_last_close query number false "Only include stocks with last_close at least this value"
+// @Param max_last_close query number false "Only include stocks with last_close at most this value"
+// @Success 200 {file} file "Exported rows"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to export"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/cluster/{cluster}/filter/export [get]
+func (sc *StockController) ExportFilterByClusterGrouped(c *gin.Context) {
+	var request validators.FilterRequest
+
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err := request.ParseQueryWeights(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid weights",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	sc.exportFilterByClusterGrouped(c, &request)
+}
+
+// ExportFilterByClusterGroupedPost handles POST /stocks/cluster/:cluster/filter/export
+// @Summary Export the filtered result set (JSON body variant)
+// @Description Same as the GET export endpoint, but takes the filter parameters and weight arrays as a JSON body. The format is still chosen with the format query parameter.
+// @Tags stocks
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param cluster path int true "Cluster id"
+// @Param format query string false "Export format: csv | xlsx (default: csv)"
+// @Param request body validators.FilterRequest true "Filter parameters"
+// @Success 200 {file} file "Exported rows"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to export"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/cluster/{cluster}/filter/export [post]
+func (sc *StockController) ExportFilterByClusterGroupedPost(c *gin.Context) {
+	var request validators.FilterRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	sc.exportFilterByClusterGrouped(c, &request)
+}
+
+// exportFilterByClusterGrouped validates the bound filter request and streams the whole result set
+func (sc *StockController) exportFilterByClusterGrouped(c *gin.Context, request *validators.FilterRequest) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid format",
+			"details": "Format must be csv or xlsx",
+		})
+		return
+	}
+
+	cluster, numericalWeights, ratingWeights, ranges, _, ok := sc.prepareFilter(c, request)
+	if !ok {
+		return
+	}
+
+	streamStocks(c, format, fmt.Sprintf("stocks-cluster-%d", cluster), fmt.Sprintf("Cluster %d", cluster), basicExportLayout, func(emit func(models.StockDataPoint) error) (int, error) {
+		return sc.stockService.ExportClusterGrouped(cluster, request.GroupingColumn, request.GroupingValue, request.SortBy, request.Order, numericalWeights, ratingWeights, request.Tags, ranges, emit)
+	})
+}
+
+// ExportAllStocks handles GET /stocks/export
+// @Summary Export every stock
+// @Description Streams the whole stock table, or the stocks of a cluster, dataset version and date window, as CSV, XLSX or NDJSON. Rows are read in batches of 1000 with their relations loaded per batch, so memory stays flat on large datasets. CSV and XLSX rows flatten the rating sentiments (score and normalized score columns) and numerical indicators (value and norm_ columns) into the header names the CSV import reads, so the file can be imported back; NDJSON lines carry the full stock objects.
+// @Tags stocks
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Param format query string false "Export format: csv | xlsx | ndjson (default: csv)"
+// @Param cluster query int false "Only export stocks of this cluster"
+// @Param dataset query int false "Only export stocks of this dataset version"
+// @Param from query string false "Only export stocks recorded on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only export stocks recorded on or before this date (YYYY-MM-DD)"
+// @Success 200 {file} file "Exported rows"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to export"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/export [get]
+func (sc *StockController) ExportAllStocks(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "xlsx" && format != "ndjson" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid format",
+			"details": "Format must be csv, xlsx or ndjson",
+		})
+		return
+	}
+	cluster, dataset, ok := bindClusterAndDataset(c)
+	if !ok {
+		return
+	}
+
+	stockService := sc.stockService.WithContext(c.Request.Context())
+	streamStocks(c, format, "stocks", "Stocks", enrichedExportLayout, func(emit func(models.StockDataPoint) error) (int, error) {
+		return stockService.StreamScope(cluster, dataset, c.Query("from"), c.Query("to"), emit)
+	})
+}
+
+// streamStocks writes the stocks produced by run as a file download in format (csv, xlsx or ndjson).
+// The attachment headers are only sent once the first row is ready, so failures that happen before
+// any output (bad weights, query errors) still produce the normal JSON error response.
+func streamStocks(c *gin.Context, format, basename, sheetName string, layout exportLayout, run func(emit func(models.StockDataPoint) error) (int, error)) {
+	var writer stockWriter
+	start := func() error {
+		filename := fmt.Sprintf("%s.%s", basename, format)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Header("Content-Type", exportContentTypes[format])
+		c.Status(http.StatusOK)
+		var err error
+		writer, err = newStockWriter(c.Writer, format, sheetName, layout)
+		return err
+	}
+
+	count, err := run(func(stock models.StockDataPoint) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.Write(stock)
+	})
+	if err != nil && writer == nil {
+		respondError(c, err)
+		return
+	}
+	if err != nil {
+		// Headers are already on the wire; the truncated file is the only signal left to the client
+		logging.FromContext(c.Request.Context()).Warn("Export aborted", "export", basename, "rows", count, "error", err)
+	}
+
+	// An empty result set still yields a file (with just the header row for csv and xlsx)
+	if writer == nil {
+		if err := start(); err != nil {
+			logging.FromContext(c.Request.Context()).Warn("Export failed", "export", basename, "error", err)
+			return
+		}
+	}
+	if err := writer.Close(); err != nil {
+		logging.FromContext(c.Request.Context()).Warn("Export failed to flush", "export", basename, "error", err)
+	}
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseExportID parses the :id path parameter of an export job, writing a 400 response when it is malformed
+func parseExportID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid export job ID format",
+			"details": "Export job ID must be a positive number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// StartExport handles POST /exports
+// @Summary Start an export job
+// @Description Export the whole stock table asynchronously as CSV, XLSX or NDJSON (same layout as GET /stocks/export). The file is written to the export spool; poll GET /exports/{id} for the status and a signed download URL. Jobs and files are deleted once their retention ends
+// @Tags exports
+// @Produce json
+// @Param format query string false "Export format: csv | xlsx | ndjson (default: csv)"
+// @Success 202 {object} map[string]interface{} "Export job queued"
+// @Failure 400 {object} map[string]interface{} "Invalid format"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/exports [post]
+func (sc *StockController) StartExport(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if _, ok := exportContentTypes[format]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid format",
+			"details": "Format must be csv, xlsx or ndjson",
+		})
+		return
+	}
+
+	// The job outlives the request, but keeps its actor
+	job, err := sc.stockService.WithContext(context.WithoutCancel(c.Request.Context())).StartExport(format, func(w io.Writer, run func(emit func(models.StockDataPoint) error) (int, error)) (int, error) {
+		writer, err := newStockWriter(w, format, "Stocks", enrichedExportLayout)
+		if err != nil {
+			return 0, err
+		}
+		count, err := run(writer.Write)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		return count, err
+	})
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to start export"))
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/exports/%d", job.ID))
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Export job queued",
+		"data":    job,
+	})
+}
+
+// GetExportJob handles GET /exports/:id
+// @Summary Get an export job
+// @Description Status of an export job. Once it is complete the response carries a time-limited signed download_url; request the job again for a fresh link after it expires
+// @Tags exports
+// @Produce json
+// @Param id path int true "Export job ID"
+// @Success 200 {object} map[string]interface{} "Export job"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 404 {object} map[string]interface{} "Export job not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/exports/{id} [get]
+func (sc *StockController) GetExportJob(c *gin.Context) {
+	id, ok := parseExportID(c)
+	if !ok {
+		return
+	}
+
+	job, err := sc.stockService.GetExportJob(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get export job"))
+		return
+	}
+
+	response := gin.H{"data": job}
+	if job.Status == models.ExportComplete {
+		expires, signature, err := sc.stockService.SignExportDownload(job)
+		if err != nil {
+			respondError(c, apperrors.Wrap(err, "failed to sign export download"))
+			return
+		}
+		response["download_url"] = fmt.Sprintf("/api/v1/exports/%d/download?expires=%d&signature=%s", job.ID, expires, signature)
+		response["download_expires_at"] = time.Unix(expires, 0).UTC()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DownloadExport handles GET /exports/:id/download
+// @Summary Download an export file
+// @Description Download the file of a complete export job through the signed link returned by GET /exports/{id}. The link needs no other credentials and stops working when it expires
+// @Tags exports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Param id path int true "Export job ID"
+// @Param expires query int true "Link expiry (unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "Exported rows"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired link"
+// @Failure 404 {object} map[string]interface{} "Export job or file not found"
+// @Failure 409 {object} map[string]interface{} "Export job not complete"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/exports/{id}/download [get]
+func (sc *StockController) DownloadExport(c *gin.Context) {
+	id, ok := parseExportID(c)
+	if !ok {
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid parameters",
+			"details": "expires must be a unix timestamp",
+		})
+		return
+	}
+
+	job, err := sc.stockService.OpenExportDownload(id, expires, c.Query("signature"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", exportContentTypes[job.Format])
+	c.FileAttachment(job.Path, fmt.Sprintf("stocks-export-%d.%s", job.ID, job.Format))
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondQuotaExceeded writes a 429 response with the remaining budget when err refuses a run
+// over the daily upstream request quota
+func respondQuotaExceeded(c *gin.Context, err error) bool {
+	var quotaErr *service.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":   apperrors.Title(err),
+		"details": err.Error(),
+		"budget":  quotaErr.Budget,
+	})
+	return true
+}
+
+// GetExtractionBudget handles GET /stocks/extract/budget
+// @Summary Get the daily upstream request budget
+// @Description Upstream requests made today (UTC) with the configured API key, the EXTRACT_DAILY_REQUEST_QUOTA and how many requests remain before it resets. unlimited is true when no quota is configured
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Request budget"
+// @Failure 500 {object} map[string]interface{} "Failed to get extraction budget"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/extract/budget [get]
+func (sc *StockController) GetExtractionBudget(c *gin.Context) {
+	budget, err := sc.stockService.GetExtractionBudget()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": budget,
+	})
+}
+
+// GetExtractionPages handles GET /stocks/extract/pages
+// @Summary List extraction page-key history
+// @Description Returns the page keys visited by the API extractor with their page number, status and time, newest first. Entries older than EXTRACT_PAGE_HISTORY_RETENTION are pruned after each extraction run.
+// @Tags stocks
+// @Produce json
+// @Param status query string false "Only include entries with this status: success | error | retried"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)"
+// @Param sort_by query string false "Sort by column: recorded_at | page_number; a comma-separated list of column [asc|desc] keys (e.g. page_number asc, recorded_at desc) sorts by each in turn, keys without a direction use order (default: recorded_at)"
+// @Param order query string false "Sort order: asc | desc (default: desc)"
+// @Success 200 {object} map[string]interface{} "Extraction history page"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to get extraction pages"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/extract/pages [get]
+func (sc *StockController) GetExtractionPages(c *gin.Context) {
+	opts, ok := sc.bindListRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).GetExtractionPages(c.Query("status"), opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPage(c, result.Items, result.Pagination, nil)
+}
+
+// RetryFailedExtractionPages handles POST /stocks/extract/retry-failed
+// @Summary Replay failed extraction pages
+// @Description Fetches again only the pages recorded in the page history with status error and appends their items to the extraction CSV, instead of re-running the whole extraction. Recovered pages are marked retried; pages that fail again stay error and are listed in failed_keys. Each page is one upstream request, so a replay that could exceed EXTRACT_DAILY_REQUEST_QUOTA is refused with the budget in the response
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Replay summary"
+// @Failure 429 {object} map[string]interface{} "Daily upstream request quota exceeded"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to replay extraction pages"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/extract/retry-failed [post]
+func (sc *StockController) RetryFailedExtractionPages(c *gin.Context) {
+	// The replay finishes even if the client disconnects
+	replay, err := sc.stockService.WithContext(context.WithoutCancel(c.Request.Context())).RetryFailedExtractionPages()
+	if respondQuotaExceeded(c, err) {
+		return
+	}
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to replay extraction pages"))
+		return
+	}
+
+	message := "Failed extraction pages replayed successfully"
+	if len(replay.FailedKeys) > 0 {
+		message = "Some extraction pages failed again"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    replay,
+	})
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"net/http"
+
+	"dataextractor/graph"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLRequest is the JSON body of POST /graphql
+type GraphQLRequest struct {
+	Query         string                 `json:"query" example:"{ clusters }"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// GraphQLHandler builds the handler served by GraphQLQuery and GraphQLPost; pageRules bound the
+// stocks query like the REST list routes
+func (sc *StockController) GraphQLHandler(pageRules validators.PageRules) http.Handler {
+	return graph.NewHandler(sc.stockService, pageRules)
+}
+
+// GraphQLQuery handles GET /graphql
+// @Summary Run a GraphQL query
+// @Description Runs a GraphQL query against the stock schema (graph/schema.graphqls): stocks with filter and pagination arguments, stock by uuid or ticker, clusters and companies, with nested tags, ratingSentiments and numericalIndicators
+// @Tags graphql
+// @Produce json
+// @Param query query string true "GraphQL query"
+// @Param variables query string false "JSON-encoded query variables"
+// @Param operationName query string false "Operation to run when the query defines several"
+// @Success 200 {object} map[string]interface{} "GraphQL response with data and errors"
+// @Failure 400 {object} map[string]interface{} "Unparsable GraphQL request"
+// @Failure 422 {object} map[string]interface{} "Query does not validate against the schema"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/graphql [get]
+func GraphQLQuery(h http.Handler) gin.HandlerFunc {
+	return gin.WrapH(h)
+}
+
+// GraphQLPost handles POST /graphql
+// @Summary Run a GraphQL query
+// @Description Same as GET /graphql with the query, variables and operation name in a JSON body
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body GraphQLRequest true "GraphQL request"
+// @Success 200 {object} map[string]interface{} "GraphQL response with data and errors"
+// @Failure 400 {object} map[string]interface{} "Unparsable GraphQL request"
+// @Failure 422 {object} map[string]interface{} "Query does not validate against the schema"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/graphql [post]
+func GraphQLPost(h http.Handler) gin.HandlerFunc {
+	return gin.WrapH(h)
+}
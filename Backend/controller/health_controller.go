@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthPingTimeout bounds the database round trip of a health check
+const healthPingTimeout = 2 * time.Second
+
+// DatabaseConnected reports whether the database connection is established; the router answers
+// 503 on API routes while it is not
+func (sc *StockController) DatabaseConnected() bool {
+	return sc.stockService.DatabaseConnected()
+}
+
+// HealthCheck handles GET /health
+// @Summary Health check
+// @Description Reports healthy when the database answers a ping. While the server runs degraded (the database was unreachable at startup and is being reconnected in the background) or the database stops answering, it reports unhealthy with 503
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Service healthy"
+// @Failure 503 {object} map[string]interface{} "Service unhealthy"
+// @Router /health [get]
+func (sc *StockController) HealthCheck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	if err := sc.stockService.PingDatabase(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":   "unhealthy",
+			"message":  "Stock API is running but the database is unavailable",
+			"database": "down",
+			"degraded": !sc.stockService.DatabaseConnected(),
+			"details":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "healthy",
+		"message":  "Stock API is running",
+		"database": "up",
+	})
+}
+
+// ReadinessCheck handles GET /health/ready
+// @Summary Readiness check
+// @Description Pings the database and, when it answers, reports the connection pool statistics (open, in use and idle connections, waits) and the migration status (tables and columns missing from the database). Reports not ready with 503 when the database is unreachable; an incomplete migration is reported in migration without failing the check
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Service ready"
+// @Failure 503 {object} map[string]interface{} "Database unreachable"
+// @Router /health/ready [get]
+func (sc *StockController) ReadinessCheck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	readiness := sc.stockService.WithContext(ctx).CheckReadiness(ctx)
+	if !readiness.Ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":   "not_ready",
+			"message":  "Stock API is running but the database is unreachable",
+			"database": "down",
+			"degraded": readiness.Degraded,
+			"details":  readiness.Error,
+		})
+		return
+	}
+
+	body := gin.H{
+		"status":    "ready",
+		"message":   "Stock API is ready",
+		"database":  "up",
+		"pool":      readiness.Pool,
+		"migration": readiness.Migration,
+	}
+	if readiness.Error != "" {
+		body["details"] = readiness.Error
+	}
+	c.JSON(http.StatusOK, body)
+}
@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetIndicators handles GET /stocks/:id/indicators
+// @Summary List stock indicators
+// @Description Retrieve the numerical indicators of a stock
+// @Tags indicators
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Success 200 {object} map[string]interface{} "List of indicators"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/indicators [get]
+func (sc *StockController) GetIndicators(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	indicators, err := sc.stockService.WithContext(c.Request.Context()).GetIndicators(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get indicators"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  indicators,
+		"count": len(indicators),
+	})
+}
+
+// AddIndicator handles POST /stocks/:id/indicators
+// @Summary Add a stock indicator
+// @Description Add one numerical indicator to a stock and recalculate its final score
+// @Tags indicators
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param request body validators.NumericalIndicatorRequest true "Indicator"
+// @Success 201 {object} map[string]interface{} "Indicator added successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 409 {object} map[string]interface{} "The stock already has an indicator with this name"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/indicators [post]
+func (sc *StockController) AddIndicator(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.NumericalIndicatorRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).AddIndicator(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to add indicator"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Indicator added successfully",
+		"data":    stock,
+	})
+}
+
+// UpdateIndicator handles PUT /stocks/:id/indicators/:name
+// @Summary Correct a stock indicator
+// @Description Replace the values of one numerical indicator of a stock, addressed by name, and recalculate its final score
+// @Tags indicators
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param name path string true "Indicator name"
+// @Param request body validators.NumericalIndicatorUpdateRequest true "Indicator values"
+// @Success 200 {object} map[string]interface{} "Indicator updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock or indicator not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/indicators/{name} [put]
+func (sc *StockController) UpdateIndicator(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.NumericalIndicatorUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).UpdateIndicator(id, c.Param("name"), &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to update indicator"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Indicator updated successfully",
+		"data":    stock,
+	})
+}
+
+// DeleteIndicator handles DELETE /stocks/:id/indicators/:name
+// @Summary Delete a stock indicator
+// @Description Remove one numerical indicator from a stock and recalculate its final score
+// @Tags indicators
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param name path string true "Indicator name"
+// @Success 200 {object} map[string]interface{} "Indicator deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock or indicator not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/indicators/{name} [delete]
+func (sc *StockController) DeleteIndicator(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).DeleteIndicator(id, c.Param("name"))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete indicator"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Indicator deleted successfully",
+		"data":    stock,
+	})
+}
+
+// GetSentiments handles GET /stocks/:id/sentiments
+// @Summary List stock sentiments
+// @Description Retrieve the rating sentiments of a stock
+// @Tags sentiments
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Success 200 {object} map[string]interface{} "List of sentiments"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/sentiments [get]
+func (sc *StockController) GetSentiments(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	sentiments, err := sc.stockService.WithContext(c.Request.Context()).GetSentiments(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get sentiments"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  sentiments,
+		"count": len(sentiments),
+	})
+}
+
+// AddSentiment handles POST /stocks/:id/sentiments
+// @Summary Add a stock sentiment
+// @Description Add one rating sentiment to a stock, score it with the rating rubric and recalculate the final score
+// @Tags sentiments
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param request body validators.RatingSentimentRequest true "Sentiment"
+// @Success 201 {object} map[string]interface{} "Sentiment added successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 409 {object} map[string]interface{} "The stock already has a sentiment with this name"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/sentiments [post]
+func (sc *StockController) AddSentiment(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.RatingSentimentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).AddSentiment(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to add sentiment"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Sentiment added successfully",
+		"data":    stock,
+	})
+}
+
+// UpdateSentiment handles PUT /stocks/:id/sentiments/:name
+// @Summary Correct a stock sentiment
+// @Description Replace the rating and scores of one sentiment of a stock, addressed by name, and recalculate its final score
+// @Tags sentiments
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param name path string true "Sentiment name"
+// @Param request body validators.RatingSentimentUpdateRequest true "Sentiment values"
+// @Success 200 {object} map[string]interface{} "Sentiment updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock or sentiment not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/sentiments/{name} [put]
+func (sc *StockController) UpdateSentiment(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.RatingSentimentUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).UpdateSentiment(id, c.Param("name"), &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to update sentiment"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sentiment updated successfully",
+		"data":    stock,
+	})
+}
+
+// DeleteSentiment handles DELETE /stocks/:id/sentiments/:name
+// @Summary Delete a stock sentiment
+// @Description Remove one rating sentiment from a stock and recalculate its final score
+// @Tags sentiments
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param name path string true "Sentiment name"
+// @Success 200 {object} map[string]interface{} "Sentiment deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock or sentiment not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/sentiments/{name} [delete]
+func (sc *StockController) DeleteSentiment(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).DeleteSentiment(id, c.Param("name"))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete sentiment"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sentiment deleted successfully",
+		"data":    stock,
+	})
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/logging"
+	"dataextractor/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJob handles GET /jobs/:id
+// @Summary Get a background job
+// @Description Status of a background job such as an extraction started with POST /stocks/extract: pages processed and items written so far, and the error of a failed run. Progress is saved after every page, so the job can be polled while it runs
+// @Tags jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} map[string]interface{} "Job"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 404 {object} map[string]interface{} "Job not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/jobs/{id} [get]
+func (sc *StockController) GetJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid job ID format",
+			"details": "Job ID must be a positive number",
+		})
+		return
+	}
+
+	job, err := sc.stockService.GetJob(uint(id))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get job"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": job,
+	})
+}
+
+// jobEventsHeartbeat is how often an idle event stream sends a comment line, so proxies keep it open
+const jobEventsHeartbeat = 15 * time.Second
+
+// StreamExtractionEvents handles GET /stocks/extract/:job_id/events
+// @Summary Stream the progress of an extraction job
+// @Description Server-Sent Events stream of an extraction job started with POST /stocks/extract. A job event carries the job as it is when the stream opens. Then a page event (page, items_fetched, items_written, total_written) is sent after each page is written, and an error event when a page fails to fetch. A final done event carries the finished job, and the stream closes. A job that has already finished gets the job and done events right away. Events are not replayed, so use the job event for the progress made before connecting. Idle streams get a comment line every 15 seconds
+// @Tags jobs
+// @Produce text/event-stream
+// @Param job_id path int true "Extraction job ID"
+// @Success 200 {file} file "Event stream"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 404 {object} map[string]interface{} "Extraction job not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/extract/{job_id}/events [get]
+func (sc *StockController) StreamExtractionEvents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("job_id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid job ID format",
+			"details": "Job ID must be a positive number",
+		})
+		return
+	}
+
+	stockService := sc.stockService.WithContext(c.Request.Context())
+	job, events, unsubscribe, err := stockService.SubscribeJob(uint(id))
+	if err == nil && job.Kind != models.JobExtraction {
+		unsubscribe()
+		err = apperrors.NotFound("extraction job %d not found", id)
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("job", job)
+	if events == nil {
+		c.SSEvent("done", job)
+		c.Writer.Flush()
+		return
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(jobEventsHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				finished, err := stockService.GetJob(uint(id))
+				if err != nil {
+					logging.FromContext(c.Request.Context()).Warn("Job event stream ended", "job_id", id, "error", err)
+					return false
+				}
+				c.SSEvent("done", finished)
+				return false
+			}
+			c.SSEvent(event.Type, event.Progress)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
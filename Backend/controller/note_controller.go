@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseNoteID parses the :note_id path parameter, writing a 400 response when it is malformed
+func parseNoteID(c *gin.Context) (uint, bool) {
+	noteID, err := strconv.ParseUint(c.Param("note_id"), 10, 32)
+	if err != nil || noteID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid note ID format",
+			"details": "Note ID must be a positive number",
+		})
+		return 0, false
+	}
+	return uint(noteID), true
+}
+
+// GetNotes handles GET /stocks/:id/notes
+// @Summary List stock notes
+// @Description Retrieve the analyst notes recorded for a stock, newest first
+// @Tags notes
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Success 200 {object} map[string]interface{} "List of notes"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/notes [get]
+func (sc *StockController) GetNotes(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	notes, err := sc.stockService.WithContext(c.Request.Context()).GetNotes(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get notes"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  notes,
+		"count": len(notes),
+	})
+}
+
+// CreateNote handles POST /stocks/:id/notes
+// @Summary Add a stock note
+// @Description Record a free-text note next to a stock. Authenticated callers are recorded as the author; otherwise author is required
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param request body validators.NoteRequest true "Note content"
+// @Success 201 {object} map[string]interface{} "Note created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/notes [post]
+func (sc *StockController) CreateNote(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.NoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	note, err := sc.stockService.WithContext(c.Request.Context()).CreateNote(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to create note"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Note created successfully",
+		"data":    note,
+	})
+}
+
+// UpdateNote handles PUT /stocks/:id/notes/:note_id
+// @Summary Edit a stock note
+// @Description Replace the body of a note. The author and creation time are kept
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param note_id path int true "Note ID"
+// @Param request body validators.NoteRequest true "Note content"
+// @Success 200 {object} map[string]interface{} "Note updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Note not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/notes/{note_id} [put]
+func (sc *StockController) UpdateNote(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+	noteID, ok := parseNoteID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.NoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	note, err := sc.stockService.WithContext(c.Request.Context()).UpdateNote(id, noteID, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to update note"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note updated successfully",
+		"data":    note,
+	})
+}
+
+// DeleteNote handles DELETE /stocks/:id/notes/:note_id
+// @Summary Delete a stock note
+// @Description Remove a note from a stock
+// @Tags notes
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param note_id path int true "Note ID"
+// @Success 200 {object} map[string]interface{} "Note deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid note ID"
+// @Failure 404 {object} map[string]interface{} "Note not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/notes/{note_id} [delete]
+func (sc *StockController) DeleteNote(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+	noteID, ok := parseNoteID(c)
+	if !ok {
+		return
+	}
+
+	err := sc.stockService.WithContext(c.Request.Context()).DeleteNote(id, noteID)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete note"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note deleted successfully",
+	})
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SendTestNotification handles POST /notifications/test
+// @Summary Send a test notification
+// @Description Send a test message on the configured email (SMTP) and Slack webhook channels, or only on the requested one, and report each delivery. The same channels report failed imports and extractions (admin only)
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body validators.NotificationTestRequest false "Channel to test (all when omitted)"
+// @Success 200 {object} map[string]interface{} "Delivery results per channel"
+// @Failure 400 {object} map[string]interface{} "Invalid or unconfigured channel"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications/test [post]
+func (sc *StockController) SendTestNotification(c *gin.Context) {
+	var request validators.NotificationTestRequest
+	if err := c.ShouldBindJSON(&request); err != nil && err != io.EOF {
+		respondBindError(c, err)
+		return
+	}
+
+	deliveries, err := sc.stockService.SendTestNotification(&request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to send test notification"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  deliveries,
+		"count": len(deliveries),
+	})
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPreferences handles GET /me/preferences
+// @Summary Get my dashboard preferences
+// @Description Default cluster and weights of the authenticated caller. The weights are applied to the cluster filter when it is called without any
+// @Tags preferences
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Preferences (empty when none are stored)"
+// @Failure 401 {object} map[string]interface{} "Not authenticated"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/me/preferences [get]
+func (sc *StockController) GetPreferences(c *gin.Context) {
+	preferences, err := sc.stockService.WithContext(c.Request.Context()).GetPreferences(models.ActorFromContext(c.Request.Context()))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": preferences,
+	})
+}
+
+// SavePreferences handles PUT /me/preferences
+// @Summary Save my dashboard preferences
+// @Description Replace the default cluster and weights of the authenticated caller. Weights are checked like filter weights
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param request body validators.PreferencesRequest true "Preferences"
+// @Success 200 {object} map[string]interface{} "Preferences saved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 401 {object} map[string]interface{} "Not authenticated"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/me/preferences [put]
+func (sc *StockController) SavePreferences(c *gin.Context) {
+	var request validators.PreferencesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	preferences, err := sc.stockService.WithContext(c.Request.Context()).SavePreferences(models.ActorFromContext(c.Request.Context()), &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to save preferences"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Preferences saved successfully",
+		"data":    preferences,
+	})
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+	"dataextractor/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfirmationHeader carries the token returned by a destructive dry run
+const ConfirmationHeader = "X-Confirmation-Token"
+
+// PurgeStocks handles DELETE /stocks/purge
+// @Summary Delete the stocks in a cluster, dataset version or date range
+// @Description Deletes the data points matching every given criterion, together with their sentiments, indicators, notes and tag links (ticker history is kept). Call with dry_run=true first: it returns the matching count and a confirmation token, which the delete must send back in the X-Confirmation-Token header before it expires. The token is rejected if the matching rows changed in between.
+// @Tags stocks
+// @Produce json
+// @Param cluster query int false "Cluster id"
+// @Param dataset query int false "Dataset version ID"
+// @Param from query string false "Earliest record date, inclusive (YYYY-MM-DD)"
+// @Param to query string false "Latest record date, inclusive (YYYY-MM-DD)"
+// @Param dry_run query bool false "Only count the matching rows and issue a confirmation token (default: false)"
+// @Param X-Confirmation-Token header string false "Token from the dry run (required unless dry_run=true)"
+// @Success 200 {object} map[string]interface{} "Dry run result or deletion summary"
+// @Failure 400 {object} map[string]interface{} "Invalid scope or confirmation token"
+// @Failure 500 {object} map[string]interface{} "Failed to delete"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/purge [delete]
+func (sc *StockController) PurgeStocks(c *gin.Context) {
+	dryRun, ok := bindDryRun(c)
+	if !ok {
+		return
+	}
+
+	cluster, dataset, ok := bindClusterAndDataset(c)
+	if !ok {
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).PurgeStocks(cluster, dataset, c.Query("from"), c.Query("to"), dryRun, c.GetHeader(ConfirmationHeader))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	message := "Stocks deleted successfully"
+	if dryRun {
+		message = "Dry run: nothing was deleted"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// EmptyAllTables handles DELETE /stocks/tables
+// @Summary Empty all tables
+// @Description Wipes every data table (stocks, sentiments, indicators, history, notes, tags, dataset versions, import fingerprints) with TRUNCATE. Requires the admin role. Call with dry_run=true first to get the per-table row counts and a confirmation token, then repeat without dry_run and with the token in the X-Confirmation-Token header.
+// @Tags stocks
+// @Produce json
+// @Param dry_run query bool false "Only count the rows and issue a confirmation token (default: false)"
+// @Param X-Confirmation-Token header string false "Token from the dry run (required unless dry_run=true)"
+// @Success 200 {object} map[string]interface{} "Dry run result or tables emptied"
+// @Failure 400 {object} map[string]interface{} "Invalid confirmation token"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to empty tables"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/tables [delete]
+func (sc *StockController) EmptyAllTables(c *gin.Context) {
+	dryRun, ok := bindDryRun(c)
+	if !ok {
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).EmptyAllTables(dryRun, c.GetHeader(ConfirmationHeader))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	message := "All tables emptied successfully"
+	if dryRun {
+		message = "Dry run: nothing was deleted"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// GetIntegrity handles GET /stocks/integrity
+// @Summary Check referential integrity
+// @Description Counts sentiments and indicators whose stock no longer exists, and stocks without sentiments, without indicators or without either (incomplete_stocks). Such rows are left behind by interrupted imports or manual SQL. Requires the admin role.
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Problem counts"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to check integrity"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/integrity [get]
+func (sc *StockController) GetIntegrity(c *gin.Context) {
+	problems, err := sc.stockService.WithContext(c.Request.Context()).CheckIntegrity()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to check integrity"))
+		return
+	}
+
+	orphans := problems[repository.IntegrityOrphanedSentiments] + problems[repository.IntegrityOrphanedIndicators]
+	c.JSON(http.StatusOK, gin.H{
+		"data":    problems,
+		"healthy": orphans == 0 && problems[repository.IntegrityIncompleteStocks] == 0,
+	})
+}
+
+// DeleteOrphans handles DELETE /stocks/orphans
+// @Summary Delete orphaned sentiments and indicators
+// @Description Deletes the sentiments and indicators whose stock no longer exists and, with incomplete=true, the stocks missing their sentiments or indicators. Requires the admin role. Call with dry_run=true first to get the counts and a confirmation token, then repeat without dry_run and with the token in the X-Confirmation-Token header.
+// @Tags stocks
+// @Produce json
+// @Param incomplete query bool false "Also delete the stocks without sentiments or without indicators (default: false)"
+// @Param dry_run query bool false "Only count the rows and issue a confirmation token (default: false)"
+// @Param X-Confirmation-Token header string false "Token from the dry run (required unless dry_run=true)"
+// @Success 200 {object} map[string]interface{} "Dry run result or deletion summary"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters or confirmation token"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to delete"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/orphans [delete]
+func (sc *StockController) DeleteOrphans(c *gin.Context) {
+	dryRun, ok := bindDryRun(c)
+	if !ok {
+		return
+	}
+	incomplete := false
+	if incompleteStr := c.Query("incomplete"); incompleteStr != "" {
+		value, err := strconv.ParseBool(incompleteStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid incomplete parameter",
+				"details": "Incomplete must be true or false",
+			})
+			return
+		}
+		incomplete = value
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).DeleteOrphans(incomplete, dryRun, c.GetHeader(ConfirmationHeader))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	message := "Orphaned rows deleted successfully"
+	if dryRun {
+		message = "Dry run: nothing was deleted"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// bindDryRun parses the dry_run flag, writing a 400 response when it is not a boolean
+func bindDryRun(c *gin.Context) (bool, bool) {
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr == "" {
+		return false, true
+	}
+	dryRun, err := strconv.ParseBool(dryRunStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid dry_run parameter",
+			"details": "Dry run must be true or false",
+		})
+		return false, false
+	}
+	return dryRun, true
+}
+
+// bindClusterAndDataset parses the optional cluster and dataset query parameters shared by the
+// scoped purge and export; it writes the 400 response and returns false when either is invalid
+func bindClusterAndDataset(c *gin.Context) (*int, uint, bool) {
+	var cluster *int
+	if clusterStr := c.Query("cluster"); clusterStr != "" {
+		value, err := strconv.Atoi(clusterStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid cluster parameter",
+				"details": "Cluster must be an integer",
+			})
+			return nil, 0, false
+		}
+		cluster = &value
+	}
+	var dataset uint
+	if datasetStr := c.Query("dataset"); datasetStr != "" {
+		value, err := strconv.ParseUint(datasetStr, 10, 32)
+		if err != nil || value == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid dataset parameter",
+				"details": "Dataset must be a positive dataset version ID",
+			})
+			return nil, 0, false
+		}
+		dataset = uint(value)
+	}
+	return cluster, dataset, true
+}
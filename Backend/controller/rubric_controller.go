@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseRubricID parses the :id path parameter of a rubric entry, writing a 400 response when it is malformed
+func parseRubricID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid rubric entry ID format",
+			"details": "Rubric entry ID must be a positive number",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// GetRatingRubric handles GET /rating-rubric
+// @Summary List the rating rubric
+// @Description Rating and action terms with the scores given to sentiments carrying them, ordered by kind and term
+// @Tags rubric
+// @Produce json
+// @Param kind query string false "Only entries of this kind (rating or action)"
+// @Success 200 {object} map[string]interface{} "Rubric entries"
+// @Failure 400 {object} map[string]interface{} "Invalid kind"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/rating-rubric [get]
+func (sc *StockController) GetRatingRubric(c *gin.Context) {
+	entries, err := sc.stockService.GetRatingRubric(c.Query("kind"))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get rating rubric"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  entries,
+		"count": len(entries),
+	})
+}
+
+// CreateRatingRubric handles POST /rating-rubric
+// @Summary Add a rubric term
+// @Description Map a rating (rating_from/rating_to) or action term to a raw and normalized score. Sentiments carrying the term are scored from the rubric on API writes and imports. Terms are case-insensitive
+// @Tags rubric
+// @Accept json
+// @Produce json
+// @Param request body validators.RatingRubricRequest true "Rubric entry"
+// @Success 201 {object} map[string]interface{} "Rubric entry created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 409 {object} map[string]interface{} "Term already in the rubric"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/rating-rubric [post]
+func (sc *StockController) CreateRatingRubric(c *gin.Context) {
+	var request validators.RatingRubricRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	entry, err := sc.stockService.WithContext(c.Request.Context()).CreateRatingRubric(&request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to create rating rubric entry"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Rubric entry created successfully",
+		"data":    entry,
+	})
+}
+
+// UpdateRatingRubric handles PUT /rating-rubric/:id
+// @Summary Edit a rubric term
+// @Description Replace the kind, term and scores of a rubric entry. Existing sentiments are rescored on their next write
+// @Tags rubric
+// @Accept json
+// @Produce json
+// @Param id path int true "Rubric entry ID"
+// @Param request body validators.RatingRubricRequest true "Rubric entry"
+// @Success 200 {object} map[string]interface{} "Rubric entry updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Rubric entry not found"
+// @Failure 409 {object} map[string]interface{} "Term already in the rubric"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/rating-rubric/{id} [put]
+func (sc *StockController) UpdateRatingRubric(c *gin.Context) {
+	id, ok := parseRubricID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.RatingRubricRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	entry, err := sc.stockService.WithContext(c.Request.Context()).UpdateRatingRubric(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to update rating rubric entry"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rubric entry updated successfully",
+		"data":    entry,
+	})
+}
+
+// DeleteRatingRubric handles DELETE /rating-rubric/:id
+// @Summary Delete a rubric term
+// @Description Remove a term from the rubric; sentiments carrying it keep the scores they have
+// @Tags rubric
+// @Produce json
+// @Param id path int true "Rubric entry ID"
+// @Success 200 {object} map[string]interface{} "Rubric entry deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid rubric entry ID"
+// @Failure 404 {object} map[string]interface{} "Rubric entry not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/rating-rubric/{id} [delete]
+func (sc *StockController) DeleteRatingRubric(c *gin.Context) {
+	id, ok := parseRubricID(c)
+	if !ok {
+		return
+	}
+
+	err := sc.stockService.WithContext(c.Request.Context()).DeleteRatingRubric(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete rating rubric entry"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rubric entry deleted successfully",
+	})
+}
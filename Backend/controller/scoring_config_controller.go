@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportScoringConfig handles GET /scoring-config
+// @Summary Export the scoring configuration
+// @Description Download the rating rubric, the weight profiles of every user and the indicator and sentiment names they refer to as one JSON document, to import on another environment with POST /scoring-config/import. The configured default weight profile (SCORING_DEFAULT_WEIGHT, SCORING_DEFAULT_WEIGHTS) is included for comparison; it comes from the environment and is not imported. Requires the admin role.
+// @Tags scoring
+// @Produce json
+// @Success 200 {object} service.ScoringConfigDocument "Scoring configuration document"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to export the scoring configuration"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/scoring-config [get]
+func (sc *StockController) ExportScoringConfig(c *gin.Context) {
+	document, err := sc.stockService.WithContext(c.Request.Context()).ExportScoringConfig()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to export scoring configuration"))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "scoring-config.json"))
+	c.JSON(http.StatusOK, document)
+}
+
+// ImportScoringConfig handles POST /scoring-config/import
+// @Summary Import a scoring configuration
+// @Description Apply a document exported by GET /scoring-config. Rubric entries are matched by kind and term and weight profiles by user; both are created or overwritten. The whole document is validated first and written in one transaction, so a rejected document changes nothing. A document naming indicators or sentiments unknown to this environment is rejected. With replace=true, rubric entries and weight profiles that are not in the document are deleted. Requires the admin role.
+// @Tags scoring
+// @Accept json
+// @Produce json
+// @Param replace query bool false "Delete rubric entries and weight profiles missing from the document (default: false)"
+// @Param request body validators.ScoringConfigRequest true "Scoring configuration document"
+// @Success 200 {object} map[string]interface{} "Scoring configuration imported"
+// @Failure 400 {object} map[string]interface{} "Invalid document"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to import the scoring configuration"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/scoring-config/import [post]
+func (sc *StockController) ImportScoringConfig(c *gin.Context) {
+	replace := false
+	if replaceStr := c.Query("replace"); replaceStr != "" {
+		value, err := strconv.ParseBool(replaceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid replace parameter",
+				"details": "Replace must be true or false",
+			})
+			return
+		}
+		replace = value
+	}
+
+	var request validators.ScoringConfigRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).ImportScoringConfig(&request, replace)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to import scoring configuration"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring configuration imported successfully",
+		"data":    result,
+	})
+}
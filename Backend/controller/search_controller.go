@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Search handles GET /search
+// @Summary Search tickers and companies
+// @Description Autocomplete for the dashboard omnibox: stocks whose ticker or company matches q, ranked exact ticker, ticker prefix, company prefix, company word prefix, then substring matches
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param limit query int false "Maximum number of results, 1-50 (default: 10)"
+// @Success 200 {object} map[string]interface{} "Matching stocks"
+// @Failure 400 {object} map[string]interface{} "Invalid query or limit"
+// @Failure 500 {object} map[string]interface{} "Failed to search"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/search [get]
+func (sc *StockController) Search(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid limit parameter",
+				"details": "Limit must be an integer",
+			})
+			return
+		}
+		limit = value
+	}
+
+	query := c.Query("q")
+	results, err := sc.stockService.WithContext(c.Request.Context()).Search(query, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query": query,
+		"data":  results,
+		"count": len(results),
+	})
+}
@@ -1,14 +1,18 @@
 package controller
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"dataextractor/apperrors"
+	"dataextractor/db_populate"
+	"dataextractor/models"
 	"dataextractor/repository"
 	"dataextractor/service"
-	"dataextractor/utils"
 	"dataextractor/validators"
 
 	"github.com/gin-gonic/gin"
@@ -17,25 +21,166 @@ import (
 // StockController handles HTTP requests for stock operations
 type StockController struct {
 	stockService service.StockServiceInterface
+	validator    *validators.StockValidator
 }
 
-// NewStockController creates a new StockController instance
-func NewStockController() *StockController {
-	// Create repository factory
-	repoFactory := repository.NewRepositoryFactory()
-	repo := repoFactory.CreateDataRepository()
-
-	// Create stock service
-	stockService := service.NewStockService(repo)
-
+// NewStockController creates a new StockController instance backed by the given service
+func NewStockController(stockService service.StockServiceInterface) *StockController {
 	return &StockController{
 		stockService: stockService,
+		validator:    validators.NewStockValidator(),
+	}
+}
+
+// respondError writes the error envelope with the status mapped from the error's kind. The error
+// is also attached to the context, so the access log line of the request records it.
+func respondError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.JSON(apperrors.HTTPStatus(err), gin.H{
+		"error":   apperrors.Title(err),
+		"details": err.Error(),
+	})
+}
+
+// respondBindError writes the 400 envelope of a request body that could not be decoded, or 413 when
+// it exceeded the size limit router.BodyLimitMiddleware enforces while the body is read
+func respondBindError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error":   "Request body too large",
+			"details": fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Invalid request format",
+		"details": err.Error(),
+	})
+}
+
+// resolveStockID resolves the :id path parameter, writing a 400 response when it is malformed and
+// the mapped error response when it cannot be resolved
+func (sc *StockController) resolveStockID(c *gin.Context) (uint, bool) {
+	id, err := sc.stockService.ResolveID(c.Param("id"))
+	if errors.Is(err, service.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid ID format",
+			"details": err.Error(),
+		})
+		return 0, false
+	}
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to resolve stock ID"))
+		return 0, false
 	}
+	return id, true
+}
+
+// stockIncludes parses the ?include= list of related resources, writing a 400 response for unknown ones
+func stockIncludes(c *gin.Context) (map[string]bool, bool) {
+	includes := map[string]bool{}
+	for _, name := range strings.Split(c.Query("include"), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if name != "notes" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid include parameter",
+				"details": "include must be a comma-separated list of: notes",
+			})
+			return nil, false
+		}
+		includes[name] = true
+	}
+	return includes, true
+}
+
+// writePrecondition reads the If-Match and If-Unmodified-Since headers of a conditional write
+func writePrecondition(c *gin.Context) service.WritePrecondition {
+	return service.WritePrecondition{
+		IfMatch:           c.GetHeader("If-Match"),
+		IfUnmodifiedSince: c.GetHeader("If-Unmodified-Since"),
+	}
+}
+
+// setStockValidators sets the ETag and Last-Modified headers clients send back on conditional writes
+func setStockValidators(c *gin.Context, stock *models.StockDataPoint) {
+	c.Header("ETag", service.StockETag(stock))
+	c.Header("Last-Modified", service.StockLastModified(stock))
+}
+
+// DataVersion reports the current data version; the router's cache middleware uses it to
+// derive Last-Modified/ETag validators for cacheable read endpoints
+func (sc *StockController) DataVersion() (repository.DataVersion, error) {
+	return sc.stockService.GetDataVersion()
+}
+
+// bindListRequest returns the page/sort parameters parsed by the list parameter middleware. Routes
+// registered without it parse the query here with the default rules. The optional dataset parameter
+// pins the listing to a dataset version. It writes a 400 response when the parameters are invalid.
+func (sc *StockController) bindListRequest(c *gin.Context) (repository.ListOptions, bool) {
+	params, ok := c.Get(validators.PageParamsContextKey)
+	if !ok {
+		rules := validators.DefaultPageRules
+		rules.SortColumns = repository.ListSortColumns
+		parsed, err := validators.ParsePageParams(c.Query("page"), c.Query("per_page"), c.Query("sort_by"), c.Query("order"), rules)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid parameters",
+				"details": err.Error(),
+			})
+			return repository.ListOptions{}, false
+		}
+		params = parsed
+	}
+
+	p := params.(validators.PageParams)
+	opts := repository.ListOptions{Page: p.Page, PerPage: p.PerPage, SortBy: p.SortBy, Order: p.Order}
+	if datasetStr := c.Query("dataset"); datasetStr != "" {
+		dataset, err := strconv.ParseUint(datasetStr, 10, 32)
+		if err != nil || dataset == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid parameters",
+				"details": "dataset must be a positive dataset version ID",
+			})
+			return repository.ListOptions{}, false
+		}
+		opts.Dataset = uint(dataset)
+	}
+	return opts, true
+}
+
+// respondPage writes one page of a listing together with its pagination fields; extra adds
+// endpoint-specific fields such as the applied filters
+func respondPage(c *gin.Context, items interface{}, page service.Pagination, extra gin.H) {
+	body := gin.H{
+		"data":        items,
+		"count":       page.Count,
+		"total_count": page.TotalCount,
+		"page":        page.Page,
+		"per_page":    page.PerPage,
+		"total_pages": page.TotalPages,
+		"has_next":    page.HasNext,
+		"sort_by":     page.SortBy,
+		"order":       page.Order,
+	}
+	if page.WeightsHash != "" {
+		body["weights_hash"] = page.WeightsHash
+	}
+	if len(page.WeightSets) > 0 {
+		body["weight_sets"] = page.WeightSets
+	}
+	for key, value := range extra {
+		body[key] = value
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 // CreateStock handles POST /stocks
 // @Summary Create a new stock
-// @Description Create a new stock record with the provided information
+// @Description Create a new stock record with the provided information. A stock without a cluster is placed at the nearest cluster centroid, and final_score is computed from its indicators and sentiments with the default weight profile
 // @Tags stocks
 // @Accept json
 // @Produce json
@@ -43,22 +188,24 @@ func NewStockController() *StockController {
 // @Success 201 {object} map[string]interface{} "Stock created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request format"
 // @Failure 500 {object} map[string]interface{} "Failed to create stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks [post]
 func (sc *StockController) CreateStock(c *gin.Context) {
 	var request validators.StockCreateRequest
 
 	// Bind JSON request to StockCreateRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	// Create stock using service
-	stock, err := sc.stockService.Create(&request)
-	utils.ErrorPanic(err, "failed to create stock")
+	stock, err := sc.stockService.WithContext(c.Request.Context()).Create(&request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to create stock"))
+		return
+	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Stock created successfully",
@@ -66,33 +213,82 @@ func (sc *StockController) CreateStock(c *gin.Context) {
 	})
 }
 
+// CreateStocksBatch handles POST /stocks/batch
+// @Summary Create stocks in bulk
+// @Description Create up to 1000 stocks in one call. Every item is validated like POST /stocks; the valid ones are inserted in a single transaction and the invalid ones are reported and skipped. results holds one entry per submitted item, in request order, with the created stock or the validation error. Answers 201 when every item was created and 207 when some were rejected; a database error rolls back the whole batch.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param stocks body []validators.StockCreateRequest true "Stocks to create"
+// @Success 201 {object} service.BatchCreateResult "All stocks created"
+// @Success 207 {object} service.BatchCreateResult "Some stocks rejected"
+// @Failure 400 {object} map[string]interface{} "Invalid request format, empty or oversized batch"
+// @Failure 500 {object} map[string]interface{} "Failed to create stocks"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/batch [post]
+func (sc *StockController) CreateStocksBatch(c *gin.Context) {
+	var requests []validators.StockCreateRequest
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).CreateBatch(requests)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to create stocks"))
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, result)
+}
+
 // GetStockByID handles GET /stocks/:id
 // @Summary Get stock by ID
 // @Description Retrieve a specific stock record by its ID
 // @Tags stocks
 // @Produce json
-// @Param id path int true "Stock ID"
-// @Success 200 {object} map[string]interface{} "Stock found"
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param include query string false "Related resources to embed: notes"
+// @Success 200 {object} map[string]interface{} "Stock found, with ETag and Last-Modified headers for conditional writes"
 // @Failure 400 {object} map[string]interface{} "Invalid stock ID"
 // @Failure 404 {object} map[string]interface{} "Stock not found"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/{id} [get]
 func (sc *StockController) GetStockByID(c *gin.Context) {
-	// Parse ID from URL parameter
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid ID format",
-			"details": "ID must be a valid number",
-		})
+	// Resolve the numeric ID or UUID from the URL parameter
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	includes, ok := stockIncludes(c)
+	if !ok {
 		return
 	}
 
 	// Get stock by ID
-	stock, err := sc.stockService.GetByID(uint(id))
-	utils.ErrorPanic(err, "failed to get stock by ID")
+	stock, err := sc.stockService.WithContext(c.Request.Context()).GetByID(id)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stock by ID"))
+		return
+	}
+
+	if includes["notes"] {
+		stock.Notes, err = sc.stockService.WithContext(c.Request.Context()).GetNotes(id)
+		if err != nil {
+			respondError(c, apperrors.Wrap(err, "failed to get notes"))
+			return
+		}
+	}
 
+	setStockValidators(c, stock)
 	c.JSON(http.StatusOK, gin.H{
 		"data": stock,
 	})
@@ -100,16 +296,21 @@ func (sc *StockController) GetStockByID(c *gin.Context) {
 
 // GetAllStocks handles GET /stocks
 // @Summary Get all stocks
-// @Description Retrieve all stock records from the database
+// @Description Retrieve all stock records from the database in one response. For large datasets use GET /stocks/export, which streams in batches.
 // @Tags stocks
 // @Produce json
 // @Success 200 {object} map[string]interface{} "List of stocks"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stocks"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks [get]
 func (sc *StockController) GetAllStocks(c *gin.Context) {
 	// Get all stocks
-	stocks, err := sc.stockService.GetAll()
-	utils.ErrorPanic(err, "failed to get all stocks")
+	stocks, err := sc.stockService.WithContext(c.Request.Context()).GetAll()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get all stocks"))
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data":  stocks,
@@ -119,27 +320,26 @@ func (sc *StockController) GetAllStocks(c *gin.Context) {
 
 // UpdateStock handles PUT /stocks/:id
 // @Summary Update stock by ID
-// @Description Update an existing stock record with the provided information
+// @Description Update an existing stock record. Only fields present in the body are changed; omitted fields keep their current values. final_score is recomputed from the resulting indicators and sentiments. With If-Match (an ETag from GET /stocks/{id}) or If-Unmodified-Since the update only applies while the stock is unchanged, and a stale client gets 412
 // @Tags stocks
 // @Accept json
 // @Produce json
-// @Param id path int true "Stock ID"
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param If-Match header string false "ETag the stock must still have"
+// @Param If-Unmodified-Since header string false "HTTP date the stock must not have been modified after"
 // @Param stock body validators.StockUpdateRequest true "Updated stock information"
 // @Success 200 {object} map[string]interface{} "Stock updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request format"
 // @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 412 {object} map[string]interface{} "The stock was modified since the client read it"
 // @Failure 500 {object} map[string]interface{} "Failed to update stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/{id} [put]
 func (sc *StockController) UpdateStock(c *gin.Context) {
-	// Parse ID from URL parameter
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid ID format",
-			"details": "ID must be a valid number",
-		})
+	// Resolve the numeric ID or UUID from the URL parameter
+	id, ok := sc.resolveStockID(c)
+	if !ok {
 		return
 	}
 
@@ -147,20 +347,21 @@ func (sc *StockController) UpdateStock(c *gin.Context) {
 
 	// Bind JSON request to StockUpdateRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	// Set the ID from URL parameter
-	request.ID = uint(id)
+	request.ID = id
 
 	// Update stock using service
-	stock, err := sc.stockService.Update(&request)
-	utils.ErrorPanic(err, "failed to update stock")
+	stock, err := sc.stockService.WithContext(c.Request.Context()).Update(&request, writePrecondition(c))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to update stock"))
+		return
+	}
 
+	setStockValidators(c, stock)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Stock updated successfully",
 		"data":    stock,
@@ -169,30 +370,33 @@ func (sc *StockController) UpdateStock(c *gin.Context) {
 
 // DeleteStock handles DELETE /stocks/:id
 // @Summary Delete stock by ID
-// @Description Delete a specific stock record by its ID
+// @Description Delete a specific stock record by its ID. With If-Match or If-Unmodified-Since the delete only applies while the stock is unchanged, and a stale client gets 412
 // @Tags stocks
 // @Produce json
-// @Param id path int true "Stock ID"
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param If-Match header string false "ETag the stock must still have"
+// @Param If-Unmodified-Since header string false "HTTP date the stock must not have been modified after"
 // @Success 200 {object} map[string]interface{} "Stock deleted successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid stock ID"
 // @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 412 {object} map[string]interface{} "The stock was modified since the client read it"
 // @Failure 500 {object} map[string]interface{} "Failed to delete stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/{id} [delete]
 func (sc *StockController) DeleteStock(c *gin.Context) {
-	// Parse ID from URL parameter
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid ID format",
-			"details": "ID must be a valid number",
-		})
+	// Resolve the numeric ID or UUID from the URL parameter
+	id, ok := sc.resolveStockID(c)
+	if !ok {
 		return
 	}
 
 	// Delete stock using service
-	err = sc.stockService.Delete(uint(id))
-	utils.ErrorPanic(err, "failed to delete stock")
+	err := sc.stockService.WithContext(c.Request.Context()).Delete(id, writePrecondition(c))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete stock"))
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Stock deleted successfully",
@@ -209,6 +413,8 @@ func (sc *StockController) DeleteStock(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{} "Invalid ticker format"
 // @Failure 404 {object} map[string]interface{} "Stock not found"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/ticker/{ticker} [get]
 func (sc *StockController) GetStockByTicker(c *gin.Context) {
 	// Get ticker from URL parameter
@@ -222,24 +428,64 @@ func (sc *StockController) GetStockByTicker(c *gin.Context) {
 	}
 
 	// Get stock by ticker
-	stock, err := sc.stockService.GetByTicker(ticker)
-	utils.ErrorPanic(err, "failed to get stock by ticker")
+	stock, err := sc.stockService.WithContext(c.Request.Context()).GetByTicker(ticker)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stock by ticker"))
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": stock,
 	})
 }
 
+// GetTickerHistory handles GET /stocks/ticker/:ticker/history
+// @Summary Get a ticker's score history
+// @Description Retrieve the dated final_score, targets and ratings recorded for a ticker, oldest first, for charting
+// @Tags stocks
+// @Produce json
+// @Param ticker path string true "Stock ticker symbol"
+// @Param from query string false "Earliest date (YYYY-MM-DD, inclusive)"
+// @Param to query string false "Latest date (YYYY-MM-DD, inclusive)"
+// @Success 200 {object} map[string]interface{} "Ticker history"
+// @Failure 400 {object} map[string]interface{} "Invalid ticker or date range"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve history"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/ticker/{ticker}/history [get]
+func (sc *StockController) GetTickerHistory(c *gin.Context) {
+	ticker := c.Param("ticker")
+
+	history, err := sc.stockService.WithContext(c.Request.Context()).GetTickerHistory(ticker, c.Query("from"), c.Query("to"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker": ticker,
+		"data":   history,
+		"count":  len(history),
+	})
+}
+
 // GetStocksByCompany handles GET /stocks/company/:company
 // @Summary Get stocks by company
-// @Description Retrieve all stock records for a specific company
+// @Description Retrieve one page of the stock records for a specific company
 // @Tags stocks
 // @Produce json
 // @Param company path string true "Company name"
+// @Param sort_by query string false "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)"
+// @Param order query string false "Sort order: asc | desc (default: desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)"
+// @Param dataset query int false "Only include data points last written by this dataset version"
 // @Success 200 {object} map[string]interface{} "List of stocks for company"
 // @Failure 400 {object} map[string]interface{} "Invalid company name"
 // @Failure 404 {object} map[string]interface{} "No stocks found for company"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stocks"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/company/{company} [get]
 func (sc *StockController) GetStocksByCompany(c *gin.Context) {
 	// Get company from URL parameter
@@ -252,14 +498,19 @@ func (sc *StockController) GetStocksByCompany(c *gin.Context) {
 		return
 	}
 
+	opts, ok := sc.bindListRequest(c)
+	if !ok {
+		return
+	}
+
 	// Get stocks by company
-	stocks, err := sc.stockService.GetByCompany(company)
-	utils.ErrorPanic(err, "failed to get stocks by company")
+	result, err := sc.stockService.WithContext(c.Request.Context()).GetByCompany(company, opts)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stocks by company"))
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":  stocks,
-		"count": len(stocks),
-	})
+	respondPage(c, result.Items, result.Pagination, nil)
 }
 
 // GetUniqueClusters handles GET /stocks/clusters
@@ -269,10 +520,15 @@ func (sc *StockController) GetStocksByCompany(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{} "List of unique clusters"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve clusters"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/clusters [get]
 func (sc *StockController) GetUniqueClusters(c *gin.Context) {
 	clusters, err := sc.stockService.GetUniqueClusters()
-	utils.ErrorPanic(err, "failed to get unique clusters")
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get unique clusters"))
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data":  clusters,
 		"count": len(clusters),
@@ -281,13 +537,20 @@ func (sc *StockController) GetUniqueClusters(c *gin.Context) {
 
 // GetStocksByCluster handles GET /stocks/cluster/:cluster
 // @Summary Get stocks by cluster
-// @Description Retrieve all stock records for a specific cluster
+// @Description Retrieve one page of the stock records for a specific cluster
 // @Tags stocks
 // @Produce json
 // @Param cluster path int true "Cluster id"
+// @Param sort_by query string false "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)"
+// @Param order query string false "Sort order: asc | desc (default: desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)"
+// @Param dataset query int false "Only include data points last written by this dataset version"
 // @Success 200 {object} map[string]interface{} "List of stocks for cluster"
 // @Failure 400 {object} map[string]interface{} "Invalid cluster"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stocks"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/cluster/{cluster} [get]
 func (sc *StockController) GetStocksByCluster(c *gin.Context) {
 	clusterStr := c.Param("cluster")
@@ -300,12 +563,17 @@ func (sc *StockController) GetStocksByCluster(c *gin.Context) {
 		return
 	}
 
-	stocks, err := sc.stockService.GetStocksByCluster(cluster)
-	utils.ErrorPanic(err, "failed to get stocks by cluster")
-	c.JSON(http.StatusOK, gin.H{
-		"data":  stocks,
-		"count": len(stocks),
-	})
+	opts, ok := sc.bindListRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).GetStocksByCluster(cluster, opts)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stocks by cluster"))
+		return
+	}
+	respondPage(c, result.Items, result.Pagination, nil)
 }
 
 // GetUniqueCompanies handles GET /stocks/companies
@@ -315,10 +583,15 @@ func (sc *StockController) GetStocksByCluster(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{} "List of unique companies"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve companies"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/companies [get]
 func (sc *StockController) GetUniqueCompanies(c *gin.Context) {
 	companies, err := sc.stockService.GetUniqueCompanies()
-	utils.ErrorPanic(err, "failed to get unique companies")
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get unique companies"))
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data":  companies,
 		"count": len(companies),
@@ -332,25 +605,185 @@ func (sc *StockController) GetUniqueCompanies(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} map[string]interface{} "List of unique actions"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve actions"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/actions [get]
 func (sc *StockController) GetUniqueActions(c *gin.Context) {
 	actions, err := sc.stockService.GetUniqueActions()
-	utils.ErrorPanic(err, "failed to get unique actions")
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get unique actions"))
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"data":  actions,
 		"count": len(actions),
 	})
 }
 
+// GetUniqueTags handles GET /stocks/tags
+// @Summary Get tags
+// @Description Retrieve all tag names that have been attached to stocks
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of tags"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve tags"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/tags [get]
+func (sc *StockController) GetUniqueTags(c *gin.Context) {
+	tags, err := sc.stockService.GetUniqueTags()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get unique tags"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":  tags,
+		"count": len(tags),
+	})
+}
+
+// TagStock handles POST /stocks/:id/tags
+// @Summary Tag a stock
+// @Description Attach one or more tags (e.g. "earnings-week", "review") to a stock. Tags are lower-cased, spaces become dashes, and unknown tags are created
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param request body validators.TagRequest true "Tags to attach"
+// @Success 200 {object} map[string]interface{} "Stock tagged successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request data"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 500 {object} map[string]interface{} "Failed to tag stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/tags [post]
+func (sc *StockController) TagStock(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	var request validators.TagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).TagStock(id, &request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to tag stock"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock tagged successfully",
+		"data":    stock,
+	})
+}
+
+// UntagStock handles DELETE /stocks/:id/tags/:tag
+// @Summary Untag a stock
+// @Description Detach a tag from a stock. The tag itself is kept for other stocks
+// @Tags stocks
+// @Produce json
+// @Param id path string true "Stock UUID (or numeric ID during the transition period)"
+// @Param tag path string true "Tag name"
+// @Success 200 {object} map[string]interface{} "Stock untagged successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid stock ID"
+// @Failure 404 {object} map[string]interface{} "Stock not found"
+// @Failure 500 {object} map[string]interface{} "Failed to untag stock"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/{id}/tags/{tag} [delete]
+func (sc *StockController) UntagStock(c *gin.Context) {
+	id, ok := sc.resolveStockID(c)
+	if !ok {
+		return
+	}
+
+	stock, err := sc.stockService.WithContext(c.Request.Context()).UntagStock(id, c.Param("tag"))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to untag stock"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock untagged successfully",
+		"data":    stock,
+	})
+}
+
+// GetEnumerations handles GET /stocks/enums
+// @Summary Get allowed action and rating values
+// @Description Retrieve the enumerations that action, rating_to and rating_from are validated against on create, update and import
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Allowed values by enumeration"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/enums [get]
+func (sc *StockController) GetEnumerations(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": sc.stockService.GetEnumerations(),
+	})
+}
+
+// GetJSONSchemas handles GET /schema
+// @Summary Get JSON Schemas for request bodies
+// @Description Retrieve JSON Schema (draft-07) documents for the create, update and filter request bodies, generated from the server-side validation rules
+// @Tags schema
+// @Produce json
+// @Success 200 {object} map[string]interface{} "JSON Schema documents keyed by request name"
+// @Router /api/v1/schema [get]
+func (sc *StockController) GetJSONSchemas(c *gin.Context) {
+	enums := sc.stockService.GetEnumerations()
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"StockCreateRequest": validators.GenerateJSONSchema(validators.StockCreateRequest{}, enums),
+			"StockUpdateRequest": validators.GenerateJSONSchema(validators.StockUpdateRequest{}, enums),
+			"FilterRequest":      validators.GenerateJSONSchema(validators.FilterRequest{}, enums),
+		},
+	})
+}
+
+// GetDataDictionary handles GET /stocks/dictionary
+// @Summary Get the data dictionary
+// @Description List the indicator and sentiment names present in the database (with counts and value ranges), the allowed grouping columns, the sortable columns, and the action/rating enumerations
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Data dictionary"
+// @Failure 500 {object} map[string]interface{} "Failed to build data dictionary"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/dictionary [get]
+func (sc *StockController) GetDataDictionary(c *gin.Context) {
+	dictionary, err := sc.stockService.GetDataDictionary()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get data dictionary"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dictionary,
+	})
+}
+
 // GetStocksByAction handles GET /stocks/action/:action
 // @Summary Get stocks by action
-// @Description Retrieve all stock records for a specific action
+// @Description Retrieve one page of the stock records for a specific action
 // @Tags stocks
 // @Produce json
 // @Param action path string true "Action value"
+// @Param sort_by query string false "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)"
+// @Param order query string false "Sort order: asc | desc (default: desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)"
+// @Param dataset query int false "Only include data points last written by this dataset version"
 // @Success 200 {object} map[string]interface{} "List of stocks for action"
 // @Failure 400 {object} map[string]interface{} "Invalid action"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve stocks"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/action/{action} [get]
 func (sc *StockController) GetStocksByAction(c *gin.Context) {
 	action := c.Param("action")
@@ -362,24 +795,30 @@ func (sc *StockController) GetStocksByAction(c *gin.Context) {
 		return
 	}
 
-	stocks, err := sc.stockService.GetStocksByAction(action)
-	utils.ErrorPanic(err, "failed to get stocks by action")
-	c.JSON(http.StatusOK, gin.H{
-		"data":  stocks,
-		"count": len(stocks),
-	})
+	opts, ok := sc.bindListRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).GetStocksByAction(action, opts)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stocks by action"))
+		return
+	}
+	respondPage(c, result.Items, result.Pagination, nil)
 }
 
 // GetStockStats handles GET /stocks/stats/:ticker
 // @Summary Get stock statistics by ticker
-// @Description Retrieve statistical information for a specific stock ticker
+// @Description Retrieve the data point count and the earliest and latest date of a stock ticker. A ticker without data points has a count of 0 and null times.
 // @Tags stocks
 // @Produce json
 // @Param ticker path string true "Stock ticker symbol"
-// @Success 200 {object} map[string]interface{} "Stock statistics"
+// @Success 200 {object} object{data=repository.TickerStats} "Stock statistics"
 // @Failure 400 {object} map[string]interface{} "Invalid ticker format"
-// @Failure 404 {object} map[string]interface{} "Stock not found"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve statistics"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/stats/{ticker} [get]
 func (sc *StockController) GetStockStats(c *gin.Context) {
 	// Get ticker from URL parameter
@@ -394,7 +833,10 @@ func (sc *StockController) GetStockStats(c *gin.Context) {
 
 	// Get stock statistics
 	stats, err := sc.stockService.GetStats(ticker)
-	utils.ErrorPanic(err, "failed to get stock statistics")
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get stock statistics"))
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": stats,
@@ -403,180 +845,523 @@ func (sc *StockController) GetStockStats(c *gin.Context) {
 
 // GetDatabaseStats handles GET /stocks/database/stats
 // @Summary Get database statistics
-// @Description Retrieve statistical information about the database
+// @Description Retrieve the total, unique ticker and unique company counts and the data point count of every non-empty cluster
 // @Tags stocks
 // @Produce json
-// @Success 200 {object} map[string]interface{} "Database statistics"
+// @Success 200 {object} object{data=repository.DatabaseStats} "Database statistics"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve database statistics"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/database/stats [get]
 func (sc *StockController) GetDatabaseStats(c *gin.Context) {
 	// Get database statistics
 	stats, err := sc.stockService.GetDatabaseStats()
-	utils.ErrorPanic(err, "failed to get database statistics")
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get database statistics"))
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": stats,
 	})
 }
 
+// GetDataMeta handles GET /stocks/meta
+// @Summary Get data freshness
+// @Description Report when the data was last imported and extracted, the latest complete dataset version, the total row count and the age of the data in hours. data_as_of is the later of the last import and extraction (the last modification when neither is recorded); stale is set once it is older than EXTRACT_STALE_AFTER. The response is not cached, so staleness_hours is current.
+// @Tags stocks
+// @Produce json
+// @Success 200 {object} object{data=service.DataMeta} "Data freshness"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve data freshness"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/meta [get]
+func (sc *StockController) GetDataMeta(c *gin.Context) {
+	meta, err := sc.stockService.WithContext(c.Request.Context()).GetDataMeta()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get data freshness"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": meta,
+	})
+}
+
+// GetDiagnostics handles GET /admin/diagnostics
+// @Summary Get database diagnostics
+// @Description Report the schema version, migration status (tables and columns missing from information_schema), row count of every table, the index list and the largest tables, to speed up support. Table sizes are included when the database reports them. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Database diagnostics"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to gather diagnostics"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/admin/diagnostics [get]
+func (sc *StockController) GetDiagnostics(c *gin.Context) {
+	diagnostics, err := sc.stockService.WithContext(c.Request.Context()).GetDiagnostics()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get diagnostics"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": diagnostics,
+	})
+}
+
 // ExtractDataFromApi handles POST /stocks/extract
 // @Summary Extract data from API
-// @Description Trigger data extraction from external API with specified max pages
+// @Description Start a data extraction from the external API with the specified max pages. The crawl runs in the background: the response carries the job, and GET /jobs/{id} reports the pages processed, items written and error state. Each page is one upstream request; when EXTRACT_DAILY_REQUEST_QUOTA is set, runs without max_pages are capped at the remaining daily budget and runs that could exceed it are refused with the budget in the response
 // @Tags stocks
 // @Accept json
 // @Produce json
 // @Param request body validators.StockExtractRequest true "Extraction request"
-// @Success 200 {object} map[string]interface{} "Data extraction completed"
+// @Success 202 {object} map[string]interface{} "Extraction job queued"
 // @Failure 400 {object} map[string]interface{} "Invalid request format"
-// @Failure 500 {object} map[string]interface{} "Failed to extract data from API"
+// @Failure 429 {object} map[string]interface{} "Daily upstream request quota exceeded"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to start the extraction"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/extract [post]
 func (sc *StockController) ExtractDataFromApi(c *gin.Context) {
 	var request validators.StockExtractRequest
 
 	// Bind JSON request
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
-	// Extract data from API using service
-	err := sc.stockService.StoreDataFromApi(request.MaxPages)
-	utils.ErrorPanic(err, "failed to extract data from API")
+	// The job outlives the request, but keeps its actor
+	job, err := sc.stockService.WithContext(context.WithoutCancel(c.Request.Context())).StartExtraction(request.MaxPages)
+	if respondQuotaExceeded(c, err) {
+		return
+	}
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to start data extraction"))
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":   "Data extraction completed successfully",
-		"max_pages": request.MaxPages,
-		"status":    "completed",
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Data extraction job queued",
+		"data":    job,
 	})
 }
 
 // ImportEnrichedCSV handles POST /stocks/import-enriched
 // @Summary Import enriched stock data from default CSV
-// @Description Import rows from ./stock_data_enriched.csv into the database
+// @Description Import rows from ./stock_data_enriched.csv into the database. The file's SHA-256 fingerprint is recorded; importing an unchanged file again returns status already_imported without writing anything unless force=true.
 // @Tags stocks
 // @Produce json
-// @Success 200 {object} map[string]interface{} "CSV imported"
+// @Param force query bool false "Import even if this exact file was imported before (default: false)"
+// @Success 200 {object} map[string]interface{} "CSV imported, or already imported"
+// @Failure 400 {object} map[string]interface{} "Invalid force parameter"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
 // @Failure 500 {object} map[string]interface{} "Failed to import CSV"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/import-enriched [post]
 func (sc *StockController) ImportEnrichedCSV(c *gin.Context) {
-	count, err := sc.stockService.ImportFromEnrichedCSV()
-	utils.ErrorPanic(err, "failed to import enriched CSV")
+	force := false
+	if forceStr := c.Query("force"); forceStr != "" {
+		value, err := strconv.ParseBool(forceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid force parameter",
+				"details": "Force must be true or false",
+			})
+			return
+		}
+		force = value
+	}
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).ImportFromEnrichedCSV(force)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to import enriched CSV"))
+		return
+	}
+	if result.AlreadyImported {
+		c.JSON(http.StatusOK, gin.H{
+			"message":       "File already imported",
+			"status":        "already_imported",
+			"rows_ingested": 0,
+			"fingerprint":   result.Fingerprint,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"message":       "Enriched CSV imported successfully",
-		"rows_ingested": count,
+		"status":        "imported",
+		"rows_ingested": result.RowsIngested,
+		"fingerprint":   result.Fingerprint,
+	})
+}
+
+// ImportUploadedCSV handles POST /stocks/import
+// @Summary Import stock data from an uploaded CSV
+// @Description Import the rows of a CSV file sent as the multipart form field file, in the enriched CSV format. Unlike /stocks/import-enriched, rows that cannot be parsed or fail validation are skipped: rows_skipped counts them and row_errors lists the first 100 with their row number (the header is row 1). Uploads are fingerprinted like the enriched import; an unchanged file is not imported twice unless force=true.
+// @Tags stocks
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param force query bool false "Import even if this exact file was imported before (default: false)"
+// @Success 200 {object} map[string]interface{} "CSV imported, or already imported"
+// @Failure 400 {object} map[string]interface{} "Missing file or invalid force parameter"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 413 {object} map[string]interface{} "File too large"
+// @Failure 500 {object} map[string]interface{} "Failed to import CSV"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/import [post]
+func (sc *StockController) ImportUploadedCSV(c *gin.Context) {
+	force := false
+	if forceStr := c.Query("force"); forceStr != "" {
+		value, err := strconv.ParseBool(forceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid force parameter",
+				"details": "Force must be true or false",
+			})
+			return
+		}
+		force = value
+	}
+
+	header, err := c.FormFile("file")
+	if errors.As(err, new(*http.MaxBytesError)) {
+		respondBindError(c, err)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Missing file",
+			"details": "Send the CSV as the multipart form field file",
+		})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to read uploaded file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := sc.stockService.WithContext(c.Request.Context()).ImportUpload(header.Filename, file, force)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to import uploaded CSV"))
+		return
+	}
+	if result.AlreadyImported {
+		c.JSON(http.StatusOK, gin.H{
+			"message":       "File already imported",
+			"status":        "already_imported",
+			"rows_ingested": 0,
+			"fingerprint":   result.Fingerprint,
+		})
+		return
+	}
+	rowErrors := result.RowErrors
+	if rowErrors == nil {
+		rowErrors = []db_populate.RowError{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "CSV imported successfully",
+		"status":        "imported",
+		"rows_ingested": result.RowsIngested,
+		"rows_skipped":  result.RowsSkipped,
+		"row_errors":    rowErrors,
+		"fingerprint":   result.Fingerprint,
 	})
 }
 
 // FilterByClusterGrouped handles GET /stocks/cluster/:cluster/filter
 // @Summary Filter stocks by cluster with grouping, pagination, sorting, and weighted scoring
-// @Description Filter stocks by cluster with optional grouping, pagination, sorting, and weighted scoring. Supports numerical and rating weights via query parameters. Note: grouping_column can only be action, rating_to, or rating_from (company and date are excluded due to too many distinct values).
+// @Description Filter stocks by cluster with optional grouping, pagination, sorting, and weighted scoring. Supports numerical and rating weights via query parameters. Note: grouping_column can only be action, rating_to, or rating_from (company and date are excluded due to too many distinct values). Authenticated callers that send no weights get the default weights saved under /me/preferences; sorting by weighted_score without any weights uses the configured default weight profile (SCORING_DEFAULT_WEIGHTS). weight_profile reports which weights were applied: request | user | default. Either weight array alone produces a weighted_score and can be sorted by; weight_sets lists the arrays the score was computed from (numerical, rating).
 // @Tags stocks
 // @Produce json
 // @Param cluster path int true "Cluster id"
-// @Param grouping_column query string false "Grouping column: action | rating_to | rating_from | None (default: None). Note: company and date are excluded."
-// @Param grouping_value query string false "Grouping value to filter by (required if grouping_column is not None)"
-// @Param sort_by query string false "Sort by column: ticker | action | date | company | target_to | target_from | rating_to | rating_from | final_score (default: date)"
+// @Param grouping_column query string false "Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected). Note: company and date are excluded."
+// @Param grouping_value query string false "Grouping value to filter by (required if grouping_column is not none)"
+// @Param sort_by query string false "Sort by column: ticker | action | date | company | target_to | target_from | rating_to | rating_from | final_score | weighted_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)"
 // @Param order query string false "Sort order: asc | desc (default: desc)"
 // @Param page query int false "Page number (default: 1)"
-// @Param per_page query int false "Items per page (default: 20)"
+// @Param per_page query int false "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)"
 // @Param numerical_weights query string false "JSON array of numerical weights: [{\"indicator_name\":\"atr\",\"weight\":0.5}]"
 // @Param rating_weights query string false "JSON array of rating weights: [{\"indicator_name\":\"action\",\"weight\":0.7}]"
-// @Success 200 {object} map[string]interface{} "Paged grouped results"
-// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Param tags query []string false "Only include stocks carrying any of these tags" collectionFormat(multi)
+// @Param min_final_score query number false "Only include stocks with final_score at least this value"
+// @Param max_final_score query number false "Only include stocks with final_score at most this value"
+// @Param min_target_to query number false "Only include stocks with target_to at least this value"
+// @Param max_target_to query number false "Only include stocks with target_to at most this value"
+// @Param min_target_from query number false "Only include stocks with target_from at least this value"
+// @Param max_target_from query number false "Only include stocks with target_from at most this value"
+// @Param min_target_delta query number false "Only include stocks with target_delta at least this value"
+// @Param max_target_delta query number false "Only include stocks with target_delta at most this value"
+// @Param min_last_close query number false "Only include stocks with last_close at least this value"
+// @Param max_last_close query number false "Only include stocks with last_close at most this value"
+// @Param relations query string false "Child rows loaded per stock: full | scoring (only name and normalized score/value) | none (default: full)"
+// @Param contributions query bool false "With weights, add a contributions object to each row mapping every weighted name to weight x normalized value; the values add up to weighted_score (default: false)"
+// @Param aggregate query bool false "Return one record per grouping value (group_value, count, avg_final_score, avg_weighted_score) instead of rows; requires grouping_column (default: false)"
+// @Param explain query bool false "Admin only: return the EXPLAIN ANALYZE plan of the page query (data.sql, data.plan) instead of rows; not combinable with aggregate (default: false)"
+// @Success 200 {object} map[string]interface{} "Paged grouped results, group summaries with aggregate=true, or the query plan with explain=true"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters, or a grouping_value not present in the cluster (with suggestions)"
+// @Failure 403 {object} map[string]interface{} "explain=true without the admin role"
 // @Failure 500 {object} map[string]interface{} "Failed to filter"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/cluster/{cluster}/filter [get]
 func (sc *StockController) FilterByClusterGrouped(c *gin.Context) {
-	// Parse cluster from path
-	clusterStr := c.Param("cluster")
-	cluster, err := strconv.Atoi(clusterStr)
-	if err != nil {
+	var request validators.FilterRequest
+
+	// Bind query parameters and decode the URL-encoded JSON weight arrays
+	if err := c.ShouldBindQuery(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid cluster parameter",
-			"details": "Cluster must be an integer",
+			"error":   "Invalid parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err := request.ParseQueryWeights(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid weights",
+			"details": err.Error(),
 		})
 		return
 	}
 
-	// Parse query parameters with defaults
-	groupingColumn := c.DefaultQuery("grouping_column", "None")
-	groupingValue := c.Query("grouping_value")
-	sortByColumn := c.DefaultQuery("sort_by", "date")
-	order := strings.ToLower(c.DefaultQuery("order", "desc"))
+	sc.filterByClusterGrouped(c, &request)
+}
 
-	// Parse pagination with defaults
-	page := 1
-	if pageStr := c.Query("page"); pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+// FilterByClusterGroupedPost handles POST /stocks/cluster/:cluster/filter
+// @Summary Filter stocks by cluster (JSON body variant)
+// @Description Same as the GET filter endpoint, but takes the parameters and weight arrays as a JSON body, avoiding URL length limits for large weight sets.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Param cluster path int true "Cluster id"
+// @Param request body validators.FilterRequest true "Filter parameters"
+// @Param explain query bool false "Admin only: return the EXPLAIN ANALYZE plan of the page query instead of rows (default: false)"
+// @Success 200 {object} map[string]interface{} "Paged grouped results, or the query plan with explain=true"
+// @Failure 400 {object} map[string]interface{} "Invalid parameters"
+// @Failure 403 {object} map[string]interface{} "explain=true without the admin role"
+// @Failure 500 {object} map[string]interface{} "Failed to filter"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/stocks/cluster/{cluster}/filter [post]
+func (sc *StockController) FilterByClusterGroupedPost(c *gin.Context) {
+	var request validators.FilterRequest
+
+	// Bind JSON request to FilterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
 	}
-	perPage := 20
-	if perPageStr := c.Query("per_page"); perPageStr != "" {
-		if pp, err := strconv.Atoi(perPageStr); err == nil && pp > 0 {
-			perPage = pp
+
+	sc.filterByClusterGrouped(c, &request)
+}
+
+// filterByClusterGrouped validates a bound FilterRequest and writes the filtered page
+func (sc *StockController) filterByClusterGrouped(c *gin.Context, request *validators.FilterRequest) {
+	explain, ok := bindExplain(c)
+	if !ok {
+		return
+	}
+	cluster, numericalWeights, ratingWeights, ranges, weightProfile, ok := sc.prepareFilter(c, request)
+	if !ok {
+		return
+	}
+
+	// Explain mode (admins only, enforced by the router): the plan of the page query instead of rows
+	if explain {
+		if request.Aggregate {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid parameters",
+				"details": "explain cannot be combined with aggregate",
+			})
+			return
 		}
+		plan, err := sc.stockService.WithContext(c.Request.Context()).ExplainClusterGrouped(cluster, request.GroupingColumn, request.GroupingValue, request.SortBy, request.Order, request.Page, request.PerPage, numericalWeights, ratingWeights, request.Tags, ranges)
+		if err != nil {
+			respondFilterError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": plan})
+		return
 	}
 
-	// Parse numerical weights from query parameter (URL-encoded JSON array)
-	var numericalWeights []repository.NumericalWeightEntry
-	if numericalWeightsStr := c.Query("numerical_weights"); numericalWeightsStr != "" {
-		var weights []struct {
-			IndicatorName string  `json:"indicator_name"`
-			Weight        float64 `json:"weight"`
+	// Aggregate mode: one summary per grouping value instead of rows
+	if request.Aggregate {
+		aggregates, err := sc.stockService.WithContext(c.Request.Context()).AggregateClusterGrouped(cluster, request.GroupingColumn, request.GroupingValue, numericalWeights, ratingWeights, request.Tags, ranges)
+		if err != nil {
+			respondFilterError(c, err)
+			return
 		}
-		if err := json.Unmarshal([]byte(numericalWeightsStr), &weights); err == nil {
-			numericalWeights = make([]repository.NumericalWeightEntry, len(weights))
-			for i, w := range weights {
-				numericalWeights[i] = repository.NumericalWeightEntry{
-					IndicatorName: w.IndicatorName,
-					Weight:        w.Weight,
-				}
-			}
+		body := gin.H{
+			"data":            aggregates.Groups,
+			"count":           len(aggregates.Groups),
+			"grouping_column": aggregates.GroupingColumn,
+			"grouping_value":  request.GroupingValue,
+			"tags":            request.Tags,
+		}
+		if aggregates.WeightsHash != "" {
+			body["weights_hash"] = aggregates.WeightsHash
+			body["weight_profile"] = weightProfile
 		}
+		c.JSON(http.StatusOK, body)
+		return
 	}
 
-	// Parse rating weights from query parameter (URL-encoded JSON array)
-	var ratingWeights []repository.RatingWeightEntry
-	if ratingWeightsStr := c.Query("rating_weights"); ratingWeightsStr != "" {
-		var weights []struct {
-			IndicatorName string  `json:"indicator_name"`
-			Weight        float64 `json:"weight"`
+	// Call service
+	result, err := sc.stockService.WithContext(c.Request.Context()).FilterByClusterGrouped(cluster, request.GroupingColumn, request.GroupingValue, request.SortBy, request.Order, request.Page, request.PerPage, numericalWeights, ratingWeights, request.Tags, ranges, repository.PreloadMode(request.Relations), request.Contributions)
+	if err != nil {
+		respondFilterError(c, err)
+		return
+	}
+
+	extra := gin.H{
+		"grouping_column": request.GroupingColumn,
+		"grouping_value":  request.GroupingValue,
+		"tags":            request.Tags,
+	}
+	if result.WeightsHash != "" {
+		extra["weight_profile"] = weightProfile
+	}
+	respondPage(c, result.Items, result.Pagination, extra)
+}
+
+// bindExplain parses the explain flag, writing a 400 response when it is not a boolean
+func bindExplain(c *gin.Context) (bool, bool) {
+	explainStr := c.Query("explain")
+	if explainStr == "" {
+		return false, true
+	}
+	explain, err := strconv.ParseBool(explainStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid explain parameter",
+			"details": "Explain must be true or false",
+		})
+		return false, false
+	}
+	return explain, true
+}
+
+// respondFilterError writes a filter failure; an unknown grouping value also lists the closest known values
+func respondFilterError(c *gin.Context, err error) {
+	var unknownErr *service.UnknownGroupingValueError
+	if errors.As(err, &unknownErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       apperrors.Title(err),
+			"details":     err.Error(),
+			"suggestions": unknownErr.Suggestions,
+		})
+		return
+	}
+	respondError(c, err)
+}
+
+// prepareFilter parses the cluster path parameter, applies defaults, validates the bound filter request,
+// and converts its weights and range bounds to repository entries. It also returns the weight profile
+// the weights come from (empty without weights). It writes the error response and returns false on failure.
+func (sc *StockController) prepareFilter(c *gin.Context, request *validators.FilterRequest) (int, []repository.NumericalWeightEntry, []repository.RatingWeightEntry, []repository.RangeFilter, string, bool) {
+	// Parse cluster from path
+	clusterStr := c.Param("cluster")
+	cluster, err := strconv.Atoi(clusterStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid cluster parameter",
+			"details": "Cluster must be an integer",
+		})
+		return 0, nil, nil, nil, "", false
+	}
+
+	// Body parameters (POST) bypass the list parameter middleware, so the same caps are checked here
+	rules, hasRules := c.Get(validators.PageRulesContextKey)
+	if hasRules && request.PerPage == 0 {
+		request.PerPage = rules.(validators.PageRules).DefaultPerPage
+	}
+	request.ApplyDefaults()
+	if err := request.ValidateEnums(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid parameters",
+			"details": err.Error(),
+		})
+		return 0, nil, nil, nil, "", false
+	}
+	if err := sc.validator.ValidateRequest(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid parameters",
+			"details": err.Error(),
+		})
+		return 0, nil, nil, nil, "", false
+	}
+	if hasRules {
+		params := validators.PageParams{Page: request.Page, PerPage: request.PerPage, SortBy: request.SortBy, Order: request.Order}
+		if err := params.Validate(rules.(validators.PageRules)); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid parameters",
+				"details": err.Error(),
+			})
+			return 0, nil, nil, nil, "", false
 		}
-		if err := json.Unmarshal([]byte(ratingWeightsStr), &weights); err == nil {
-			ratingWeights = make([]repository.RatingWeightEntry, len(weights))
-			for i, w := range weights {
-				ratingWeights[i] = repository.RatingWeightEntry{
-					IndicatorName: w.IndicatorName,
-					Weight:        w.Weight,
-				}
+	}
+
+	// Authenticated callers that send no weights get their saved default weights; sorting by
+	// weighted_score without any weights falls back to the configured default profile
+	weightProfile := service.WeightProfileRequest
+	if len(request.NumericalWeights) == 0 && len(request.RatingWeights) == 0 {
+		weightProfile = ""
+		if user := models.ActorFromContext(c.Request.Context()); user != "" {
+			preferences, err := sc.stockService.WithContext(c.Request.Context()).GetPreferences(user)
+			if err != nil {
+				respondError(c, err)
+				return 0, nil, nil, nil, "", false
 			}
+			request.NumericalWeights = preferences.NumericalWeights
+			request.RatingWeights = preferences.RatingWeights
+			if len(request.NumericalWeights) > 0 || len(request.RatingWeights) > 0 {
+				weightProfile = service.WeightProfileUser
+			}
+		}
+		if weightProfile == "" && request.SortsByWeightedScore() {
+			request.NumericalWeights, request.RatingWeights = sc.stockService.DefaultWeights()
+			weightProfile = service.WeightProfileDefault
 		}
 	}
 
-	// Call service
-	result, err := sc.stockService.FilterByClusterGrouped(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights)
+	bounds, err := request.RangeBounds()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to filter stocks",
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid parameters",
 			"details": err.Error(),
 		})
-		return
+		return 0, nil, nil, nil, "", false
+	}
+	ranges := make([]repository.RangeFilter, len(bounds))
+	for i, b := range bounds {
+		ranges[i] = repository.RangeFilter{Column: b.Column, Min: b.Min, Max: b.Max}
 	}
 
-	// Return response
-	c.JSON(http.StatusOK, gin.H{
-		"data":            result.Items,
-		"total_count":     result.TotalCount,
-		"page":            result.Page,
-		"per_page":        result.PerPage,
-		"grouping_column": groupingColumn,
-		"grouping_value":  groupingValue,
-		"sort_by":         sortByColumn,
-		"order":           order,
-	})
+	numericalWeights := make([]repository.NumericalWeightEntry, len(request.NumericalWeights))
+	for i, w := range request.NumericalWeights {
+		numericalWeights[i] = repository.NumericalWeightEntry{
+			IndicatorName: w.IndicatorName,
+			Weight:        w.Weight,
+		}
+	}
+	ratingWeights := make([]repository.RatingWeightEntry, len(request.RatingWeights))
+	for i, w := range request.RatingWeights {
+		ratingWeights[i] = repository.RatingWeightEntry{
+			IndicatorName: w.IndicatorName,
+			Weight:        w.Weight,
+		}
+	}
+
+	return cluster, numericalWeights, ratingWeights, ranges, weightProfile, true
 }
 
 // GetUniqueByGroupSelectColumn handles GET /stocks/cluster/:cluster/unique/:column_name
@@ -589,6 +1374,8 @@ func (sc *StockController) FilterByClusterGrouped(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "Unique values"
 // @Failure 400 {object} map[string]interface{} "Invalid parameters"
 // @Failure 500 {object} map[string]interface{} "Failed to get unique values"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/stocks/cluster/{cluster}/unique/{column_name} [get]
 func (sc *StockController) GetUniqueByGroupSelectColumn(c *gin.Context) {
 	// Parse cluster from path parameter
@@ -630,25 +1417,3 @@ func (sc *StockController) GetUniqueByGroupSelectColumn(c *gin.Context) {
 		"count":       len(values),
 	})
 }
-
-// EmptyAllTables handles DELETE /stocks/tables
-// @Summary Empty all tables
-// @Description Deletes all records from all tables (rating_sentiments, numerical_indicators, stock_data_points)
-// @Tags stocks
-// @Produce json
-// @Success 200 {object} map[string]interface{} "Tables emptied successfully"
-// @Failure 500 {object} map[string]interface{} "Failed to empty tables"
-// @Router /api/v1/stocks/tables [delete]
-func (sc *StockController) EmptyAllTables(c *gin.Context) {
-	if err := sc.stockService.EmptyAllTables(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to empty tables",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"message": "All tables emptied successfully",
-	})
-}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"dataextractor/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// stockEventsKeepAlive is the event type an idle WebSocket receives every jobEventsHeartbeat, so
+// proxies keep the connection open
+const stockEventsKeepAlive = "keep-alive"
+
+// StreamStockEvents handles GET /ws
+// @Summary Stream stock changes over a WebSocket
+// @Description Upgrade to a WebSocket that pushes a JSON text message for every stock data point created, updated or deleted through the API, so dashboards can refresh without polling GET /stocks. Messages carry type (created, updated, deleted), id, uuid, ticker and at; creates and updates made through the stock endpoints also carry the record as data. Bulk changes (imports, purges, rollbacks, wipes) are sent as one reloaded message with the reason and the number of rows, and call for a refetch. A client that falls too far behind receives reloaded with reason "lagged" and is disconnected. Idle connections receive a keep-alive message every 15 seconds. Messages sent by the client are ignored.
+// @Tags stocks
+// @Success 101 {string} string "Switching to the WebSocket protocol"
+// @Failure 400 {object} map[string]interface{} "Not a WebSocket handshake"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/ws [get]
+func (sc *StockController) StreamStockEvents(c *gin.Context) {
+	server := websocket.Server{
+		// Any origin is accepted, like the CORS policy of the REST endpoints
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			events, unsubscribe := sc.stockService.SubscribeStockEvents()
+			defer unsubscribe()
+
+			// Read (and discard) client messages so a closed connection is noticed
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				var message []byte
+				for websocket.Message.Receive(ws, &message) == nil {
+				}
+			}()
+
+			heartbeat := time.NewTicker(jobEventsHeartbeat)
+			defer heartbeat.Stop()
+			for {
+				var event service.StockEvent
+				select {
+				case received, ok := <-events:
+					if !ok {
+						websocket.JSON.Send(ws, service.StockEvent{Type: service.StocksReloaded, Reason: "lagged", At: time.Now().UTC()})
+						return
+					}
+					event = received
+				case <-heartbeat.C:
+					event = service.StockEvent{Type: stockEventsKeepAlive, At: time.Now().UTC()}
+				case <-closed:
+					return
+				}
+				if err := websocket.JSON.Send(ws, event); err != nil {
+					return
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
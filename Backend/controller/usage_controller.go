@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordClientRequest meters one request for the usage report; the router calls it after every
+// API response
+func (sc *StockController) RecordClientRequest(keyHash, method, route string, bytesIn, bytesOut int64, status int) {
+	sc.stockService.RecordClientRequest(keyHash, method, route, bytesIn, bytesOut, status)
+}
+
+// GetUsageReport handles GET /admin/usage
+// @Summary Get API usage per client
+// @Description Report the requests, error responses (status >= 400) and bytes received and sent per API client over the last days UTC days, today included, with each client's busiest endpoints. Clients are identified by the SHA-256 fingerprint of their X-API-Key header; requests without one are reported as "anonymous". Counters are aggregated in memory and written every SERVER_USAGE_FLUSH_INTERVAL; the report flushes them first. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param days query int false "Number of days to report, 1-90 (default: 7)"
+// @Param top query int false "Busiest endpoints listed per client, 1-50 (default: 5)"
+// @Success 200 {object} map[string]interface{} "Usage per client"
+// @Failure 400 {object} map[string]interface{} "Invalid days or top"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 500 {object} map[string]interface{} "Failed to get usage"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/admin/usage [get]
+func (sc *StockController) GetUsageReport(c *gin.Context) {
+	var bounds [2]int
+	for i, name := range []string{"days", "top"} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid " + name + " parameter",
+				"details": name + " must be an integer",
+			})
+			return
+		}
+		bounds[i] = value
+	}
+
+	report, err := sc.stockService.WithContext(c.Request.Context()).GetUsageReport(bounds[0], bounds[1])
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"dataextractor/apperrors"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhookSubscription handles POST /webhooks
+// @Summary Subscribe a URL to webhook events
+// @Description Register a URL that receives the listed events (extraction.completed, extraction.failed) as JSON callbacks carrying the job. Every delivery is signed with HMAC-SHA256 over "<timestamp>.<nonce>.<raw body>" using the subscription secret, sent in the X-Webhook-Signature header (v1=<hex>) with X-Webhook-Timestamp (Unix seconds) and X-Webhook-Nonce. The secret is only returned by this call; the response also describes the verification and the replay window consumers should enforce (NOTIFY_WEBHOOK_TOLERANCE). Requires the admin role.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body validators.WebhookSubscriptionRequest true "Callback URL and events"
+// @Success 201 {object} map[string]interface{} "Subscription, secret and verification scheme"
+// @Failure 400 {object} map[string]interface{} "Invalid URL or events"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/webhooks [post]
+func (sc *StockController) CreateWebhookSubscription(c *gin.Context) {
+	var request validators.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	created, err := sc.stockService.WithContext(c.Request.Context()).CreateWebhookSubscription(&request)
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to create webhook subscription"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook subscription created; store the secret, it is not shown again",
+		"data":    created,
+	})
+}
+
+// GetWebhookSubscriptions handles GET /webhooks
+// @Summary List webhook subscriptions
+// @Description List the webhook subscriptions (without their secrets) and the scheme for verifying deliveries. Requires the admin role.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Subscriptions and verification scheme"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/webhooks [get]
+func (sc *StockController) GetWebhookSubscriptions(c *gin.Context) {
+	stockService := sc.stockService.WithContext(c.Request.Context())
+	subscriptions, err := stockService.GetWebhookSubscriptions()
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to get webhook subscriptions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         subscriptions,
+		"count":        len(subscriptions),
+		"verification": stockService.WebhookVerification(),
+	})
+}
+
+// DeleteWebhookSubscription handles DELETE /webhooks/:id
+// @Summary Delete a webhook subscription
+// @Description Stop the deliveries of a webhook subscription. Requires the admin role.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} map[string]interface{} "Webhook subscription deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid subscription ID"
+// @Failure 403 {object} map[string]interface{} "Admin role required"
+// @Failure 404 {object} map[string]interface{} "Subscription not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/webhooks/{id} [delete]
+func (sc *StockController) DeleteWebhookSubscription(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid subscription ID format",
+			"details": "Subscription ID must be a positive number",
+		})
+		return
+	}
+
+	err = sc.stockService.WithContext(c.Request.Context()).DeleteWebhookSubscription(uint(id))
+	if err != nil {
+		respondError(c, apperrors.Wrap(err, "failed to delete webhook subscription"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook subscription deleted successfully",
+	})
+}
@@ -5,23 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"math"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
+	"dataextractor/apperrors"
+	"dataextractor/models"
 	"dataextractor/repository"
-	"dataextractor/utils"
 )
 
 // File constants for data storage
 const (
-	resumeKeyFile       = "processed_pages.txt"
-	lastPageFile        = "last_page.txt"
-	pageKeysHistoryFile = "page_keys_history.txt"
-	csvOutputFile       = "extracted_stock_data.csv"
+	resumeKeyFile = "processed_pages.txt"
+	lastPageFile  = "last_page.txt"
+	csvOutputFile = "extracted_stock_data.csv"
 )
 
 // API endpoint constants
@@ -59,10 +59,38 @@ type DataExtractor struct {
 	baseURL    string
 	apiKey     string
 	repository repository.DataRepositoryInterface
+
+	// transform maps the provider's items to data points before they are written
+	transform ItemTransformer
+
+	// OnProgress, when set, is called by ExtractAndProcessAllPages after each page is written and
+	// when a page fails to fetch
+	OnProgress func(progress ExtractionProgress)
+
+	// Logger, when set, receives the extraction log lines (the default logger otherwise); the
+	// service passes its request-scoped logger so they carry the triggering request's ID
+	Logger *slog.Logger
+}
+
+// ExtractionProgress reports one page of an extraction run
+type ExtractionProgress struct {
+	Page         int    `json:"page"`
+	ItemsFetched int    `json:"items_fetched"`
+	ItemsWritten int    `json:"items_written"`
+	TotalWritten int    `json:"total_written"`
+	Error        string `json:"error,omitempty"`
+}
+
+// reportProgress hands progress to OnProgress when one is set
+func (de *DataExtractor) reportProgress(progress ExtractionProgress) {
+	if de.OnProgress != nil {
+		de.OnProgress(progress)
+	}
 }
 
-// NewDataExtractor creates a new DataExtractor instance
-func NewDataExtractor(baseURL, apiKey string, repository repository.DataRepositoryInterface) *DataExtractor {
+// NewDataExtractor creates a new DataExtractor instance; items are mapped with the transformer
+// registered for provider
+func NewDataExtractor(baseURL, apiKey, provider string, repository repository.DataRepositoryInterface) *DataExtractor {
 	return &DataExtractor{
 		client: &http.Client{
 			Timeout: 30 * time.Second,
@@ -70,7 +98,16 @@ func NewDataExtractor(baseURL, apiKey string, repository repository.DataReposito
 		baseURL:    baseURL,
 		apiKey:     apiKey,
 		repository: repository,
+		transform:  transformerFor(provider),
+	}
+}
+
+// logger returns the logger extraction lines are written to
+func (de *DataExtractor) logger() *slog.Logger {
+	if de.Logger != nil {
+		return de.Logger
 	}
+	return slog.Default()
 }
 
 // FetchData retrieves data from the API
@@ -82,33 +119,44 @@ func (de *DataExtractor) FetchData(endpoint string) (*APIResponse, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	log.Printf("Fetching data from: %s", url)
+	de.logger().Info("Fetching data", "url", url)
 
 	resp, err := de.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, apperrors.WrapAs(err, apperrors.KindUpstream, "failed to make request")
+	}
+
+	// Every answered request counts against the provider's daily budget for the key
+	if err := de.repository.RecordAPIRequest(models.APIKeyFingerprint(de.apiKey), models.UsageDay(time.Now())); err != nil {
+		de.logger().Warn("Failed to record upstream API request", "error", err)
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, apperrors.Upstream("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	body, err := io.ReadAll(resp.Body)
-	utils.ErrorPanic(err, "failed to read response body")
+	if err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindUpstream, "failed to read response body")
+	}
 
 	// Parse JSON response
 	var apiResponse APIResponse
-	utils.ErrorPanic(json.Unmarshal(body, &apiResponse), "failed to parse JSON response")
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindUpstream, "failed to parse JSON response")
+	}
 
 	return &apiResponse, nil
 }
 
 func createRequest(url string, de *DataExtractor) (*http.Request, error) {
 	req, err := http.NewRequest("GET", url, nil)
-	utils.ErrorPanic(err, "failed to create request")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to create request")
+	}
 
 	// Add authentication header
 	if de.apiKey == "" {
@@ -126,41 +174,28 @@ func createRequest(url string, de *DataExtractor) (*http.Request, error) {
 // updateResumeKeyFile saves the current page key to the resume file (overwrites previous value)
 func updateResumeKeyFile(pageKey string) error {
 	file, err := os.OpenFile(lastPageFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	utils.ErrorPanic(err, "failed to open resume file")
-	defer file.Close()
-
-	_, err = file.WriteString(pageKey)
-	utils.ErrorPanic(err, "failed to write page key to resume file")
-	log.Printf("Updated resume file with next page token: %s", pageKey)
-
-	return nil
-}
-
-// savePageKeyToHistory saves a page key to the history file in CSV format
-func savePageKeyToHistory(pageKey string, pageNumber int, status string) error {
-	// Check if file exists to determine if we need to write header
-	fileExists := true
-	if _, err := os.Stat(pageKeysHistoryFile); os.IsNotExist(err) {
-		fileExists = false
+	if err != nil {
+		return apperrors.Wrap(err, "failed to open resume file")
 	}
-
-	file, err := os.OpenFile(pageKeysHistoryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	utils.ErrorPanic(err, "failed to open page keys history file")
 	defer file.Close()
 
-	// Write CSV header if file is new
-	if !fileExists {
-		_, err = file.WriteString("key,page_number,date,status\n")
-		utils.ErrorPanic(err, "failed to write CSV header")
+	if _, err := file.WriteString(pageKey); err != nil {
+		return apperrors.Wrap(err, "failed to write page key to resume file")
 	}
-
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	_, err = file.WriteString(fmt.Sprintf("%s,%d,%s,%s\n", pageKey, pageNumber, timestamp, status))
-	utils.ErrorPanic(err, "failed to write page key to history file")
+	slog.Info("Updated resume file with next page token", "page_key", pageKey)
 
 	return nil
 }
 
+// savePageKeyToHistory records a page key in the extraction history table
+func (de *DataExtractor) savePageKeyToHistory(pageKey string, pageNumber int, status string) error {
+	return de.repository.SaveExtractionPage(&models.ExtractionPage{
+		PageKey:    pageKey,
+		PageNumber: pageNumber,
+		Status:     status,
+	})
+}
+
 // ExtractAndProcessAllPages processes all pages of data from the API
 // maxPages: maximum number of pages to process (0 means no limit, default infinity)
 func (de *DataExtractor) ExtractAndProcessAllPages(maxPages int) error {
@@ -177,70 +212,87 @@ func (de *DataExtractor) ExtractAndProcessAllPages(maxPages int) error {
 	for {
 
 		if pageCount > maxPages {
-			log.Printf("Reached maximum page limit of %d pages", maxPages)
+			de.logger().Info("Reached maximum page limit", "max_pages", maxPages)
 			break
 		}
 
 		endpoint := de.buildEndpoint(nextPage)
 
-		log.Printf("Processing page %d (key: %s)...", pageCount, nextPage)
+		de.logger().Info("Processing page", "page", pageCount, "page_key", nextPage)
 
 		apiResponse, err := de.FetchData(endpoint)
 
 		if err != nil {
-			// Save page key to history file with error status
-			if saveErr := savePageKeyToHistory(nextPage, pageCount+1, "error"); saveErr != nil {
-				log.Printf("Warning: Failed to save error page key to history: %v", saveErr)
+			// Save page key to history with error status
+			if saveErr := de.savePageKeyToHistory(nextPage, pageCount+1, models.ExtractionPageError); saveErr != nil {
+				de.logger().Warn("Failed to save error page key to history", "page_key", nextPage, "error", saveErr)
 			}
-			return fmt.Errorf("failed to fetch page %d: %w", pageCount, err)
+			err = fmt.Errorf("failed to fetch page %d: %w", pageCount, err)
+			de.reportProgress(ExtractionProgress{Page: pageCount, TotalWritten: totalProcessed, Error: err.Error()})
+			return err
 		}
 
-		log.Printf("Retrieved %d items from page %d", len(apiResponse.Items), pageCount)
+		de.logger().Info("Retrieved page", "page", pageCount, "items", len(apiResponse.Items))
 
-		successCount := 0
-		for _, item := range apiResponse.Items {
-			if err := de.writeToCSV(&item); err != nil {
-				log.Printf("Warning: Failed to write data point %s to CSV: %v", item.Ticker, err)
-			} else {
-				successCount++
-				totalProcessed++
-			}
-		}
+		successCount := de.writeItems(apiResponse.Items)
+		totalProcessed += successCount
 
-		log.Printf("Successfully wrote %d out of %d items from page %d to CSV", successCount, len(apiResponse.Items), pageCount)
+		de.logger().Info("Wrote page items to CSV", "page", pageCount, "written", successCount, "items", len(apiResponse.Items))
 
 		nextPage = apiResponse.NextPage
 
 		if err := updateResumeKeyFile(nextPage); err != nil {
-			log.Printf("Warning: Failed to save resume page key %s: %v", nextPage, err)
+			de.logger().Warn("Failed to save resume page key", "page_key", nextPage, "error", err)
 		}
 
-		// Save page key to history file with success status
-		if err := savePageKeyToHistory(nextPage, pageCount+1, "success"); err != nil {
-			log.Printf("Warning: Failed to save page key to history: %v", err)
+		// Save page key to history with success status
+		if err := de.savePageKeyToHistory(nextPage, pageCount+1, models.ExtractionPageSuccess); err != nil {
+			de.logger().Warn("Failed to save page key to history", "page_key", nextPage, "error", err)
 		}
 
+		de.reportProgress(ExtractionProgress{
+			Page:         pageCount,
+			ItemsFetched: len(apiResponse.Items),
+			ItemsWritten: successCount,
+			TotalWritten: totalProcessed,
+		})
+
 		pageCount++
 
 		if nextPage == "" {
-			log.Println("No more pages to process")
+			de.logger().Info("No more pages to process")
 			break
 		}
 
 		time.Sleep(10 * time.Millisecond)
 	}
 
-	log.Printf("Data extraction completed! Total items written to CSV: %d across %d pages", totalProcessed, pageCount)
+	de.logger().Info("Data extraction completed", "items", totalProcessed, "pages", pageCount)
 	return nil
 }
 
-func (*DataExtractor) getResumePage() string {
+// ReplayPage fetches the page with the given key again and appends its items to the CSV output,
+// returning the number of items written. The resume file is left alone, so a replay never moves
+// the position of the next full extraction.
+func (de *DataExtractor) ReplayPage(pageKey string) (int, error) {
+	de.logger().Info("Replaying page", "page_key", pageKey)
+	apiResponse, err := de.FetchData(de.buildEndpoint(pageKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay page %q: %w", pageKey, err)
+	}
+
+	written := de.writeItems(apiResponse.Items)
+	de.logger().Info("Wrote replayed page items to CSV", "page_key", pageKey, "written", written, "items", len(apiResponse.Items))
+	return written, nil
+}
+
+func (de *DataExtractor) getResumePage() string {
 	nextPage := ""
 	if data, err := os.ReadFile(lastPageFile); err == nil {
 		nextPage = strings.TrimSpace(string(data))
-		log.Printf("Resuming from last page: %s", nextPage)
+		de.logger().Info("Resuming from last page", "page_key", nextPage)
 	} else {
-		log.Println("No previous page found, starting from the beginning")
+		de.logger().Info("No previous page found, starting from the beginning")
 	}
 	return nextPage
 }
@@ -258,8 +310,29 @@ func (*DataExtractor) buildEndpoint(nextPage string) string {
 	return endpoint
 }
 
-// writeToCSV writes a stock item to the CSV file
-func (de *DataExtractor) writeToCSV(item *OldStock) error {
+// writeItems transforms the items of a page and writes them to the CSV file, returning the number written
+func (de *DataExtractor) writeItems(items []OldStock) int {
+	written := 0
+	for i := range items {
+		point, err := de.transform(&items[i])
+		if err != nil {
+			de.logger().Warn("Failed to transform data point", "ticker", items[i].Ticker, "error", err)
+			continue
+		}
+		if point == nil {
+			continue
+		}
+		if err := de.writeToCSV(point); err != nil {
+			de.logger().Warn("Failed to write data point to CSV", "ticker", point.Ticker, "error", err)
+			continue
+		}
+		written++
+	}
+	return written
+}
+
+// writeToCSV writes a data point to the CSV file
+func (de *DataExtractor) writeToCSV(point *models.StockDataPoint) error {
 	// Check if CSV file exists to determine if we need to write headers
 	fileExists := false
 	if _, err := os.Stat(csvOutputFile); err == nil {
@@ -296,15 +369,15 @@ func (de *DataExtractor) writeToCSV(item *OldStock) error {
 
 	// Write stock data
 	record := []string{
-		item.Ticker,
-		item.Company,
-		fmt.Sprintf("%.2f", item.TargetFrom),
-		fmt.Sprintf("%.2f", item.TargetTo),
-		item.Action,
-		item.Brokerage,
-		item.RatingFrom,
-		item.RatingTo,
-		item.Time.Format("2006-01-02 15:04:05"),
+		point.Ticker,
+		point.Company,
+		fmt.Sprintf("%.2f", point.TargetFrom),
+		fmt.Sprintf("%.2f", point.TargetTo),
+		point.Action,
+		point.Brokerage,
+		point.RatingFrom,
+		point.RatingTo,
+		point.Date.Format("2006-01-02 15:04:05"),
 	}
 
 	if err := writer.Write(record); err != nil {
@@ -0,0 +1,106 @@
+package data_extractor
+
+import (
+	"strings"
+	"sync"
+
+	"dataextractor/models"
+)
+
+// ItemTransformer maps one item returned by a provider to a data point. Returning a nil data point
+// drops the item; an error is logged and the item skipped, the rest of the page is still written.
+type ItemTransformer func(*OldStock) (*models.StockDataPoint, error)
+
+// DefaultProvider is the challenge API the extractor was written for; its items go through NormalizeItem
+const DefaultProvider = "swechallenge"
+
+var (
+	transformersMu sync.RWMutex
+	transformers   = map[string]ItemTransformer{DefaultProvider: NormalizeItem}
+)
+
+// RegisterTransformer sets the item transformer of a provider (selected with EXTRACT_PROVIDER),
+// replacing any previous one. Custom mappings register themselves from an init function, so the
+// extraction loop never changes.
+func RegisterTransformer(provider string, transformer ItemTransformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers[strings.ToLower(provider)] = transformer
+}
+
+// transformerFor returns the transformer registered for provider, or MapItem when there is none
+func transformerFor(provider string) ItemTransformer {
+	transformersMu.RLock()
+	defer transformersMu.RUnlock()
+	if transformer, ok := transformers[strings.ToLower(provider)]; ok {
+		return transformer
+	}
+	return MapItem
+}
+
+// MapItem copies an item into a data point field by field, without normalization
+func MapItem(item *OldStock) (*models.StockDataPoint, error) {
+	return &models.StockDataPoint{
+		Ticker:     item.Ticker,
+		Company:    item.Company,
+		TargetFrom: item.TargetFrom,
+		TargetTo:   item.TargetTo,
+		Action:     item.Action,
+		Brokerage:  item.Brokerage,
+		RatingFrom: item.RatingFrom,
+		RatingTo:   item.RatingTo,
+		Date:       item.Time,
+	}, nil
+}
+
+// NormalizeItem is MapItem followed by ticker and brokerage normalization, so the same symbol and
+// firm spelled differently by the provider end up as one value
+func NormalizeItem(item *OldStock) (*models.StockDataPoint, error) {
+	point, err := MapItem(item)
+	if err != nil {
+		return nil, err
+	}
+	point.Ticker = NormalizeTicker(point.Ticker)
+	point.Brokerage = CanonicalBrokerage(point.Brokerage)
+	return point, nil
+}
+
+// exchangeQualifiers are the exchange prefixes (NASDAQ:AAPL) and suffixes (AAPL.US) removed from tickers
+var (
+	exchangePrefixes = []string{"NASDAQ:", "NYSE:", "AMEX:", "OTC:"}
+	exchangeSuffixes = []string{".US", ":US", " US", ".OQ", ".O", ".N"}
+)
+
+// NormalizeTicker upper-cases a ticker and strips exchange qualifiers. Share classes such as
+// BRK.B are kept.
+func NormalizeTicker(ticker string) string {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	for _, prefix := range exchangePrefixes {
+		ticker = strings.TrimPrefix(ticker, prefix)
+	}
+	for _, suffix := range exchangeSuffixes {
+		if trimmed := strings.TrimSuffix(ticker, suffix); trimmed != "" {
+			ticker = trimmed
+		}
+	}
+	return strings.TrimSpace(ticker)
+}
+
+// brokerageSuffixes are the legal-form suffixes dropped from brokerage names
+var brokerageSuffixes = []string{", Inc.", " Inc.", " Inc", ", LLC", " LLC", " L.P.", " Ltd.", " plc"}
+
+// CanonicalBrokerage collapses whitespace in a brokerage name and drops a leading "The" and a
+// trailing legal form, so "The Goldman Sachs Group, Inc." and "Goldman Sachs Group" match
+func CanonicalBrokerage(brokerage string) string {
+	brokerage = strings.Join(strings.Fields(brokerage), " ")
+	if rest, ok := strings.CutPrefix(brokerage, "The "); ok && rest != "" {
+		brokerage = rest
+	}
+	for _, suffix := range brokerageSuffixes {
+		if rest, ok := strings.CutSuffix(brokerage, suffix); ok && rest != "" {
+			brokerage = rest
+			break
+		}
+	}
+	return brokerage
+}
@@ -0,0 +1,54 @@
+package data_extractor
+
+import (
+	"testing"
+	"time"
+
+	"dataextractor/models"
+)
+
+// TestNormalizeItem checks ticker and brokerage normalization of the default provider
+func TestNormalizeItem(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ticker        string
+		brokerage     string
+		wantTicker    string
+		wantBrokerage string
+	}{
+		{name: "already canonical", ticker: "AAPL", brokerage: "Goldman Sachs", wantTicker: "AAPL", wantBrokerage: "Goldman Sachs"},
+		{name: "lower case and suffix", ticker: " aapl.us ", brokerage: "The Goldman Sachs Group, Inc.", wantTicker: "AAPL", wantBrokerage: "Goldman Sachs Group"},
+		{name: "exchange prefix", ticker: "NASDAQ:MSFT", brokerage: "Morgan  Stanley", wantTicker: "MSFT", wantBrokerage: "Morgan Stanley"},
+		{name: "share class kept", ticker: "BRK.B", brokerage: "Wedbush Securities LLC", wantTicker: "BRK.B", wantBrokerage: "Wedbush Securities"},
+		{name: "bare suffix kept", ticker: ".US", brokerage: "The", wantTicker: ".US", wantBrokerage: "The"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &OldStock{Ticker: tc.ticker, Brokerage: tc.brokerage, Company: "Acme", TargetTo: 10, Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}
+			point, err := NormalizeItem(item)
+			if err != nil {
+				t.Fatalf("NormalizeItem returned %v", err)
+			}
+			if point.Ticker != tc.wantTicker || point.Brokerage != tc.wantBrokerage {
+				t.Errorf("NormalizeItem(%q, %q) = %q, %q, want %q, %q", tc.ticker, tc.brokerage, point.Ticker, point.Brokerage, tc.wantTicker, tc.wantBrokerage)
+			}
+			if point.Company != "Acme" || point.TargetTo != 10 || !point.Date.Equal(item.Time) {
+				t.Errorf("NormalizeItem did not copy the remaining fields: %+v", point)
+			}
+		})
+	}
+}
+
+// TestTransformerFor checks registration and the fallback for unknown providers
+func TestTransformerFor(t *testing.T) {
+	RegisterTransformer("Custom", func(item *OldStock) (*models.StockDataPoint, error) {
+		return nil, nil
+	})
+	if point, _ := transformerFor("custom")(&OldStock{Ticker: "X"}); point != nil {
+		t.Errorf("expected the registered transformer to drop the item, got %+v", point)
+	}
+	if point, _ := transformerFor("unknown")(&OldStock{Ticker: "aapl.us"}); point == nil || point.Ticker != "aapl.us" {
+		t.Errorf("expected unknown providers to be copied as-is, got %+v", point)
+	}
+}
@@ -2,25 +2,31 @@ package db_populate
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
+	"dataextractor/apperrors"
 	"dataextractor/models"
 	"dataextractor/repository"
 	"dataextractor/utils"
 )
 
-// GetColIndexByName reads the CSV header and returns a header->index map
-func GetColIndexByName(csvr *csv.Reader) map[string]int {
+// GetColIndexByName reads the CSV header and returns a header->index map; a file without a
+// readable header is a validation error
+func GetColIndexByName(csvr *csv.Reader) (map[string]int, error) {
 	headers, err := csvr.Read()
-	utils.ErrorPanic(err, "failed to read CSV header")
+	if err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "failed to read CSV header")
+	}
 
 	idx := map[string]int{}
 	for i, h := range headers {
-		idx[h] = i
+		idx[strings.TrimSpace(h)] = i
 	}
-	return idx
+	return idx, nil
 }
 
 // GetRatingColsValues builds a map of rating column values from a row
@@ -41,26 +47,27 @@ func GetNumericalColsValues(numericalColsNames []string, row []string, idx map[s
 	return values
 }
 
+// RatingScoreColumns returns the CSV columns holding the score and normalized score of the rating
+// sentiment name
+func RatingScoreColumns(name string) (scoreColumn, normScoreColumn string) {
+	switch name {
+	case "rating_from":
+		return "rating_from_score", "norm_rating_from_score"
+	case "rating_to":
+		return "rating_to_score", "norm_rating_to_score"
+	case "action":
+		return "rating_delta", "norm_rating_delta"
+	default:
+		return name, "norm_" + name
+	}
+}
+
 // GetRatingScoresAndNormScores returns rating scores and normalized rating scores maps
 func GetRatingScoresAndNormScores(ratingColsNames []string, row []string, idx map[string]int) (map[string]string, map[string]string) {
 	ratingScores := map[string]string{}
 	normRatingScores := map[string]string{}
 	for _, name := range ratingColsNames {
-		var scoreKey, normScoreKey string
-		switch name {
-		case "rating_from":
-			scoreKey = "rating_from_score"
-			normScoreKey = "norm_rating_from_score"
-		case "rating_to":
-			scoreKey = "rating_to_score"
-			normScoreKey = "norm_rating_to_score"
-		case "action":
-			scoreKey = "rating_delta"
-			normScoreKey = "norm_rating_delta"
-		default:
-			scoreKey = name
-			normScoreKey = "norm_" + name
-		}
+		scoreKey, normScoreKey := RatingScoreColumns(name)
 		ratingScores[name] = utils.GetCSVValue(row, idx, scoreKey)
 		normRatingScores[name] = utils.GetCSVValue(row, idx, normScoreKey)
 	}
@@ -76,22 +83,28 @@ func GetNormNumericalValues(numericalColsNames []string, row []string, idx map[s
 	return values
 }
 
-// CreateDataPoint builds a StockDataPoint base struct from the row
-func CreateDataPoint(row []string, idx map[string]int, ratingColsValues map[string]string) *models.StockDataPoint {
+// CreateDataPoint builds a StockDataPoint base struct from the row; zone-less timestamps are read in loc
+func CreateDataPoint(row []string, idx map[string]int, ratingColsValues map[string]string, loc *time.Location) (*models.StockDataPoint, error) {
+	date, err := utils.ParseTime(utils.GetCSVValue(row, idx, "date"), utils.GetCSVValue(row, idx, "time"), loc)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.StockDataPoint{
 		Ticker:     utils.GetCSVValue(row, idx, "ticker"),
 		Company:    utils.GetCSVValue(row, idx, "company"),
 		Action:     utils.GetCSVValue(row, idx, "action"),
 		Cluster:    utils.ParseInt(utils.GetCSVValue(row, idx, "cluster")),
-		Date:       utils.ParseTime(utils.GetCSVValue(row, idx, "date"), utils.GetCSVValue(row, idx, "time")),
+		Date:       date,
 		TargetTo:   utils.ParseFloat(utils.GetCSVValue(row, idx, "target_to")),
 		TargetFrom: utils.ParseFloat(utils.GetCSVValue(row, idx, "target_from")),
 		TargetDelta: utils.ParseFloat(utils.GetCSVValue(row, idx, "target_delta")),
 		LastClose: utils.ParseFloat(utils.GetCSVValue(row, idx, "last_close")),
 		RatingTo:   ratingColsValues["rating_to"],
 		RatingFrom: ratingColsValues["rating_from"],
+		Brokerage:  utils.GetCSVValue(row, idx, "brokerage"),
 		FinalScore: utils.ParseFloat(utils.GetCSVValue(row, idx, "final_score")),
-	}
+	}, nil
 }
 
 // CreateSentimentsArray builds RatingSentiment slice from rating maps
@@ -131,43 +144,78 @@ func CreateIndicatorsArray(numericalColsNames []string, numericalColsValues map[
 	return indicators
 }
 
-// ImportFromCSV reads a CSV and builds StockDataPoint entries (no persistence yet)
-func ImportFromCSV(reader io.Reader, repo repository.DataRepositoryInterface) (int, error) {
-	csvr := csv.NewReader(reader)
-	csvr.TrimLeadingSpace = true
-	csvr.ReuseRecord = false
+// ImportOptions controls how CSV input is parsed
+type ImportOptions struct {
+	// Location for timestamps that carry no zone (UTC when nil)
+	Location *time.Location
+	// Delimiter, quoting and BOM handling of the CSV reader
+	CSV utils.CSVOptions
+	// Dataset version stamped on every imported row and archived with it (0 disables versioning)
+	DatasetVersionID uint
+	// Places rows whose cluster cell is empty; nil leaves them in cluster 0
+	AssignCluster func(sdp *models.StockDataPoint)
+	// Receives the rows that cannot be parsed or fail validation, which are then skipped; nil
+	// aborts the import at the first such row
+	OnRowError func(RowError)
+}
 
-	idx := GetColIndexByName(csvr)
+// RowError is a CSV row skipped by an import. Row counts records from 1 for the header.
+type RowError struct {
+	Row    int    `json:"row"`
+	Ticker string `json:"ticker,omitempty"`
+	Error  string `json:"error"`
+}
+
+// RowValidator checks (and may normalize) a data point built from a CSV row before it is persisted
+type RowValidator func(sdp *models.StockDataPoint) error
 
-	ratingColsNames := []string{
-		"rating_from",
-		"rating_to",
-		"action",
+// ImportFromCSV reads a CSV, validates each row with validate (if non-nil), and persists StockDataPoint entries
+func ImportFromCSV(reader io.Reader, repo repository.DataRepositoryInterface, validate RowValidator, opts ImportOptions) (int, error) {
+	csvr, err := utils.NewCSVReader(reader, opts.CSV)
+	if err != nil {
+		return 0, err
 	}
+	csvr.TrimLeadingSpace = true
+	csvr.ReuseRecord = false
 
-	numericalColsNames := []string{
-		"target_from", "target_to", "target_delta", "target_growth", "relative_growth",
-		"last_close",
-		"atr", "std_dev", "ulcer_index", "price_distance", "obv", "ad_line", "pvt", "force_index",
-		"hlc3", "typical_price", "vwap",
+	idx, err := GetColIndexByName(csvr)
+	if err != nil {
+		return 0, err
 	}
 
+	ratingColsNames := models.RatingSentimentNames
+	numericalColsNames := models.NumericalIndicatorNames
+
 	count := 0
+	row := 1
 	for {
-		row, err := csvr.Read()
+		record, err := csvr.Read()
 		if err == io.EOF {
 			break
 		}
+		row++
+		var parseErr *csv.ParseError
+		if err != nil && errors.As(err, &parseErr) && opts.OnRowError != nil {
+			opts.OnRowError(RowError{Row: row, Error: err.Error()})
+			continue
+		}
 		if err != nil {
 			return count, fmt.Errorf("failed to read CSV row: %w", err)
 		}
 
-		ratingColsValues := GetRatingColsValues(ratingColsNames, row, idx)
-		numericalColsValues := GetNumericalColsValues(numericalColsNames, row, idx)
+		ratingColsValues := GetRatingColsValues(ratingColsNames, record, idx)
+		numericalColsValues := GetNumericalColsValues(numericalColsNames, record, idx)
 
-		ratingScores, normRatingScores := GetRatingScoresAndNormScores(ratingColsNames, row, idx)
-		normNumericalColsValues := GetNormNumericalValues(numericalColsNames, row, idx)
-		sdp := CreateDataPoint(row, idx, ratingColsValues)
+		ratingScores, normRatingScores := GetRatingScoresAndNormScores(ratingColsNames, record, idx)
+		normNumericalColsValues := GetNormNumericalValues(numericalColsNames, record, idx)
+		sdp, err := CreateDataPoint(record, idx, ratingColsValues, opts.Location)
+		if err != nil {
+			if opts.OnRowError != nil {
+				opts.OnRowError(RowError{Row: row, Ticker: utils.GetCSVValue(record, idx, "ticker"), Error: err.Error()})
+				continue
+			}
+			return count, apperrors.WrapAs(err, apperrors.KindValidation, fmt.Sprintf("invalid row %d", row))
+		}
 
 		sentiments := CreateSentimentsArray(ratingColsNames, ratingScores, normRatingScores, ratingColsValues)
 		sdp.RatingSentiments = sentiments
@@ -175,9 +223,33 @@ func ImportFromCSV(reader io.Reader, repo repository.DataRepositoryInterface) (i
 		indicators := CreateIndicatorsArray(numericalColsNames, numericalColsValues, normNumericalColsValues)
 		sdp.NumericalIndicators = indicators
 
+		if opts.AssignCluster != nil && strings.TrimSpace(utils.GetCSVValue(record, idx, "cluster")) == "" {
+			opts.AssignCluster(sdp)
+		}
+
+		if validate != nil {
+			if err := validate(sdp); err != nil {
+				if opts.OnRowError != nil {
+					opts.OnRowError(RowError{Row: row, Ticker: sdp.Ticker, Error: err.Error()})
+					continue
+				}
+				return count, apperrors.WrapAs(err, apperrors.KindValidation, fmt.Sprintf("invalid row %d for ticker %s", row, sdp.Ticker))
+			}
+		}
+
+		if opts.DatasetVersionID > 0 {
+			version := opts.DatasetVersionID
+			sdp.DatasetVersionID = &version
+		}
+
 		if _, err := repo.UpdateOrCreate(sdp); err != nil {
 			return count, fmt.Errorf("failed to persist row for ticker %s: %w", sdp.Ticker, err)
 		}
+		if sdp.DatasetVersionID != nil {
+			if err := repo.SaveDatasetRecord(sdp); err != nil {
+				return count, err
+			}
+		}
 
 		count++
 	}
@@ -0,0 +1,164 @@
+package db_populate
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// update rewrites the golden files from the current importer output:
+//
+//	go test ./db_populate -run TestImportGolden -update
+var update = flag.Bool("update", false, "rewrite the testdata golden files")
+
+// recordingRepository keeps what the importer persists, with the one-row-per-ticker upsert
+// semantics of the real repositories. Only the methods ImportFromCSV calls are implemented.
+type recordingRepository struct {
+	repository.DataRepositoryInterface
+
+	order  []string
+	stocks map[string]*models.StockDataPoint
+}
+
+func (r *recordingRepository) UpdateOrCreate(stock *models.StockDataPoint) (*models.StockDataPoint, error) {
+	if _, ok := r.stocks[stock.Ticker]; !ok {
+		r.order = append(r.order, stock.Ticker)
+	}
+	r.stocks[stock.Ticker] = stock
+	return stock, nil
+}
+
+// goldenImport is the golden file content: the import count, the stored stocks in first-seen
+// order and the skipped rows
+type goldenImport struct {
+	Imported  int           `json:"imported"`
+	Stocks    []goldenStock `json:"stocks"`
+	RowErrors []RowError    `json:"row_errors"`
+}
+
+// goldenStock holds the columns parsed from the CSV, leaving out database-assigned fields
+type goldenStock struct {
+	Ticker      string            `json:"ticker"`
+	Company     string            `json:"company"`
+	Action      string            `json:"action"`
+	Brokerage   string            `json:"brokerage"`
+	Date        string            `json:"date"`
+	Cluster     int               `json:"cluster"`
+	TargetFrom  float64           `json:"target_from"`
+	TargetTo    float64           `json:"target_to"`
+	TargetDelta float64           `json:"target_delta"`
+	LastClose   float64           `json:"last_close"`
+	RatingFrom  string            `json:"rating_from"`
+	RatingTo    string            `json:"rating_to"`
+	FinalScore  float64           `json:"final_score"`
+	Sentiments  []goldenSentiment `json:"sentiments"`
+	Indicators  []goldenIndicator `json:"indicators"`
+}
+
+type goldenSentiment struct {
+	Name      string  `json:"name"`
+	Rating    string  `json:"rating"`
+	Score     float64 `json:"score"`
+	NormScore float64 `json:"norm_score"`
+}
+
+type goldenIndicator struct {
+	Name      string  `json:"name"`
+	Value     float64 `json:"value"`
+	NormValue float64 `json:"norm_value"`
+}
+
+// TestImportGolden imports every testdata/*.csv fixture and compares the result with its
+// .golden.json file, so changes to the parsing rules show up as reviewable diffs
+func TestImportGolden(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*.csv"))
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("no fixtures found: %v", err)
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".csv")
+		t.Run(name, func(t *testing.T) {
+			got := importFixture(t, fixture)
+
+			goldenPath := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("import of %s differs from %s (run with -update to accept):\n%s", fixture, goldenPath, got)
+			}
+		})
+	}
+}
+
+// importFixture imports the CSV at path, skipping bad rows, and renders the golden JSON
+func importFixture(t *testing.T, path string) []byte {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	repo := &recordingRepository{stocks: make(map[string]*models.StockDataPoint)}
+	result := goldenImport{Stocks: []goldenStock{}, RowErrors: []RowError{}}
+	result.Imported, err = ImportFromCSV(file, repo, nil, ImportOptions{
+		OnRowError: func(rowErr RowError) { result.RowErrors = append(result.RowErrors, rowErr) },
+	})
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+
+	for _, ticker := range repo.order {
+		result.Stocks = append(result.Stocks, newGoldenStock(repo.stocks[ticker]))
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(out, '\n')
+}
+
+// newGoldenStock converts a persisted data point to its golden form
+func newGoldenStock(stock *models.StockDataPoint) goldenStock {
+	golden := goldenStock{
+		Ticker:      stock.Ticker,
+		Company:     stock.Company,
+		Action:      stock.Action,
+		Brokerage:   stock.Brokerage,
+		Date:        stock.Date.Format(time.RFC3339),
+		Cluster:     stock.Cluster,
+		TargetFrom:  stock.TargetFrom,
+		TargetTo:    stock.TargetTo,
+		TargetDelta: stock.TargetDelta,
+		LastClose:   stock.LastClose,
+		RatingFrom:  stock.RatingFrom,
+		RatingTo:    stock.RatingTo,
+		FinalScore:  stock.FinalScore,
+		Sentiments:  []goldenSentiment{},
+		Indicators:  []goldenIndicator{},
+	}
+	for _, s := range stock.RatingSentiments {
+		golden.Sentiments = append(golden.Sentiments, goldenSentiment{Name: s.Name, Rating: s.Rating, Score: s.RatingScore, NormScore: s.NormRatingScore})
+	}
+	for _, i := range stock.NumericalIndicators {
+		golden.Indicators = append(golden.Indicators, goldenIndicator{Name: i.Name, Value: i.Value, NormValue: i.NormValue})
+	}
+	return golden
+}
@@ -0,0 +1,6431 @@
+// Code generated by swaggo/swag. DO NOT EDIT.
+
+package v1
+
+import "github.com/swaggo/swag"
+
+const docTemplatev1 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@swagger.io"
+        },
+        "license": {
+            "name": "MIT",
+            "url": "https://opensource.org/licenses/MIT"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/v1/admin/diagnostics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report the schema version, migration status (tables and columns missing from information_schema), row count of every table, the index list and the largest tables, to speed up support. Table sizes are included when the database reports them. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get database diagnostics",
+                "responses": {
+                    "200": {
+                        "description": "Database diagnostics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to gather diagnostics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report the requests, error responses (status \u003e= 400) and bytes received and sent per API client over the last days UTC days, today included, with each client's busiest endpoints. Clients are identified by the SHA-256 fingerprint of their X-API-Key header; requests without one are reported as \"anonymous\". Counters are aggregated in memory and written every SERVER_USAGE_FLUSH_INTERVAL; the report flushes them first. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get API usage per client",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days to report, 1-90 (default: 7)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Busiest endpoints listed per client, 1-50 (default: 5)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usage per client",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid days or top",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get usage",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start a background job that moves the data points dated before a day, in a cluster, and/or last written by a dataset version (dataset) or by versions older than one (dataset_before, superseded imports) from the hot table to the stock_archive table. Criteria are combined with AND; without any, records older than ARCHIVE_MAX_AGE are archived. Records move ARCHIVE_BATCH_SIZE at a time, each batch copied with its sentiments, indicators and tags and deleted in one transaction; ticker history snapshots are kept. GET /jobs/{id} reports the batches (pages_processed) and records (items_written) archived. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "archive"
+                ],
+                "summary": "Archive old data points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Archive records dated before this day (YYYY-MM-DD)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Archive records of this cluster",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Archive records last written by this dataset version",
+                        "name": "dataset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Archive records last written by a dataset version older than this one",
+                        "name": "dataset_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Archival job queued",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing criteria",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start the archival",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/archive/{ticker}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records of a ticker moved to the archive, most recently archived first (at most 100). Each entry carries the record as it was archived, with its sentiments, indicators and tags, in payload.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "archive"
+                ],
+                "summary": "Get the archived records of a ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Archived records",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/datasets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the import runs, newest first. Every file import creates a dataset version whose ID is stored on the rows it wrote; listing endpoints accept ?dataset= to pin results to a version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "datasets"
+                ],
+                "summary": "List dataset versions",
+                "responses": {
+                    "200": {
+                        "description": "Dataset versions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get dataset versions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/datasets/{version}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reverts every data point written by a newer import to its state as of the given version, deleting data points that did not exist yet. Newer versions are marked rolled_back. Rows created through the API are not affected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "datasets"
+                ],
+                "summary": "Roll back to a dataset version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dataset version ID to roll back to",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rollback summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid version or version not complete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Dataset version not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to roll back",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Export the whole stock table asynchronously as CSV, XLSX or NDJSON (same layout as GET /stocks/export). The file is written to the export spool; poll GET /exports/{id} for the status and a signed download URL. Jobs and files are deleted once their retention ends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Start an export job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format: csv | xlsx | ndjson (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Export job queued",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Status of an export job. Once it is complete the response carries a time-limited signed download_url; request the job again for a fresh link after it expires",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Get an export job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Export job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download the file of a complete export job through the signed link returned by GET /exports/{id}. The link needs no other credentials and stops working when it expires",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Download an export file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Link expiry (unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported rows",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired link",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Export job or file not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Export job not complete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/graphql": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Runs a GraphQL query against the stock schema (graph/schema.graphqls): stocks with filter and pagination arguments, stock by uuid or ticker, clusters and companies, with nested tags, ratingSentiments and numericalIndicators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GraphQL query",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON-encoded query variables",
+                        "name": "variables",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation to run when the query defines several",
+                        "name": "operationName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL response with data and errors",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Unparsable GraphQL request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Query does not validate against the schema",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Same as GET /graphql with the query, variables and operation name in a JSON body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL response with data and errors",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Unparsable GraphQL request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Query does not validate against the schema",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Status of a background job such as an extraction started with POST /stocks/extract: pages processed and items written so far, and the error of a failed run. Progress is saved after every page, so the job can be polled while it runs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get a background job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Default cluster and weights of the authenticated caller. The weights are applied to the cluster filter when it is called without any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Get my dashboard preferences",
+                "responses": {
+                    "200": {
+                        "description": "Preferences (empty when none are stored)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the default cluster and weights of the authenticated caller. Weights are checked like filter weights",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Save my dashboard preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.PreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences saved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/notifications/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a test message on the configured email (SMTP) and Slack webhook channels, or only on the requested one, and report each delivery. The same channels report failed imports and extractions (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Send a test notification",
+                "parameters": [
+                    {
+                        "description": "Channel to test (all when omitted)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/validators.NotificationTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery results per channel",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or unconfigured channel",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/openapi.json": {
+            "get": {
+                "description": "The raw OpenAPI (Swagger 2.0) document of this API version, as used by the client generator (cmd/sdkgen) and the spec validation middleware",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "docs"
+                ],
+                "summary": "Get the OpenAPI document",
+                "responses": {
+                    "200": {
+                        "description": "OpenAPI document",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rating-rubric": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rating and action terms with the scores given to sentiments carrying them, ordered by kind and term",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rubric"
+                ],
+                "summary": "List the rating rubric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only entries of this kind (rating or action)",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric entries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kind",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Map a rating (rating_from/rating_to) or action term to a raw and normalized score. Sentiments carrying the term are scored from the rubric on API writes and imports. Terms are case-insensitive",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rubric"
+                ],
+                "summary": "Add a rubric term",
+                "parameters": [
+                    {
+                        "description": "Rubric entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.RatingRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rubric entry created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Term already in the rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rating-rubric/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the kind, term and scores of a rubric entry. Existing sentiments are rescored on their next write",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rubric"
+                ],
+                "summary": "Edit a rubric term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rubric entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.RatingRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric entry updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric entry not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Term already in the rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a term from the rubric; sentiments carrying it keep the scores they have",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rubric"
+                ],
+                "summary": "Delete a rubric term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric entry deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric entry ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric entry not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schema": {
+            "get": {
+                "description": "Retrieve JSON Schema (draft-07) documents for the create, update and filter request bodies, generated from the server-side validation rules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schema"
+                ],
+                "summary": "Get JSON Schemas for request bodies",
+                "responses": {
+                    "200": {
+                        "description": "JSON Schema documents keyed by request name",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/scoring-config": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download the rating rubric, the weight profiles of every user and the indicator and sentiment names they refer to as one JSON document, to import on another environment with POST /scoring-config/import. The configured default weight profile (SCORING_DEFAULT_WEIGHT, SCORING_DEFAULT_WEIGHTS) is included for comparison; it comes from the environment and is not imported. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Export the scoring configuration",
+                "responses": {
+                    "200": {
+                        "description": "Scoring configuration document",
+                        "schema": {
+                            "$ref": "#/definitions/service.ScoringConfigDocument"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export the scoring configuration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/scoring-config/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Apply a document exported by GET /scoring-config. Rubric entries are matched by kind and term and weight profiles by user; both are created or overwritten. The whole document is validated first and written in one transaction, so a rejected document changes nothing. A document naming indicators or sentiments unknown to this environment is rejected. With replace=true, rubric entries and weight profiles that are not in the document are deleted. Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scoring"
+                ],
+                "summary": "Import a scoring configuration",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Delete rubric entries and weight profiles missing from the document (default: false)",
+                        "name": "replace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Scoring configuration document",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.ScoringConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scoring configuration imported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import the scoring configuration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Autocomplete for the dashboard omnibox: stocks whose ticker or company matches q, ranked exact ticker, ticker prefix, company prefix, company word prefix, then substring matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search tickers and companies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results, 1-50 (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to search",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all stock records from the database in one response. For large datasets use GET /stocks/export, which streams in batches.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get all stocks",
+                "responses": {
+                    "200": {
+                        "description": "List of stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new stock record with the provided information. A stock without a cluster is placed at the nearest cluster centroid, and final_score is computed from its indicators and sentiments with the default weight profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Create a new stock",
+                "parameters": [
+                    {
+                        "description": "Stock information",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.StockCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/action/{action}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve one page of the stock records for a specific action",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stocks by action",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Action value",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include data points last written by this dataset version",
+                        "name": "dataset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stocks for action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/actions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all unique action values",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get unique actions",
+                "responses": {
+                    "200": {
+                        "description": "List of unique actions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve actions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/analytics/dispersion": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mean, median, sample standard deviation, quartiles and interquartile range of target_delta and final_score per cluster, so averages can be read together with their spread",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get per-cluster dispersion statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Restrict to one cluster (default: all clusters)",
+                        "name": "cluster",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dispersion statistics by cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Cluster has no stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compute dispersion",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/analytics/heatmap": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Matrix of stock counts and average final scores for cluster vs action (or rating_to), computed with one GROUP BY query",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get the cluster heatmap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Column crossed with cluster: action | rating_to (default: action)",
+                        "name": "dimension",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Heatmap matrix",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid dimension",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build heatmap",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create up to 1000 stocks in one call. Every item is validated like POST /stocks; the valid ones are inserted in a single transaction and the invalid ones are reported and skipped. results holds one entry per submitted item, in request order, with the created stock or the validation error. Answers 201 when every item was created and 207 when some were rejected; a database error rolls back the whole batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Create stocks in bulk",
+                "parameters": [
+                    {
+                        "description": "Stocks to create",
+                        "name": "stocks",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/validators.StockCreateRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "All stocks created",
+                        "schema": {
+                            "$ref": "#/definitions/service.BatchCreateResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Some stocks rejected",
+                        "schema": {
+                            "$ref": "#/definitions/service.BatchCreateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format, empty or oversized batch",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/cluster": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Bulk variant of the cluster override: moves every listed ticker to the target cluster in one transaction, recording an audit entry per changed stock. Unknown tickers abort the whole move",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clusters"
+                ],
+                "summary": "Move tickers to a cluster",
+                "parameters": [
+                    {
+                        "description": "Tickers, target cluster and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.BulkClusterAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clusters reassigned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown tickers",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/cluster/{cluster}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve one page of the stock records for a specific cluster",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stocks by cluster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include data points last written by this dataset version",
+                        "name": "dataset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stocks for cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/cluster/{cluster}/filter": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Filter stocks by cluster with optional grouping, pagination, sorting, and weighted scoring. Supports numerical and rating weights via query parameters. Note: grouping_column can only be action, rating_to, or rating_from (company and date are excluded due to too many distinct values). Authenticated callers that send no weights get the default weights saved under /me/preferences; sorting by weighted_score without any weights uses the configured default weight profile (SCORING_DEFAULT_WEIGHTS). weight_profile reports which weights were applied: request | user | default. Either weight array alone produces a weighted_score and can be sorted by; weight_sets lists the arrays the score was computed from (numerical, rating).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Filter stocks by cluster with grouping, pagination, sorting, and weighted scoring",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected). Note: company and date are excluded.",
+                        "name": "grouping_column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grouping value to filter by (required if grouping_column is not none)",
+                        "name": "grouping_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column: ticker | action | date | company | target_to | target_from | rating_to | rating_from | final_score | weighted_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of numerical weights: [{\\",
+                        "name": "numerical_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of rating weights: [{\\",
+                        "name": "rating_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only include stocks carrying any of these tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with final_score at least this value",
+                        "name": "min_final_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with final_score at most this value",
+                        "name": "max_final_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_to at least this value",
+                        "name": "min_target_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_to at most this value",
+                        "name": "max_target_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_from at least this value",
+                        "name": "min_target_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_from at most this value",
+                        "name": "max_target_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_delta at least this value",
+                        "name": "min_target_delta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_delta at most this value",
+                        "name": "max_target_delta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with last_close at least this value",
+                        "name": "min_last_close",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with last_close at most this value",
+                        "name": "max_last_close",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child rows loaded per stock: full | scoring (only name and normalized score/value) | none (default: full)",
+                        "name": "relations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With weights, add a contributions object to each row mapping every weighted name to weight x normalized value; the values add up to weighted_score (default: false)",
+                        "name": "contributions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return one record per grouping value (group_value, count, avg_final_score, avg_weighted_score) instead of rows; requires grouping_column (default: false)",
+                        "name": "aggregate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Admin only: return the EXPLAIN ANALYZE plan of the page query (data.sql, data.plan) instead of rows; not combinable with aggregate (default: false)",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paged grouped results, group summaries with aggregate=true, or the query plan with explain=true",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters, or a grouping_value not present in the cluster (with suggestions)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "explain=true without the admin role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Same as the GET filter endpoint, but takes the parameters and weight arrays as a JSON body, avoiding URL length limits for large weight sets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Filter stocks by cluster (JSON body variant)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Filter parameters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.FilterRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Admin only: return the EXPLAIN ANALYZE plan of the page query instead of rows (default: false)",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paged grouped results, or the query plan with explain=true",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "explain=true without the admin role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/cluster/{cluster}/filter/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams every page of the filter endpoint's result set (same grouping, tags, sort, and weights) as a CSV or XLSX download, so the file matches exactly what the user sees.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Export the filtered result set",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format: csv | xlsx (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected)",
+                        "name": "grouping_column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grouping value to filter by (required if grouping_column is not none)",
+                        "name": "grouping_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of numerical weights: [{\\",
+                        "name": "numerical_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of rating weights: [{\\",
+                        "name": "rating_weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only include stocks carrying any of these tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with final_score at least this value",
+                        "name": "min_final_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with final_score at most this value",
+                        "name": "max_final_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_to at least this value",
+                        "name": "min_target_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_to at most this value",
+                        "name": "max_target_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_from at least this value",
+                        "name": "min_target_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_from at most this value",
+                        "name": "max_target_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_delta at least this value",
+                        "name": "min_target_delta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with target_delta at most this value",
+                        "name": "max_target_delta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with last_close at least this value",
+                        "name": "min_last_close",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include stocks with last_close at most this value",
+                        "name": "max_last_close",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported rows",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Same as the GET export endpoint, but takes the filter parameters and weight arrays as a JSON body. The format is still chosen with the format query parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Export the filtered result set (JSON body variant)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format: csv | xlsx (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Filter parameters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.FilterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported rows",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/cluster/{cluster}/unique/{column_name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get unique values for a column from StockDataPoint filtered by cluster. Allowed columns: action, rating_to, rating_from. Note: company and date are excluded due to having too many distinct values.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get unique values for a specified column filtered by cluster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column name: action | rating_to | rating_from",
+                        "name": "column_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unique values",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get unique values",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/clusters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all unique cluster IDs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get unique clusters",
+                "responses": {
+                    "200": {
+                        "description": "List of unique clusters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve clusters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/clusters/centroids": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stored centroid of every cluster: the mean normalized value of each indicator over the cluster's stocks. New stocks without a cluster are assigned to the nearest centroid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clusters"
+                ],
+                "summary": "Get cluster centroids",
+                "responses": {
+                    "200": {
+                        "description": "Centroid coordinates ordered by cluster and indicator",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recompute the centroid of every cluster from the current stocks (noise points excluded). Imports do this automatically; call it after manual cluster overrides",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clusters"
+                ],
+                "summary": "Recompute cluster centroids",
+                "responses": {
+                    "200": {
+                        "description": "Recomputed centroid coordinates",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/companies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all unique company names",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get unique companies",
+                "responses": {
+                    "200": {
+                        "description": "List of unique companies",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve companies",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/company/{company}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve one page of the stock records for a specific company",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stocks by company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company name",
+                        "name": "company",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include data points last written by this dataset version",
+                        "name": "dataset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stocks for company",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company name",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "No stocks found for company",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/database/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the total, unique ticker and unique company counts and the data point count of every non-empty cluster",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get database statistics",
+                "responses": {
+                    "200": {
+                        "description": "Database statistics",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/repository.DatabaseStats"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve database statistics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/dictionary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the indicator and sentiment names present in the database (with counts and value ranges), the allowed grouping columns, the sortable columns, and the action/rating enumerations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get the data dictionary",
+                "responses": {
+                    "200": {
+                        "description": "Data dictionary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build data dictionary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/enums": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the enumerations that action, rating_to and rating_from are validated against on create, update and import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get allowed action and rating values",
+                "responses": {
+                    "200": {
+                        "description": "Allowed values by enumeration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the whole stock table, or the stocks of a cluster, dataset version and date window, as CSV, XLSX or NDJSON. Rows are read in batches of 1000 with their relations loaded per batch, so memory stays flat on large datasets. CSV and XLSX rows flatten the rating sentiments (score and normalized score columns) and numerical indicators (value and norm_ columns) into the header names the CSV import reads, so the file can be imported back; NDJSON lines carry the full stock objects.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Export every stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format: csv | xlsx | ndjson (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only export stocks of this cluster",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only export stocks of this dataset version",
+                        "name": "dataset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export stocks recorded on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export stocks recorded on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported rows",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/extract": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start a data extraction from the external API with the specified max pages. The crawl runs in the background: the response carries the job, and GET /jobs/{id} reports the pages processed, items written and error state. Each page is one upstream request; when EXTRACT_DAILY_REQUEST_QUOTA is set, runs without max_pages are capped at the remaining daily budget and runs that could exceed it are refused with the budget in the response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Extract data from API",
+                "parameters": [
+                    {
+                        "description": "Extraction request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.StockExtractRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Extraction job queued",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Daily upstream request quota exceeded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start the extraction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/extract/budget": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upstream requests made today (UTC) with the configured API key, the EXTRACT_DAILY_REQUEST_QUOTA and how many requests remain before it resets. unlimited is true when no quota is configured",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get the daily upstream request budget",
+                "responses": {
+                    "200": {
+                        "description": "Request budget",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get extraction budget",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/extract/pages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the page keys visited by the API extractor with their page number, status and time, newest first. Entries older than EXTRACT_PAGE_HISTORY_RETENTION are pruned after each extraction run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "List extraction page-key history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only include entries with this status: success | error | retried",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by column: recorded_at | page_number; a comma-separated list of column [asc|desc] keys (e.g. page_number asc, recorded_at desc) sorts by each in turn, keys without a direction use order (default: recorded_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc | desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Extraction history page",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get extraction pages",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/extract/retry-failed": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetches again only the pages recorded in the page history with status error and appends their items to the extraction CSV, instead of re-running the whole extraction. Recovered pages are marked retried; pages that fail again stay error and are listed in failed_keys. Each page is one upstream request, so a replay that could exceed EXTRACT_DAILY_REQUEST_QUOTA is refused with the budget in the response",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Replay failed extraction pages",
+                "responses": {
+                    "200": {
+                        "description": "Replay summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Daily upstream request quota exceeded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to replay extraction pages",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/extract/{job_id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of an extraction job started with POST /stocks/extract. A job event carries the job as it is when the stream opens. Then a page event (page, items_fetched, items_written, total_written) is sent after each page is written, and an error event when a page fails to fetch. A final done event carries the finished job, and the stream closes. A job that has already finished gets the job and done events right away. Events are not replayed, so use the job event for the progress made before connecting. Idle streams get a comment line every 15 seconds",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Stream the progress of an extraction job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Extraction job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Extraction job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import the rows of a CSV file sent as the multipart form field file, in the enriched CSV format. Unlike /stocks/import-enriched, rows that cannot be parsed or fail validation are skipped: rows_skipped counts them and row_errors lists the first 100 with their row number (the header is row 1). Uploads are fingerprinted like the enriched import; an unchanged file is not imported twice unless force=true.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Import stock data from an uploaded CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Import even if this exact file was imported before (default: false)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV imported, or already imported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file or invalid force parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import CSV",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/import-enriched": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import rows from ./stock_data_enriched.csv into the database. The file's SHA-256 fingerprint is recorded; importing an unchanged file again returns status already_imported without writing anything unless force=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Import enriched stock data from default CSV",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Import even if this exact file was imported before (default: false)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV imported, or already imported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid force parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import CSV",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/integrity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Counts sentiments and indicators whose stock no longer exists, and stocks without sentiments, without indicators or without either (incomplete_stocks). Such rows are left behind by interrupted imports or manual SQL. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Check referential integrity",
+                "responses": {
+                    "200": {
+                        "description": "Problem counts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to check integrity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/meta": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report when the data was last imported and extracted, the latest complete dataset version, the total row count and the age of the data in hours. data_as_of is the later of the last import and extraction (the last modification when neither is recorded); stale is set once it is older than EXTRACT_STALE_AFTER. The response is not cached, so staleness_hours is current.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get data freshness",
+                "responses": {
+                    "200": {
+                        "description": "Data freshness",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/service.DataMeta"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve data freshness",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/movers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stocks with the largest positive (up) or negative (down) target change, optionally limited to records dated within a window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get the top movers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric to rank by: target_delta | final_score (default: target_delta)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "up (largest positive) | down (largest negative) (default: up)",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest date (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of stocks to return, 1-100 (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top movers",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid metric, direction, date window or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get top movers",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/orphans": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the sentiments and indicators whose stock no longer exists and, with incomplete=true, the stocks missing their sentiments or indicators. Requires the admin role. Call with dry_run=true first to get the counts and a confirmation token, then repeat without dry_run and with the token in the X-Confirmation-Token header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Delete orphaned sentiments and indicators",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also delete the stocks without sentiments or without indicators (default: false)",
+                        "name": "incomplete",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only count the rows and issue a confirmation token (default: false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token from the dry run (required unless dry_run=true)",
+                        "name": "X-Confirmation-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run result or deletion summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters or confirmation token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the data points matching every given criterion, together with their sentiments, indicators, notes and tag links (ticker history is kept). Call with dry_run=true first: it returns the matching count and a confirmation token, which the delete must send back in the X-Confirmation-Token header before it expires. The token is rejected if the matching rows changed in between.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Delete the stocks in a cluster, dataset version or date range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cluster id",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Dataset version ID",
+                        "name": "dataset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest record date, inclusive (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest record date, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only count the matching rows and issue a confirmation token (default: false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token from the dry run (required unless dry_run=true)",
+                        "name": "X-Confirmation-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run result or deletion summary",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scope or confirmation token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/stats/{ticker}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the data point count and the earliest and latest date of a stock ticker. A ticker without data points has a count of 0 and null times.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stock statistics by ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock statistics",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/repository.TickerStats"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve statistics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/tables": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Wipes every data table (stocks, sentiments, indicators, history, notes, tags, dataset versions, import fingerprints) with TRUNCATE. Requires the admin role. Call with dry_run=true first to get the per-table row counts and a confirmation token, then repeat without dry_run and with the token in the X-Confirmation-Token header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Empty all tables",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only count the rows and issue a confirmation token (default: false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token from the dry run (required unless dry_run=true)",
+                        "name": "X-Confirmation-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run result or tables emptied",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid confirmation token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to empty tables",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all tag names that have been attached to stocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get tags",
+                "responses": {
+                    "200": {
+                        "description": "List of tags",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tags",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/ticker/{ticker}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific stock record by its ticker symbol",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stock by ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/ticker/{ticker}/consensus": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Takes each brokerage's latest rating of the ticker from its history and aggregates them (grouped SQL) into Buy/Hold/Sell counts and a mean price target",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get the brokerage consensus for a ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consensus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "No ratings recorded for ticker",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get consensus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/ticker/{ticker}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the dated final_score, targets and ratings recorded for a ticker, oldest first, for charting",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get a ticker's score history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest date (YYYY-MM-DD, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticker history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker or date range",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/ticker/{ticker}/moving-averages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rolling 7/30/90 day means of selected indicators over the ticker's indicator history, computed with SQL window functions. Series are keyed by indicator name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get rolling means of a ticker's indicators",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated indicator names (default: all indicators)",
+                        "name": "indicators",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Moving averages by indicator",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker or indicator",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compute moving averages",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/ticker/{ticker}/similar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Nearest neighbors of a ticker within its cluster by cosine distance over normalized indicator vectors, closest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Find comparable stocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock ticker symbol",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of neighbors (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Similar stocks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticker or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific stock record by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Get stock by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: notes",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock found, with ETag and Last-Modified headers for conditional writes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing stock record. Only fields present in the body are changed; omitted fields keep their current values. final_score is recomputed from the resulting indicators and sentiments. With If-Match (an ETag from GET /stocks/{id}) or If-Unmodified-Since the update only applies while the stock is unchanged, and a stale client gets 412",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Update stock by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the stock must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date the stock must not have been modified after",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated stock information",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.StockUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "The stock was modified since the client read it",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a specific stock record by its ID. With If-Match or If-Unmodified-Since the delete only applies while the stock is unchanged, and a stale client gets 412",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Delete stock by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the stock must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date the stock must not have been modified after",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "The stock was modified since the client read it",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/cluster": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Manually move a stock to another cluster. The change is recorded in the cluster audit trail with the given reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clusters"
+                ],
+                "summary": "Override a stock's cluster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target cluster and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.ClusterAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cluster reassigned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/cluster/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Audit trail of manual cluster overrides for a stock, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clusters"
+                ],
+                "summary": "Get a stock's cluster override history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cluster overrides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/indicators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the numerical indicators of a stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "indicators"
+                ],
+                "summary": "List stock indicators",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of indicators",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add one numerical indicator to a stock and recalculate its final score",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "indicators"
+                ],
+                "summary": "Add a stock indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Indicator",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.NumericalIndicatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Indicator added successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "The stock already has an indicator with this name",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/indicators/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the values of one numerical indicator of a stock, addressed by name, and recalculate its final score",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "indicators"
+                ],
+                "summary": "Correct a stock indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Indicator name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Indicator values",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.NumericalIndicatorUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Indicator updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock or indicator not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove one numerical indicator from a stock and recalculate its final score",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "indicators"
+                ],
+                "summary": "Delete a stock indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Indicator name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Indicator deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock or indicator not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the analyst notes recorded for a stock, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List stock notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of notes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record a free-text note next to a stock. Authenticated callers are recorded as the author; otherwise author is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Add a stock note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Note created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/notes/{note_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the body of a note. The author and creation time are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Edit a stock note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "note_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a note from a stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete a stock note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "note_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid note ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/percentile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Where a stock's final_score and each normalized indicator sit percentile-wise (0-100) within a cluster, computed with SQL window functions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get a stock's percentile ranks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cluster to rank against (default: the stock's own cluster)",
+                        "name": "cluster",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Percentile ranks",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID or cluster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/sentiments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the rating sentiments of a stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sentiments"
+                ],
+                "summary": "List stock sentiments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of sentiments",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add one rating sentiment to a stock, score it with the rating rubric and recalculate the final score",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sentiments"
+                ],
+                "summary": "Add a stock sentiment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sentiment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.RatingSentimentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sentiment added successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "The stock already has a sentiment with this name",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/sentiments/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the rating and scores of one sentiment of a stock, addressed by name, and recalculate its final score",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sentiments"
+                ],
+                "summary": "Correct a stock sentiment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sentiment name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sentiment values",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.RatingSentimentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sentiment updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock or sentiment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove one rating sentiment from a stock and recalculate its final score",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sentiments"
+                ],
+                "summary": "Delete a stock sentiment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sentiment name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sentiment deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock or sentiment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/tags": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Attach one or more tags (e.g. \"earnings-week\", \"review\") to a stock. Tags are lower-cased, spaces become dashes, and unknown tags are created",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Tag a stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to attach",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock tagged successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to tag stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stocks/{id}/tags/{tag}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Detach a tag from a stock. The tag itself is kept for other stocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Untag a stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock UUID (or numeric ID during the transition period)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock untagged successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Stock not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to untag stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the webhook subscriptions (without their secrets) and the scheme for verifying deliveries. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Subscriptions and verification scheme",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a URL that receives the listed events (extraction.completed, extraction.failed) as JSON callbacks carrying the job. Every delivery is signed with HMAC-SHA256 over \"\u003ctimestamp\u003e.\u003cnonce\u003e.\u003craw body\u003e\" using the subscription secret, sent in the X-Webhook-Signature header (v1=\u003chex\u003e) with X-Webhook-Timestamp (Unix seconds) and X-Webhook-Nonce. The secret is only returned by this call; the response also describes the verification and the replay window consumers should enforce (NOTIFY_WEBHOOK_TOLERANCE). Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Subscribe a URL to webhook events",
+                "parameters": [
+                    {
+                        "description": "Callback URL and events",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/validators.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription, secret and verification scheme",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or events",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop the deliveries of a webhook subscription. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscription deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket that pushes a JSON text message for every stock data point created, updated or deleted through the API, so dashboards can refresh without polling GET /stocks. Messages carry type (created, updated, deleted), id, uuid, ticker and at; creates and updates made through the stock endpoints also carry the record as data. Bulk changes (imports, purges, rollbacks, wipes) are sent as one reloaded message with the reason and the number of rows, and call for a refetch. A client that falls too far behind receives reloaded with reason \"lagged\" and is disconnected. Idle connections receive a keep-alive message every 15 seconds. Messages sent by the client are ignored.",
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Stream stock changes over a WebSocket",
+                "responses": {
+                    "101": {
+                        "description": "Switching to the WebSocket protocol",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a WebSocket handshake",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Reports healthy when the database answers a ping. While the server runs degraded (the database was unreachable at startup and is being reconnected in the background) or the database stops answering, it reports unhealthy with 503",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Service healthy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service unhealthy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Pings the database and, when it answers, reports the connection pool statistics (open, in use and idle connections, waits) and the migration status (tables and columns missing from the database). Reports not ready with 503 when the database is unreachable; an incomplete migration is reported in migration without failing the check",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "Service ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Database unreachable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ clusters }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "models.Note": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NumericalIndicator": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "norm_value": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.RatingSentiment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "norm_rating_score": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "rating_score": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StockDataPoint": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "integer"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "contributions": {
+                    "description": "Computed per weighted indicator/sentiment name (weight x normalized value) when the filter is asked\nfor contributions; they add up to WeightedScore",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dataset_version_id": {
+                    "description": "Import run that last wrote the row; nil for rows created through the API",
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "final_score": {
+                    "type": "number"
+                },
+                "last_close": {
+                    "type": "number"
+                },
+                "notes": {
+                    "description": "Analyst notes, only loaded on request (?include=notes)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Note"
+                    }
+                },
+                "numerical_indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NumericalIndicator"
+                    }
+                },
+                "rating_from": {
+                    "type": "string"
+                },
+                "rating_sentiments": {
+                    "description": "Relations",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingSentiment"
+                    }
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "target_delta": {
+                    "type": "number"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "weighted_score": {
+                    "description": "Computed field from queries (not persisted)\nNo gorm tag - GORM will map weighted_score column (snake_case) to WeightedScore field (PascalCase) automatically\nThis field is never written to the database, only populated from SELECT queries",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.DatabaseStats": {
+            "type": "object",
+            "properties": {
+                "cluster_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_records": {
+                    "type": "integer"
+                },
+                "unique_companies": {
+                    "type": "integer"
+                },
+                "unique_tickers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.TickerStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "earliest_time": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "latest_time": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "ticker": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.BatchCreateResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BatchItemResult"
+                    }
+                }
+            }
+        },
+        "service.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.StockDataPoint"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.DataMeta": {
+            "type": "object",
+            "properties": {
+                "data_as_of": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "dataset_version": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "last_extracted_at": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "last_imported_at": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "stale_after_hours": {
+                    "type": "number"
+                },
+                "staleness_hours": {
+                    "type": "number",
+                    "x-nullable": true
+                },
+                "total_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.DefaultWeightProfile": {
+            "type": "object",
+            "properties": {
+                "default_weight": {
+                    "type": "number"
+                },
+                "weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "service.ScoringConfigDocument": {
+            "type": "object",
+            "properties": {
+                "default_weights": {
+                    "$ref": "#/definitions/service.DefaultWeightProfile"
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "indicators": {
+                    "$ref": "#/definitions/validators.ScoringIndicators"
+                },
+                "rating_rubric": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.RatingRubricRequest"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weight_profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightProfileRequest"
+                    }
+                }
+            }
+        },
+        "validators.BulkClusterAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "cluster",
+                "reason",
+                "tickers"
+            ],
+            "properties": {
+                "cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1
+                },
+                "tickers": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "validators.ClusterAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "cluster",
+                "reason"
+            ],
+            "properties": {
+                "cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.FilterRequest": {
+            "type": "object",
+            "properties": {
+                "aggregate": {
+                    "type": "boolean"
+                },
+                "contributions": {
+                    "type": "boolean"
+                },
+                "grouping_column": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "action",
+                        "rating_to",
+                        "rating_from"
+                    ]
+                },
+                "grouping_value": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "max_final_score": {
+                    "type": "number"
+                },
+                "max_last_close": {
+                    "type": "number"
+                },
+                "max_target_delta": {
+                    "type": "number"
+                },
+                "max_target_from": {
+                    "type": "number"
+                },
+                "max_target_to": {
+                    "type": "number"
+                },
+                "min_final_score": {
+                    "description": "Inclusive bounds on the numeric columns; unset bounds are open",
+                    "type": "number"
+                },
+                "min_last_close": {
+                    "type": "number"
+                },
+                "min_target_delta": {
+                    "type": "number"
+                },
+                "min_target_from": {
+                    "type": "number"
+                },
+                "min_target_to": {
+                    "type": "number"
+                },
+                "numerical_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                },
+                "order": {
+                    "type": "string",
+                    "enum": [
+                        "asc",
+                        "desc"
+                    ]
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "per_page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "rating_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                },
+                "relations": {
+                    "type": "string",
+                    "enum": [
+                        "full",
+                        "scoring",
+                        "none"
+                    ]
+                },
+                "sort_by": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "validators.NoteRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.NotificationTestRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "slack"
+                    ]
+                }
+            }
+        },
+        "validators.NumericalIndicatorRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "norm_value",
+                "value"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "norm_value": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "validators.NumericalIndicatorUpdateRequest": {
+            "type": "object",
+            "required": [
+                "norm_value",
+                "value"
+            ],
+            "properties": {
+                "norm_value": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "validators.PreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "default_cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "numerical_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                },
+                "rating_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                }
+            }
+        },
+        "validators.RatingRubricRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "norm_score",
+                "score",
+                "term"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "rating",
+                        "action"
+                    ]
+                },
+                "norm_score": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "term": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.RatingSentimentRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "norm_rating_score",
+                "rating",
+                "rating_score"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "norm_rating_score": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "rating_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "validators.RatingSentimentUpdateRequest": {
+            "type": "object",
+            "required": [
+                "norm_rating_score",
+                "rating",
+                "rating_score"
+            ],
+            "properties": {
+                "norm_rating_score": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "rating_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "validators.ScoringConfigRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "indicators": {
+                    "$ref": "#/definitions/validators.ScoringIndicators"
+                },
+                "rating_rubric": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/validators.RatingRubricRequest"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weight_profiles": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightProfileRequest"
+                    }
+                }
+            }
+        },
+        "validators.ScoringIndicators": {
+            "type": "object",
+            "properties": {
+                "numerical": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "validators.StockCreateRequest": {
+            "type": "object",
+            "required": [
+                "company",
+                "date",
+                "ticker"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "brokerage": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "company": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "date": {
+                    "type": "string"
+                },
+                "last_close": {
+                    "type": "number"
+                },
+                "numerical_indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.NumericalIndicatorRequest"
+                    }
+                },
+                "rating_from": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "rating_sentiments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.RatingSentimentRequest"
+                    }
+                },
+                "rating_to": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "target_delta": {
+                    "type": "number"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.StockExtractRequest": {
+            "type": "object",
+            "required": [
+                "max_pages"
+            ],
+            "properties": {
+                "max_pages": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "validators.StockUpdateRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "brokerage": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "company": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "last_close": {
+                    "type": "number"
+                },
+                "numerical_indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.NumericalIndicatorRequest"
+                    }
+                },
+                "rating_from": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "rating_sentiments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.RatingSentimentRequest"
+                    }
+                },
+                "rating_to": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "target_delta": {
+                    "type": "number"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.TagRequest": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "validators.WebhookSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "validators.WeightProfileRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "default_cluster": {
+                    "type": "integer",
+                    "minimum": -1
+                },
+                "numerical_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                },
+                "rating_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.WeightRequest"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "validators.WeightRequest": {
+            "type": "object",
+            "required": [
+                "indicator_name"
+            ],
+            "properties": {
+                "indicator_name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "One of the AUTH_API_KEYS, with the role configured for it",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT signed with AUTH_JWT_SECRET, sent as \"Bearer \u003ctoken\u003e\"; its role claim is checked against the route group's role",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfov1 holds exported Swagger Info so clients can modify it
+var SwaggerInfov1 = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8888",
+	BasePath:         "/",
+	Schemes:          []string{"http", "https"},
+	Title:            "Stock Data Extractor API",
+	Description:      "A RESTful API for managing stock data with full CRUD operations",
+	InfoInstanceName: "v1",
+	SwaggerTemplate:  docTemplatev1,
+}
+
+func init() {
+	swag.Register(SwaggerInfov1.InstanceName(), SwaggerInfov1)
+}
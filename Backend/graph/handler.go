@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"dataextractor/apperrors"
+	"dataextractor/service"
+	"dataextractor/validators"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// NewHandler serves GraphQL queries over GET and POST. Resolver errors carry the same error title
+// as the REST responses in extensions.error.
+func NewHandler(stockService service.StockServiceInterface, pageRules validators.PageRules) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: NewResolver(stockService, pageRules)}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		var appErr *apperrors.Error
+		if errors.As(err, &appErr) {
+			if gqlErr.Extensions == nil {
+				gqlErr.Extensions = map[string]interface{}{}
+			}
+			gqlErr.Extensions["error"] = apperrors.Title(err)
+		}
+		return gqlErr
+	})
+	return srv
+}
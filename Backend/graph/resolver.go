@@ -0,0 +1,67 @@
+// Package graph serves the GraphQL API at /api/v1/graphql over the stock model. The executable
+// schema (generated.go, model/models_gen.go) and the resolver signatures in schema.resolvers.go
+// are produced by gqlgen from schema.graphqls and ../gqlgen.yml; run go generate in the module
+// root after editing the schema.
+package graph
+
+import (
+	"dataextractor/graph/model"
+	"dataextractor/repository"
+	"dataextractor/service"
+	"dataextractor/validators"
+)
+
+// Resolver is the root resolver; field resolvers answer through the same service layer as the
+// REST controller, so validation, caching and scoring stay in one place
+type Resolver struct {
+	stockService service.StockServiceInterface
+	pageRules    validators.PageRules
+}
+
+// NewResolver creates the root resolver over stockService; pageRules bound the stocks query like
+// the REST list routes
+func NewResolver(stockService service.StockServiceInterface, pageRules validators.PageRules) *Resolver {
+	return &Resolver{stockService: stockService, pageRules: pageRules}
+}
+
+// stockQuery converts the GraphQL filter to the repository criteria
+func stockQuery(filter *model.StockFilter) repository.StockQuery {
+	if filter == nil {
+		return repository.StockQuery{}
+	}
+	query := repository.StockQuery{
+		Cluster:       filter.Cluster,
+		Tags:          filter.Tags,
+		From:          filter.From,
+		To:            filter.To,
+		MinFinalScore: filter.MinFinalScore,
+		MaxFinalScore: filter.MaxFinalScore,
+	}
+	for _, field := range []struct {
+		dst *string
+		src *string
+	}{
+		{&query.Company, filter.Company},
+		{&query.Action, filter.Action},
+		{&query.RatingTo, filter.RatingTo},
+		{&query.Brokerage, filter.Brokerage},
+	} {
+		if field.src != nil {
+			*field.dst = *field.src
+		}
+	}
+	return query
+}
+
+// selected reports whether name passes the names argument of a nested list; no names selects all
+func selected(names []string, name string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
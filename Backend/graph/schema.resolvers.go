@@ -0,0 +1,122 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.85
+
+import (
+	"context"
+	"dataextractor/apperrors"
+	"dataextractor/graph/model"
+	"dataextractor/models"
+	"dataextractor/repository"
+	"dataextractor/service"
+	"dataextractor/validators"
+)
+
+// Stocks is the resolver for the stocks field.
+func (r *queryResolver) Stocks(ctx context.Context, filter *model.StockFilter, page *int, perPage *int, sortBy *string, order *model.SortOrder) (*service.PagedGroupedResults, error) {
+	params := validators.PageParams{}
+	if page != nil {
+		params.Page = *page
+	}
+	if perPage != nil {
+		params.PerPage = *perPage
+	}
+	if sortBy != nil {
+		params.SortBy = *sortBy
+	}
+	if order != nil {
+		params.Order = string(*order)
+	}
+	params.ApplyDefaults(r.pageRules)
+	if err := params.Validate(r.pageRules); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid page parameters")
+	}
+
+	results, err := r.stockService.WithContext(ctx).QueryStocks(stockQuery(filter), repository.ListOptions{
+		Page: params.Page, PerPage: params.PerPage, SortBy: params.SortBy, Order: params.Order,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *queryResolver) Stock(ctx context.Context, uuid *string, ticker *string) (*models.StockDataPoint, error) {
+	stockService := r.stockService.WithContext(ctx)
+	var (
+		stock *models.StockDataPoint
+		err   error
+	)
+	switch {
+	case (uuid == nil) == (ticker == nil):
+		return nil, apperrors.Validation("exactly one of uuid and ticker is required")
+	case uuid != nil:
+		var id uint
+		if id, err = stockService.ResolveID(*uuid); err == nil {
+			stock, err = stockService.GetByID(id)
+		}
+	default:
+		// the ticker lookup does not load tags, so the stock is read again by ID
+		if stock, err = stockService.GetByTicker(*ticker); err == nil {
+			stock, err = stockService.GetByID(stock.ID)
+		}
+	}
+	if apperrors.KindOf(err) == apperrors.KindNotFound {
+		return nil, nil
+	}
+	return stock, err
+}
+
+// Clusters is the resolver for the clusters field.
+func (r *queryResolver) Clusters(ctx context.Context) ([]int, error) {
+	return r.stockService.WithContext(ctx).GetUniqueClusters()
+}
+
+// Companies is the resolver for the companies field.
+func (r *queryResolver) Companies(ctx context.Context) ([]string, error) {
+	return r.stockService.WithContext(ctx).GetUniqueCompanies()
+}
+
+// Tags is the resolver for the tags field.
+func (r *stockResolver) Tags(ctx context.Context, obj *models.StockDataPoint) ([]string, error) {
+	names := make([]string, len(obj.Tags))
+	for i, tag := range obj.Tags {
+		names[i] = tag.Name
+	}
+	return names, nil
+}
+
+// RatingSentiments is the resolver for the ratingSentiments field.
+func (r *stockResolver) RatingSentiments(ctx context.Context, obj *models.StockDataPoint, names []string) ([]*models.RatingSentiment, error) {
+	sentiments := []*models.RatingSentiment{}
+	for i := range obj.RatingSentiments {
+		if selected(names, obj.RatingSentiments[i].Name) {
+			sentiments = append(sentiments, &obj.RatingSentiments[i])
+		}
+	}
+	return sentiments, nil
+}
+
+// NumericalIndicators is the resolver for the numericalIndicators field.
+func (r *stockResolver) NumericalIndicators(ctx context.Context, obj *models.StockDataPoint, names []string) ([]*models.NumericalIndicator, error) {
+	indicators := []*models.NumericalIndicator{}
+	for i := range obj.NumericalIndicators {
+		if selected(names, obj.NumericalIndicators[i].Name) {
+			indicators = append(indicators, &obj.NumericalIndicators[i])
+		}
+	}
+	return indicators, nil
+}
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Stock returns StockResolver implementation.
+func (r *Resolver) Stock() StockResolver { return &stockResolver{r} }
+
+type queryResolver struct{ *Resolver }
+type stockResolver struct{ *Resolver }
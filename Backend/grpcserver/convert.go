@@ -0,0 +1,198 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"dataextractor/models"
+	stockv1 "dataextractor/proto/stock/v1"
+	"dataextractor/service"
+	"dataextractor/validators"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toStock converts a data point to its message
+func toStock(stock *models.StockDataPoint) *stockv1.Stock {
+	msg := &stockv1.Stock{
+		Uuid:                stock.UUID,
+		Ticker:              stock.Ticker,
+		Company:             stock.Company,
+		Action:              stock.Action,
+		Brokerage:           stock.Brokerage,
+		Date:                timestamppb.New(stock.Date),
+		Cluster:             int32(stock.Cluster),
+		TargetFrom:          stock.TargetFrom,
+		TargetTo:            stock.TargetTo,
+		TargetDelta:         stock.TargetDelta,
+		LastClose:           stock.LastClose,
+		RatingFrom:          stock.RatingFrom,
+		RatingTo:            stock.RatingTo,
+		FinalScore:          stock.FinalScore,
+		WeightedScore:       stock.WeightedScore,
+		CreatedAt:           timestamppb.New(stock.CreatedAt),
+		UpdatedAt:           timestamppb.New(stock.UpdatedAt),
+		Tags:                make([]string, len(stock.Tags)),
+		RatingSentiments:    make([]*stockv1.RatingSentiment, len(stock.RatingSentiments)),
+		NumericalIndicators: make([]*stockv1.NumericalIndicator, len(stock.NumericalIndicators)),
+	}
+	for i, tag := range stock.Tags {
+		msg.Tags[i] = tag.Name
+	}
+	for i, s := range stock.RatingSentiments {
+		msg.RatingSentiments[i] = &stockv1.RatingSentiment{
+			Uuid: s.UUID, Name: s.Name, Rating: s.Rating, RatingScore: s.RatingScore, NormRatingScore: s.NormRatingScore,
+		}
+	}
+	for i, n := range stock.NumericalIndicators {
+		msg.NumericalIndicators[i] = &stockv1.NumericalIndicator{
+			Uuid: n.UUID, Name: n.Name, Value: n.Value, NormValue: n.NormValue,
+		}
+	}
+	return msg
+}
+
+// toStockPage converts a filtered page to its message
+func toStockPage(result service.PagedGroupedResults) *stockv1.StockPage {
+	page := &stockv1.StockPage{
+		Items: make([]*stockv1.Stock, len(result.Items)),
+		Pagination: &stockv1.Pagination{
+			Count:       int32(result.Count),
+			TotalCount:  result.TotalCount,
+			Page:        int32(result.Page),
+			PerPage:     int32(result.PerPage),
+			TotalPages:  int32(result.TotalPages),
+			HasNext:     result.HasNext,
+			SortBy:      result.SortBy,
+			Order:       result.Order,
+			WeightsHash: result.WeightsHash,
+		},
+	}
+	for i := range result.Items {
+		page.Items[i] = toStock(&result.Items[i])
+	}
+	return page
+}
+
+// createRequest converts a stock input to the REST create request, validated by the service
+func createRequest(input *stockv1.StockInput) *validators.StockCreateRequest {
+	request := &validators.StockCreateRequest{StockBase: validators.StockBase{
+		Ticker:              input.GetTicker(),
+		Company:             input.GetCompany(),
+		Action:              input.GetAction(),
+		Brokerage:           input.GetBrokerage(),
+		TargetFrom:          input.GetTargetFrom(),
+		TargetTo:            input.GetTargetTo(),
+		TargetDelta:         input.GetTargetDelta(),
+		LastClose:           input.GetLastClose(),
+		RatingFrom:          input.GetRatingFrom(),
+		RatingTo:            input.GetRatingTo(),
+		RatingSentiments:    sentimentRequests(input.GetRatingSentiments()),
+		NumericalIndicators: indicatorRequests(input.GetNumericalIndicators()),
+	}}
+	if input.Date != nil {
+		request.Date = input.GetDate().AsTime()
+	}
+	if input.Cluster != nil {
+		cluster := int(input.GetCluster())
+		request.Cluster = &cluster
+	}
+	return request
+}
+
+// updateRequest converts the set fields of an update to the REST update request
+func updateRequest(id uint, req *stockv1.UpdateStockRequest) *validators.StockUpdateRequest {
+	request := &validators.StockUpdateRequest{
+		ID:                  id,
+		Ticker:              req.Ticker,
+		Company:             req.Company,
+		Action:              req.Action,
+		Brokerage:           req.Brokerage,
+		TargetFrom:          req.TargetFrom,
+		TargetTo:            req.TargetTo,
+		TargetDelta:         req.TargetDelta,
+		LastClose:           req.LastClose,
+		RatingFrom:          req.RatingFrom,
+		RatingTo:            req.RatingTo,
+		RatingSentiments:    sentimentRequests(req.GetRatingSentiments()),
+		NumericalIndicators: indicatorRequests(req.GetNumericalIndicators()),
+	}
+	if req.Date != nil {
+		date := req.GetDate().AsTime()
+		request.Date = &date
+	}
+	if req.Cluster != nil {
+		cluster := int(req.GetCluster())
+		request.Cluster = &cluster
+	}
+	return request
+}
+
+// sentimentRequests converts sentiment messages; nil stays nil so an update keeps the current ones
+func sentimentRequests(sentiments []*stockv1.RatingSentiment) []validators.RatingSentimentRequest {
+	if len(sentiments) == 0 {
+		return nil
+	}
+	requests := make([]validators.RatingSentimentRequest, len(sentiments))
+	for i, s := range sentiments {
+		requests[i] = validators.RatingSentimentRequest{
+			Name: s.GetName(), Rating: s.GetRating(), RatingScore: s.GetRatingScore(), NormRatingScore: s.GetNormRatingScore(),
+		}
+	}
+	return requests
+}
+
+// indicatorRequests converts indicator messages; nil stays nil so an update keeps the current ones
+func indicatorRequests(indicators []*stockv1.NumericalIndicator) []validators.NumericalIndicatorRequest {
+	if len(indicators) == 0 {
+		return nil
+	}
+	requests := make([]validators.NumericalIndicatorRequest, len(indicators))
+	for i, n := range indicators {
+		requests[i] = validators.NumericalIndicatorRequest{Name: n.GetName(), Value: n.GetValue(), NormValue: n.GetNormValue()}
+	}
+	return requests
+}
+
+// filterRequest converts a filter message to the REST filter request; ranges fill the min/max
+// bound of their column
+func filterRequest(req *stockv1.FilterClusterRequest) (*validators.FilterRequest, error) {
+	request := &validators.FilterRequest{
+		GroupingColumn:   req.GetGroupingColumn(),
+		GroupingValue:    req.GetGroupingValue(),
+		SortBy:           req.GetSortBy(),
+		Order:            req.GetOrder(),
+		Page:             int(req.GetPage()),
+		PerPage:          int(req.GetPerPage()),
+		Tags:             req.GetTags(),
+		NumericalWeights: weightRequests(req.GetNumericalWeights()),
+		RatingWeights:    weightRequests(req.GetRatingWeights()),
+	}
+	for _, r := range req.GetRanges() {
+		var min, max **float64
+		switch r.GetColumn() {
+		case "final_score":
+			min, max = &request.MinFinalScore, &request.MaxFinalScore
+		case "target_to":
+			min, max = &request.MinTargetTo, &request.MaxTargetTo
+		case "target_from":
+			min, max = &request.MinTargetFrom, &request.MaxTargetFrom
+		case "target_delta":
+			min, max = &request.MinTargetDelta, &request.MaxTargetDelta
+		case "last_close":
+			min, max = &request.MinLastClose, &request.MaxLastClose
+		default:
+			return nil, fmt.Errorf("invalid range column %q: allowed values are [final_score target_to target_from target_delta last_close]", r.GetColumn())
+		}
+		*min, *max = r.Min, r.Max
+	}
+	return request, nil
+}
+
+// weightRequests converts weight messages
+func weightRequests(weights []*stockv1.Weight) []validators.WeightRequest {
+	requests := make([]validators.WeightRequest, len(weights))
+	for i, w := range weights {
+		requests[i] = validators.WeightRequest{IndicatorName: w.GetName(), Weight: w.GetWeight()}
+	}
+	return requests
+}
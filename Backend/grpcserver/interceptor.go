@@ -0,0 +1,81 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dataextractor/auth"
+	"dataextractor/config"
+	"dataextractor/models"
+	stockv1 "dataextractor/proto/stock/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// writeMethods need the write role of the stocks group; every other RPC only reads
+var writeMethods = map[string]bool{
+	stockv1.StockService_CreateStock_FullMethodName: true,
+	stockv1.StockService_UpdateStock_FullMethodName: true,
+	stockv1.StockService_DeleteStock_FullMethodName: true,
+}
+
+// requireDatabase answers Unavailable while the server is degraded, like RequireDatabase
+func requireDatabase(connected func() bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !connected() {
+			return nil, status.Error(codes.Unavailable, "the database is unavailable, retry later")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authorize resolves the caller from the authorization or x-api-key metadata (x-actor and x-role
+// when trustHeader is enabled, else the anonymous role) and holds each RPC to the read or write role
+// AUTH_GROUP_ROLES sets for the stocks group. The actor is stored for write attribution.
+func authorize(cfg config.AuthConfig, trustHeader bool) grpc.UnaryServerInterceptor {
+	policy := auth.PolicyFor(auth.AccessPolicies(cfg.GroupRoles, nil), "stocks")
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		header := func(key string) string {
+			if values := md.Get(key); len(values) > 0 {
+				return strings.TrimSpace(values[0])
+			}
+			return ""
+		}
+
+		actor, role, err := auth.Authenticate(header("authorization"), header("x-api-key"), cfg.JWTSecret, cfg.APIKeys, time.Now())
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if actor == "" && trustHeader {
+			actor = header("x-actor")
+		}
+		if role == "" && trustHeader {
+			role = header("x-role")
+		}
+		if role == "" && actor == "" {
+			role = cfg.AnonymousRole
+		}
+
+		required := policy.Read
+		if writeMethods[info.FullMethod] {
+			required = policy.Write
+		}
+		if !auth.HasRole(role, required) {
+			if role == "" {
+				return nil, status.Error(codes.Unauthenticated, "authentication required (authorization: Bearer token or x-api-key)")
+			}
+			return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("this operation requires the %s role", required))
+		}
+
+		if actor != "" {
+			ctx = models.WithActor(ctx, actor)
+		}
+		return handler(ctx, req)
+	}
+}
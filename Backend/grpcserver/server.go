@@ -0,0 +1,234 @@
+// Package grpcserver serves the gRPC API of proto/stock/v1/stock.proto next to the Gin HTTP server.
+// RPCs answer through the same service layer as the REST controller, and callers authenticate with
+// the same credentials (authorization and x-api-key metadata) and roles.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"dataextractor/apperrors"
+	"dataextractor/config"
+	"dataextractor/models"
+	stockv1 "dataextractor/proto/stock/v1"
+	"dataextractor/repository"
+	"dataextractor/service"
+	"dataextractor/validators"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements stockv1.StockServiceServer over the stock service
+type Server struct {
+	stockv1.UnimplementedStockServiceServer
+
+	stockService service.StockServiceInterface
+	validator    *validators.StockValidator
+	pageRules    validators.PageRules
+}
+
+// NewServer creates a gRPC server exposing stockService, with the authentication, role and
+// database checks of the /api/v1 routes
+func NewServer(stockService service.StockServiceInterface, cfg *config.AppConfig) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requireDatabase(stockService.DatabaseConnected),
+		authorize(cfg.Auth, cfg.Server.TrustActorHeader),
+	))
+	stockv1.RegisterStockServiceServer(srv, &Server{
+		stockService: stockService,
+		validator:    validators.NewStockValidator(),
+		pageRules: validators.PageRules{
+			DefaultPerPage: cfg.Server.DefaultPerPage,
+			MaxPerPage:     cfg.Server.MaxPerPage,
+			DefaultSortBy:  "date",
+			SortColumns:    repository.AllowedSortColumns,
+		},
+	})
+	return srv
+}
+
+// CreateStock creates a stock; an unset cluster places it at the nearest centroid
+func (s *Server) CreateStock(ctx context.Context, req *stockv1.CreateStockRequest) (*stockv1.Stock, error) {
+	if req.GetStock() == nil {
+		return nil, status.Error(codes.InvalidArgument, "stock is required")
+	}
+	stock, err := s.stockService.WithContext(ctx).Create(createRequest(req.GetStock()))
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to create stock"))
+	}
+	return toStock(stock), nil
+}
+
+// GetStock returns a stock by UUID or ticker
+func (s *Server) GetStock(ctx context.Context, req *stockv1.GetStockRequest) (*stockv1.Stock, error) {
+	stockService := s.stockService.WithContext(ctx)
+	var stock *models.StockDataPoint
+	var err error
+	switch key := req.GetKey().(type) {
+	case *stockv1.GetStockRequest_Uuid:
+		var id uint
+		if id, err = stockService.ResolveID(key.Uuid); err == nil {
+			stock, err = stockService.GetByID(id)
+		}
+	case *stockv1.GetStockRequest_Ticker:
+		// the ticker lookup does not load tags, so the stock is read again by ID
+		if stock, err = stockService.GetByTicker(key.Ticker); err == nil {
+			stock, err = stockService.GetByID(stock.ID)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "uuid or ticker is required")
+	}
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to get stock"))
+	}
+	return toStock(stock), nil
+}
+
+// UpdateStock applies the set fields of the request, conditionally on if_match
+func (s *Server) UpdateStock(ctx context.Context, req *stockv1.UpdateStockRequest) (*stockv1.Stock, error) {
+	stockService := s.stockService.WithContext(ctx)
+	id, err := stockService.ResolveID(req.GetUuid())
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to resolve stock ID"))
+	}
+	stock, err := stockService.Update(updateRequest(id, req), service.WritePrecondition{IfMatch: req.GetIfMatch()})
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to update stock"))
+	}
+	return toStock(stock), nil
+}
+
+// DeleteStock deletes a stock, conditionally on if_match
+func (s *Server) DeleteStock(ctx context.Context, req *stockv1.DeleteStockRequest) (*stockv1.DeleteStockResponse, error) {
+	stockService := s.stockService.WithContext(ctx)
+	id, err := stockService.ResolveID(req.GetUuid())
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to resolve stock ID"))
+	}
+	if err := stockService.Delete(id, service.WritePrecondition{IfMatch: req.GetIfMatch()}); err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to delete stock"))
+	}
+	return &stockv1.DeleteStockResponse{}, nil
+}
+
+// ListClusters returns the distinct clusters
+func (s *Server) ListClusters(ctx context.Context, _ *stockv1.ListClustersRequest) (*stockv1.ListClustersResponse, error) {
+	clusters, err := s.stockService.WithContext(ctx).GetUniqueClusters()
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to get clusters"))
+	}
+	resp := &stockv1.ListClustersResponse{Clusters: make([]int32, len(clusters))}
+	for i, cluster := range clusters {
+		resp.Clusters[i] = int32(cluster)
+	}
+	return resp, nil
+}
+
+// FilterCluster returns one page of a cluster with the defaults, caps and weight profiles of the
+// REST cluster filter
+func (s *Server) FilterCluster(ctx context.Context, req *stockv1.FilterClusterRequest) (*stockv1.StockPage, error) {
+	request, err := filterRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.PerPage == 0 {
+		request.PerPage = s.pageRules.DefaultPerPage
+	}
+	request.ApplyDefaults()
+	if err := request.ValidateEnums(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	params := validators.PageParams{Page: request.Page, PerPage: request.PerPage, SortBy: request.SortBy, Order: request.Order}
+	if err := params.Validate(s.pageRules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	bounds, err := request.RangeBounds()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stockService := s.stockService.WithContext(ctx)
+
+	// Like the REST filter: without weights, the caller's saved weights apply, then the default
+	// profile when sorting by weighted_score
+	if len(request.NumericalWeights) == 0 && len(request.RatingWeights) == 0 {
+		if user := models.ActorFromContext(ctx); user != "" {
+			preferences, err := stockService.GetPreferences(user)
+			if err != nil {
+				return nil, statusError(err)
+			}
+			request.NumericalWeights, request.RatingWeights = preferences.NumericalWeights, preferences.RatingWeights
+		}
+		if len(request.NumericalWeights) == 0 && len(request.RatingWeights) == 0 && request.SortsByWeightedScore() {
+			request.NumericalWeights, request.RatingWeights = stockService.DefaultWeights()
+		}
+	}
+
+	ranges := make([]repository.RangeFilter, len(bounds))
+	for i, b := range bounds {
+		ranges[i] = repository.RangeFilter{Column: b.Column, Min: b.Min, Max: b.Max}
+	}
+	numericalWeights := make([]repository.NumericalWeightEntry, len(request.NumericalWeights))
+	for i, w := range request.NumericalWeights {
+		numericalWeights[i] = repository.NumericalWeightEntry{IndicatorName: w.IndicatorName, Weight: w.Weight}
+	}
+	ratingWeights := make([]repository.RatingWeightEntry, len(request.RatingWeights))
+	for i, w := range request.RatingWeights {
+		ratingWeights[i] = repository.RatingWeightEntry{IndicatorName: w.IndicatorName, Weight: w.Weight}
+	}
+
+	result, err := stockService.FilterByClusterGrouped(int(req.GetCluster()), request.GroupingColumn, request.GroupingValue,
+		request.SortBy, request.Order, request.Page, request.PerPage, numericalWeights, ratingWeights, request.Tags, ranges,
+		repository.PreloadFull, false)
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to filter stocks"))
+	}
+	return toStockPage(result), nil
+}
+
+// RankCluster scores every stock of a cluster with the given weights, best first
+func (s *Server) RankCluster(ctx context.Context, req *stockv1.RankClusterRequest) (*stockv1.RankClusterResponse, error) {
+	weights := make([]service.WeightEntry, len(req.GetWeights()))
+	for i, w := range req.GetWeights() {
+		weights[i] = service.WeightEntry{IndicatorName: w.GetName(), Weight: w.GetWeight()}
+	}
+	results, err := s.stockService.WithContext(ctx).RankByWeightedScore(int(req.GetCluster()), weights)
+	if err != nil {
+		return nil, statusError(apperrors.Wrap(err, "failed to rank cluster"))
+	}
+	resp := &stockv1.RankClusterResponse{Results: make([]*stockv1.RankedStock, len(results))}
+	for i := range results {
+		resp.Results[i] = &stockv1.RankedStock{Stock: toStock(&results[i].Stock), Score: results[i].Score}
+	}
+	return resp, nil
+}
+
+// statusError maps an application error to the gRPC status matching its HTTP status
+func statusError(err error) error {
+	if errors.Is(err, service.ErrInvalidID) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	code := codes.Internal
+	switch apperrors.KindOf(err) {
+	case apperrors.KindNotFound:
+		code = codes.NotFound
+	case apperrors.KindValidation:
+		code = codes.InvalidArgument
+	case apperrors.KindConflict:
+		code = codes.AlreadyExists
+	case apperrors.KindUpstream, apperrors.KindUnavailable:
+		code = codes.Unavailable
+	case apperrors.KindUnauthorized:
+		code = codes.Unauthenticated
+	case apperrors.KindTooManyRequests:
+		code = codes.ResourceExhausted
+	case apperrors.KindPreconditionFailed:
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,112 @@
+// Package logging configures the process-wide structured logger and carries the request ID of an
+// API call through its context, so service and repository log lines can be matched to the access
+// log line of the request that produced them.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Formats accepted by New
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// RequestIDKey is the attribute name of the request ID in log lines
+const RequestIDKey = "request_id"
+
+// maxRequestIDLength bounds client-supplied request IDs so they cannot bloat every log line
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+
+// New creates a logger writing to w in format (json or text) at level (debug, info, warn or error)
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatJSON, "":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q (expected json or text)", format)
+}
+
+// Setup makes a logger created by New the default, which also routes the standard log package
+// through it
+func Setup(w io.Writer, format, level string) error {
+	logger, err := New(w, format, level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// ParseLevel converts a level name to its slog level; "warning" is accepted for "warn"
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", level)
+}
+
+// NewRequestID returns a random 128-bit request ID in hex
+func NewRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID reports whether a client-supplied request ID can be reused: 1 to 64 letters,
+// digits, dots, dashes or underscores
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger, with the request ID attached when ctx carries one
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With(RequestIDKey, id)
+	}
+	return slog.Default()
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestNew checks the JSON output, level filtering and rejected settings
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "json", "warn")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger.Info("dropped")
+	logger.Warn("kept", "ticker", "AAPL")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("output is not one JSON line: %q", buf.String())
+	}
+	if line["msg"] != "kept" || line["level"] != "WARN" || line["ticker"] != "AAPL" {
+		t.Errorf("line = %v", line)
+	}
+
+	if _, err := New(&buf, "xml", "info"); err == nil {
+		t.Error("New(xml) error = nil")
+	}
+	if _, err := New(&buf, "text", "verbose"); err == nil {
+		t.Error("New(verbose) error = nil")
+	}
+}
+
+// TestFromContext checks that the request ID is attached only when the context carries one, and
+// that the standard log package goes through the default logger after Setup
+func TestFromContext(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	var buf bytes.Buffer
+	if err := Setup(&buf, "json", "info"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	FromContext(ctx).Info("with id")
+	FromContext(context.Background()).Info("without id")
+	log.Printf("legacy %d", 1)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-1"`) || strings.Contains(lines[1], RequestIDKey) {
+		t.Errorf("request ID attached wrongly:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[2], `"msg":"legacy 1"`) {
+		t.Errorf("log.Printf not routed through the default logger: %s", lines[2])
+	}
+}
+
+// TestValidRequestID checks which client-supplied IDs are reused
+func TestValidRequestID(t *testing.T) {
+	for id, want := range map[string]bool{
+		"3f2a9c":                true,
+		"trace-1_2.3":           true,
+		NewRequestID():          true,
+		"":                      false,
+		"has space":             false,
+		"new\nline":             false,
+		strings.Repeat("a", 65): false,
+	} {
+		if got := ValidRequestID(id); got != want {
+			t.Errorf("ValidRequestID(%q) = %t, want %t", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package metrics keeps in-process counters and latency histograms and exposes them in the
+// Prometheus text exposition format, so the server can be scraped without a metrics agent.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the Prometheus text exposition format served by Handler
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the latency histogram upper bounds in seconds (the Prometheus client defaults)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector writes its metric families in the text exposition format
+type Collector interface {
+	WritePrometheus(w io.Writer)
+}
+
+// Registry holds the collectors exposed on one scrape endpoint
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// Default is the registry served on /metrics
+var Default = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a collector to the registry
+func (r *Registry) Register(c Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// WritePrometheus writes every registered collector in registration order
+func (r *Registry) WritePrometheus(w io.Writer) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.collectors {
+		c.WritePrometheus(w)
+	}
+}
+
+// Handler serves the registry for Prometheus scrapes
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		buf := bufio.NewWriter(w)
+		r.WritePrometheus(buf)
+		buf.Flush()
+	})
+}
+
+// methodStats are the counters of one method
+type methodStats struct {
+	calls   uint64
+	errors  uint64
+	sum     float64
+	buckets []uint64 // non-cumulative counts per upper bound; rendered cumulatively
+}
+
+// MethodMetrics counts calls, errors and latencies per method name. It renders three families:
+// <prefix>_calls_total, <prefix>_errors_total and the <prefix>_call_duration_seconds histogram,
+// each labeled with method; the error rate is errors_total / calls_total.
+type MethodMetrics struct {
+	prefix  string
+	subject string
+	buckets []float64
+
+	mu      sync.Mutex
+	methods map[string]*methodStats
+}
+
+// NewMethodMetrics creates per-method metrics named <namespace>_<subsystem>_*; subject describes
+// what is measured in the HELP lines (e.g. "repository"). Nil buckets select DefaultBuckets.
+func NewMethodMetrics(namespace, subsystem, subject string, buckets []float64) *MethodMetrics {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &MethodMetrics{
+		prefix:  namespace + "_" + subsystem,
+		subject: subject,
+		buckets: sorted,
+		methods: make(map[string]*methodStats),
+	}
+}
+
+// Observe records one call of method that took d and failed when err is not nil
+func (m *MethodMetrics) Observe(method string, d time.Duration, err error) {
+	seconds := d.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.methods[method]
+	if !ok {
+		stats = &methodStats{buckets: make([]uint64, len(m.buckets))}
+		m.methods[method] = stats
+	}
+	stats.calls++
+	if err != nil {
+		stats.errors++
+	}
+	stats.sum += seconds
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			stats.buckets[i]++
+			break
+		}
+	}
+}
+
+// WritePrometheus writes the three metric families with methods in name order
+func (m *MethodMetrics) WritePrometheus(w io.Writer) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.methods))
+	snapshot := make(map[string]methodStats, len(m.methods))
+	for name, stats := range m.methods {
+		names = append(names, name)
+		copied := *stats
+		copied.buckets = append([]uint64(nil), stats.buckets...)
+		snapshot[name] = copied
+	}
+	m.mu.Unlock()
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "# HELP %s_calls_total Total %s calls by method.\n", m.prefix, m.subject)
+	fmt.Fprintf(w, "# TYPE %s_calls_total counter\n", m.prefix)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s_calls_total{method=%s} %d\n", m.prefix, quote(name), snapshot[name].calls)
+	}
+
+	fmt.Fprintf(w, "# HELP %s_errors_total Total %s calls that returned an error, by method.\n", m.prefix, m.subject)
+	fmt.Fprintf(w, "# TYPE %s_errors_total counter\n", m.prefix)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s_errors_total{method=%s} %d\n", m.prefix, quote(name), snapshot[name].errors)
+	}
+
+	fmt.Fprintf(w, "# HELP %s_call_duration_seconds Latency of %s calls by method.\n", m.prefix, m.subject)
+	fmt.Fprintf(w, "# TYPE %s_call_duration_seconds histogram\n", m.prefix)
+	for _, name := range names {
+		stats := snapshot[name]
+		label := quote(name)
+		var cumulative uint64
+		for i, bound := range m.buckets {
+			cumulative += stats.buckets[i]
+			fmt.Fprintf(w, "%s_call_duration_seconds_bucket{method=%s,le=\"%s\"} %d\n",
+				m.prefix, label, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "%s_call_duration_seconds_bucket{method=%s,le=\"+Inf\"} %d\n", m.prefix, label, stats.calls)
+		fmt.Fprintf(w, "%s_call_duration_seconds_sum{method=%s} %s\n", m.prefix, label, strconv.FormatFloat(stats.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_call_duration_seconds_count{method=%s} %d\n", m.prefix, label, stats.calls)
+	}
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote renders a label value
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
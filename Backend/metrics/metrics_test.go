@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMethodMetrics checks the counters and the cumulative histogram buckets of one method
+func TestMethodMetrics(t *testing.T) {
+	m := NewMethodMetrics("app", "repo", "repository", []float64{0.1, 0.01})
+	m.Observe("GetAll", 5*time.Millisecond, nil)
+	m.Observe("GetAll", 50*time.Millisecond, errors.New("boom"))
+	m.Observe("GetAll", 2*time.Second, nil)
+
+	var out strings.Builder
+	m.WritePrometheus(&out)
+	for _, want := range []string{
+		"# TYPE app_repo_calls_total counter\n",
+		`app_repo_calls_total{method="GetAll"} 3` + "\n",
+		`app_repo_errors_total{method="GetAll"} 1` + "\n",
+		"# TYPE app_repo_call_duration_seconds histogram\n",
+		`app_repo_call_duration_seconds_bucket{method="GetAll",le="0.01"} 1` + "\n",
+		`app_repo_call_duration_seconds_bucket{method="GetAll",le="0.1"} 2` + "\n",
+		`app_repo_call_duration_seconds_bucket{method="GetAll",le="+Inf"} 3` + "\n",
+		`app_repo_call_duration_seconds_count{method="GetAll"} 3` + "\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+// TestRegistryHandler checks that the handler serves every registered collector as text
+func TestRegistryHandler(t *testing.T) {
+	registry := NewRegistry()
+	first := NewMethodMetrics("app", "a", "a", nil)
+	second := NewMethodMetrics("app", "b", "b", nil)
+	first.Observe("x", time.Millisecond, nil)
+	second.Observe(`y"z`, time.Millisecond, nil)
+	registry.Register(first)
+	registry.Register(second)
+
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`app_a_calls_total{method="x"} 1`, `app_b_calls_total{method="y\"z"} 1`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
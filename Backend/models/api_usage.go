@@ -0,0 +1,34 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// APIUsage counts the requests sent to the upstream provider with one API key on one UTC day.
+// Keys are identified by APIKeyFingerprint so the secret itself is never stored.
+type APIUsage struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	KeyHash   string    `json:"key_hash" gorm:"size:64;not null;uniqueIndex:idx_api_usage_key_day,priority:1"`
+	Day       time.Time `json:"day" gorm:"type:date;not null;uniqueIndex:idx_api_usage_key_day,priority:2"`
+	Requests  int       `json:"requests" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for APIUsage
+func (APIUsage) TableName() string {
+	return "api_usage"
+}
+
+// APIKeyFingerprint returns the hex SHA-256 digest identifying an API key in APIUsage
+func APIKeyFingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// UsageDay returns the UTC day t is counted under
+func UsageDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ArchivedStock is a data point moved out of stock_data_points by an archival job. Payload is the
+// record as it was archived, with its sentiments, indicators and tags; the identifying columns are
+// kept alongside for lookups.
+type ArchivedStock struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	StockID          uint      `json:"-" gorm:"not null;index"`
+	UUID             string    `json:"uuid" gorm:"type:uuid;not null;index"`
+	Ticker           string    `json:"ticker" gorm:"size:20;not null;index"`
+	Company          string    `json:"company" gorm:"size:100;not null"`
+	Cluster          int       `json:"cluster" gorm:"not null"`
+	Date             time.Time `json:"date" gorm:"not null;index"`
+	DatasetVersionID *uint     `json:"dataset_version_id,omitempty" gorm:"index"`
+	Payload          string    `json:"payload" gorm:"type:jsonb;not null"`
+	JobID            uint      `json:"job_id" gorm:"not null;index"`
+	ArchivedAt       time.Time `json:"archived_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for ArchivedStock
+func (ArchivedStock) TableName() string {
+	return "stock_archive"
+}
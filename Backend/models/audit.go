@@ -0,0 +1,72 @@
+package models
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the identity (user or API key ID) responsible for writes
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or "" when the request is unattributed
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// stampActor sets the attribution columns from the statement context.
+// created_by is only set on insert; updated_by is refreshed on every attributed write.
+func stampActor(tx *gorm.DB, createdBy, updatedBy *string, creating bool) {
+	actor := ActorFromContext(tx.Statement.Context)
+	if actor == "" {
+		return
+	}
+	if creating && *createdBy == "" {
+		*createdBy = actor
+	}
+	*updatedBy = actor
+}
+
+// BeforeCreate stamps created_by/updated_by on new stock data points
+func (s *StockDataPoint) BeforeCreate(tx *gorm.DB) error {
+	stampActor(tx, &s.CreatedBy, &s.UpdatedBy, true)
+	return nil
+}
+
+// BeforeUpdate stamps updated_by on modified stock data points
+func (s *StockDataPoint) BeforeUpdate(tx *gorm.DB) error {
+	stampActor(tx, &s.CreatedBy, &s.UpdatedBy, false)
+	return nil
+}
+
+// BeforeCreate stamps created_by/updated_by on new rating sentiments
+func (r *RatingSentiment) BeforeCreate(tx *gorm.DB) error {
+	stampActor(tx, &r.CreatedBy, &r.UpdatedBy, true)
+	return nil
+}
+
+// BeforeUpdate stamps updated_by on modified rating sentiments
+func (r *RatingSentiment) BeforeUpdate(tx *gorm.DB) error {
+	stampActor(tx, &r.CreatedBy, &r.UpdatedBy, false)
+	return nil
+}
+
+// BeforeCreate stamps created_by/updated_by on new numerical indicators
+func (n *NumericalIndicator) BeforeCreate(tx *gorm.DB) error {
+	stampActor(tx, &n.CreatedBy, &n.UpdatedBy, true)
+	return nil
+}
+
+// BeforeUpdate stamps updated_by on modified numerical indicators
+func (n *NumericalIndicator) BeforeUpdate(tx *gorm.DB) error {
+	stampActor(tx, &n.CreatedBy, &n.UpdatedBy, false)
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// AnonymousClient is the KeyHash of requests sent without an X-API-Key header
+const AnonymousClient = "anonymous"
+
+// ClientUsage counts the requests one API client sent to one route on one UTC day, with the bytes
+// exchanged and the error responses. Clients are identified by APIKeyFingerprint of their X-API-Key
+// header so the key itself is never stored.
+type ClientUsage struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	KeyHash   string    `json:"key_hash" gorm:"size:64;not null;uniqueIndex:idx_client_usage_key_route,priority:1"`
+	Day       time.Time `json:"day" gorm:"type:date;not null;index;uniqueIndex:idx_client_usage_key_route,priority:2"`
+	Method    string    `json:"method" gorm:"size:10;not null;uniqueIndex:idx_client_usage_key_route,priority:3"`
+	Route     string    `json:"route" gorm:"size:255;not null;uniqueIndex:idx_client_usage_key_route,priority:4"`
+	Requests  int64     `json:"requests" gorm:"not null;default:0"`
+	Errors    int64     `json:"errors" gorm:"not null;default:0"`
+	BytesIn   int64     `json:"bytes_in" gorm:"not null;default:0"`
+	BytesOut  int64     `json:"bytes_out" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for ClientUsage
+func (ClientUsage) TableName() string {
+	return "client_usage"
+}
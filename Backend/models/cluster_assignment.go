@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClusterAssignment is an audit trail entry for a manual cluster override
+type ClusterAssignment struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	StockDataPointID uint      `json:"-" gorm:"not null;index"`
+	Ticker           string    `json:"ticker" gorm:"size:20;not null;index"`
+	FromCluster      int       `json:"from_cluster" gorm:"not null"`
+	ToCluster        int       `json:"to_cluster" gorm:"not null"`
+	Reason           string    `json:"reason" gorm:"size:500;not null"`
+	ChangedBy        string    `json:"changed_by" gorm:"size:100"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for ClusterAssignment
+func (ClusterAssignment) TableName() string {
+	return "cluster_assignments"
+}
+
+// BeforeCreate attributes the override to the request actor when one is known
+func (a *ClusterAssignment) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		a.ChangedBy = actor
+	}
+	return nil
+}
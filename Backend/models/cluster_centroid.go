@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ClusterCentroid is one coordinate of a cluster centroid: the mean normalized value of an
+// indicator over the cluster's stocks
+type ClusterCentroid struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Cluster    int       `json:"cluster" gorm:"not null;uniqueIndex:idx_centroid_cluster_name"`
+	Name       string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_centroid_cluster_name"`
+	NormValue  float64   `json:"norm_value" gorm:"not null"`
+	Members    int64     `json:"members" gorm:"not null"`
+	ComputedAt time.Time `json:"computed_at" gorm:"not null"`
+}
+
+// TableName returns the table name for ClusterCentroid
+func (ClusterCentroid) TableName() string {
+	return "cluster_centroids"
+}
@@ -0,0 +1,17 @@
+package models
+
+// ClusterDistribution is the number of data points, and the sum of their final scores, per
+// cluster, action and rating_to. Database triggers on the stock table keep it current so the
+// heatmap reads this small table instead of grouping every stock.
+type ClusterDistribution struct {
+	Cluster  int     `json:"cluster" gorm:"primaryKey;autoIncrement:false"`
+	Action   string  `json:"action" gorm:"primaryKey;size:100"`
+	RatingTo string  `json:"rating_to" gorm:"primaryKey;size:50"`
+	Count    int64   `json:"count" gorm:"not null;default:0"`
+	ScoreSum float64 `json:"score_sum" gorm:"type:decimal(24,6);not null;default:0"`
+}
+
+// TableName returns the table name for ClusterDistribution
+func (ClusterDistribution) TableName() string {
+	return "cluster_distributions"
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Dataset version statuses
+const (
+	DatasetImporting  = "importing"
+	DatasetComplete   = "complete"
+	DatasetFailed     = "failed"
+	DatasetRolledBack = "rolled_back"
+)
+
+// DatasetVersion identifies one import run. Data points written by the run carry its ID, and a copy
+// of every written row is kept in DatasetRecord so the data can be rolled back to an earlier version.
+type DatasetVersion struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Source       string     `json:"source" gorm:"size:500;not null"`
+	SHA256       string     `json:"sha256" gorm:"size:64"`
+	Status       string     `json:"status" gorm:"size:20;not null;index"`
+	RowCount     int        `json:"row_count" gorm:"not null;default:0"`
+	CreatedBy    string     `json:"created_by" gorm:"size:100"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	RolledBackAt *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+// TableName returns the table name for DatasetVersion
+func (DatasetVersion) TableName() string {
+	return "dataset_versions"
+}
+
+// BeforeCreate attributes the import to the request actor when one is known
+func (v *DatasetVersion) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		v.CreatedBy = actor
+	}
+	return nil
+}
+
+// DatasetRecord is the archived copy of a data point (with its sentiments and indicators) as written by a dataset version
+type DatasetRecord struct {
+	ID               uint   `json:"id" gorm:"primaryKey"`
+	DatasetVersionID uint   `json:"dataset_version_id" gorm:"not null;uniqueIndex:idx_dataset_record_version_ticker,priority:1"`
+	Ticker           string `json:"ticker" gorm:"size:20;not null;uniqueIndex:idx_dataset_record_version_ticker,priority:2;index"`
+	Payload          string `json:"payload" gorm:"type:jsonb;not null"`
+}
+
+// TableName returns the table name for DatasetRecord
+func (DatasetRecord) TableName() string {
+	return "dataset_records"
+}
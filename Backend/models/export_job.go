@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Export job statuses
+const (
+	ExportPending  = "pending"
+	ExportRunning  = "running"
+	ExportComplete = "complete"
+	ExportFailed   = "failed"
+)
+
+// ExportJob is an asynchronous export of the stock table. The file is written to the export spool
+// and downloaded through a signed URL until ExpiresAt, when the job and its file are pruned.
+type ExportJob struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Format      string     `json:"format" gorm:"size:10;not null"`
+	Status      string     `json:"status" gorm:"size:20;not null;index"`
+	RowCount    int        `json:"row_count" gorm:"not null;default:0"`
+	SizeBytes   int64      `json:"size_bytes" gorm:"not null;default:0"`
+	Path        string     `json:"-" gorm:"size:500"`
+	Error       string     `json:"error,omitempty" gorm:"size:1000"`
+	CreatedBy   string     `json:"created_by" gorm:"size:100"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" gorm:"index"`
+}
+
+// TableName returns the table name for ExportJob
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
+
+// BeforeCreate attributes the export to the request actor when one is known
+func (j *ExportJob) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		j.CreatedBy = actor
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Extraction page statuses; error entries become retried once a replay fetched their page
+const (
+	ExtractionPageSuccess = "success"
+	ExtractionPageError   = "error"
+	ExtractionPageRetried = "retried"
+)
+
+// ExtractionPage records one page key visited by the API extractor, so runs can be inspected and resumed remotely
+type ExtractionPage struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	PageKey    string    `json:"page_key" gorm:"type:text;not null"`
+	PageNumber int       `json:"page_number" gorm:"not null"`
+	Status     string    `json:"status" gorm:"size:20;not null;index"`
+	RecordedAt time.Time `json:"recorded_at" gorm:"autoCreateTime;index"`
+}
+
+// TableName returns the table name for ExtractionPage
+func (ExtractionPage) TableName() string {
+	return "extraction_pages"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportFingerprint records a successfully imported source file by its SHA-256 digest, so re-running
+// the same import can be detected and skipped
+type ImportFingerprint struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	SHA256     string    `json:"sha256" gorm:"size:64;not null;uniqueIndex"`
+	Source     string    `json:"source" gorm:"size:500;not null"`
+	SizeBytes  int64     `json:"size_bytes" gorm:"not null"`
+	RowCount   int       `json:"row_count" gorm:"not null"`
+	ImportedBy string    `json:"imported_by" gorm:"size:100"`
+	ImportedAt time.Time `json:"imported_at" gorm:"not null"`
+}
+
+// TableName returns the table name for ImportFingerprint
+func (ImportFingerprint) TableName() string {
+	return "import_fingerprints"
+}
+
+// BeforeCreate attributes the import to the request actor when one is known
+func (f *ImportFingerprint) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		f.ImportedBy = actor
+	}
+	return nil
+}
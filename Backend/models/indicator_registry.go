@@ -0,0 +1,36 @@
+package models
+
+// NumericalIndicatorNames lists the numerical indicators produced by the enrichment pipeline
+var NumericalIndicatorNames = []string{
+	"target_from", "target_to", "target_delta", "target_growth", "relative_growth",
+	"last_close",
+	"atr", "std_dev", "ulcer_index", "price_distance", "obv", "ad_line", "pvt", "force_index",
+	"hlc3", "typical_price", "vwap",
+}
+
+// RatingSentimentNames lists the rating sentiments produced by the enrichment pipeline
+var RatingSentimentNames = []string{
+	"rating_from",
+	"rating_to",
+	"action",
+}
+
+// IsKnownNumericalIndicator reports whether name is a registered numerical indicator
+func IsKnownNumericalIndicator(name string) bool {
+	return containsName(NumericalIndicatorNames, name)
+}
+
+// IsKnownRatingSentiment reports whether name is a registered rating sentiment
+func IsKnownRatingSentiment(name string) bool {
+	return containsName(RatingSentimentNames, name)
+}
+
+// containsName checks if name is present in names
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Job kinds
+const (
+	JobExtraction = "extraction"
+	JobArchive    = "archive"
+)
+
+// Job statuses
+const (
+	JobPending  = "pending"
+	JobRunning  = "running"
+	JobComplete = "complete"
+	JobFailed   = "failed"
+)
+
+// Job is a background run started by the API, such as a multi-page extraction. Progress counters
+// are saved as the run advances so GET /jobs/:id can be polled while it is running.
+type Job struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	Kind           string     `json:"kind" gorm:"size:20;not null;index"`
+	Status         string     `json:"status" gorm:"size:20;not null;index"`
+	MaxPages       int        `json:"max_pages" gorm:"not null;default:0"`
+	PagesProcessed int        `json:"pages_processed" gorm:"not null;default:0"`
+	ItemsWritten   int        `json:"items_written" gorm:"not null;default:0"`
+	Error          string     `json:"error,omitempty" gorm:"size:1000"`
+	CreatedBy      string     `json:"created_by" gorm:"size:100"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName returns the table name for Job
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// BeforeCreate attributes the job to the request actor when one is known
+func (j *Job) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		j.CreatedBy = actor
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Note is a free-text annotation an analyst records next to a stock data point
+type Note struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	StockDataPointID uint      `json:"-" gorm:"not null;index"`
+	Author           string    `json:"author" gorm:"size:100;not null"`
+	Body             string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for Note
+func (Note) TableName() string {
+	return "stock_notes"
+}
+
+// BeforeCreate attributes the note to the request actor when one is known
+func (n *Note) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		n.Author = actor
+	}
+	if n.Author == "" {
+		return errors.New("invalid note: author is required")
+	}
+	return nil
+}
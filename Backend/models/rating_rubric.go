@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rubric kinds: the vocabulary a rubric term belongs to
+const (
+	RubricKindRating = "rating"
+	RubricKindAction = "action"
+)
+
+// RubricKinds lists the accepted rubric kinds
+var RubricKinds = []string{RubricKindRating, RubricKindAction}
+
+// RubricKindOf returns the rubric kind that scores a rating sentiment, or "" when none does
+func RubricKindOf(sentimentName string) string {
+	switch sentimentName {
+	case "rating_from", "rating_to":
+		return RubricKindRating
+	case "action":
+		return RubricKindAction
+	}
+	return ""
+}
+
+// RatingRubric maps a rating or action string (e.g. "overweight", "upgraded by") to the raw and
+// normalized score given to sentiments carrying it. Terms are stored lower-cased.
+type RatingRubric struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Kind      string    `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_rubric_kind_term"`
+	Term      string    `json:"term" gorm:"size:100;not null;uniqueIndex:idx_rubric_kind_term"`
+	Score     float64   `json:"score" gorm:"type:decimal(10,4);not null"`
+	NormScore float64   `json:"norm_score" gorm:"type:decimal(10,4);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedBy string    `json:"created_by" gorm:"size:100"`
+	UpdatedBy string    `json:"updated_by" gorm:"size:100"`
+}
+
+// TableName returns the table name for RatingRubric
+func (RatingRubric) TableName() string {
+	return "rating_rubric"
+}
+
+// BeforeCreate stamps created_by/updated_by on new rubric entries
+func (r *RatingRubric) BeforeCreate(tx *gorm.DB) error {
+	stampActor(tx, &r.CreatedBy, &r.UpdatedBy, true)
+	return nil
+}
+
+// BeforeUpdate stamps updated_by on modified rubric entries
+func (r *RatingRubric) BeforeUpdate(tx *gorm.DB) error {
+	stampActor(tx, &r.CreatedBy, &r.UpdatedBy, false)
+	return nil
+}
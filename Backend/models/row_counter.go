@@ -0,0 +1,26 @@
+package models
+
+// Counter scopes of RowCounter
+const (
+	CounterStocks          = "stocks"           // every data point (name and value empty)
+	CounterCluster         = "cluster"          // data points per cluster id
+	CounterCompany         = "company"          // data points per company
+	CounterIndicator       = "indicator"        // numerical indicators per name
+	CounterSentiment       = "sentiment"        // rating sentiments per name
+	CounterSentimentRating = "sentiment_rating" // rating sentiments per name and rating
+	CounterStockWrites     = "stock_writes"     // inserts, updates and deletes of data points; only grows
+)
+
+// RowCounter is a denormalized row count kept current by database triggers on the stock, indicator
+// and sentiment tables, so the stats and dictionary endpoints read counts instead of scanning.
+type RowCounter struct {
+	Scope string `json:"scope" gorm:"primaryKey;size:30"`
+	Name  string `json:"name" gorm:"primaryKey;size:100"`
+	Value string `json:"value" gorm:"primaryKey;size:100"`
+	Count int64  `json:"count" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for RowCounter
+func (RowCounter) TableName() string {
+	return "row_counters"
+}
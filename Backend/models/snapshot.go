@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockSnapshot is a point in a ticker's history: the scored values of a stock as of a record date.
+// Snapshots are written whenever a stock data point is saved, one per ticker, date and brokerage,
+// so ratings issued by several brokerages on the same day are all kept.
+type StockSnapshot struct {
+	ID         uint      `json:"-" gorm:"primaryKey"`
+	Ticker     string    `json:"ticker" gorm:"size:20;not null;uniqueIndex:idx_snapshot_ticker_date_brokerage,priority:1"`
+	Date       time.Time `json:"date" gorm:"not null;uniqueIndex:idx_snapshot_ticker_date_brokerage,priority:2"`
+	Brokerage  string    `json:"brokerage" gorm:"size:100;not null;default:'';uniqueIndex:idx_snapshot_ticker_date_brokerage,priority:3"`
+	FinalScore float64   `json:"final_score" gorm:"type:decimal(18,6);not null;default:0"`
+	TargetTo   float64   `json:"target_to" gorm:"type:decimal(18,6)"`
+	TargetFrom float64   `json:"target_from" gorm:"type:decimal(18,6)"`
+	RatingTo   string    `json:"rating_to" gorm:"size:50"`
+	RatingFrom string    `json:"rating_from" gorm:"size:50"`
+	RecordedAt time.Time `json:"recorded_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for StockSnapshot
+func (StockSnapshot) TableName() string {
+	return "stock_snapshots"
+}
+
+// IndicatorSnapshot is a point in the history of one numerical indicator of a ticker
+type IndicatorSnapshot struct {
+	ID         uint      `json:"-" gorm:"primaryKey"`
+	Ticker     string    `json:"ticker" gorm:"size:20;not null;uniqueIndex:idx_indicator_snapshot,priority:1"`
+	Name       string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_indicator_snapshot,priority:2"`
+	Date       time.Time `json:"date" gorm:"not null;uniqueIndex:idx_indicator_snapshot,priority:3"`
+	Value      float64   `json:"value" gorm:"type:decimal(18,6);not null"`
+	NormValue  float64   `json:"norm_value" gorm:"type:decimal(18,6);not null"`
+	RecordedAt time.Time `json:"recorded_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for IndicatorSnapshot
+func (IndicatorSnapshot) TableName() string {
+	return "indicator_snapshots"
+}
+
+// snapshotColumns are the columns refreshed when a snapshot for the same ticker and date is saved again
+var snapshotColumns = []string{"final_score", "target_to", "target_from", "rating_to", "rating_from", "recorded_at"}
+
+// AfterSave records the saved values (and indicator values) in the ticker's history within the same transaction
+func (s *StockDataPoint) AfterSave(tx *gorm.DB) error {
+	snapshot := StockSnapshot{
+		Ticker:     s.Ticker,
+		Date:       s.Date,
+		Brokerage:  s.Brokerage,
+		FinalScore: s.FinalScore,
+		TargetTo:   s.TargetTo,
+		TargetFrom: s.TargetFrom,
+		RatingTo:   s.RatingTo,
+		RatingFrom: s.RatingFrom,
+	}
+	if err := tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ticker"}, {Name: "date"}, {Name: "brokerage"}},
+		DoUpdates: clause.AssignmentColumns(snapshotColumns),
+	}).Create(&snapshot).Error; err != nil {
+		return err
+	}
+
+	if len(s.NumericalIndicators) == 0 {
+		return nil
+	}
+	indicators := make([]IndicatorSnapshot, len(s.NumericalIndicators))
+	for i, ni := range s.NumericalIndicators {
+		indicators[i] = IndicatorSnapshot{
+			Ticker:    s.Ticker,
+			Name:      ni.Name,
+			Date:      s.Date,
+			Value:     ni.Value,
+			NormValue: ni.NormValue,
+		}
+	}
+	return tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ticker"}, {Name: "name"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "norm_value", "recorded_at"}),
+	}).Create(&indicators).Error
+}
@@ -4,9 +4,17 @@ import (
 	"time"
 )
 
-// StockDataPoint represents a stock data point with related sentiments and indicators
+// NoiseCluster is the cluster id assigned to unclustered (DBSCAN-style noise) data points.
+// Valid cluster ids are NoiseCluster or any non-negative integer.
+const NoiseCluster = -1
+
+// StockDataPoint represents a stock data point with related sentiments and indicators.
+// UUID is the identity the API exposes. ID is an internal surrogate key, the join column of the
+// child tables, and is never serialized, so responses do not leak record counts. With
+// DB_UUID_PRIMARY_KEYS the tables are keyed by UUID and ID is demoted to a unique secondary key.
 type StockDataPoint struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	UUID        string    `json:"uuid" gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
 	Ticker      string    `json:"ticker" gorm:"size:20;not null;uniqueIndex"`
 	Action      string    `json:"action" gorm:"size:100"`
 	Date        time.Time `json:"date" gorm:"not null;index"`
@@ -14,22 +22,36 @@ type StockDataPoint struct {
 	Cluster     int       `json:"cluster" gorm:"not null"`
 	TargetTo    float64   `json:"target_to" gorm:"type:decimal(18,6)"`
 	TargetFrom  float64   `json:"target_from" gorm:"type:decimal(18,6)"`
-	TargetDelta float64   `json:"target_delta" gorm:"type:decimal(18,6)"`
+	TargetDelta float64   `json:"target_delta" gorm:"type:decimal(18,6);index"`
 	LastClose   float64   `json:"last_close" gorm:"type:decimal(18,6)"`
 	RatingTo    string    `json:"rating_to" gorm:"size:50"`
 	RatingFrom  string    `json:"rating_from" gorm:"size:50"`
-	FinalScore  float64   `json:"final_score" gorm:"type:decimal(18,6);not null;default:0"`
+	Brokerage   string    `json:"brokerage" gorm:"size:100"`
+	FinalScore  float64   `json:"final_score" gorm:"type:decimal(18,6);not null;default:0;index"`
 	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedBy   string    `json:"created_by" gorm:"size:100"`
+	UpdatedBy   string    `json:"updated_by" gorm:"size:100"`
+
+	// Import run that last wrote the row; nil for rows created through the API
+	DatasetVersionID *uint `json:"dataset_version_id,omitempty" gorm:"index"`
 
 	// Relations
 	RatingSentiments    []RatingSentiment    `json:"rating_sentiments" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	NumericalIndicators []NumericalIndicator `json:"numerical_indicators" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Tags                []Tag                `json:"tags" gorm:"many2many:stock_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	// Analyst notes, only loaded on request (?include=notes)
+	Notes []Note `json:"notes,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 
 	// Computed field from queries (not persisted)
 	// No gorm tag - GORM will map weighted_score column (snake_case) to WeightedScore field (PascalCase) automatically
 	// This field is never written to the database, only populated from SELECT queries
 	WeightedScore *float64 `json:"weighted_score,omitempty"`
+
+	// Computed per weighted indicator/sentiment name (weight x normalized value) when the filter is asked
+	// for contributions; they add up to WeightedScore
+	Contributions map[string]float64 `json:"contributions,omitempty" gorm:"-"`
 }
 
 // TableName returns the table name for StockDataPoint
@@ -39,14 +61,17 @@ func (StockDataPoint) TableName() string {
 
 // RatingSentiment represents a qualitative rating with scores
 type RatingSentiment struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	StockDataPointID uint      `json:"stock_data_point_id" gorm:"not null;uniqueIndex:idx_stock_rating_unique"`
+	ID               uint      `json:"-" gorm:"primaryKey"`
+	UUID             string    `json:"uuid" gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	StockDataPointID uint      `json:"-" gorm:"not null;uniqueIndex:idx_stock_rating_unique"`
 	Name             string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_stock_rating_unique"`
 	Rating           string    `json:"rating" gorm:"size:50;not null"`
 	RatingScore      float64   `json:"rating_score" gorm:"type:decimal(10,4);not null"`
 	NormRatingScore  float64   `json:"norm_rating_score" gorm:"type:decimal(10,4);not null"`
 	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedBy        string    `json:"created_by" gorm:"size:100"`
+	UpdatedBy        string    `json:"updated_by" gorm:"size:100"`
 }
 
 // TableName returns the table name for RatingSentiment
@@ -56,13 +81,16 @@ func (RatingSentiment) TableName() string {
 
 // NumericalIndicator represents a quantitative indicator value
 type NumericalIndicator struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	StockDataPointID uint      `json:"stock_data_point_id" gorm:"not null;uniqueIndex:idx_stock_indicator_unique"`
+	ID               uint      `json:"-" gorm:"primaryKey"`
+	UUID             string    `json:"uuid" gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	StockDataPointID uint      `json:"-" gorm:"not null;uniqueIndex:idx_stock_indicator_unique"`
 	Name             string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_stock_indicator_unique"`
 	Value            float64   `json:"value" gorm:"type:decimal(18,6);not null"`
 	NormValue        float64   `json:"norm_value" gorm:"type:decimal(18,6);not null"`
 	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedBy        string    `json:"created_by" gorm:"size:100"`
+	UpdatedBy        string    `json:"updated_by" gorm:"size:100"`
 }
 
 // TableName returns the table name for NumericalIndicator
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Tag is a free-form label (e.g. "earnings-week", "review") analysts attach to stock data points
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"size:50;not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for Tag
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// StockTagsJoinTable is the many-to-many join table between stock data points and tags
+const StockTagsJoinTable = "stock_tags"
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// UserPreference holds the dashboard defaults of an authenticated user (keyed by the request actor):
+// the cluster to open and the weights applied when the filter endpoint is called without any
+type UserPreference struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	UserID         string `json:"user_id" gorm:"size:100;not null;uniqueIndex"`
+	DefaultCluster *int   `json:"default_cluster"`
+	// JSON arrays of {indicator_name, weight}
+	NumericalWeights string    `json:"-" gorm:"type:jsonb;not null"`
+	RatingWeights    string    `json:"-" gorm:"type:jsonb;not null"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for UserPreference
+func (UserPreference) TableName() string {
+	return "user_preferences"
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook events
+const (
+	WebhookExtractionCompleted = "extraction.completed"
+	WebhookExtractionFailed    = "extraction.failed"
+)
+
+// WebhookSubscription registers a URL that receives the listed events as signed callbacks. Secret
+// signs every delivery; it is only shown when the subscription is created.
+type WebhookSubscription struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	URL       string    `json:"url" gorm:"size:2048;not null"`
+	Events    string    `json:"events" gorm:"size:255;not null"`
+	Secret    string    `json:"-" gorm:"size:100;not null"`
+	CreatedBy string    `json:"created_by" gorm:"size:100"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// BeforeCreate attributes the subscription to the request actor when one is known
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if actor := ActorFromContext(tx.Statement.Context); actor != "" {
+		w.CreatedBy = actor
+	}
+	return nil
+}
+
+// Subscribes reports whether event is among the comma-separated Events
+func (w *WebhookSubscription) Subscribes(event string) bool {
+	for _, subscribed := range strings.Split(w.Events, ",") {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Package notifications delivers operational messages (alerts, failed import and extraction jobs)
+// over the channels configured in config.NotificationConfig: SMTP email and a Slack webhook.
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"dataextractor/config"
+)
+
+// Message is a notification; Subject doubles as the email subject and the bold first line on Slack
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages over one channel
+type Sender interface {
+	// Channel names the channel ("email", "slack")
+	Channel() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Delivery is the outcome of sending a message on one channel
+type Delivery struct {
+	Channel string `json:"channel"`
+	Sent    bool   `json:"sent"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ErrUnknownChannel is returned when a message is addressed to a channel that is not configured
+var ErrUnknownChannel = errors.New("notification channel not configured")
+
+// Notifier fans messages out to every configured channel
+type Notifier struct {
+	senders []Sender
+	timeout time.Duration
+}
+
+// New builds a Notifier with a sender for each configured channel; with none configured every
+// send is a no-op
+func New(cfg config.NotificationConfig) *Notifier {
+	n := &Notifier{timeout: cfg.Timeout}
+	if cfg.SMTPHost != "" {
+		n.senders = append(n.senders, NewSMTPSender(cfg))
+	}
+	if cfg.SlackWebhookURL != "" {
+		n.senders = append(n.senders, NewSlackSender(cfg.SlackWebhookURL, cfg.Timeout))
+	}
+	return n
+}
+
+// NewWithSenders builds a Notifier over explicit senders
+func NewWithSenders(timeout time.Duration, senders ...Sender) *Notifier {
+	return &Notifier{senders: senders, timeout: timeout}
+}
+
+// Channels lists the configured channels
+func (n *Notifier) Channels() []string {
+	channels := make([]string, len(n.senders))
+	for i, sender := range n.senders {
+		channels[i] = sender.Channel()
+	}
+	return channels
+}
+
+// Send delivers msg on every configured channel, or only on channel when it is not empty, and
+// reports the outcome per channel. Each channel gets its own timeout so a slow one does not
+// starve the others.
+func (n *Notifier) Send(ctx context.Context, channel string, msg Message) ([]Delivery, error) {
+	var deliveries []Delivery
+	for _, sender := range n.senders {
+		if channel != "" && sender.Channel() != channel {
+			continue
+		}
+		sendCtx, cancel := ctx, context.CancelFunc(func() {})
+		if n.timeout > 0 {
+			sendCtx, cancel = context.WithTimeout(ctx, n.timeout)
+		}
+		err := sender.Send(sendCtx, msg)
+		cancel()
+
+		delivery := Delivery{Channel: sender.Channel(), Sent: err == nil}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if channel != "" && len(deliveries) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChannel, channel)
+	}
+	return deliveries, nil
+}
+
+// Notify sends msg on every channel in the background, logging failed deliveries, so callers on
+// a request or job path are never delayed or failed by a notification
+func (n *Notifier) Notify(msg Message) {
+	if len(n.senders) == 0 {
+		return
+	}
+	go func() {
+		deliveries, _ := n.Send(context.Background(), "", msg)
+		for _, delivery := range deliveries {
+			if !delivery.Sent {
+				slog.Warn("Failed to send notification", "subject", msg.Subject, "channel", delivery.Channel, "error", delivery.Error)
+			}
+		}
+	}()
+}
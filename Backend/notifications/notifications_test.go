@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSlackSender checks the webhook payload and that non-200 responses are reported
+func TestSlackSender(t *testing.T) {
+	var text string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		text = payload["text"]
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender := NewSlackSender(server.URL, time.Second)
+	if err := sender.Send(context.Background(), Message{Subject: "Import failed", Body: "boom"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if text != "*Import failed*\nboom" {
+		t.Errorf("text = %q, want %q", text, "*Import failed*\nboom")
+	}
+
+	status = http.StatusForbidden
+	if err := sender.Send(context.Background(), Message{Subject: "x"}); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Send() error = %v, want status 403", err)
+	}
+}
+
+type fakeSender struct {
+	channel string
+	err     error
+	sent    int
+}
+
+func (f *fakeSender) Channel() string { return f.channel }
+
+func (f *fakeSender) Send(ctx context.Context, msg Message) error {
+	f.sent++
+	return f.err
+}
+
+// TestNotifierSend checks fan-out, per-channel outcomes and channel selection
+func TestNotifierSend(t *testing.T) {
+	email := &fakeSender{channel: "email", err: errors.New("connection refused")}
+	slack := &fakeSender{channel: "slack"}
+	notifier := NewWithSenders(time.Second, email, slack)
+
+	deliveries, err := notifier.Send(context.Background(), "", Message{Subject: "test"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Sent || deliveries[0].Error != "connection refused" || !deliveries[1].Sent {
+		t.Errorf("deliveries = %+v", deliveries)
+	}
+
+	if _, err := notifier.Send(context.Background(), "slack", Message{Subject: "test"}); err != nil {
+		t.Fatalf("Send(slack) error = %v", err)
+	}
+	if email.sent != 1 || slack.sent != 2 {
+		t.Errorf("sent email=%d slack=%d, want 1 and 2", email.sent, slack.sent)
+	}
+
+	if _, err := notifier.Send(context.Background(), "pager", Message{}); !errors.Is(err, ErrUnknownChannel) {
+		t.Errorf("Send(pager) error = %v, want ErrUnknownChannel", err)
+	}
+}
@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SlackSender posts messages to a Slack incoming webhook
+type SlackSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackSender creates a sender for the given incoming webhook URL
+func NewSlackSender(webhookURL string, timeout time.Duration) *SlackSender {
+	return &SlackSender{webhookURL: webhookURL, client: &http.Client{Timeout: timeout}}
+}
+
+// Channel returns "slack"
+func (s *SlackSender) Channel() string {
+	return "slack"
+}
+
+// Send posts the message as {"text": "*subject*\nbody"}
+func (s *SlackSender) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(map[string]string{"text": fmt.Sprintf("*%s*\n%s", msg.Subject, msg.Body)})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"dataextractor/config"
+)
+
+// SMTPSender emails messages through an SMTP relay, upgrading to TLS when the server offers STARTTLS
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewSMTPSender creates a sender from the SMTP settings of cfg
+func NewSMTPSender(cfg config.NotificationConfig) *SMTPSender {
+	return &SMTPSender{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     cfg.SMTPFrom,
+		to:       cfg.SMTPTo,
+	}
+}
+
+// Channel returns "email"
+func (s *SMTPSender) Channel() string {
+	return "email"
+}
+
+// Send emails the message to every configured recipient; the context deadline bounds the whole exchange
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if s.from == "" || len(s.to) == 0 {
+		return fmt.Errorf("email requires a sender and at least one recipient")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected: %w", err)
+	}
+	for _, recipient := range s.to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP recipient %s rejected: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA rejected: %w", err)
+	}
+	if _, err := writer.Write(s.compose(msg)); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose builds a plain-text RFC 5322 message; header values are stripped of line breaks
+func (s *SMTPSender) compose(msg Message) []byte {
+	header := func(value string) string {
+		return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", header(s.from))
+	fmt.Fprintf(&b, "To: %s\r\n", header(strings.Join(s.to, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", header(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,1825 @@
+// gRPC contract over the stock service layer (service.StockServiceInterface): CRUD, the cluster
+// filter and weighted ranking. Messages mirror the REST JSON shapes (validators.StockBase,
+// models.StockDataPoint, service.Pagination) so both transports validate and score identically.
+//
+// Generate the Go stubs into Backend/proto/stock/v1 with:
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/stock/v1/stock.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: proto/stock/v1/stock.proto
+
+package stockv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RatingSentiment struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Uuid            string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rating          string                 `protobuf:"bytes,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatingScore     float64                `protobuf:"fixed64,4,opt,name=rating_score,json=ratingScore,proto3" json:"rating_score,omitempty"`
+	NormRatingScore float64                `protobuf:"fixed64,5,opt,name=norm_rating_score,json=normRatingScore,proto3" json:"norm_rating_score,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RatingSentiment) Reset() {
+	*x = RatingSentiment{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSentiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSentiment) ProtoMessage() {}
+
+func (x *RatingSentiment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSentiment.ProtoReflect.Descriptor instead.
+func (*RatingSentiment) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RatingSentiment) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RatingSentiment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RatingSentiment) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *RatingSentiment) GetRatingScore() float64 {
+	if x != nil {
+		return x.RatingScore
+	}
+	return 0
+}
+
+func (x *RatingSentiment) GetNormRatingScore() float64 {
+	if x != nil {
+		return x.NormRatingScore
+	}
+	return 0
+}
+
+type NumericalIndicator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	NormValue     float64                `protobuf:"fixed64,4,opt,name=norm_value,json=normValue,proto3" json:"norm_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NumericalIndicator) Reset() {
+	*x = NumericalIndicator{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumericalIndicator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumericalIndicator) ProtoMessage() {}
+
+func (x *NumericalIndicator) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumericalIndicator.ProtoReflect.Descriptor instead.
+func (*NumericalIndicator) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NumericalIndicator) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *NumericalIndicator) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NumericalIndicator) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *NumericalIndicator) GetNormValue() float64 {
+	if x != nil {
+		return x.NormValue
+	}
+	return 0
+}
+
+type Stock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Always 0: integer ids are internal and never leave the server. Use uuid.
+	//
+	// Deprecated: Marked as deprecated in proto/stock/v1/stock.proto.
+	Id                  uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid                string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Ticker              string                 `protobuf:"bytes,3,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company             string                 `protobuf:"bytes,4,opt,name=company,proto3" json:"company,omitempty"`
+	Action              string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Brokerage           string                 `protobuf:"bytes,6,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Date                *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=date,proto3" json:"date,omitempty"`
+	Cluster             int32                  `protobuf:"varint,8,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	TargetFrom          float64                `protobuf:"fixed64,9,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo            float64                `protobuf:"fixed64,10,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	TargetDelta         float64                `protobuf:"fixed64,11,opt,name=target_delta,json=targetDelta,proto3" json:"target_delta,omitempty"`
+	LastClose           float64                `protobuf:"fixed64,12,opt,name=last_close,json=lastClose,proto3" json:"last_close,omitempty"`
+	RatingFrom          string                 `protobuf:"bytes,13,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo            string                 `protobuf:"bytes,14,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	FinalScore          float64                `protobuf:"fixed64,15,opt,name=final_score,json=finalScore,proto3" json:"final_score,omitempty"`
+	WeightedScore       *float64               `protobuf:"fixed64,16,opt,name=weighted_score,json=weightedScore,proto3,oneof" json:"weighted_score,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags                []string               `protobuf:"bytes,19,rep,name=tags,proto3" json:"tags,omitempty"`
+	RatingSentiments    []*RatingSentiment     `protobuf:"bytes,20,rep,name=rating_sentiments,json=ratingSentiments,proto3" json:"rating_sentiments,omitempty"`
+	NumericalIndicators []*NumericalIndicator  `protobuf:"bytes,21,rep,name=numerical_indicators,json=numericalIndicators,proto3" json:"numerical_indicators,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+// Deprecated: Marked as deprecated in proto/stock/v1/stock.proto.
+func (x *Stock) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Stock) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Stock) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Stock) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Stock) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Stock) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *Stock) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Stock) GetCluster() int32 {
+	if x != nil {
+		return x.Cluster
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetFrom() float64 {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetDelta() float64 {
+	if x != nil {
+		return x.TargetDelta
+	}
+	return 0
+}
+
+func (x *Stock) GetLastClose() float64 {
+	if x != nil {
+		return x.LastClose
+	}
+	return 0
+}
+
+func (x *Stock) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *Stock) GetFinalScore() float64 {
+	if x != nil {
+		return x.FinalScore
+	}
+	return 0
+}
+
+func (x *Stock) GetWeightedScore() float64 {
+	if x != nil && x.WeightedScore != nil {
+		return *x.WeightedScore
+	}
+	return 0
+}
+
+func (x *Stock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Stock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Stock) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Stock) GetRatingSentiments() []*RatingSentiment {
+	if x != nil {
+		return x.RatingSentiments
+	}
+	return nil
+}
+
+func (x *Stock) GetNumericalIndicators() []*NumericalIndicator {
+	if x != nil {
+		return x.NumericalIndicators
+	}
+	return nil
+}
+
+// Fields of a new stock; an unset cluster places it at the nearest centroid
+type StockInput struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Ticker              string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company             string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Action              string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Brokerage           string                 `protobuf:"bytes,4,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Date                *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	Cluster             *int32                 `protobuf:"varint,6,opt,name=cluster,proto3,oneof" json:"cluster,omitempty"`
+	TargetFrom          float64                `protobuf:"fixed64,7,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo            float64                `protobuf:"fixed64,8,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	TargetDelta         float64                `protobuf:"fixed64,9,opt,name=target_delta,json=targetDelta,proto3" json:"target_delta,omitempty"`
+	LastClose           float64                `protobuf:"fixed64,10,opt,name=last_close,json=lastClose,proto3" json:"last_close,omitempty"`
+	RatingFrom          string                 `protobuf:"bytes,11,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo            string                 `protobuf:"bytes,12,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	RatingSentiments    []*RatingSentiment     `protobuf:"bytes,13,rep,name=rating_sentiments,json=ratingSentiments,proto3" json:"rating_sentiments,omitempty"`
+	NumericalIndicators []*NumericalIndicator  `protobuf:"bytes,14,rep,name=numerical_indicators,json=numericalIndicators,proto3" json:"numerical_indicators,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *StockInput) Reset() {
+	*x = StockInput{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockInput) ProtoMessage() {}
+
+func (x *StockInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockInput.ProtoReflect.Descriptor instead.
+func (*StockInput) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StockInput) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StockInput) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *StockInput) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *StockInput) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *StockInput) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *StockInput) GetCluster() int32 {
+	if x != nil && x.Cluster != nil {
+		return *x.Cluster
+	}
+	return 0
+}
+
+func (x *StockInput) GetTargetFrom() float64 {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return 0
+}
+
+func (x *StockInput) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *StockInput) GetTargetDelta() float64 {
+	if x != nil {
+		return x.TargetDelta
+	}
+	return 0
+}
+
+func (x *StockInput) GetLastClose() float64 {
+	if x != nil {
+		return x.LastClose
+	}
+	return 0
+}
+
+func (x *StockInput) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *StockInput) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *StockInput) GetRatingSentiments() []*RatingSentiment {
+	if x != nil {
+		return x.RatingSentiments
+	}
+	return nil
+}
+
+func (x *StockInput) GetNumericalIndicators() []*NumericalIndicator {
+	if x != nil {
+		return x.NumericalIndicators
+	}
+	return nil
+}
+
+type CreateStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         *StockInput            `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStockRequest) Reset() {
+	*x = CreateStockRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStockRequest) ProtoMessage() {}
+
+func (x *CreateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStockRequest.ProtoReflect.Descriptor instead.
+func (*CreateStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateStockRequest) GetStock() *StockInput {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+// Exactly one of the identifiers selects the stock
+type GetStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*GetStockRequest_Uuid
+	//	*GetStockRequest_Ticker
+	Key           isGetStockRequest_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetStockRequest) GetKey() isGetStockRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *GetStockRequest) GetUuid() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetStockRequest_Uuid); ok {
+			return x.Uuid
+		}
+	}
+	return ""
+}
+
+func (x *GetStockRequest) GetTicker() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetStockRequest_Ticker); ok {
+			return x.Ticker
+		}
+	}
+	return ""
+}
+
+type isGetStockRequest_Key interface {
+	isGetStockRequest_Key()
+}
+
+type GetStockRequest_Uuid struct {
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3,oneof"`
+}
+
+type GetStockRequest_Ticker struct {
+	Ticker string `protobuf:"bytes,2,opt,name=ticker,proto3,oneof"`
+}
+
+func (*GetStockRequest_Uuid) isGetStockRequest_Key() {}
+
+func (*GetStockRequest_Ticker) isGetStockRequest_Key() {}
+
+// Only the set fields are applied (validators.StockUpdateRequest)
+type UpdateStockRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Uuid                string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Ticker              *string                `protobuf:"bytes,2,opt,name=ticker,proto3,oneof" json:"ticker,omitempty"`
+	Company             *string                `protobuf:"bytes,3,opt,name=company,proto3,oneof" json:"company,omitempty"`
+	Action              *string                `protobuf:"bytes,4,opt,name=action,proto3,oneof" json:"action,omitempty"`
+	Brokerage           *string                `protobuf:"bytes,5,opt,name=brokerage,proto3,oneof" json:"brokerage,omitempty"`
+	Date                *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	Cluster             *int32                 `protobuf:"varint,7,opt,name=cluster,proto3,oneof" json:"cluster,omitempty"`
+	TargetFrom          *float64               `protobuf:"fixed64,8,opt,name=target_from,json=targetFrom,proto3,oneof" json:"target_from,omitempty"`
+	TargetTo            *float64               `protobuf:"fixed64,9,opt,name=target_to,json=targetTo,proto3,oneof" json:"target_to,omitempty"`
+	TargetDelta         *float64               `protobuf:"fixed64,10,opt,name=target_delta,json=targetDelta,proto3,oneof" json:"target_delta,omitempty"`
+	LastClose           *float64               `protobuf:"fixed64,11,opt,name=last_close,json=lastClose,proto3,oneof" json:"last_close,omitempty"`
+	RatingFrom          *string                `protobuf:"bytes,12,opt,name=rating_from,json=ratingFrom,proto3,oneof" json:"rating_from,omitempty"`
+	RatingTo            *string                `protobuf:"bytes,13,opt,name=rating_to,json=ratingTo,proto3,oneof" json:"rating_to,omitempty"`
+	RatingSentiments    []*RatingSentiment     `protobuf:"bytes,14,rep,name=rating_sentiments,json=ratingSentiments,proto3" json:"rating_sentiments,omitempty"`
+	NumericalIndicators []*NumericalIndicator  `protobuf:"bytes,15,rep,name=numerical_indicators,json=numericalIndicators,proto3" json:"numerical_indicators,omitempty"`
+	// Conditional write, like the If-Match header of the REST API
+	IfMatch       string `protobuf:"bytes,16,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockRequest) Reset() {
+	*x = UpdateStockRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockRequest) ProtoMessage() {}
+
+func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateStockRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetTicker() string {
+	if x != nil && x.Ticker != nil {
+		return *x.Ticker
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetCompany() string {
+	if x != nil && x.Company != nil {
+		return *x.Company
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetAction() string {
+	if x != nil && x.Action != nil {
+		return *x.Action
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetBrokerage() string {
+	if x != nil && x.Brokerage != nil {
+		return *x.Brokerage
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *UpdateStockRequest) GetCluster() int32 {
+	if x != nil && x.Cluster != nil {
+		return *x.Cluster
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetTargetFrom() float64 {
+	if x != nil && x.TargetFrom != nil {
+		return *x.TargetFrom
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetTargetTo() float64 {
+	if x != nil && x.TargetTo != nil {
+		return *x.TargetTo
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetTargetDelta() float64 {
+	if x != nil && x.TargetDelta != nil {
+		return *x.TargetDelta
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetLastClose() float64 {
+	if x != nil && x.LastClose != nil {
+		return *x.LastClose
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetRatingFrom() string {
+	if x != nil && x.RatingFrom != nil {
+		return *x.RatingFrom
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetRatingTo() string {
+	if x != nil && x.RatingTo != nil {
+		return *x.RatingTo
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetRatingSentiments() []*RatingSentiment {
+	if x != nil {
+		return x.RatingSentiments
+	}
+	return nil
+}
+
+func (x *UpdateStockRequest) GetNumericalIndicators() []*NumericalIndicator {
+	if x != nil {
+		return x.NumericalIndicators
+	}
+	return nil
+}
+
+func (x *UpdateStockRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+type DeleteStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	IfMatch       string                 `protobuf:"bytes,2,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStockRequest) Reset() {
+	*x = DeleteStockRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStockRequest) ProtoMessage() {}
+
+func (x *DeleteStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStockRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteStockRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *DeleteStockRequest) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+type DeleteStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStockResponse) Reset() {
+	*x = DeleteStockResponse{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStockResponse) ProtoMessage() {}
+
+func (x *DeleteStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStockResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{8}
+}
+
+type ListClustersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersRequest) Reset() {
+	*x = ListClustersRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersRequest) ProtoMessage() {}
+
+func (x *ListClustersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersRequest.ProtoReflect.Descriptor instead.
+func (*ListClustersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{9}
+}
+
+type ListClustersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []int32                `protobuf:"varint,1,rep,packed,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersResponse) Reset() {
+	*x = ListClustersResponse{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersResponse) ProtoMessage() {}
+
+func (x *ListClustersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersResponse.ProtoReflect.Descriptor instead.
+func (*ListClustersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListClustersResponse) GetClusters() []int32 {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type Weight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Weight) Reset() {
+	*x = Weight{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Weight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weight) ProtoMessage() {}
+
+func (x *Weight) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weight.ProtoReflect.Descriptor instead.
+func (*Weight) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Weight) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Weight) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type RangeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Min           *float64               `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeFilter) Reset() {
+	*x = RangeFilter{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeFilter) ProtoMessage() {}
+
+func (x *RangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeFilter.ProtoReflect.Descriptor instead.
+func (*RangeFilter) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RangeFilter) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *RangeFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+// FilterClusterGrouped: stocks of a cluster, optionally restricted to one grouping value and
+// ranked by the weighted score of the given indicators and sentiments
+type FilterClusterRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Cluster          int32                  `protobuf:"varint,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	GroupingColumn   string                 `protobuf:"bytes,2,opt,name=grouping_column,json=groupingColumn,proto3" json:"grouping_column,omitempty"`
+	GroupingValue    string                 `protobuf:"bytes,3,opt,name=grouping_value,json=groupingValue,proto3" json:"grouping_value,omitempty"`
+	SortBy           string                 `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Order            string                 `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	Page             int32                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage          int32                  `protobuf:"varint,7,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	NumericalWeights []*Weight              `protobuf:"bytes,8,rep,name=numerical_weights,json=numericalWeights,proto3" json:"numerical_weights,omitempty"`
+	RatingWeights    []*Weight              `protobuf:"bytes,9,rep,name=rating_weights,json=ratingWeights,proto3" json:"rating_weights,omitempty"`
+	Tags             []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Ranges           []*RangeFilter         `protobuf:"bytes,11,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FilterClusterRequest) Reset() {
+	*x = FilterClusterRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterClusterRequest) ProtoMessage() {}
+
+func (x *FilterClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterClusterRequest.ProtoReflect.Descriptor instead.
+func (*FilterClusterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FilterClusterRequest) GetCluster() int32 {
+	if x != nil {
+		return x.Cluster
+	}
+	return 0
+}
+
+func (x *FilterClusterRequest) GetGroupingColumn() string {
+	if x != nil {
+		return x.GroupingColumn
+	}
+	return ""
+}
+
+func (x *FilterClusterRequest) GetGroupingValue() string {
+	if x != nil {
+		return x.GroupingValue
+	}
+	return ""
+}
+
+func (x *FilterClusterRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *FilterClusterRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *FilterClusterRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FilterClusterRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *FilterClusterRequest) GetNumericalWeights() []*Weight {
+	if x != nil {
+		return x.NumericalWeights
+	}
+	return nil
+}
+
+func (x *FilterClusterRequest) GetRatingWeights() []*Weight {
+	if x != nil {
+		return x.RatingWeights
+	}
+	return nil
+}
+
+func (x *FilterClusterRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *FilterClusterRequest) GetRanges() []*RangeFilter {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,6,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	SortBy        string                 `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Order         string                 `protobuf:"bytes,8,opt,name=order,proto3" json:"order,omitempty"`
+	WeightsHash   string                 `protobuf:"bytes,9,opt,name=weights_hash,json=weightsHash,proto3" json:"weights_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Pagination) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *Pagination) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pagination) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *Pagination) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *Pagination) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *Pagination) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *Pagination) GetWeightsHash() string {
+	if x != nil {
+		return x.WeightsHash
+	}
+	return ""
+}
+
+type StockPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Stock               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockPage) Reset() {
+	*x = StockPage{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockPage) ProtoMessage() {}
+
+func (x *StockPage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockPage.ProtoReflect.Descriptor instead.
+func (*StockPage) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StockPage) GetItems() []*Stock {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *StockPage) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type RankClusterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cluster       int32                  `protobuf:"varint,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Weights       []*Weight              `protobuf:"bytes,2,rep,name=weights,proto3" json:"weights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankClusterRequest) Reset() {
+	*x = RankClusterRequest{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankClusterRequest) ProtoMessage() {}
+
+func (x *RankClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankClusterRequest.ProtoReflect.Descriptor instead.
+func (*RankClusterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RankClusterRequest) GetCluster() int32 {
+	if x != nil {
+		return x.Cluster
+	}
+	return 0
+}
+
+func (x *RankClusterRequest) GetWeights() []*Weight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type RankedStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedStock) Reset() {
+	*x = RankedStock{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedStock) ProtoMessage() {}
+
+func (x *RankedStock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedStock.ProtoReflect.Descriptor instead.
+func (*RankedStock) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RankedStock) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *RankedStock) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type RankClusterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*RankedStock         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankClusterResponse) Reset() {
+	*x = RankClusterResponse{}
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankClusterResponse) ProtoMessage() {}
+
+func (x *RankClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stock_v1_stock_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankClusterResponse.ProtoReflect.Descriptor instead.
+func (*RankClusterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stock_v1_stock_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RankClusterResponse) GetResults() []*RankedStock {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_proto_stock_v1_stock_proto protoreflect.FileDescriptor
+
+const file_proto_stock_v1_stock_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/stock/v1/stock.proto\x12\bstock.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x01\n" +
+	"\x0fRatingSentiment\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\tR\x06rating\x12!\n" +
+	"\frating_score\x18\x04 \x01(\x01R\vratingScore\x12*\n" +
+	"\x11norm_rating_score\x18\x05 \x01(\x01R\x0fnormRatingScore\"q\n" +
+	"\x12NumericalIndicator\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x1d\n" +
+	"\n" +
+	"norm_value\x18\x04 \x01(\x01R\tnormValue\"\xa2\x06\n" +
+	"\x05Stock\x12\x12\n" +
+	"\x02id\x18\x01 \x01(\rB\x02\x18\x01R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06ticker\x18\x03 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x04 \x01(\tR\acompany\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1c\n" +
+	"\tbrokerage\x18\x06 \x01(\tR\tbrokerage\x12.\n" +
+	"\x04date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\acluster\x18\b \x01(\x05R\acluster\x12\x1f\n" +
+	"\vtarget_from\x18\t \x01(\x01R\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\n" +
+	" \x01(\x01R\btargetTo\x12!\n" +
+	"\ftarget_delta\x18\v \x01(\x01R\vtargetDelta\x12\x1d\n" +
+	"\n" +
+	"last_close\x18\f \x01(\x01R\tlastClose\x12\x1f\n" +
+	"\vrating_from\x18\r \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\x0e \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vfinal_score\x18\x0f \x01(\x01R\n" +
+	"finalScore\x12*\n" +
+	"\x0eweighted_score\x18\x10 \x01(\x01H\x00R\rweightedScore\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04tags\x18\x13 \x03(\tR\x04tags\x12F\n" +
+	"\x11rating_sentiments\x18\x14 \x03(\v2\x19.stock.v1.RatingSentimentR\x10ratingSentiments\x12O\n" +
+	"\x14numerical_indicators\x18\x15 \x03(\v2\x1c.stock.v1.NumericalIndicatorR\x13numericalIndicatorsB\x11\n" +
+	"\x0f_weighted_score\"\xa6\x04\n" +
+	"\n" +
+	"StockInput\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x01(\tR\tbrokerage\x12.\n" +
+	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\acluster\x18\x06 \x01(\x05H\x00R\acluster\x88\x01\x01\x12\x1f\n" +
+	"\vtarget_from\x18\a \x01(\x01R\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\b \x01(\x01R\btargetTo\x12!\n" +
+	"\ftarget_delta\x18\t \x01(\x01R\vtargetDelta\x12\x1d\n" +
+	"\n" +
+	"last_close\x18\n" +
+	" \x01(\x01R\tlastClose\x12\x1f\n" +
+	"\vrating_from\x18\v \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\f \x01(\tR\bratingTo\x12F\n" +
+	"\x11rating_sentiments\x18\r \x03(\v2\x19.stock.v1.RatingSentimentR\x10ratingSentiments\x12O\n" +
+	"\x14numerical_indicators\x18\x0e \x03(\v2\x1c.stock.v1.NumericalIndicatorR\x13numericalIndicatorsB\n" +
+	"\n" +
+	"\b_cluster\"@\n" +
+	"\x12CreateStockRequest\x12*\n" +
+	"\x05stock\x18\x01 \x01(\v2\x14.stock.v1.StockInputR\x05stock\"H\n" +
+	"\x0fGetStockRequest\x12\x14\n" +
+	"\x04uuid\x18\x01 \x01(\tH\x00R\x04uuid\x12\x18\n" +
+	"\x06ticker\x18\x02 \x01(\tH\x00R\x06tickerB\x05\n" +
+	"\x03key\"\x9b\x06\n" +
+	"\x12UpdateStockRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\x06ticker\x18\x02 \x01(\tH\x00R\x06ticker\x88\x01\x01\x12\x1d\n" +
+	"\acompany\x18\x03 \x01(\tH\x01R\acompany\x88\x01\x01\x12\x1b\n" +
+	"\x06action\x18\x04 \x01(\tH\x02R\x06action\x88\x01\x01\x12!\n" +
+	"\tbrokerage\x18\x05 \x01(\tH\x03R\tbrokerage\x88\x01\x01\x12.\n" +
+	"\x04date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\acluster\x18\a \x01(\x05H\x04R\acluster\x88\x01\x01\x12$\n" +
+	"\vtarget_from\x18\b \x01(\x01H\x05R\n" +
+	"targetFrom\x88\x01\x01\x12 \n" +
+	"\ttarget_to\x18\t \x01(\x01H\x06R\btargetTo\x88\x01\x01\x12&\n" +
+	"\ftarget_delta\x18\n" +
+	" \x01(\x01H\aR\vtargetDelta\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"last_close\x18\v \x01(\x01H\bR\tlastClose\x88\x01\x01\x12$\n" +
+	"\vrating_from\x18\f \x01(\tH\tR\n" +
+	"ratingFrom\x88\x01\x01\x12 \n" +
+	"\trating_to\x18\r \x01(\tH\n" +
+	"R\bratingTo\x88\x01\x01\x12F\n" +
+	"\x11rating_sentiments\x18\x0e \x03(\v2\x19.stock.v1.RatingSentimentR\x10ratingSentiments\x12O\n" +
+	"\x14numerical_indicators\x18\x0f \x03(\v2\x1c.stock.v1.NumericalIndicatorR\x13numericalIndicators\x12\x19\n" +
+	"\bif_match\x18\x10 \x01(\tR\aifMatchB\t\n" +
+	"\a_tickerB\n" +
+	"\n" +
+	"\b_companyB\t\n" +
+	"\a_actionB\f\n" +
+	"\n" +
+	"_brokerageB\n" +
+	"\n" +
+	"\b_clusterB\x0e\n" +
+	"\f_target_fromB\f\n" +
+	"\n" +
+	"_target_toB\x0f\n" +
+	"\r_target_deltaB\r\n" +
+	"\v_last_closeB\x0e\n" +
+	"\f_rating_fromB\f\n" +
+	"\n" +
+	"_rating_to\"C\n" +
+	"\x12DeleteStockRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x19\n" +
+	"\bif_match\x18\x02 \x01(\tR\aifMatch\"\x15\n" +
+	"\x13DeleteStockResponse\"\x15\n" +
+	"\x13ListClustersRequest\"2\n" +
+	"\x14ListClustersResponse\x12\x1a\n" +
+	"\bclusters\x18\x01 \x03(\x05R\bclusters\"4\n" +
+	"\x06Weight\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\"c\n" +
+	"\vRangeFilter\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12\x15\n" +
+	"\x03min\x18\x02 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x03 \x01(\x01H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\x99\x03\n" +
+	"\x14FilterClusterRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\x05R\acluster\x12'\n" +
+	"\x0fgrouping_column\x18\x02 \x01(\tR\x0egroupingColumn\x12%\n" +
+	"\x0egrouping_value\x18\x03 \x01(\tR\rgroupingValue\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05order\x18\x05 \x01(\tR\x05order\x12\x12\n" +
+	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\a \x01(\x05R\aperPage\x12=\n" +
+	"\x11numerical_weights\x18\b \x03(\v2\x10.stock.v1.WeightR\x10numericalWeights\x127\n" +
+	"\x0erating_weights\x18\t \x03(\v2\x10.stock.v1.WeightR\rratingWeights\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12-\n" +
+	"\x06ranges\x18\v \x03(\v2\x15.stock.v1.RangeFilterR\x06ranges\"\x80\x02\n" +
+	"\n" +
+	"Pagination\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x04 \x01(\x05R\aperPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\x06 \x01(\bR\ahasNext\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05order\x18\b \x01(\tR\x05order\x12!\n" +
+	"\fweights_hash\x18\t \x01(\tR\vweightsHash\"h\n" +
+	"\tStockPage\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.stock.v1.StockR\x05items\x124\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x14.stock.v1.PaginationR\n" +
+	"pagination\"Z\n" +
+	"\x12RankClusterRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\x05R\acluster\x12*\n" +
+	"\aweights\x18\x02 \x03(\v2\x10.stock.v1.WeightR\aweights\"J\n" +
+	"\vRankedStock\x12%\n" +
+	"\x05stock\x18\x01 \x01(\v2\x0f.stock.v1.StockR\x05stock\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"F\n" +
+	"\x13RankClusterResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.stock.v1.RankedStockR\aresults2\xef\x03\n" +
+	"\fStockService\x12<\n" +
+	"\vCreateStock\x12\x1c.stock.v1.CreateStockRequest\x1a\x0f.stock.v1.Stock\x126\n" +
+	"\bGetStock\x12\x19.stock.v1.GetStockRequest\x1a\x0f.stock.v1.Stock\x12<\n" +
+	"\vUpdateStock\x12\x1c.stock.v1.UpdateStockRequest\x1a\x0f.stock.v1.Stock\x12J\n" +
+	"\vDeleteStock\x12\x1c.stock.v1.DeleteStockRequest\x1a\x1d.stock.v1.DeleteStockResponse\x12M\n" +
+	"\fListClusters\x12\x1d.stock.v1.ListClustersRequest\x1a\x1e.stock.v1.ListClustersResponse\x12D\n" +
+	"\rFilterCluster\x12\x1e.stock.v1.FilterClusterRequest\x1a\x13.stock.v1.StockPage\x12J\n" +
+	"\vRankCluster\x12\x1c.stock.v1.RankClusterRequest\x1a\x1d.stock.v1.RankClusterResponseB&Z$dataextractor/proto/stock/v1;stockv1b\x06proto3"
+
+var (
+	file_proto_stock_v1_stock_proto_rawDescOnce sync.Once
+	file_proto_stock_v1_stock_proto_rawDescData []byte
+)
+
+func file_proto_stock_v1_stock_proto_rawDescGZIP() []byte {
+	file_proto_stock_v1_stock_proto_rawDescOnce.Do(func() {
+		file_proto_stock_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_stock_v1_stock_proto_rawDesc), len(file_proto_stock_v1_stock_proto_rawDesc)))
+	})
+	return file_proto_stock_v1_stock_proto_rawDescData
+}
+
+var file_proto_stock_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_proto_stock_v1_stock_proto_goTypes = []any{
+	(*RatingSentiment)(nil),       // 0: stock.v1.RatingSentiment
+	(*NumericalIndicator)(nil),    // 1: stock.v1.NumericalIndicator
+	(*Stock)(nil),                 // 2: stock.v1.Stock
+	(*StockInput)(nil),            // 3: stock.v1.StockInput
+	(*CreateStockRequest)(nil),    // 4: stock.v1.CreateStockRequest
+	(*GetStockRequest)(nil),       // 5: stock.v1.GetStockRequest
+	(*UpdateStockRequest)(nil),    // 6: stock.v1.UpdateStockRequest
+	(*DeleteStockRequest)(nil),    // 7: stock.v1.DeleteStockRequest
+	(*DeleteStockResponse)(nil),   // 8: stock.v1.DeleteStockResponse
+	(*ListClustersRequest)(nil),   // 9: stock.v1.ListClustersRequest
+	(*ListClustersResponse)(nil),  // 10: stock.v1.ListClustersResponse
+	(*Weight)(nil),                // 11: stock.v1.Weight
+	(*RangeFilter)(nil),           // 12: stock.v1.RangeFilter
+	(*FilterClusterRequest)(nil),  // 13: stock.v1.FilterClusterRequest
+	(*Pagination)(nil),            // 14: stock.v1.Pagination
+	(*StockPage)(nil),             // 15: stock.v1.StockPage
+	(*RankClusterRequest)(nil),    // 16: stock.v1.RankClusterRequest
+	(*RankedStock)(nil),           // 17: stock.v1.RankedStock
+	(*RankClusterResponse)(nil),   // 18: stock.v1.RankClusterResponse
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+}
+var file_proto_stock_v1_stock_proto_depIdxs = []int32{
+	19, // 0: stock.v1.Stock.date:type_name -> google.protobuf.Timestamp
+	19, // 1: stock.v1.Stock.created_at:type_name -> google.protobuf.Timestamp
+	19, // 2: stock.v1.Stock.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: stock.v1.Stock.rating_sentiments:type_name -> stock.v1.RatingSentiment
+	1,  // 4: stock.v1.Stock.numerical_indicators:type_name -> stock.v1.NumericalIndicator
+	19, // 5: stock.v1.StockInput.date:type_name -> google.protobuf.Timestamp
+	0,  // 6: stock.v1.StockInput.rating_sentiments:type_name -> stock.v1.RatingSentiment
+	1,  // 7: stock.v1.StockInput.numerical_indicators:type_name -> stock.v1.NumericalIndicator
+	3,  // 8: stock.v1.CreateStockRequest.stock:type_name -> stock.v1.StockInput
+	19, // 9: stock.v1.UpdateStockRequest.date:type_name -> google.protobuf.Timestamp
+	0,  // 10: stock.v1.UpdateStockRequest.rating_sentiments:type_name -> stock.v1.RatingSentiment
+	1,  // 11: stock.v1.UpdateStockRequest.numerical_indicators:type_name -> stock.v1.NumericalIndicator
+	11, // 12: stock.v1.FilterClusterRequest.numerical_weights:type_name -> stock.v1.Weight
+	11, // 13: stock.v1.FilterClusterRequest.rating_weights:type_name -> stock.v1.Weight
+	12, // 14: stock.v1.FilterClusterRequest.ranges:type_name -> stock.v1.RangeFilter
+	2,  // 15: stock.v1.StockPage.items:type_name -> stock.v1.Stock
+	14, // 16: stock.v1.StockPage.pagination:type_name -> stock.v1.Pagination
+	11, // 17: stock.v1.RankClusterRequest.weights:type_name -> stock.v1.Weight
+	2,  // 18: stock.v1.RankedStock.stock:type_name -> stock.v1.Stock
+	17, // 19: stock.v1.RankClusterResponse.results:type_name -> stock.v1.RankedStock
+	4,  // 20: stock.v1.StockService.CreateStock:input_type -> stock.v1.CreateStockRequest
+	5,  // 21: stock.v1.StockService.GetStock:input_type -> stock.v1.GetStockRequest
+	6,  // 22: stock.v1.StockService.UpdateStock:input_type -> stock.v1.UpdateStockRequest
+	7,  // 23: stock.v1.StockService.DeleteStock:input_type -> stock.v1.DeleteStockRequest
+	9,  // 24: stock.v1.StockService.ListClusters:input_type -> stock.v1.ListClustersRequest
+	13, // 25: stock.v1.StockService.FilterCluster:input_type -> stock.v1.FilterClusterRequest
+	16, // 26: stock.v1.StockService.RankCluster:input_type -> stock.v1.RankClusterRequest
+	2,  // 27: stock.v1.StockService.CreateStock:output_type -> stock.v1.Stock
+	2,  // 28: stock.v1.StockService.GetStock:output_type -> stock.v1.Stock
+	2,  // 29: stock.v1.StockService.UpdateStock:output_type -> stock.v1.Stock
+	8,  // 30: stock.v1.StockService.DeleteStock:output_type -> stock.v1.DeleteStockResponse
+	10, // 31: stock.v1.StockService.ListClusters:output_type -> stock.v1.ListClustersResponse
+	15, // 32: stock.v1.StockService.FilterCluster:output_type -> stock.v1.StockPage
+	18, // 33: stock.v1.StockService.RankCluster:output_type -> stock.v1.RankClusterResponse
+	27, // [27:34] is the sub-list for method output_type
+	20, // [20:27] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_proto_stock_v1_stock_proto_init() }
+func file_proto_stock_v1_stock_proto_init() {
+	if File_proto_stock_v1_stock_proto != nil {
+		return
+	}
+	file_proto_stock_v1_stock_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_stock_v1_stock_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_stock_v1_stock_proto_msgTypes[5].OneofWrappers = []any{
+		(*GetStockRequest_Uuid)(nil),
+		(*GetStockRequest_Ticker)(nil),
+	}
+	file_proto_stock_v1_stock_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_stock_v1_stock_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stock_v1_stock_proto_rawDesc), len(file_proto_stock_v1_stock_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_stock_v1_stock_proto_goTypes,
+		DependencyIndexes: file_proto_stock_v1_stock_proto_depIdxs,
+		MessageInfos:      file_proto_stock_v1_stock_proto_msgTypes,
+	}.Build()
+	File_proto_stock_v1_stock_proto = out.File
+	file_proto_stock_v1_stock_proto_goTypes = nil
+	file_proto_stock_v1_stock_proto_depIdxs = nil
+}
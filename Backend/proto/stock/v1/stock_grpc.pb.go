@@ -0,0 +1,363 @@
+// gRPC contract over the stock service layer (service.StockServiceInterface): CRUD, the cluster
+// filter and weighted ranking. Messages mirror the REST JSON shapes (validators.StockBase,
+// models.StockDataPoint, service.Pagination) so both transports validate and score identically.
+//
+// Generate the Go stubs into Backend/proto/stock/v1 with:
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/stock/v1/stock.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: proto/stock/v1/stock.proto
+
+package stockv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockService_CreateStock_FullMethodName   = "/stock.v1.StockService/CreateStock"
+	StockService_GetStock_FullMethodName      = "/stock.v1.StockService/GetStock"
+	StockService_UpdateStock_FullMethodName   = "/stock.v1.StockService/UpdateStock"
+	StockService_DeleteStock_FullMethodName   = "/stock.v1.StockService/DeleteStock"
+	StockService_ListClusters_FullMethodName  = "/stock.v1.StockService/ListClusters"
+	StockService_FilterCluster_FullMethodName = "/stock.v1.StockService/FilterCluster"
+	StockService_RankCluster_FullMethodName   = "/stock.v1.StockService/RankCluster"
+)
+
+// StockServiceClient is the client API for StockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StockServiceClient interface {
+	// CRUD
+	CreateStock(ctx context.Context, in *CreateStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	DeleteStock(ctx context.Context, in *DeleteStockRequest, opts ...grpc.CallOption) (*DeleteStockResponse, error)
+	// Clusters
+	ListClusters(ctx context.Context, in *ListClustersRequest, opts ...grpc.CallOption) (*ListClustersResponse, error)
+	FilterCluster(ctx context.Context, in *FilterClusterRequest, opts ...grpc.CallOption) (*StockPage, error)
+	// Weighted ranking of a cluster (RankByWeightedScore)
+	RankCluster(ctx context.Context, in *RankClusterRequest, opts ...grpc.CallOption) (*RankClusterResponse, error)
+}
+
+type stockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockServiceClient(cc grpc.ClientConnInterface) StockServiceClient {
+	return &stockServiceClient{cc}
+}
+
+func (c *stockServiceClient) CreateStock(ctx context.Context, in *CreateStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, StockService_CreateStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, StockService_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, StockService_UpdateStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) DeleteStock(ctx context.Context, in *DeleteStockRequest, opts ...grpc.CallOption) (*DeleteStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStockResponse)
+	err := c.cc.Invoke(ctx, StockService_DeleteStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListClusters(ctx context.Context, in *ListClustersRequest, opts ...grpc.CallOption) (*ListClustersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClustersResponse)
+	err := c.cc.Invoke(ctx, StockService_ListClusters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) FilterCluster(ctx context.Context, in *FilterClusterRequest, opts ...grpc.CallOption) (*StockPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockPage)
+	err := c.cc.Invoke(ctx, StockService_FilterCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) RankCluster(ctx context.Context, in *RankClusterRequest, opts ...grpc.CallOption) (*RankClusterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RankClusterResponse)
+	err := c.cc.Invoke(ctx, StockService_RankCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockServiceServer is the server API for StockService service.
+// All implementations must embed UnimplementedStockServiceServer
+// for forward compatibility.
+type StockServiceServer interface {
+	// CRUD
+	CreateStock(context.Context, *CreateStockRequest) (*Stock, error)
+	GetStock(context.Context, *GetStockRequest) (*Stock, error)
+	UpdateStock(context.Context, *UpdateStockRequest) (*Stock, error)
+	DeleteStock(context.Context, *DeleteStockRequest) (*DeleteStockResponse, error)
+	// Clusters
+	ListClusters(context.Context, *ListClustersRequest) (*ListClustersResponse, error)
+	FilterCluster(context.Context, *FilterClusterRequest) (*StockPage, error)
+	// Weighted ranking of a cluster (RankByWeightedScore)
+	RankCluster(context.Context, *RankClusterRequest) (*RankClusterResponse, error)
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+// UnimplementedStockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockServiceServer struct{}
+
+func (UnimplementedStockServiceServer) CreateStock(context.Context, *CreateStockRequest) (*Stock, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateStock not implemented")
+}
+func (UnimplementedStockServiceServer) GetStock(context.Context, *GetStockRequest) (*Stock, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedStockServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*Stock, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateStock not implemented")
+}
+func (UnimplementedStockServiceServer) DeleteStock(context.Context, *DeleteStockRequest) (*DeleteStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteStock not implemented")
+}
+func (UnimplementedStockServiceServer) ListClusters(context.Context, *ListClustersRequest) (*ListClustersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListClusters not implemented")
+}
+func (UnimplementedStockServiceServer) FilterCluster(context.Context, *FilterClusterRequest) (*StockPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method FilterCluster not implemented")
+}
+func (UnimplementedStockServiceServer) RankCluster(context.Context, *RankClusterRequest) (*RankClusterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RankCluster not implemented")
+}
+func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
+func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockServiceServer will
+// result in compilation errors.
+type UnsafeStockServiceServer interface {
+	mustEmbedUnimplementedStockServiceServer()
+}
+
+func RegisterStockServiceServer(s grpc.ServiceRegistrar, srv StockServiceServer) {
+	// If the following call panics, it indicates UnimplementedStockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockService_ServiceDesc, srv)
+}
+
+func _StockService_CreateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).CreateStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_CreateStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).CreateStock(ctx, req.(*CreateStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).UpdateStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_UpdateStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).UpdateStock(ctx, req.(*UpdateStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_DeleteStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).DeleteStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_DeleteStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).DeleteStock(ctx, req.(*DeleteStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListClusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClustersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListClusters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListClusters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListClusters(ctx, req.(*ListClustersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_FilterCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).FilterCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_FilterCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).FilterCluster(ctx, req.(*FilterClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_RankCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).RankCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_RankCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).RankCluster(ctx, req.(*RankClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stock.v1.StockService",
+	HandlerType: (*StockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateStock",
+			Handler:    _StockService_CreateStock_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _StockService_GetStock_Handler,
+		},
+		{
+			MethodName: "UpdateStock",
+			Handler:    _StockService_UpdateStock_Handler,
+		},
+		{
+			MethodName: "DeleteStock",
+			Handler:    _StockService_DeleteStock_Handler,
+		},
+		{
+			MethodName: "ListClusters",
+			Handler:    _StockService_ListClusters_Handler,
+		},
+		{
+			MethodName: "FilterCluster",
+			Handler:    _StockService_FilterCluster_Handler,
+		},
+		{
+			MethodName: "RankCluster",
+			Handler:    _StockService_RankCluster_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/stock/v1/stock.proto",
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+)
+
+// PercentileRank places a value within a population: Percentile is the share (0-100) of the
+// population with a strictly lower value
+type PercentileRank struct {
+	Name       string  `json:"name"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"`
+}
+
+// StockPercentiles holds a stock's percentile ranks within a cluster
+type StockPercentiles struct {
+	StockID    uint             `json:"-"`
+	Cluster    int              `json:"cluster"`
+	Population int64            `json:"population"`
+	FinalScore PercentileRank   `json:"final_score"`
+	Indicators []PercentileRank `json:"indicators"`
+}
+
+// GetPercentileRanks ranks a stock's final_score and normalized indicators against the stocks of a
+// cluster using PERCENT_RANK window functions. The stock is always part of the ranked population,
+// so it can be compared against a cluster it does not belong to.
+func (r *CockroachDBRepository) GetPercentileRanks(stockID uint, cluster int) (*StockPercentiles, error) {
+	sdpTable := (&models.StockDataPoint{}).TableName()
+	niTable := (&models.NumericalIndicator{}).TableName()
+
+	result := &StockPercentiles{StockID: stockID, Cluster: cluster}
+
+	// Final score rank (and population size) within the cluster
+	var finalScore struct {
+		Value      float64
+		Percentile float64
+		Population int64
+	}
+	if err := r.db.Raw(fmt.Sprintf(`SELECT value, percentile, population FROM (
+			SELECT id, final_score AS value,
+				PERCENT_RANK() OVER (ORDER BY final_score) * 100 AS percentile,
+				COUNT(*) OVER () AS population
+			FROM %s WHERE cluster = ? OR id = ?
+		) ranked WHERE id = ?`, sdpTable), cluster, stockID, stockID).
+		Scan(&finalScore).Error; err != nil {
+		return nil, fmt.Errorf("failed to rank final score for stock %d: %w", stockID, err)
+	}
+	result.Population = finalScore.Population
+	result.FinalScore = PercentileRank{Name: "final_score", Value: finalScore.Value, Percentile: finalScore.Percentile}
+
+	// Normalized indicator ranks, each indicator ranked separately
+	if err := r.db.Raw(fmt.Sprintf(`SELECT name, value, percentile FROM (
+			SELECT ni.stock_data_point_id, ni.name, ni.norm_value AS value,
+				PERCENT_RANK() OVER (PARTITION BY ni.name ORDER BY ni.norm_value) * 100 AS percentile
+			FROM %[1]s ni JOIN %[2]s sdp ON sdp.id = ni.stock_data_point_id
+			WHERE sdp.cluster = ? OR sdp.id = ?
+		) ranked WHERE stock_data_point_id = ? ORDER BY name`, niTable, sdpTable), cluster, stockID, stockID).
+		Scan(&result.Indicators).Error; err != nil {
+		return nil, fmt.Errorf("failed to rank indicators for stock %d: %w", stockID, err)
+	}
+
+	return result, nil
+}
+
+// MovingAverageWindows are the rolling windows (in days) computed by GetMovingAverages
+var MovingAverageWindows = []int{7, 30, 90}
+
+// MovingAveragePoint is an indicator value with its trailing 7/30/90 day means
+type MovingAveragePoint struct {
+	Name  string    `json:"name"`
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+	MA7   float64   `json:"ma_7" gorm:"column:ma_7"`
+	MA30  float64   `json:"ma_30" gorm:"column:ma_30"`
+	MA90  float64   `json:"ma_90" gorm:"column:ma_90"`
+}
+
+// GetMovingAverages computes rolling means of the named indicators of a ticker over the trailing
+// 7, 30 and 90 days of its indicator history, using RANGE window frames over the snapshot date
+func (r *CockroachDBRepository) GetMovingAverages(ticker string, names []string) ([]MovingAveragePoint, error) {
+	frames := make([]string, len(MovingAverageWindows))
+	for i, days := range MovingAverageWindows {
+		frames[i] = fmt.Sprintf(
+			"AVG(value) OVER (PARTITION BY name ORDER BY date RANGE BETWEEN INTERVAL '%d days' PRECEDING AND CURRENT ROW) AS ma_%d",
+			days-1, days)
+	}
+
+	var points []MovingAveragePoint
+	if err := r.db.Model(&models.IndicatorSnapshot{}).
+		Select("name, date, value, "+strings.Join(frames, ", ")).
+		Where("ticker = ? AND name IN ?", ticker, names).
+		Order("name, date").
+		Scan(&points).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute moving averages for ticker %s: %w", ticker, err)
+	}
+	return points, nil
+}
+
+// SimilarStock is a neighbor of a stock by cosine similarity of normalized indicators
+type SimilarStock struct {
+	ID         uint    `json:"-"`
+	UUID       string  `json:"uuid"`
+	Ticker     string  `json:"ticker"`
+	Company    string  `json:"company"`
+	Similarity float64 `json:"similarity"`
+	Distance   float64 `json:"distance"`
+}
+
+// GetSimilarStocks returns the stocks of a cluster nearest to ticker by cosine distance over their
+// normalized indicator vectors (compared on the indicators both stocks have), closest first
+func (r *CockroachDBRepository) GetSimilarStocks(ticker string, cluster int, limit int) ([]SimilarStock, error) {
+	sdpTable := (&models.StockDataPoint{}).TableName()
+	niTable := (&models.NumericalIndicator{}).TableName()
+
+	var neighbors []SimilarStock
+	if err := r.db.Raw(fmt.Sprintf(`WITH target AS (
+			SELECT ni.name, ni.norm_value FROM %[1]s ni
+			JOIN %[2]s sdp ON sdp.id = ni.stock_data_point_id
+			WHERE sdp.ticker = ?
+		), scored AS (
+			SELECT sdp.id, sdp.uuid, sdp.ticker, sdp.company,
+				SUM(ni.norm_value * t.norm_value) /
+					NULLIF(SQRT(SUM(ni.norm_value * ni.norm_value)) * SQRT(SUM(t.norm_value * t.norm_value)), 0) AS similarity
+			FROM %[1]s ni
+			JOIN target t ON t.name = ni.name
+			JOIN %[2]s sdp ON sdp.id = ni.stock_data_point_id
+			WHERE sdp.cluster = ? AND sdp.ticker <> ?
+			GROUP BY sdp.id, sdp.uuid, sdp.ticker, sdp.company
+		)
+		SELECT id, uuid, ticker, company, similarity, 1 - similarity AS distance
+		FROM scored WHERE similarity IS NOT NULL
+		ORDER BY similarity DESC, ticker
+		LIMIT ?`, niTable, sdpTable), ticker, cluster, ticker, limit).
+		Scan(&neighbors).Error; err != nil {
+		return nil, fmt.Errorf("failed to find stocks similar to %s: %w", ticker, err)
+	}
+	return neighbors, nil
+}
+
+// HeatmapDimensions are the columns that can be crossed with cluster in GetClusterHeatmap
+var HeatmapDimensions = []string{"action", "rating_to"}
+
+// HeatmapCell is the count and average final score of the stocks in one cluster/value pair
+type HeatmapCell struct {
+	Cluster  int     `json:"cluster"`
+	Value    string  `json:"value"`
+	Count    int64   `json:"count"`
+	AvgScore float64 `json:"avg_score"`
+}
+
+// GetClusterHeatmap counts stocks (and averages final_score) per cluster and dimension value, from the
+// cluster distribution summary when it is installed and with one GROUP BY over the stocks otherwise
+func (r *CockroachDBRepository) GetClusterHeatmap(dimension string) ([]HeatmapCell, error) {
+	if r.distributions.Load() {
+		return r.distributionHeatmap(dimension)
+	}
+	if !validateColumnName(dimension, HeatmapDimensions) {
+		return nil, apperrors.Validation("invalid heatmap dimension: %s. Allowed dimensions: %v", dimension, HeatmapDimensions)
+	}
+
+	var cells []HeatmapCell
+	if err := r.db.Model(&models.StockDataPoint{}).
+		Select(fmt.Sprintf("cluster, %s AS value, COUNT(*) AS count, AVG(final_score) AS avg_score", dimension)).
+		Group("cluster, " + dimension).
+		Order("cluster, " + dimension).
+		Scan(&cells).Error; err != nil {
+		return nil, fmt.Errorf("failed to build %s heatmap: %w", dimension, err)
+	}
+	return cells, nil
+}
+
+// Dispersion summarizes the spread of one column: mean, median, sample standard deviation and quartiles
+type Dispersion struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	StdDev float64 `json:"std_dev"`
+	Q1     float64 `json:"q1"`
+	Q3     float64 `json:"q3"`
+	IQR    float64 `json:"iqr"`
+}
+
+// ClusterDispersion is the spread of target_delta and final_score within one cluster
+type ClusterDispersion struct {
+	Cluster     int        `json:"cluster"`
+	Count       int64      `json:"count"`
+	TargetDelta Dispersion `json:"target_delta"`
+	FinalScore  Dispersion `json:"final_score"`
+}
+
+// dispersionColumns are the columns summarized by GetClusterDispersion
+var dispersionColumns = []string{"target_delta", "final_score"}
+
+// GetClusterDispersion computes median, standard deviation and interquartile range of target_delta and
+// final_score per cluster (optionally a single cluster) with ordered-set aggregates in one GROUP BY
+func (r *CockroachDBRepository) GetClusterDispersion(cluster *int) ([]ClusterDispersion, error) {
+	selects := []string{"cluster", "COUNT(*) AS count"}
+	for _, column := range dispersionColumns {
+		value := column + "::FLOAT"
+		selects = append(selects,
+			fmt.Sprintf("AVG(%s) AS %s_mean", value, column),
+			fmt.Sprintf("COALESCE(STDDEV_SAMP(%s), 0) AS %s_std_dev", value, column),
+			fmt.Sprintf("PERCENTILE_CONT(0.25) WITHIN GROUP (ORDER BY %s) AS %s_q1", value, column),
+			fmt.Sprintf("PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY %s) AS %s_median", value, column),
+			fmt.Sprintf("PERCENTILE_CONT(0.75) WITHIN GROUP (ORDER BY %s) AS %s_q3", value, column),
+		)
+	}
+
+	query := r.db.Model(&models.StockDataPoint{}).Select(strings.Join(selects, ", "))
+	if cluster != nil {
+		query = query.Where("cluster = ?", *cluster)
+	}
+
+	var rows []struct {
+		Cluster           int
+		Count             int64
+		TargetDeltaMean   float64
+		TargetDeltaStdDev float64
+		TargetDeltaQ1     float64
+		TargetDeltaMedian float64
+		TargetDeltaQ3     float64
+		FinalScoreMean    float64
+		FinalScoreStdDev  float64
+		FinalScoreQ1      float64
+		FinalScoreMedian  float64
+		FinalScoreQ3      float64
+	}
+	if err := query.Group("cluster").Order("cluster").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute cluster dispersion: %w", err)
+	}
+
+	dispersions := make([]ClusterDispersion, 0, len(rows))
+	for _, row := range rows {
+		dispersions = append(dispersions, ClusterDispersion{
+			Cluster: row.Cluster,
+			Count:   row.Count,
+			TargetDelta: Dispersion{
+				Mean:   row.TargetDeltaMean,
+				Median: row.TargetDeltaMedian,
+				StdDev: row.TargetDeltaStdDev,
+				Q1:     row.TargetDeltaQ1,
+				Q3:     row.TargetDeltaQ3,
+				IQR:    row.TargetDeltaQ3 - row.TargetDeltaQ1,
+			},
+			FinalScore: Dispersion{
+				Mean:   row.FinalScoreMean,
+				Median: row.FinalScoreMedian,
+				StdDev: row.FinalScoreStdDev,
+				Q1:     row.FinalScoreQ1,
+				Q3:     row.FinalScoreQ3,
+				IQR:    row.FinalScoreQ3 - row.FinalScoreQ1,
+			},
+		})
+	}
+	return dispersions, nil
+}
+
+// MoverMetrics are the columns GetTopMovers can rank by; each is indexed so the ORDER BY ... LIMIT
+// is served from the index
+var MoverMetrics = []string{"target_delta", "final_score"}
+
+// GetTopMovers returns the stocks with the largest positive (up) or negative (down) metric value,
+// optionally restricted to records dated within [from, to]
+func (r *CockroachDBRepository) GetTopMovers(metric string, up bool, from, to *time.Time, limit int) ([]models.StockDataPoint, error) {
+	if !validateColumnName(metric, MoverMetrics) {
+		return nil, apperrors.Validation("invalid metric: %s. Allowed metrics: %v", metric, MoverMetrics)
+	}
+
+	query := r.db.Model(&models.StockDataPoint{})
+	if up {
+		query = query.Where(metric + " > 0").Order(metric + " DESC")
+	} else {
+		query = query.Where(metric + " < 0").Order(metric + " ASC")
+	}
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date <= ?", *to)
+	}
+
+	var stocks []models.StockDataPoint
+	if err := query.Order("id").Limit(limit).Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get top movers by %s: %w", metric, err)
+	}
+	return stocks, nil
+}
+
+// ConsensusRating counts the brokerages whose latest rating for a ticker is Rating, with their mean target
+type ConsensusRating struct {
+	Rating     string  `json:"rating"`
+	Count      int64   `json:"count"`
+	MeanTarget float64 `json:"mean_target"`
+}
+
+// GetConsensusRatings takes each brokerage's most recent snapshot of ticker and groups those latest
+// ratings by rating_to, counting brokerages and averaging their target_to
+func (r *CockroachDBRepository) GetConsensusRatings(ticker string) ([]ConsensusRating, error) {
+	snapshotTable := (&models.StockSnapshot{}).TableName()
+
+	var ratings []ConsensusRating
+	if err := r.db.Raw(fmt.Sprintf(`WITH latest AS (
+			SELECT DISTINCT ON (brokerage) brokerage, rating_to, target_to
+			FROM %s WHERE ticker = ?
+			ORDER BY brokerage, date DESC
+		)
+		SELECT rating_to AS rating, COUNT(*) AS count, AVG(target_to::FLOAT) AS mean_target
+		FROM latest
+		GROUP BY rating_to
+		ORDER BY count DESC, rating_to`, snapshotTable), ticker).
+		Scan(&ratings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get consensus ratings for ticker %s: %w", ticker, err)
+	}
+	return ratings, nil
+}
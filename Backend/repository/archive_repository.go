@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// maxArchivedStocks caps the archived records returned for one ticker
+const maxArchivedStocks = 100
+
+// defaultArchiveBatchSize is the number of records moved per transaction when none is configured
+const defaultArchiveBatchSize = 500
+
+// ArchiveStocks moves the data points in scope to the stock archive, batchSize at a time: each
+// batch is copied (with its sentiments, indicators and tags) and deleted in one transaction, so a
+// record is always in exactly one of the two tables. progress is called after every batch with the
+// running total. It returns the number of records archived.
+func (r *CockroachDBRepository) ArchiveStocks(scope StockScope, batchSize int, jobID uint, progress func(batches, archived int)) (int, error) {
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+	archived, batches := 0, 0
+	for {
+		moved := 0
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var batch []models.StockDataPoint
+			if err := scope.apply(tx.Preload("RatingSentiments").Preload("NumericalIndicators").Preload("Tags")).
+				Order("id").Limit(batchSize).Find(&batch).Error; err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+
+			rows := make([]models.ArchivedStock, len(batch))
+			ids := make([]uint, len(batch))
+			for i := range batch {
+				payload, err := json.Marshal(batch[i])
+				if err != nil {
+					return fmt.Errorf("failed to encode stock %d: %w", batch[i].ID, err)
+				}
+				rows[i] = models.ArchivedStock{
+					StockID:          batch[i].ID,
+					UUID:             batch[i].UUID,
+					Ticker:           batch[i].Ticker,
+					Company:          batch[i].Company,
+					Cluster:          batch[i].Cluster,
+					Date:             batch[i].Date,
+					DatasetVersionID: batch[i].DatasetVersionID,
+					Payload:          string(payload),
+					JobID:            jobID,
+				}
+				ids[i] = batch[i].ID
+			}
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", ids).Delete(&models.StockDataPoint{}).Error; err != nil {
+				return err
+			}
+			moved = len(batch)
+			return nil
+		})
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive data points in scope %s: %w", scope, err)
+		}
+		if moved == 0 {
+			return archived, nil
+		}
+		archived += moved
+		batches++
+		if progress != nil {
+			progress(batches, archived)
+		}
+	}
+}
+
+// GetArchivedStocks returns the archived records of ticker, most recently archived first
+func (r *CockroachDBRepository) GetArchivedStocks(ticker string) ([]models.ArchivedStock, error) {
+	var archived []models.ArchivedStock
+	if err := r.db.Where("ticker = ?", ticker).Order("archived_at DESC, id DESC").Limit(maxArchivedStocks).Find(&archived).Error; err != nil {
+		return nil, fmt.Errorf("failed to get archived records of %s: %w", ticker, err)
+	}
+	return archived, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// ReassignClusters moves the stocks with the given tickers to cluster and records an audit entry
+// for every stock whose cluster changed. The move is all-or-nothing: unknown tickers abort it.
+func (r *CockroachDBRepository) ReassignClusters(tickers []string, cluster int, reason string) ([]models.ClusterAssignment, error) {
+	var assignments []models.ClusterAssignment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var stocks []models.StockDataPoint
+		if err := tx.Where("ticker IN ?", tickers).Find(&stocks).Error; err != nil {
+			return fmt.Errorf("failed to load stocks: %w", err)
+		}
+		if missing := missingTickers(tickers, stocks); len(missing) > 0 {
+			return apperrors.NotFound("stocks not found for tickers %v", missing)
+		}
+
+		for i := range stocks {
+			stock := &stocks[i]
+			if stock.Cluster == cluster {
+				continue
+			}
+			assignment := models.ClusterAssignment{
+				StockDataPointID: stock.ID,
+				Ticker:           stock.Ticker,
+				FromCluster:      stock.Cluster,
+				ToCluster:        cluster,
+				Reason:           reason,
+			}
+			if err := tx.Model(stock).Update("cluster", cluster).Error; err != nil {
+				return fmt.Errorf("failed to move %s to cluster %d: %w", stock.Ticker, cluster, err)
+			}
+			if err := tx.Create(&assignment).Error; err != nil {
+				return fmt.Errorf("failed to record cluster change for %s: %w", stock.Ticker, err)
+			}
+			assignments = append(assignments, assignment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// GetClusterAssignments returns the cluster override history of a stock, newest first
+func (r *CockroachDBRepository) GetClusterAssignments(stockID uint) ([]models.ClusterAssignment, error) {
+	var assignments []models.ClusterAssignment
+	if err := r.db.Where("stock_data_point_id = ?", stockID).Order("created_at DESC, id DESC").Find(&assignments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get cluster history for stock %d: %w", stockID, err)
+	}
+	return assignments, nil
+}
+
+// RecomputeCentroids replaces the stored centroids with the mean normalized value of every
+// indicator per cluster, computed with one GROUP BY over the current stocks. Noise points
+// (models.NoiseCluster) are not a cluster and get no centroid.
+func (r *CockroachDBRepository) RecomputeCentroids() ([]models.ClusterCentroid, error) {
+	sdpTable := (&models.StockDataPoint{}).TableName()
+	niTable := (&models.NumericalIndicator{}).TableName()
+
+	var centroids []models.ClusterCentroid
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(fmt.Sprintf(`SELECT sdp.cluster, ni.name, AVG(ni.norm_value)::FLOAT AS norm_value, COUNT(*) AS members
+			FROM %s ni JOIN %s sdp ON sdp.id = ni.stock_data_point_id
+			WHERE sdp.cluster <> ?
+			GROUP BY sdp.cluster, ni.name
+			ORDER BY sdp.cluster, ni.name`, niTable, sdpTable), models.NoiseCluster).
+			Scan(&centroids).Error; err != nil {
+			return fmt.Errorf("failed to compute cluster centroids: %w", err)
+		}
+
+		if err := tx.Where("1 = 1").Delete(&models.ClusterCentroid{}).Error; err != nil {
+			return fmt.Errorf("failed to clear cluster centroids: %w", err)
+		}
+		if len(centroids) == 0 {
+			return nil
+		}
+		computedAt := time.Now().UTC()
+		for i := range centroids {
+			centroids[i].ComputedAt = computedAt
+		}
+		if err := tx.Create(&centroids).Error; err != nil {
+			return fmt.Errorf("failed to save cluster centroids: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return centroids, nil
+}
+
+// GetCentroids returns the stored centroid coordinates ordered by cluster and indicator name
+func (r *CockroachDBRepository) GetCentroids() ([]models.ClusterCentroid, error) {
+	var centroids []models.ClusterCentroid
+	if err := r.db.Order("cluster, name").Find(&centroids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get cluster centroids: %w", err)
+	}
+	return centroids, nil
+}
+
+// missingTickers returns the requested tickers that have no matching stock, sorted
+func missingTickers(tickers []string, stocks []models.StockDataPoint) []string {
+	found := make(map[string]bool, len(stocks))
+	for _, stock := range stocks {
+		found[stock.Ticker] = true
+	}
+	var missing []string
+	for _, ticker := range tickers {
+		if !found[ticker] {
+			missing = append(missing, ticker)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
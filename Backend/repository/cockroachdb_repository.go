@@ -1,19 +1,26 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"dataextractor/apperrors"
 	"dataextractor/config"
+	"dataextractor/logging"
 	"dataextractor/models"
-	"dataextractor/utils"
+	"dataextractor/scoring"
+	"dataextractor/validators"
 
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
 
@@ -29,24 +36,152 @@ type RatingWeightEntry struct {
 	Weight        float64
 }
 
+// AllowedSortColumns is the whitelist of column names accepted for sorting/filtering
+var AllowedSortColumns = []string{
+	"ticker", "action", "date", "company", "cluster",
+	"target_to", "target_from", "target_delta", "last_close", "rating_to", "rating_from", "final_score", "weighted_score",
+}
+
+// ListSortColumns are the sort columns of the plain listing queries, which compute no weighted score
+var ListSortColumns = []string{
+	"ticker", "action", "date", "company", "cluster",
+	"target_to", "target_from", "target_delta", "last_close", "rating_to", "rating_from", "final_score",
+}
+
+// AllowedGroupingColumns is the whitelist of grouping columns (company and date are excluded due to too many distinct values)
+var AllowedGroupingColumns = []string{
+	"action", "rating_to", "rating_from",
+}
+
+// RangeFilterColumns is the whitelist of numeric columns the cluster filter can bound
+var RangeFilterColumns = []string{
+	"final_score", "target_to", "target_from", "target_delta", "last_close",
+}
+
+// RangeFilter is an inclusive bound on a numeric column of the cluster filter; a nil Min or Max
+// leaves that side open
+type RangeFilter struct {
+	Column string
+	Min    *float64
+	Max    *float64
+}
+
+// ListOptions pages and sorts the plain listing queries (by company, action, cluster).
+// A zero PerPage returns every matching row, for internal callers that need the full set.
+type ListOptions struct {
+	Page    int
+	PerPage int
+	SortBy  string
+	Order   string
+
+	// Dataset restricts results to data points last written by this dataset version (0 = all)
+	Dataset uint
+
+	// Preload selects the child columns loaded with each data point (empty = PreloadFull)
+	Preload PreloadMode
+}
+
+// IndicatorSummary describes a numerical indicator present in the database
+type IndicatorSummary struct {
+	Name     string  `json:"name"`
+	Count    int64   `json:"count"`
+	MinValue float64 `json:"min_value"`
+	MaxValue float64 `json:"max_value"`
+}
+
+// SentimentSummary describes a rating sentiment present in the database
+type SentimentSummary struct {
+	Name            string `json:"name"`
+	Count           int64  `json:"count"`
+	DistinctRatings int64  `json:"distinct_ratings"`
+}
+
+// ErrNotConnected is returned by Ping until Connect has succeeded
+var ErrNotConnected = apperrors.New(apperrors.KindUnavailable, "database is not connected")
+
+// GroupAggregate summarizes the filtered stocks sharing one grouping value; AvgWeightedScore is
+// nil unless weights were given
+type GroupAggregate struct {
+	GroupValue       string   `json:"group_value"`
+	Count            int64    `json:"count"`
+	AvgFinalScore    *float64 `json:"avg_final_score"`
+	AvgWeightedScore *float64 `json:"avg_weighted_score,omitempty"`
+}
+
 // CockroachDBRepository implements DataRepositoryInterface for CockroachDB using GORM
 type CockroachDBRepository struct {
 	db *gorm.DB
+
+	// connected is set once db is usable; shared with the copies made by WithContext so a
+	// repository handed out before a background reconnect sees it complete
+	connected *atomic.Bool
+
+	// counters is set when the row counter triggers are installed, so counts are read from RowCounter
+	counters *atomic.Bool
+
+	// distributions is set when the distribution trigger is installed, so the heatmap is read from
+	// ClusterDistribution
+	distributions *atomic.Bool
 }
 
-// NewCockroachDBRepository creates a new CockroachDBRepository instance
+// NewCockroachDBRepository creates a new CockroachDBRepository instance; a nil db leaves it
+// unconnected until Connect succeeds
 func NewCockroachDBRepository(db *gorm.DB) *CockroachDBRepository {
-	return &CockroachDBRepository{db: db}
+	r := &CockroachDBRepository{db: db, connected: &atomic.Bool{}, counters: &atomic.Bool{}, distributions: &atomic.Bool{}}
+	r.connected.Store(db != nil)
+	return r
+}
+
+// WithContext returns a copy of the repository bound to ctx
+func (r *CockroachDBRepository) WithContext(ctx context.Context) DataRepositoryInterface {
+	if !r.connected.Load() {
+		return r
+	}
+	return &CockroachDBRepository{db: r.db.WithContext(ctx), connected: r.connected, counters: r.counters, distributions: r.distributions}
+}
+
+// logger returns the default logger with the request ID of the context the repository is bound to
+func (r *CockroachDBRepository) logger() *slog.Logger {
+	if ctx := r.db.Statement.Context; ctx != nil {
+		return logging.FromContext(ctx)
+	}
+	return slog.Default()
+}
+
+// Connected reports whether Connect has succeeded
+func (r *CockroachDBRepository) Connected() bool {
+	return r.connected.Load()
+}
+
+// Ping checks that the database is connected and answering
+func (r *CockroachDBRepository) Ping(ctx context.Context) error {
+	if !r.connected.Load() {
+		return ErrNotConnected
+	}
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database handle: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return apperrors.WrapAs(err, apperrors.KindUnavailable, "database is not answering")
+	}
+	return nil
 }
 
-// Connect establishes CockroachDB connection and runs migrations
+// migratedModels lists the models whose tables Connect migrates
+func migratedModels() []interface{} {
+	return []interface{}{&models.StockDataPoint{}, &models.RatingSentiment{}, &models.NumericalIndicator{}, &models.Tag{}, &models.Note{}, &models.StockSnapshot{}, &models.IndicatorSnapshot{}, &models.ClusterAssignment{}, &models.ExtractionPage{}, &models.ImportFingerprint{}, &models.DatasetVersion{}, &models.DatasetRecord{}, &models.ClusterCentroid{}, &models.RatingRubric{}, &models.UserPreference{}, &models.ExportJob{}, &models.Job{}, &models.APIUsage{}, &models.ClientUsage{}, &models.WebhookSubscription{}, &models.ArchivedStock{}, &models.RowCounter{}, &models.ClusterDistribution{}}
+}
+
+// Connect establishes CockroachDB connection and runs migrations. It fails without side effects
+// when the database is unreachable, so callers can retry it.
 func (r *CockroachDBRepository) Connect() error {
 	// Load configuration from environment variables
 	cfg := config.LoadConfig()
 
 	// Load environment variables
 	if err := godotenv.Load(".env"); err != nil {
-		log.Printf("Warning: Could not load .env file: %v", err)
+		slog.Warn("Could not load .env file", "error", err)
 	}
 
 	// Build CockroachDB connection string
@@ -54,43 +189,123 @@ func (r *CockroachDBRepository) Connect() error {
 		cfg.CockroachDB.Host, cfg.CockroachDB.Port, cfg.CockroachDB.User, cfg.CockroachDB.Password,
 		cfg.CockroachDB.DBName, cfg.CockroachDB.SSLMode, cfg.CockroachDB.CertsDir, cfg.CockroachDB.CertsDir, cfg.CockroachDB.CertsDir)
 
-	log.Printf("Connecting to CockroachDB: %s:%s/%s", cfg.CockroachDB.Host, cfg.CockroachDB.Port, cfg.CockroachDB.DBName)
+	slog.Info("Connecting to CockroachDB", "host", cfg.CockroachDB.Host, "port", cfg.CockroachDB.Port, "database", cfg.CockroachDB.DBName)
 
 	// Connect to CockroachDB
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			TablePrefix: "stock_data.",
 		},
+		// Failed and slow statements are logged with the request ID of their context
+		Logger: newGormLogger(cfg.Database.LogLevel),
+		// Constraint violations come back as GORM's typed errors (ErrDuplicatedKey, ...), which
+		// apperrors maps to a kind
+		TranslateError:         true,
+		PrepareStmt:            cfg.Database.PrepareStmt,
+		SkipDefaultTransaction: cfg.Database.SkipDefaultTransaction,
+		CreateBatchSize:        cfg.Database.CreateBatchSize,
+		// Timestamps are stored with microsecond precision; truncating them up front keeps the
+		// in-memory updated_at of a saved row equal to the stored one, which ETags are derived from
+		NowFunc: func() time.Time { return time.Now().Truncate(time.Microsecond) },
 	})
-	utils.ErrorPanic(err, "failed to connect to CockroachDB")
+	if err != nil {
+		return apperrors.WrapAs(err, apperrors.KindUnavailable, "failed to connect to CockroachDB")
+	}
+
+	// Time every statement for requests that opt into query diagnostics
+	if err := registerQueryStatsCallbacks(db); err != nil {
+		closeDB(db)
+		return fmt.Errorf("failed to register query diagnostics callbacks: %w", err)
+	}
 
 	// Run database migrations
-	utils.ErrorPanic(db.AutoMigrate(&models.StockDataPoint{}, &models.RatingSentiment{}, &models.NumericalIndicator{}), "failed to run migrations")
+	if err := db.AutoMigrate(migratedModels()...); err != nil {
+		closeDB(db)
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	// Key the stock tables by uuid or id as DB_UUID_PRIMARY_KEYS asks
+	if err := usePrimaryKeys(db, cfg.Database.UUIDPrimaryKeys); err != nil {
+		closeDB(db)
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	// Snapshots are keyed by ticker, date and brokerage; drop the earlier ticker/date key so same-day
+	// ratings from different brokerages no longer collide
+	db.Exec("DROP INDEX IF EXISTS stock_data.stock_snapshots@idx_snapshot_ticker_date CASCADE")
+
+	// Seed the ticker history with the current values of records saved before snapshots existed
+	db.Exec(fmt.Sprintf(`INSERT INTO %s (ticker, date, brokerage, final_score, target_to, target_from, rating_to, rating_from, recorded_at)
+		SELECT ticker, date, COALESCE(brokerage, ''), final_score, target_to, target_from, rating_to, rating_from, updated_at FROM %s
+		ON CONFLICT (ticker, date, brokerage) DO NOTHING`, (&models.StockSnapshot{}).TableName(), (&models.StockDataPoint{}).TableName()))
+	db.Exec(fmt.Sprintf(`INSERT INTO %s (ticker, name, date, value, norm_value, recorded_at)
+		SELECT sdp.ticker, ni.name, sdp.date, ni.value, ni.norm_value, ni.updated_at
+		FROM %s ni JOIN %s sdp ON sdp.id = ni.stock_data_point_id
+		ON CONFLICT (ticker, name, date) DO NOTHING`, (&models.IndicatorSnapshot{}).TableName(), (&models.NumericalIndicator{}).TableName(), (&models.StockDataPoint{}).TableName()))
 
 	// Create CockroachDB-specific indexes on schema-qualified table
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_sdp_ticker ON stock_data.stock_data_points (ticker)")
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_sdp_date ON stock_data.stock_data_points (date)")
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_sdp_company ON stock_data.stock_data_points (company)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_sdp_lower_ticker ON stock_data.stock_data_points (lower(ticker))")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_sdp_lower_company ON stock_data.stock_data_points (lower(company))")
+	// Covering indexes for the weighted-score subqueries: seek by stock and name, read the normalized value without an index join
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_ni_scoring ON stock_data.numerical_indicators (stock_data_point_id, name) STORING (norm_value)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_rs_scoring ON stock_data.rating_sentiments (stock_data_point_id, name) STORING (norm_rating_score)")
+	// Per-name value range of the data dictionary: two index seeks per indicator name instead of a scan
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_ni_name_value ON stock_data.numerical_indicators (name, value)")
+
+	// Keep row counts in row_counters so stats and dictionary reads do not scan
+	counters := useCounters(db)
+	// and cluster/action/rating counts in cluster_distributions so the heatmap does not group the stock table
+	distributions := useDistributions(db)
+
+	// Serve reads from the replica pool when one is configured
+	if cfg.Database.ReplicaDSN != "" {
+		useReadReplica(db, cfg.Database.ReplicaDSN)
+	}
 
-	log.Println("CockroachDB setup completed successfully")
+	slog.Info("CockroachDB setup completed")
 
 	// Set the database connection
 	r.db = db
+	r.counters.Store(counters)
+	r.distributions.Store(distributions)
+	r.connected.Store(true)
 	return nil
 }
 
+// closeDB releases the connection pool of a connection abandoned during setup
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
 // ReadById retrieves a data point by its ID
 func (r *CockroachDBRepository) ReadById(id uint) (*models.StockDataPoint, error) {
 	var stock models.StockDataPoint
-	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").First(&stock, id).Error; err != nil {
+	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Preload("Tags").First(&stock, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("stock with ID %d not found", id)
+			return nil, apperrors.NotFound("stock with ID %d not found", id)
 		}
 		return nil, fmt.Errorf("failed to get stock by ID %d: %w", id, err)
 	}
 	return &stock, nil
 }
 
+// GetIDByUUID returns the ID of the data point with the given UUID, reading only that column
+func (r *CockroachDBRepository) GetIDByUUID(uuid string) (uint, error) {
+	var stock models.StockDataPoint
+	if err := r.db.Select("id").Where("uuid = ?", uuid).First(&stock).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, apperrors.NotFound("stock with UUID %s not found", uuid)
+		}
+		return 0, fmt.Errorf("failed to get stock ID by UUID %s: %w", uuid, err)
+	}
+	return stock.ID, nil
+}
+
 // GetAll retrieves all stock records
 func (r *CockroachDBRepository) GetAll() ([]models.StockDataPoint, error) {
 	var stocks []models.StockDataPoint
@@ -100,38 +315,148 @@ func (r *CockroachDBRepository) GetAll() ([]models.StockDataPoint, error) {
 	return stocks, nil
 }
 
+// StreamAll walks every data point in scope (all of them when it is empty) in id order, batchSize
+// parents at a time, loading the sentiments and indicators of each batch with one query per
+// relation before handing it to fn. Memory stays bounded by the batch size however large the
+// table is. Iteration stops at the first error from fn.
+func (r *CockroachDBRepository) StreamAll(scope StockScope, batchSize int, fn func(batch []models.StockDataPoint) error) error {
+	var lastID uint
+	for {
+		var batch []models.StockDataPoint
+		if err := scope.apply(r.db.Preload("RatingSentiments").Preload("NumericalIndicators")).
+			Where("id > ?", lastID).Order("id").Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			return fmt.Errorf("failed to stream stocks after id %d: %w", lastID, err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
 // Create creates a new data point
 func (r *CockroachDBRepository) Create(entity *models.StockDataPoint) (*models.StockDataPoint, error) {
-	utils.ErrorPanic(r.db.Session(&gorm.Session{FullSaveAssociations: true}).Create(entity).Error, "failed to create data point")
+	if err := r.createWithAssociations(entity); err != nil {
+		return nil, apperrors.Wrap(err, "failed to create data point")
+	}
 	return entity, nil
 }
 
+// CreateBatch inserts data points with their sentiments and indicators in one transaction, so
+// either all of them are written or none is. Rows go out DB_CREATE_BATCH_SIZE per INSERT.
+func (r *CockroachDBRepository) CreateBatch(entities []*models.StockDataPoint) error {
+	batchSize := r.db.CreateBatchSize
+	if batchSize <= 0 {
+		batchSize = len(entities)
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).CreateInBatches(entities, batchSize).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %d data points: %w", len(entities), err)
+	}
+	return nil
+}
+
 // Update updates an existing data point
 func (r *CockroachDBRepository) Update(entity *models.StockDataPoint) (*models.StockDataPoint, error) {
-	utils.ErrorPanic(r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(entity).Error, "failed to update data point")
+	if err := r.saveWithAssociations(entity); err != nil {
+		return nil, apperrors.Wrap(err, "failed to update data point")
+	}
 	return entity, nil
 }
 
+// createWithAssociations inserts a data point with its sentiments and indicators. The explicit
+// transaction keeps the multi-statement write atomic when SkipDefaultTransaction is enabled.
+func (r *CockroachDBRepository) createWithAssociations(entity *models.StockDataPoint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Create(entity).Error
+	})
+}
+
+// saveWithAssociations is the update counterpart of createWithAssociations
+func (r *CockroachDBRepository) saveWithAssociations(entity *models.StockDataPoint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(entity).Error
+	})
+}
+
 // Delete deletes a data point
 func (r *CockroachDBRepository) Delete(entity *models.StockDataPoint) error {
-	utils.ErrorPanic(r.db.Delete(entity).Error, "failed to delete data point")
+	if err := r.db.Delete(entity).Error; err != nil {
+		return apperrors.Wrap(err, "failed to delete data point")
+	}
+	return nil
+}
+
+// UpdateIfUnchanged updates a data point only while its stored updated_at still equals updatedAt,
+// the value it was read with. The row is locked for the check, so a write that landed in between
+// fails with KindPreconditionFailed instead of being overwritten.
+func (r *CockroachDBRepository) UpdateIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) (*models.StockDataPoint, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkUnchanged(tx, entity.ID, updatedAt); err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(entity).Error
+	})
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to update data point")
+	}
+	return entity, nil
+}
+
+// DeleteIfUnchanged is the delete counterpart of UpdateIfUnchanged
+func (r *CockroachDBRepository) DeleteIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkUnchanged(tx, entity.ID, updatedAt); err != nil {
+			return err
+		}
+		return tx.Delete(entity).Error
+	})
+	if err != nil {
+		return apperrors.Wrap(err, "failed to delete data point")
+	}
+	return nil
+}
+
+// checkUnchanged locks the data point and fails unless its updated_at equals updatedAt
+func checkUnchanged(tx *gorm.DB, id uint, updatedAt time.Time) error {
+	var current models.StockDataPoint
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "updated_at").First(&current, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return apperrors.NotFound("stock with ID %d not found", id)
+		}
+		return err
+	}
+	if !current.UpdatedAt.Equal(updatedAt) {
+		return apperrors.PreconditionFailed("stock %d was modified at %s", id, current.UpdatedAt.UTC().Format(time.RFC3339))
+	}
 	return nil
 }
 
 // UpdateOrCreate attempts to create; on unique-constraint conflict updates the existing row
 func (r *CockroachDBRepository) UpdateOrCreate(entity *models.StockDataPoint) (*models.StockDataPoint, error) {
 	// Try create first
-	if err := r.db.Session(&gorm.Session{FullSaveAssociations: true}).Create(entity).Error; err != nil {
-		msg := err.Error()
-		lower := strings.ToLower(msg)
-		if strings.Contains(lower, "duplicate key") || strings.Contains(msg, "SQLSTATE 23505") {
+	if err := r.createWithAssociations(entity); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			// Fetch existing by unique key (ticker) and update
 			var existing models.StockDataPoint
 			if e := r.db.Where("ticker = ?", entity.Ticker).First(&existing).Error; e != nil {
 				return nil, fmt.Errorf("failed to fetch existing for upsert: %w", e)
 			}
+			// The row keeps its identity: external UUID and creation time are not part of the upsert
 			entity.ID = existing.ID
-			if e := r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(entity).Error; e != nil {
+			entity.UUID = existing.UUID
+			entity.CreatedAt = existing.CreatedAt
+			entity.CreatedBy = existing.CreatedBy
+			if e := r.saveWithAssociations(entity); e != nil {
 				return nil, fmt.Errorf("failed to update existing record: %w", e)
 			}
 			return entity, nil
@@ -173,7 +498,7 @@ func (r *CockroachDBRepository) GetDataByTicker(ticker string) (*models.StockDat
 	var stock models.StockDataPoint
 	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Where("ticker = ?", ticker).First(&stock).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("stock with ticker %s not found", ticker)
+			return nil, apperrors.NotFound("stock with ticker %s not found", ticker)
 		}
 		return nil, fmt.Errorf("failed to get data by ticker %s: %w", ticker, err)
 	}
@@ -189,15 +514,36 @@ func (r *CockroachDBRepository) GetDataByCompany(company string) ([]models.Stock
 	return stocks, nil
 }
 
-// GetStocksByCompany is an alias to GetDataByCompany matching service naming
-func (r *CockroachDBRepository) GetStocksByCompany(company string) ([]models.StockDataPoint, error) {
-	return r.GetDataByCompany(company)
+// GetTickerHistory returns a ticker's snapshots ordered by date, optionally bounded by from/to (inclusive)
+func (r *CockroachDBRepository) GetTickerHistory(ticker string, from, to *time.Time) ([]models.StockSnapshot, error) {
+	query := r.db.Where("ticker = ?", ticker)
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date <= ?", *to)
+	}
+
+	var history []models.StockSnapshot
+	if err := query.Order(orderWithTiebreaker([]string{"date ASC"}, "id", "asc")).Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to get history for ticker %s: %w", ticker, err)
+	}
+	return history, nil
+}
+
+// GetStocksByCompany returns one page of the data points for a company and their total count
+func (r *CockroachDBRepository) GetStocksByCompany(company string, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	stocks, total, err := r.listStocks("company = ?", company, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get data by company %s: %w", company, err)
+	}
+	return stocks, total, nil
 }
 
 // GetLatestData returns the most recent data points (limit specifies how many)
 func (r *CockroachDBRepository) GetLatestData(limit int) ([]models.StockDataPoint, error) {
 	var stocks []models.StockDataPoint
-	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Order("date DESC").Limit(limit).Find(&stocks).Error; err != nil {
+	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Order(orderWithTiebreaker([]string{"date DESC"}, "id", "desc")).Limit(limit).Find(&stocks).Error; err != nil {
 		return nil, fmt.Errorf("failed to get latest data: %w", err)
 	}
 	return stocks, nil
@@ -212,27 +558,26 @@ func (r *CockroachDBRepository) GetDataByTimeRange(startTime, endTime string) ([
 	return stocks, nil
 }
 
-// GetTickerStats returns statistics for a specific ticker
-func (r *CockroachDBRepository) GetTickerStats(ticker string) (map[string]interface{}, error) {
-	var count int64
-	var earliestTime, latestTime time.Time
-
-	// Get count
-	if err := r.db.Model(&models.StockDataPoint{}).Where("ticker = ?", ticker).Count(&count).Error; err != nil {
-		return nil, fmt.Errorf("failed to get ticker count: %w", err)
-	}
+// TickerStats summarizes the data points of a ticker. EarliestTime and LatestTime are nil when the
+// ticker has no data points.
+type TickerStats struct {
+	Ticker       string     `json:"ticker"`
+	Count        int64      `json:"count"`
+	EarliestTime *time.Time `json:"earliest_time" extensions:"x-nullable"`
+	LatestTime   *time.Time `json:"latest_time" extensions:"x-nullable"`
+}
 
-	// Get time statistics
-	if err := r.db.Model(&models.StockDataPoint{}).Where("ticker = ?", ticker).Select("MIN(date), MAX(date)").Row().Scan(&earliestTime, &latestTime); err != nil {
-		return nil, fmt.Errorf("failed to get ticker time stats: %w", err)
+// GetTickerStats returns statistics for a specific ticker
+func (r *CockroachDBRepository) GetTickerStats(ticker string) (TickerStats, error) {
+	stats := TickerStats{Ticker: ticker}
+
+	// MIN and MAX are NULL for a ticker without data points, which scans into nil times
+	if err := r.db.Model(&models.StockDataPoint{}).Where("ticker = ?", ticker).
+		Select("COUNT(*), MIN(date), MAX(date)").Row().
+		Scan(&stats.Count, &stats.EarliestTime, &stats.LatestTime); err != nil {
+		return TickerStats{}, fmt.Errorf("failed to get ticker stats: %w", err)
 	}
-
-	return map[string]interface{}{
-		"ticker":        ticker,
-		"count":         count,
-		"earliest_time": earliestTime,
-		"latest_time":   latestTime,
-	}, nil
+	return stats, nil
 }
 
 // GetTopTickersByCount returns the top N tickers by record count
@@ -251,35 +596,106 @@ func (r *CockroachDBRepository) GetTopTickersByCount(limit int) ([]map[string]in
 	return results, nil
 }
 
-// GetDatabaseStats returns overall database statistics
-func (r *CockroachDBRepository) GetDatabaseStats() (map[string]interface{}, error) {
-	var totalCount int64
-	var uniqueTickers, uniqueCompanies int64
+// DatabaseStats are the overall counts of the stock table. ClusterCounts maps every non-empty
+// cluster to its data point count and is empty, not null, for an empty table.
+type DatabaseStats struct {
+	TotalRecords    int64         `json:"total_records"`
+	UniqueTickers   int64         `json:"unique_tickers"`
+	UniqueCompanies int64         `json:"unique_companies"`
+	ClusterCounts   map[int]int64 `json:"cluster_counts"`
+}
+
+// GetDatabaseStats returns overall database statistics, read from the row counters when they are installed
+func (r *CockroachDBRepository) GetDatabaseStats() (DatabaseStats, error) {
+	if r.counters.Load() {
+		return r.counterDatabaseStats()
+	}
+
+	var stats DatabaseStats
 
 	// Get total count
-	if err := r.db.Model(&models.StockDataPoint{}).Count(&totalCount).Error; err != nil {
-		return nil, fmt.Errorf("failed to get total count: %w", err)
+	if err := r.db.Model(&models.StockDataPoint{}).Count(&stats.TotalRecords).Error; err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get total count: %w", err)
 	}
 
 	// Get unique tickers count
-	if err := r.db.Model(&models.StockDataPoint{}).Distinct("ticker").Count(&uniqueTickers).Error; err != nil {
-		return nil, fmt.Errorf("failed to get unique tickers count: %w", err)
+	if err := r.db.Model(&models.StockDataPoint{}).Distinct("ticker").Count(&stats.UniqueTickers).Error; err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get unique tickers count: %w", err)
 	}
 
 	// Get unique companies count
-	if err := r.db.Model(&models.StockDataPoint{}).Distinct("company").Count(&uniqueCompanies).Error; err != nil {
-		return nil, fmt.Errorf("failed to get unique companies count: %w", err)
+	if err := r.db.Model(&models.StockDataPoint{}).Distinct("company").Count(&stats.UniqueCompanies).Error; err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get unique companies count: %w", err)
+	}
+
+	// Get row count per cluster
+	var clusterRows []struct {
+		Cluster int
+		Count   int64
+	}
+	if err := r.db.Model(&models.StockDataPoint{}).Select("cluster, COUNT(*) AS count").Group("cluster").Scan(&clusterRows).Error; err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get cluster counts: %w", err)
+	}
+	stats.ClusterCounts = make(map[int]int64, len(clusterRows))
+	for _, row := range clusterRows {
+		stats.ClusterCounts[row.Cluster] = row.Count
 	}
 
-	return map[string]interface{}{
-		"total_records":    totalCount,
-		"unique_tickers":   uniqueTickers,
-		"unique_companies": uniqueCompanies,
-	}, nil
+	return stats, nil
+}
+
+// DataVersion identifies the current state of the stock table for HTTP cache validation.
+// RowCount catches deletes, which do not move LastModified; Revision catches a delete and an
+// insert within the same LastModified.
+type DataVersion struct {
+	LastModified time.Time `json:"last_modified"`
+	RowCount     int64     `json:"row_count"`
+
+	// Revision only grows: the number of stock writes counted by the row counter triggers, or
+	// max(id) without them (inserts raise it; updates and deletes change LastModified or RowCount)
+	Revision int64 `json:"revision"`
+}
+
+// GetDataVersion returns max(updated_at), the row count and the revision of the stock table
+func (r *CockroachDBRepository) GetDataVersion() (DataVersion, error) {
+	var row struct {
+		LastModified *time.Time
+		RowCount     int64
+		MaxID        int64
+	}
+	if err := r.db.Model(&models.StockDataPoint{}).Select("MAX(updated_at) AS last_modified, COUNT(*) AS row_count, COALESCE(MAX(id), 0) AS max_id").Scan(&row).Error; err != nil {
+		return DataVersion{}, fmt.Errorf("failed to get data version: %w", err)
+	}
+
+	version := DataVersion{RowCount: row.RowCount, Revision: row.MaxID}
+	if row.LastModified != nil {
+		version.LastModified = row.LastModified.UTC()
+	}
+	if r.counters.Load() {
+		writes, err := r.counterCount(models.CounterStockWrites, "")
+		if err != nil {
+			return DataVersion{}, fmt.Errorf("failed to get data version: %w", err)
+		}
+		version.Revision = writes
+	}
+	return version, nil
 }
 
 // GetUniqueClusters returns a list of unique cluster IDs
 func (r *CockroachDBRepository) GetUniqueClusters() ([]int, error) {
+	if r.counters.Load() {
+		counts, err := r.clusterCounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get unique clusters: %w", err)
+		}
+		clusters := make([]int, 0, len(counts))
+		for cluster := range counts {
+			clusters = append(clusters, cluster)
+		}
+		sort.Ints(clusters)
+		return clusters, nil
+	}
+
 	var clusters []int
 	if err := r.db.Model(&models.StockDataPoint{}).Distinct("cluster").Pluck("cluster", &clusters).Error; err != nil {
 		return nil, fmt.Errorf("failed to get unique clusters: %w", err)
@@ -288,13 +704,54 @@ func (r *CockroachDBRepository) GetUniqueClusters() ([]int, error) {
 	return clusters, nil
 }
 
-// GetStocksByCluster returns all data points for a specific cluster
-func (r *CockroachDBRepository) GetStocksByCluster(cluster int) ([]models.StockDataPoint, error) {
+// listStocks counts the data points matching where and returns the requested page of them,
+// sorted by the opts.SortBy keys (id breaks remaining ties so pages are stable) with associations preloaded
+func (r *CockroachDBRepository) listStocks(where string, arg interface{}, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	return r.listScope(r.db.Where(where, arg), opts)
+}
+
+// listScope is listStocks over the data points matching scope
+func (r *CockroachDBRepository) listScope(scope *gorm.DB, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	terms, err := orderTerms(opts.SortBy, opts.Order, "date", ListSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// A fresh session so the scope can be shared by the count and the page query
+	if opts.Dataset > 0 {
+		scope = scope.Where("dataset_version_id = ?", opts.Dataset)
+	}
+	scope = scope.Session(&gorm.Session{})
+
+	var total int64
+	if err := scope.Model(&models.StockDataPoint{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := preloadRelations(scope, opts.Preload).
+		Order(orderWithTiebreaker(terms, "id", opts.Order))
+	if opts.PerPage > 0 {
+		page := opts.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * opts.PerPage).Limit(opts.PerPage)
+	}
+
 	var stocks []models.StockDataPoint
-	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Where("cluster = ?", cluster).Find(&stocks).Error; err != nil {
-		return nil, fmt.Errorf("failed to get data by cluster %d: %w", cluster, err)
+	if err := query.Find(&stocks).Error; err != nil {
+		return nil, 0, err
 	}
-	return stocks, nil
+	return stocks, total, nil
+}
+
+// GetStocksByCluster returns one page of the data points for a cluster and their total count
+func (r *CockroachDBRepository) GetStocksByCluster(cluster int, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	stocks, total, err := r.listStocks("cluster = ?", cluster, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get data by cluster %d: %w", cluster, err)
+	}
+	return stocks, total, nil
 }
 
 // GetUniqueActions returns a list of unique actions
@@ -307,127 +764,143 @@ func (r *CockroachDBRepository) GetUniqueActions() ([]string, error) {
 	return actions, nil
 }
 
-// GetStocksByAction returns all data points for a specific action
-func (r *CockroachDBRepository) GetStocksByAction(action string) ([]models.StockDataPoint, error) {
-	var stocks []models.StockDataPoint
-	if err := r.db.Preload("RatingSentiments").Preload("NumericalIndicators").Where("action = ?", action).Find(&stocks).Error; err != nil {
-		return nil, fmt.Errorf("failed to get data by action %s: %w", action, err)
+// GetUniqueTags returns the names of all known tags
+func (r *CockroachDBRepository) GetUniqueTags() ([]string, error) {
+	var tags []string
+	if err := r.db.Model(&models.Tag{}).Order("name").Pluck("name", &tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to get unique tags: %w", err)
 	}
-	return stocks, nil
+	return tags, nil
 }
 
-// GetStocksByClusterAndGroup filters by cluster and optionally by groupingColumn using GORM
-// Returns stocks, total count, and error
-func (r *CockroachDBRepository) GetStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry) ([]models.StockDataPoint, int64, error) {
-	// Whitelist of allowed column names for sorting/filtering (full list)
-	allowedColumns := []string{
-		"ticker", "action", "date", "company", "cluster",
-		"target_to", "target_from", "target_delta", "last_close", "rating_to", "rating_from", "final_score", "weighted_score",
+// AddTags attaches the named tags to a stock, creating tags that do not exist yet
+func (r *CockroachDBRepository) AddTags(stock *models.StockDataPoint, names []string) error {
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: name}
 	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Insert missing tags, then load them all so every tag carries its ID
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&tags).Error; err != nil {
+			return fmt.Errorf("failed to create tags: %w", err)
+		}
+		if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+			return fmt.Errorf("failed to load tags: %w", err)
+		}
+		if err := tx.Model(stock).Omit("Tags.*").Association("Tags").Append(tags); err != nil {
+			return fmt.Errorf("failed to tag stock %d: %w", stock.ID, err)
+		}
+		return nil
+	})
+}
 
-	// Whitelist of allowed grouping columns (excluding company and date due to too many distinct values)
-	allowedGroupingColumns := []string{
-		"action", "rating_to", "rating_from",
+// RemoveTags detaches the named tags from a stock; the tags themselves are kept
+func (r *CockroachDBRepository) RemoveTags(stock *models.StockDataPoint, names []string) error {
+	var tags []models.Tag
+	if err := r.db.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return fmt.Errorf("failed to load tags: %w", err)
 	}
-
-	// Validate sortByColumn early
-	if sortByColumn != "" {
-		if !validateColumnName(sortByColumn, allowedColumns) {
-			return nil, 0, fmt.Errorf("invalid sort column: %s", sortByColumn)
-		}
+	if len(tags) == 0 {
+		return nil
 	}
+	if err := r.db.Model(stock).Association("Tags").Delete(tags); err != nil {
+		return fmt.Errorf("failed to untag stock %d: %w", stock.ID, err)
+	}
+	return nil
+}
 
-	// Check if both weight arrays are provided (required for weighted_score sorting)
-	hasBothWeights := len(numericalWeights) > 0 && len(ratingWeights) > 0
-	hasAnyWeights := len(numericalWeights) > 0 || len(ratingWeights) > 0
-
-	// Determine if we should sort by weighted_score (only if both arrays are provided)
-	sortByWeightedScore := sortByColumn == "weighted_score" && hasBothWeights
-
-	// Build base query for filtering and counting (before weighted scores join)
-	baseQuery := r.db.Model(&models.StockDataPoint{}).
-		Where("cluster = ?", cluster)
+// GetUniqueRatings returns the distinct rating values used in either rating_to or rating_from
+func (r *CockroachDBRepository) GetUniqueRatings() ([]string, error) {
+	var ratings []string
+	if err := r.db.Raw(fmt.Sprintf(
+		"SELECT rating_to AS rating FROM %[1]s WHERE rating_to <> '' UNION SELECT rating_from FROM %[1]s WHERE rating_from <> ''",
+		(&models.StockDataPoint{}).TableName())).
+		Scan(&ratings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get unique ratings: %w", err)
+	}
+	sort.Strings(ratings)
+	return ratings, nil
+}
 
-	// Filter by groupingColumn if not "None" - validate against grouping-specific whitelist
-	if groupingColumn != "None" && groupingValue != "" {
-		if !validateColumnName(groupingColumn, allowedGroupingColumns) {
-			return nil, 0, fmt.Errorf("invalid grouping column: %s. Allowed grouping columns: %v", groupingColumn, allowedGroupingColumns)
+// GetIndicatorSummaries returns the numerical indicator names present in the database with usage metadata
+func (r *CockroachDBRepository) GetIndicatorSummaries() ([]IndicatorSummary, error) {
+	var summaries []IndicatorSummary
+	if r.counters.Load() {
+		// Counts come from the counters; the value range of each name is read from idx_ni_name_value
+		indicators := (&models.NumericalIndicator{}).TableName()
+		if err := r.db.Model(&models.RowCounter{}).
+			Select(fmt.Sprintf(`name, count,
+				(SELECT MIN(value) FROM %[1]s ni WHERE ni.name = %[2]s.name) AS min_value,
+				(SELECT MAX(value) FROM %[1]s ni WHERE ni.name = %[2]s.name) AS max_value`, indicators, (&models.RowCounter{}).TableName())).
+			Where("scope = ? AND value = '' AND count > 0", models.CounterIndicator).
+			Order("name").
+			Scan(&summaries).Error; err != nil {
+			return nil, fmt.Errorf("failed to get indicator summaries: %w", err)
 		}
-		baseQuery = baseQuery.Where(fmt.Sprintf("%s = ?", groupingColumn), groupingValue)
+		return summaries, nil
 	}
 
-	// Calculate total count efficiently before weighted score joins
-	var totalCount int64
-	if err := baseQuery.Count(&totalCount).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count stocks: %w", err)
+	if err := r.db.Model(&models.NumericalIndicator{}).
+		Select("name, COUNT(*) AS count, MIN(value) AS min_value, MAX(value) AS max_value").
+		Group("name").
+		Order("name").
+		Scan(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get indicator summaries: %w", err)
 	}
+	return summaries, nil
+}
 
-	// Build query for fetching stocks (same filters as count query)
-	query := baseQuery
-
-	var sortOrder string
-
-	// If not sorting by weighted_score (or if weighted_score sort is not applicable), sort before the join
-	// Note: If sortByColumn is "weighted_score" but both weights aren't provided, skip sorting entirely
-	if sortByColumn != "" && !sortByWeightedScore {
-		// Only sort if it's not a weighted_score request without both weights
-		if !(sortByColumn == "weighted_score" && !hasBothWeights) {
-			if strings.ToLower(order) == "desc" {
-				sortOrder = "DESC"
-			} else {
-				sortOrder = "ASC"
-			}
-			query = query.Order(fmt.Sprintf("%s %s", sortByColumn, sortOrder))
+// GetSentimentSummaries returns the rating sentiment names present in the database with usage metadata
+func (r *CockroachDBRepository) GetSentimentSummaries() ([]SentimentSummary, error) {
+	var summaries []SentimentSummary
+	if r.counters.Load() {
+		counters := (&models.RowCounter{}).TableName()
+		if err := r.db.Model(&models.RowCounter{}).
+			Select(fmt.Sprintf(`name, count,
+				(SELECT COUNT(*) FROM %[1]s rc WHERE rc.scope = '%[2]s' AND rc.name = %[1]s.name AND rc.count > 0) AS distinct_ratings`, counters, models.CounterSentimentRating)).
+			Where("scope = ? AND value = '' AND count > 0", models.CounterSentiment).
+			Order("name").
+			Scan(&summaries).Error; err != nil {
+			return nil, fmt.Errorf("failed to get sentiment summaries: %w", err)
 		}
+		return summaries, nil
 	}
 
-	// Prepare sort order for weighted_score (always DESC when sorting by weighted_score)
-	if sortByWeightedScore {
-		sortOrder = "DESC"
+	if err := r.db.Model(&models.RatingSentiment{}).
+		Select("name, COUNT(*) AS count, COUNT(DISTINCT rating) AS distinct_ratings").
+		Group("name").
+		Order("name").
+		Scan(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sentiment summaries: %w", err)
 	}
+	return summaries, nil
+}
 
-	// Calculate combined weighted scores: join indicator and rating subqueries, sum their scores
-	if hasAnyWeights {
-		// Get table names
-		niTableName := (&models.NumericalIndicator{}).TableName()
-		rsTableName := (&models.RatingSentiment{}).TableName()
-
-		// Convert weight slices to generic format using helper methods
-		indicatorWeights := convertNumericalWeights(numericalWeights)
-		ratingWeightEntries := convertRatingWeights(ratingWeights)
-
-		// Build subqueries using helper method
-		indicatorSubquery := buildWeightedScoreSubquery(niTableName, "norm_value", "new_indicator_score", "ni_sub", indicatorWeights)
-		ratingSubquery := buildWeightedScoreSubquery(rsTableName, "norm_rating_score", "new_rating_score", "rs_sub", ratingWeightEntries)
-
-		// Combine indicator and rating subqueries into a single combined subquery
-		combinedSubquery := combineWeightedScoreSubqueries(indicatorSubquery, ratingSubquery)
-
-		// Simple INNER JOIN with stock_data_points
-		// Select weighted_score with explicit alias to ensure GORM maps it to WeightedScore field
-		// GORM maps snake_case column names (weighted_score) to PascalCase fields (WeightedScore)
-		query = query.
-			Select("stock_data_points.*, combined_scores.weighted_score AS weighted_score").
-			Joins(fmt.Sprintf("INNER JOIN %s combined_scores ON combined_scores.stock_data_point_id = stock_data_points.id", combinedSubquery))
-
-		// Sort by weighted_score after the join
-		if sortByWeightedScore {
-			query = query.Order(fmt.Sprintf("combined_scores.weighted_score %s", sortOrder))
-		}
+// GetStocksByAction returns one page of the data points for an action and their total count
+func (r *CockroachDBRepository) GetStocksByAction(action string, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	stocks, total, err := r.listStocks("action = ?", action, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get data by action %s: %w", action, err)
 	}
+	return stocks, total, nil
+}
 
-	// Apply pagination
-	if page < 1 {
-		page = 1
+// GetStocksByClusterAndGroup filters by cluster and optionally by groupingColumn using GORM
+// Returns stocks, total count, and error
+func (r *CockroachDBRepository) GetStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter, preload PreloadMode) ([]models.StockDataPoint, int64, error) {
+	baseQuery, query, err := r.clusterPageQuery(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges)
+	if err != nil {
+		return nil, 0, err
 	}
-	if perPage <= 0 {
-		perPage = 20
+
+	// Calculate total count efficiently before weighted score joins
+	var totalCount int64
+	if err := baseQuery.Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count stocks: %w", err)
 	}
-	offset := (page - 1) * perPage
-	query = query.Offset(offset).Limit(perPage)
 
-	// Preload relations: RatingSentiments and NumericalIndicators
-	query = query.Preload("RatingSentiments").Preload("NumericalIndicators")
+	// Preload relations: RatingSentiments and NumericalIndicators, as much of them as the caller reads
+	query = preloadRelations(query, preload)
 
 	// Define struct that embeds StockDataPoint and includes weighted_score
 	type StockDataPointWithWeightedScore struct {
@@ -464,16 +937,160 @@ func (r *CockroachDBRepository) GetStocksByClusterAndGroup(cluster int, grouping
 	return stocks, totalCount, nil
 }
 
+// clusterPageQuery builds the queries of GetStocksByClusterAndGroup: the filter query the total is
+// counted with, and the sorted, paginated page query (weighted_score selected when weights are
+// given) without relation preloads
+func (r *CockroachDBRepository) clusterPageQuery(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) (*gorm.DB, *gorm.DB, error) {
+	// Either weight array alone is enough for a weighted score; the other set contributes nothing
+	hasAnyWeights := len(numericalWeights) > 0 || len(ratingWeights) > 0
+
+	// Validate the sort keys early; weighted_score keys are skipped without any weights
+	var orderBy []string
+	if sortByColumn != "" {
+		terms, err := orderTerms(sortByColumn, order, "", AllowedSortColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, term := range terms {
+			if strings.HasPrefix(term, "weighted_score ") && !hasAnyWeights {
+				continue
+			}
+			orderBy = append(orderBy, term)
+		}
+	}
+
+	// Build base query for filtering and counting (before weighted scores join)
+	baseQuery, err := r.clusterFilterQuery(cluster, groupingColumn, groupingValue, tags, ranges)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Build query for fetching stocks (same filters as count query); the new session keeps the
+	// clauses added below out of the count
+	query := baseQuery.Session(&gorm.Session{})
+
+	// Calculate combined weighted scores as correlated scalar subqueries, evaluated only for the
+	// filtered rows instead of aggregating both child tables and joining the results
+	if hasAnyWeights {
+		// Stocks without any weighted child rows score 0 rather than dropping out, so the page
+		// stays consistent with totalCount
+		// Select weighted_score with explicit alias to ensure GORM maps it to WeightedScore field
+		query = query.Select(fmt.Sprintf("stock_data_points.*, %s AS weighted_score", weightedScoreExpression(numericalWeights, ratingWeights)))
+	}
+
+	// Sort by the keys in order; id breaks remaining ties so pages do not overlap
+	query = query.Order(orderWithTiebreaker(orderBy, "stock_data_points.id", order))
+
+	// Apply pagination
+	if page < 1 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+	query = query.Offset(offset).Limit(perPage)
+
+	return baseQuery, query, nil
+}
+
+// withAnyTag restricts query to the data points carrying any of tags; no tags leave it unchanged
+func (r *CockroachDBRepository) withAnyTag(query *gorm.DB, tags []string) *gorm.DB {
+	if len(tags) == 0 {
+		return query
+	}
+	taggedIDs := r.db.Table(models.StockTagsJoinTable).
+		Select(models.StockTagsJoinTable+".stock_data_point_id").
+		Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.id = %[2]s.tag_id", (&models.Tag{}).TableName(), models.StockTagsJoinTable)).
+		Where((&models.Tag{}).TableName()+".name IN ?", tags)
+	return query.Where(fmt.Sprintf("%s.id IN (?)", (&models.StockDataPoint{}).TableName()), taggedIDs)
+}
+
+// clusterFilterQuery selects the data points of a cluster, narrowed to groupingValue of
+// groupingColumn (unless the column is validators.GroupingNone or the value empty), to stocks carrying any of tags
+// and to the bounds of ranges
+func (r *CockroachDBRepository) clusterFilterQuery(cluster int, groupingColumn string, groupingValue string, tags []string, ranges []RangeFilter) (*gorm.DB, error) {
+	query := r.db.Model(&models.StockDataPoint{}).
+		Where("cluster = ?", cluster)
+
+	// Filter by groupingColumn if not none - validate against grouping-specific whitelist
+	if groupingColumn != validators.GroupingNone && groupingValue != "" {
+		if !validateColumnName(groupingColumn, AllowedGroupingColumns) {
+			return nil, apperrors.Validation("invalid grouping column: %s. Allowed grouping columns: %v", groupingColumn, AllowedGroupingColumns)
+		}
+		query = query.Where(fmt.Sprintf("%s = ?", groupingColumn), groupingValue)
+	}
+
+	// Restrict to stocks carrying any of the requested tags
+	query = r.withAnyTag(query, tags)
+
+	// Bound numeric columns - validate against the range whitelist before interpolating the name
+	for _, rf := range ranges {
+		if !validateColumnName(rf.Column, RangeFilterColumns) {
+			return nil, apperrors.Validation("invalid range column: %s. Allowed range columns: %v", rf.Column, RangeFilterColumns)
+		}
+		column := fmt.Sprintf("%s.%s", (&models.StockDataPoint{}).TableName(), rf.Column)
+		if rf.Min != nil {
+			query = query.Where(column+" >= ?", *rf.Min)
+		}
+		if rf.Max != nil {
+			query = query.Where(column+" <= ?", *rf.Max)
+		}
+	}
+	return query, nil
+}
+
+// weightedScoreExpression is the SQL weighted_score of a stock_data_points row: the sum of the
+// indicator and sentiment scalar subqueries, each 0 when the stock has no weighted child rows,
+// rounded as the scoring specification requires so it equals scoring.Score of the same row
+func weightedScoreExpression(numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry) string {
+	indicators, sentiments := ScoringWeights(numericalWeights, ratingWeights)
+	indicatorSubquery := buildWeightedScoreSubquery((&models.NumericalIndicator{}).TableName(), "norm_value", "ni_sub", indicators)
+	ratingSubquery := buildWeightedScoreSubquery((&models.RatingSentiment{}).TableName(), "norm_rating_score", "rs_sub", sentiments)
+	return scoring.SQLRoundScore(combineWeightedScoreSubqueries(indicatorSubquery, ratingSubquery))
+}
+
+// GetClusterGroupAggregates summarizes the filtered stocks of a cluster per value of groupingColumn
+// in one query: the row count, the average final_score and, when weights are given, the average
+// weighted score. Groups are ordered by count (largest first), then by value.
+func (r *CockroachDBRepository) GetClusterGroupAggregates(cluster int, groupingColumn string, groupingValue string, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) ([]GroupAggregate, error) {
+	if !validateColumnName(groupingColumn, AllowedGroupingColumns) {
+		return nil, apperrors.Validation("invalid grouping column: %s. Allowed grouping columns: %v", groupingColumn, AllowedGroupingColumns)
+	}
+
+	filtered, err := r.clusterFilterQuery(cluster, groupingColumn, groupingValue, tags, ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score each filtered row once in a derived table, then aggregate per group
+	weightedScore := "NULL::FLOAT"
+	if len(numericalWeights) > 0 || len(ratingWeights) > 0 {
+		weightedScore = weightedScoreExpression(numericalWeights, ratingWeights)
+	}
+	scored := filtered.Select(fmt.Sprintf("COALESCE(%s, '') AS group_value, final_score, %s AS weighted_score", groupingColumn, weightedScore))
+
+	var aggregates []GroupAggregate
+	if err := r.db.Table("(?) AS scored", scored).
+		Select("group_value, COUNT(*) AS count, AVG(final_score) AS avg_final_score, AVG(weighted_score) AS avg_weighted_score").
+		Group("group_value").
+		Order("count DESC, group_value").
+		Scan(&aggregates).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate cluster %d by %s: %w", cluster, groupingColumn, err)
+	}
+	return aggregates, nil
+}
+
 // GetUniqueByGroupSelectColumn returns unique values for a specified column filtered by cluster
 // columnName must be one of: 'action', 'rating_to', 'rating_from'
 // Note: 'company' and 'date' are excluded due to having too many distinct values
 func (r *CockroachDBRepository) GetUniqueByGroupSelectColumn(cluster int, columnName string) ([]string, error) {
 	// Whitelist of allowed column names (excluding company and date due to too many distinct values)
-	allowedColumns := []string{"action", "rating_to", "rating_from"}
+	allowedColumns := AllowedGroupingColumns
 
 	// Validate column name
 	if !validateColumnName(columnName, allowedColumns) {
-		return nil, fmt.Errorf("invalid column name: %s. Allowed values: %v", columnName, allowedColumns)
+		return nil, apperrors.Validation("invalid column name: %s. Allowed values: %v", columnName, allowedColumns)
 	}
 
 	// Filter by cluster first, then get distinct values for the specified column
@@ -491,45 +1108,149 @@ func (r *CockroachDBRepository) GetUniqueByGroupSelectColumn(cluster int, column
 	return values, nil
 }
 
-// EmptyAllTables deletes all records from all tables in the correct order
+// EmptyAllTables empties all data tables with a single TRUNCATE, falling back to per-table deletes
+// when the statement fails (e.g. a table has not been migrated yet)
+func (r *CockroachDBRepository) EmptyAllTables() error {
+	r.logger().Info("Truncating all tables")
+	if err := r.db.Exec("TRUNCATE TABLE " + strings.Join(wipeTables(), ", ") + " CASCADE").Error; err != nil {
+		r.logger().Warn("TRUNCATE failed, deleting table by table", "error", err)
+		return r.deleteAllTables()
+	}
+	r.logger().Info("All tables emptied")
+	return nil
+}
+
+// deleteAllTables deletes all records from all tables in the correct order
 // Deletes child tables first (rating_sentiments, numerical_indicators), then parent table (stock_data_points)
 // If tables don't exist, GORM will handle the error gracefully
-func (r *CockroachDBRepository) EmptyAllTables() error {
-	log.Println("Emptying all tables...")
+func (r *CockroachDBRepository) deleteAllTables() error {
+	r.logger().Info("Emptying all tables")
 
 	// Delete from child tables first (due to foreign key constraints)
 	// Using GORM's Model and Delete - will return error if table doesn't exist, which is acceptable
 	if err := r.db.Model(&models.RatingSentiment{}).Where("1 = 1").Delete(&models.RatingSentiment{}).Error; err != nil {
 		if strings.Contains(err.Error(), "does not exist") {
-			log.Println("rating_sentiments table does not exist, skipping")
+			r.logger().Info("Table does not exist, skipping", "table", "rating_sentiments")
 		} else {
 			return fmt.Errorf("failed to empty rating_sentiments table: %w", err)
 		}
 	} else {
-		log.Println("Emptied rating_sentiments table")
+		r.logger().Info("Emptied table", "table", "rating_sentiments")
 	}
 
 	if err := r.db.Model(&models.NumericalIndicator{}).Where("1 = 1").Delete(&models.NumericalIndicator{}).Error; err != nil {
 		if strings.Contains(err.Error(), "does not exist") {
-			log.Println("numerical_indicators table does not exist, skipping")
+			r.logger().Info("Table does not exist, skipping", "table", "numerical_indicators")
 		} else {
 			return fmt.Errorf("failed to empty numerical_indicators table: %w", err)
 		}
 	} else {
-		log.Println("Emptied numerical_indicators table")
+		r.logger().Info("Emptied table", "table", "numerical_indicators")
+	}
+
+	if err := r.db.Model(&models.ClusterAssignment{}).Where("1 = 1").Delete(&models.ClusterAssignment{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "cluster_assignments")
+		} else {
+			return fmt.Errorf("failed to empty cluster_assignments table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "cluster_assignments")
+	}
+
+	if err := r.db.Model(&models.IndicatorSnapshot{}).Where("1 = 1").Delete(&models.IndicatorSnapshot{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "indicator_snapshots")
+		} else {
+			return fmt.Errorf("failed to empty indicator_snapshots table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "indicator_snapshots")
+	}
+
+	if err := r.db.Model(&models.StockSnapshot{}).Where("1 = 1").Delete(&models.StockSnapshot{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "stock_snapshots")
+		} else {
+			return fmt.Errorf("failed to empty stock_snapshots table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "stock_snapshots")
+	}
+
+	if err := r.db.Model(&models.Note{}).Where("1 = 1").Delete(&models.Note{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "stock_notes")
+		} else {
+			return fmt.Errorf("failed to empty stock_notes table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "stock_notes")
+	}
+
+	if err := r.db.Exec("DELETE FROM " + models.StockTagsJoinTable).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "stock_tags")
+		} else {
+			return fmt.Errorf("failed to empty stock_tags table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "stock_tags")
 	}
 
 	// Delete from parent table last
 	if err := r.db.Model(&models.StockDataPoint{}).Where("1 = 1").Delete(&models.StockDataPoint{}).Error; err != nil {
 		if strings.Contains(err.Error(), "does not exist") {
-			log.Println("stock_data_points table does not exist, skipping")
+			r.logger().Info("Table does not exist, skipping", "table", "stock_data_points")
 		} else {
 			return fmt.Errorf("failed to empty stock_data_points table: %w", err)
 		}
 	} else {
-		log.Println("Emptied stock_data_points table")
+		r.logger().Info("Emptied table", "table", "stock_data_points")
+	}
+
+	// Import bookkeeping goes with the data, so the same files can be imported again
+	if err := r.db.Model(&models.DatasetRecord{}).Where("1 = 1").Delete(&models.DatasetRecord{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "dataset_records")
+		} else {
+			return fmt.Errorf("failed to empty dataset_records table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "dataset_records")
+	}
+
+	if err := r.db.Model(&models.DatasetVersion{}).Where("1 = 1").Delete(&models.DatasetVersion{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "dataset_versions")
+		} else {
+			return fmt.Errorf("failed to empty dataset_versions table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "dataset_versions")
+	}
+
+	if err := r.db.Model(&models.ImportFingerprint{}).Where("1 = 1").Delete(&models.ImportFingerprint{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "import_fingerprints")
+		} else {
+			return fmt.Errorf("failed to empty import_fingerprints table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "import_fingerprints")
+	}
+
+	// Centroids describe the deleted stocks
+	if err := r.db.Model(&models.ClusterCentroid{}).Where("1 = 1").Delete(&models.ClusterCentroid{}).Error; err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			r.logger().Info("Table does not exist, skipping", "table", "cluster_centroids")
+		} else {
+			return fmt.Errorf("failed to empty cluster_centroids table: %w", err)
+		}
+	} else {
+		r.logger().Info("Emptied table", "table", "cluster_centroids")
 	}
 
-	log.Println("All tables emptied successfully")
+	r.logger().Info("All tables emptied")
 	return nil
 }
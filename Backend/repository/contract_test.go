@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"os"
+	"testing"
+
+	"dataextractor/repository"
+	"dataextractor/repository/repositorytest"
+)
+
+// TestCockroachDBContract runs the repository contract against the configured CockroachDB, bare and
+// behind the metrics decorator. Every table is emptied before each case, so it only runs when
+// REPOSITORY_CONTRACT_TESTS=1 points it at a disposable database.
+func TestCockroachDBContract(t *testing.T) {
+	if os.Getenv("REPOSITORY_CONTRACT_TESTS") != "1" {
+		t.Skip("set REPOSITORY_CONTRACT_TESTS=1 to run the contract against the configured CockroachDB (its tables are emptied)")
+	}
+
+	repo := repository.NewCockroachDBRepository(nil)
+	if err := repo.Connect(); err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	empty := func(t *testing.T) repository.DataRepositoryInterface {
+		if err := repo.EmptyAllTables(); err != nil {
+			t.Fatalf("EmptyAllTables() error = %v", err)
+		}
+		return repo
+	}
+
+	t.Run("CockroachDB", func(t *testing.T) {
+		repositorytest.Run(t, empty)
+	})
+	t.Run("Metrics", func(t *testing.T) {
+		metrics := repository.NewRepositoryMetrics()
+		repositorytest.Run(t, func(t *testing.T) repository.DataRepositoryInterface {
+			return repository.NewMetricsRepository(empty(t), metrics)
+		})
+	})
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// counterUpsert is the PL/pgSQL statement adding delta to the counter of scope, name and value
+// (SQL expressions over NEW/OLD)
+func counterUpsert(scope, name, value string, delta int) string {
+	table := (&models.RowCounter{}).TableName()
+	return fmt.Sprintf(`INSERT INTO %[1]s (scope, name, value, count) VALUES ('%[2]s', %[3]s, %[4]s, %[5]d)
+			ON CONFLICT (scope, name, value) DO UPDATE SET count = %[1]s.count + excluded.count;`,
+		table, scope, name, value, delta)
+}
+
+// counterTrigger describes the row trigger maintaining the counters of one table
+type counterTrigger struct {
+	table    string
+	function string
+
+	// inserted and deleted are the upserts run for NEW and OLD rows; changed is the condition
+	// under which an update moves a row between counters
+	inserted []string
+	deleted  []string
+	changed  string
+
+	// written are the upserts run for every inserted, updated or deleted row
+	written []string
+}
+
+// counterTriggers returns the triggers of the stock, indicator and sentiment tables
+func counterTriggers() []counterTrigger {
+	upserts := func(row string, delta int, counters ...[3]string) []string {
+		statements := make([]string, len(counters))
+		for i, c := range counters {
+			statements[i] = counterUpsert(c[0], strings.ReplaceAll(c[1], "ROW", row), strings.ReplaceAll(c[2], "ROW", row), delta)
+		}
+		return statements
+	}
+	stockCounters := [][3]string{
+		{models.CounterStocks, "''", "''"},
+		{models.CounterCluster, "ROW.cluster::TEXT", "''"},
+		{models.CounterCompany, "ROW.company", "''"},
+	}
+	indicatorCounters := [][3]string{
+		{models.CounterIndicator, "ROW.name", "''"},
+	}
+	sentimentCounters := [][3]string{
+		{models.CounterSentiment, "ROW.name", "''"},
+		{models.CounterSentimentRating, "ROW.name", "ROW.rating"},
+	}
+
+	return []counterTrigger{
+		{
+			table:    (&models.StockDataPoint{}).TableName(),
+			function: "count_stock_data_points",
+			inserted: upserts("NEW", 1, stockCounters...),
+			deleted:  upserts("OLD", -1, stockCounters...),
+			changed:  "OLD.cluster <> NEW.cluster OR OLD.company <> NEW.company",
+			written:  []string{counterUpsert(models.CounterStockWrites, "''", "''", 1)},
+		},
+		{
+			table:    (&models.NumericalIndicator{}).TableName(),
+			function: "count_numerical_indicators",
+			inserted: upserts("NEW", 1, indicatorCounters...),
+			deleted:  upserts("OLD", -1, indicatorCounters...),
+			changed:  "OLD.name <> NEW.name",
+		},
+		{
+			table:    (&models.RatingSentiment{}).TableName(),
+			function: "count_rating_sentiments",
+			inserted: upserts("NEW", 1, sentimentCounters...),
+			deleted:  upserts("OLD", -1, sentimentCounters...),
+			changed:  "OLD.name <> NEW.name OR OLD.rating <> NEW.rating",
+		},
+	}
+}
+
+// installCounterTriggers (re)creates the row triggers maintaining RowCounter. Triggers rather than
+// GORM hooks keep the counters exact for bulk deletes, cascades and raw statements, which never run
+// model hooks.
+func installCounterTriggers(db *gorm.DB) error {
+	for _, t := range counterTriggers() {
+		if err := installTrigger(db, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installTrigger (re)creates the row trigger t and its function
+func installTrigger(db *gorm.DB, t counterTrigger) error {
+	name := t.function + "_trigger"
+	statements := []string{
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", name, t.table),
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS TRIGGER LANGUAGE plpgsql AS $$
+BEGIN
+	IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND (%[2]s)) THEN
+		%[3]s
+	END IF;
+	IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND (%[2]s)) THEN
+		%[4]s
+	END IF;
+	%[5]s
+	RETURN NULL;
+END
+$$`, t.function, t.changed, strings.Join(t.deleted, "\n\t\t"), strings.Join(t.inserted, "\n\t\t"), strings.Join(t.written, "\n\t")),
+		fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s()", name, t.table, t.function),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to install %s trigger on %s: %w", t.function, t.table, err)
+		}
+	}
+	return nil
+}
+
+// refreshCounters recounts every RowCounter from the counted tables in one transaction, so counters
+// written before the triggers existed (or while they were missing) are reconciled. The write counter
+// cannot be recounted and is kept.
+func refreshCounters(db *gorm.DB) error {
+	counters := (&models.RowCounter{}).TableName()
+	stocks := (&models.StockDataPoint{}).TableName()
+	indicators := (&models.NumericalIndicator{}).TableName()
+	sentiments := (&models.RatingSentiment{}).TableName()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			fmt.Sprintf("DELETE FROM %s WHERE scope <> '%s'", counters, models.CounterStockWrites),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', '', '', COUNT(*) FROM %s", counters, models.CounterStocks, stocks),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', cluster::TEXT, '', COUNT(*) FROM %s GROUP BY cluster", counters, models.CounterCluster, stocks),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', company, '', COUNT(*) FROM %s GROUP BY company", counters, models.CounterCompany, stocks),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', name, '', COUNT(*) FROM %s GROUP BY name", counters, models.CounterIndicator, indicators),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', name, '', COUNT(*) FROM %s GROUP BY name", counters, models.CounterSentiment, sentiments),
+			fmt.Sprintf("INSERT INTO %s (scope, name, value, count) SELECT '%s', name, rating, COUNT(*) FROM %s GROUP BY name, rating", counters, models.CounterSentimentRating, sentiments),
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to refresh row counters: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// useCounters installs the counter triggers and reconciles the counters. It reports whether the
+// counters can be read; on failure it logs a warning and readers fall back to counting the tables.
+func useCounters(db *gorm.DB) bool {
+	if err := installCounterTriggers(db); err != nil {
+		slog.Warn("Row counters unavailable; stats and dictionary counts scan the tables", "error", err)
+		return false
+	}
+	if err := refreshCounters(db); err != nil {
+		slog.Warn("Row counters unavailable; stats and dictionary counts scan the tables", "error", err)
+		return false
+	}
+	slog.Info("Row counters configured: stats and dictionary counts are read from row_counters")
+	return true
+}
+
+// counterCount returns the counter of scope and name (0 when no row has been counted)
+func (r *CockroachDBRepository) counterCount(scope, name string) (int64, error) {
+	var counts []int64
+	if err := r.db.Model(&models.RowCounter{}).
+		Where("scope = ? AND name = ? AND value = ''", scope, name).
+		Pluck("count", &counts).Error; err != nil {
+		return 0, fmt.Errorf("failed to read %s counter: %w", scope, err)
+	}
+	if len(counts) == 0 {
+		return 0, nil
+	}
+	return counts[0], nil
+}
+
+// counterSize returns the number of names of scope whose counter is positive
+func (r *CockroachDBRepository) counterSize(scope string) (int64, error) {
+	var size int64
+	if err := r.db.Model(&models.RowCounter{}).
+		Where("scope = ? AND value = '' AND count > 0", scope).
+		Count(&size).Error; err != nil {
+		return 0, fmt.Errorf("failed to read %s counters: %w", scope, err)
+	}
+	return size, nil
+}
+
+// clusterCounts returns the data point count of every non-empty cluster
+func (r *CockroachDBRepository) clusterCounts() (map[int]int64, error) {
+	var rows []models.RowCounter
+	if err := r.db.Where("scope = ? AND count > 0", models.CounterCluster).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read cluster counters: %w", err)
+	}
+	counts := make(map[int]int64, len(rows))
+	for _, row := range rows {
+		cluster, err := strconv.Atoi(row.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster counter %q: %w", row.Name, err)
+		}
+		counts[cluster] = row.Count
+	}
+	return counts, nil
+}
+
+// counterDatabaseStats is GetDatabaseStats answered from the row counters. Tickers are unique, so
+// the ticker count is the record count.
+func (r *CockroachDBRepository) counterDatabaseStats() (DatabaseStats, error) {
+	totalCount, err := r.counterCount(models.CounterStocks, "")
+	if err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get total count: %w", err)
+	}
+	uniqueCompanies, err := r.counterSize(models.CounterCompany)
+	if err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get unique companies count: %w", err)
+	}
+	clusterCounts, err := r.clusterCounts()
+	if err != nil {
+		return DatabaseStats{}, fmt.Errorf("failed to get cluster counts: %w", err)
+	}
+
+	return DatabaseStats{
+		TotalRecords:    totalCount,
+		UniqueTickers:   totalCount,
+		UniqueCompanies: uniqueCompanies,
+		ClusterCounts:   clusterCounts,
+	}, nil
+}
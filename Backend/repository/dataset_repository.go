@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DatasetRollback summarizes a rollback: data points restored from the archive, data points deleted
+// because they did not exist as of the target version, and the versions marked rolled back
+type DatasetRollback struct {
+	Version            uint   `json:"version"`
+	Restored           int64  `json:"restored"`
+	Deleted            int64  `json:"deleted"`
+	RolledBackVersions []uint `json:"rolled_back_versions"`
+}
+
+// CreateDatasetVersion registers a new import run
+func (r *CockroachDBRepository) CreateDatasetVersion(version *models.DatasetVersion) error {
+	if err := r.db.Create(version).Error; err != nil {
+		return fmt.Errorf("failed to create dataset version: %w", err)
+	}
+	return nil
+}
+
+// UpdateDatasetVersion saves the status and counters of an import run
+func (r *CockroachDBRepository) UpdateDatasetVersion(version *models.DatasetVersion) error {
+	if err := r.db.Save(version).Error; err != nil {
+		return fmt.Errorf("failed to update dataset version %d: %w", version.ID, err)
+	}
+	return nil
+}
+
+// GetDatasetVersions returns every import run, newest first
+func (r *CockroachDBRepository) GetDatasetVersions() ([]models.DatasetVersion, error) {
+	var versions []models.DatasetVersion
+	if err := r.db.Order("id DESC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get dataset versions: %w", err)
+	}
+	return versions, nil
+}
+
+// GetDatasetVersion returns a single import run
+func (r *CockroachDBRepository) GetDatasetVersion(id uint) (*models.DatasetVersion, error) {
+	var version models.DatasetVersion
+	if err := r.db.First(&version, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("dataset version %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get dataset version %d: %w", id, err)
+	}
+	return &version, nil
+}
+
+// SaveDatasetRecord archives a data point as written by its dataset version; a ticker repeated
+// within one import keeps its last row
+func (r *CockroachDBRepository) SaveDatasetRecord(stock *models.StockDataPoint) error {
+	if stock.DatasetVersionID == nil {
+		return apperrors.Validation("data point %s has no dataset version", stock.Ticker)
+	}
+	payload, err := json.Marshal(stock)
+	if err != nil {
+		return fmt.Errorf("failed to encode dataset record for %s: %w", stock.Ticker, err)
+	}
+	record := models.DatasetRecord{DatasetVersionID: *stock.DatasetVersionID, Ticker: stock.Ticker, Payload: string(payload)}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dataset_version_id"}, {Name: "ticker"}},
+		DoUpdates: clause.AssignmentColumns([]string{"payload"}),
+	}).Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to save dataset record for %s: %w", stock.Ticker, err)
+	}
+	return nil
+}
+
+// RollbackDataset reverts every data point written by a version newer than target to its archived
+// state as of target (the latest complete version at or before it that wrote the ticker). Data points
+// with no such record did not exist yet and are deleted. Rows created through the API carry no
+// version and are left alone. Newer versions are marked rolled back so they are never restored from.
+func (r *CockroachDBRepository) RollbackDataset(target uint) (DatasetRollback, error) {
+	result := DatasetRollback{Version: target, RolledBackVersions: []uint{}}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var stocks []models.StockDataPoint
+		if err := tx.Where("dataset_version_id > ?", target).Find(&stocks).Error; err != nil {
+			return fmt.Errorf("failed to find data points to roll back: %w", err)
+		}
+
+		for i := range stocks {
+			stock := &stocks[i]
+			var record models.DatasetRecord
+			err := tx.Joins(fmt.Sprintf("JOIN %s dv ON dv.id = %s.dataset_version_id", (&models.DatasetVersion{}).TableName(), record.TableName())).
+				Where("ticker = ? AND dataset_version_id <= ? AND dv.status = ?", stock.Ticker, target, models.DatasetComplete).
+				Order("dataset_version_id DESC").
+				First(&record).Error
+			if err == gorm.ErrRecordNotFound {
+				if err := tx.Delete(stock).Error; err != nil {
+					return fmt.Errorf("failed to delete %s: %w", stock.Ticker, err)
+				}
+				result.Deleted++
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to find archived record for %s: %w", stock.Ticker, err)
+			}
+			if err := restoreDatasetRecord(tx, stock, record); err != nil {
+				return err
+			}
+			result.Restored++
+		}
+
+		var newer []models.DatasetVersion
+		if err := tx.Where("id > ? AND status <> ?", target, models.DatasetRolledBack).Order("id").Find(&newer).Error; err != nil {
+			return fmt.Errorf("failed to find newer dataset versions: %w", err)
+		}
+		now := time.Now().UTC()
+		for i := range newer {
+			newer[i].Status = models.DatasetRolledBack
+			newer[i].RolledBackAt = &now
+			if err := tx.Save(&newer[i]).Error; err != nil {
+				return fmt.Errorf("failed to mark dataset version %d rolled back: %w", newer[i].ID, err)
+			}
+			result.RolledBackVersions = append(result.RolledBackVersions, newer[i].ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return DatasetRollback{}, err
+	}
+	return result, nil
+}
+
+// restoreDatasetRecord overwrites a live data point with an archived copy, keeping its identity,
+// tags and notes and replacing its sentiments and indicators
+func restoreDatasetRecord(tx *gorm.DB, current *models.StockDataPoint, record models.DatasetRecord) error {
+	var restored models.StockDataPoint
+	if err := json.Unmarshal([]byte(record.Payload), &restored); err != nil {
+		return fmt.Errorf("failed to decode archived record for %s: %w", current.Ticker, err)
+	}
+	restored.ID = current.ID
+	restored.UUID = current.UUID
+	restored.CreatedAt = current.CreatedAt
+	restored.CreatedBy = current.CreatedBy
+	restored.Tags = nil
+	restored.Notes = nil
+	restored.WeightedScore = nil
+	for i := range restored.RatingSentiments {
+		restored.RatingSentiments[i].ID = 0
+		restored.RatingSentiments[i].UUID = ""
+		restored.RatingSentiments[i].StockDataPointID = current.ID
+	}
+	for i := range restored.NumericalIndicators {
+		restored.NumericalIndicators[i].ID = 0
+		restored.NumericalIndicators[i].UUID = ""
+		restored.NumericalIndicators[i].StockDataPointID = current.ID
+	}
+
+	if err := tx.Where("stock_data_point_id = ?", current.ID).Delete(&models.RatingSentiment{}).Error; err != nil {
+		return fmt.Errorf("failed to clear sentiments of %s: %w", current.Ticker, err)
+	}
+	if err := tx.Where("stock_data_point_id = ?", current.ID).Delete(&models.NumericalIndicator{}).Error; err != nil {
+		return fmt.Errorf("failed to clear indicators of %s: %w", current.Ticker, err)
+	}
+	if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(&restored).Error; err != nil {
+		return fmt.Errorf("failed to restore %s: %w", current.Ticker, err)
+	}
+	return nil
+}
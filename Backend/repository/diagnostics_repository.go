@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// largestTablesLimit caps the tables listed in Diagnostics.LargestTables
+const largestTablesLimit = 5
+
+// Diagnostics describes the database schema, row counts and sizes, for support
+type Diagnostics struct {
+	// SchemaVersion fingerprints the tables and columns this build migrates to; deployments
+	// reporting the same version expect the same schema
+	SchemaVersion string             `json:"schema_version"`
+	Migration     MigrationStatus    `json:"migration"`
+	Tables        []TableDiagnostics `json:"tables"`
+	Indexes       []IndexDiagnostics `json:"indexes"`
+	LargestTables []TableDiagnostics `json:"largest_tables"`
+}
+
+// MigrationStatus compares the migrated models with the columns found in information_schema
+type MigrationStatus struct {
+	UpToDate       bool     `json:"up_to_date"`
+	MissingTables  []string `json:"missing_tables"`
+	MissingColumns []string `json:"missing_columns"`
+}
+
+// PoolStats is a snapshot of the database connection pool
+type PoolStats struct {
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`
+	WaitDurationMs     float64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64   `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64   `json:"max_lifetime_closed"`
+}
+
+// TableDiagnostics is the row count and, when the database reports it, on-disk size of a table
+type TableDiagnostics struct {
+	Name      string `json:"name"`
+	RowCount  int64  `json:"row_count"`
+	SizeBytes *int64 `json:"size_bytes,omitempty"`
+}
+
+// IndexDiagnostics is an index of a migrated table
+type IndexDiagnostics struct {
+	Table      string `json:"table" gorm:"column:tablename"`
+	Name       string `json:"name" gorm:"column:indexname"`
+	Definition string `json:"definition" gorm:"column:indexdef"`
+}
+
+// expectedColumns returns the columns of every migrated table (join tables included) keyed by
+// unqualified table name
+func expectedColumns(db *gorm.DB) (map[string][]string, error) {
+	expected := map[string][]string{}
+	for _, model := range migratedModels() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model schema: %w", err)
+		}
+		expected[unqualifiedTable(stmt.Schema.Table)] = stmt.Schema.DBNames
+		for _, relationship := range stmt.Schema.Relationships.Relations {
+			if relationship.JoinTable != nil {
+				expected[unqualifiedTable(relationship.JoinTable.Table)] = relationship.JoinTable.DBNames
+			}
+		}
+	}
+	return expected, nil
+}
+
+// unqualifiedTable strips the database prefix of the naming strategy from a table name
+func unqualifiedTable(table string) string {
+	return table[strings.LastIndex(table, ".")+1:]
+}
+
+// schemaVersion hashes the sorted table.column list of the expected schema
+func schemaVersion(expected map[string][]string) string {
+	var columns []string
+	for table, names := range expected {
+		for _, name := range names {
+			columns = append(columns, table+"."+name)
+		}
+	}
+	sort.Strings(columns)
+	sum := sha256.Sum256([]byte(strings.Join(columns, "\n")))
+	return hex.EncodeToString(sum[:6])
+}
+
+// GetDiagnostics gathers the schema version, migration status, row counts, indexes and largest
+// tables from information_schema and pg_indexes. Table sizes come from pg_total_relation_size and
+// are left out on databases that do not implement it (CockroachDB).
+func (r *CockroachDBRepository) GetDiagnostics() (Diagnostics, error) {
+	expected, err := expectedColumns(r.db)
+	if err != nil {
+		return Diagnostics{}, err
+	}
+	tables := make([]string, 0, len(expected))
+	for table := range expected {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	found, err := r.presentColumns(tables)
+	if err != nil {
+		return Diagnostics{}, err
+	}
+
+	diagnostics := Diagnostics{
+		SchemaVersion: schemaVersion(expected),
+		Migration:     migrationStatus(expected, found),
+		Tables:        []TableDiagnostics{},
+	}
+	for _, table := range tables {
+		if _, ok := found[table]; !ok {
+			continue
+		}
+
+		info := TableDiagnostics{Name: table}
+		if err := r.db.Table(table).Count(&info.RowCount).Error; err != nil {
+			return Diagnostics{}, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		var size int64
+		if err := r.db.Raw("SELECT pg_total_relation_size(?::regclass)", table).Row().Scan(&size); err == nil {
+			info.SizeBytes = &size
+		}
+		diagnostics.Tables = append(diagnostics.Tables, info)
+	}
+
+	if err := r.db.Raw(`SELECT tablename, indexname, indexdef FROM pg_indexes
+		WHERE schemaname = current_schema() AND tablename IN ? ORDER BY tablename, indexname`, tables).
+		Scan(&diagnostics.Indexes).Error; err != nil {
+		return Diagnostics{}, fmt.Errorf("failed to read indexes: %w", err)
+	}
+
+	diagnostics.LargestTables = largestTables(diagnostics.Tables)
+	return diagnostics, nil
+}
+
+// GetMigrationStatus compares the migrated models with the columns found in information_schema,
+// without the row counts and index listing of GetDiagnostics
+func (r *CockroachDBRepository) GetMigrationStatus() (MigrationStatus, error) {
+	if !r.connected.Load() {
+		return MigrationStatus{}, ErrNotConnected
+	}
+	expected, err := expectedColumns(r.db)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	tables := make([]string, 0, len(expected))
+	for table := range expected {
+		tables = append(tables, table)
+	}
+	found, err := r.presentColumns(tables)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	return migrationStatus(expected, found), nil
+}
+
+// GetPoolStats returns a snapshot of the connection pool
+func (r *CockroachDBRepository) GetPoolStats() (PoolStats, error) {
+	if !r.connected.Load() {
+		return PoolStats{}, ErrNotConnected
+	}
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return PoolStats{}, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	stats := sqlDB.Stats()
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     float64(stats.WaitDuration.Microseconds()) / 1000,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, nil
+}
+
+// presentColumns reads the columns of tables from information_schema, keyed by table and column name
+func (r *CockroachDBRepository) presentColumns(tables []string) (map[string]map[string]bool, error) {
+	var present []struct {
+		TableName  string
+		ColumnName string
+	}
+	if err := r.db.Raw(`SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name IN ?`, tables).Scan(&present).Error; err != nil {
+		return nil, fmt.Errorf("failed to read information_schema columns: %w", err)
+	}
+	found := map[string]map[string]bool{}
+	for _, p := range present {
+		if found[p.TableName] == nil {
+			found[p.TableName] = map[string]bool{}
+		}
+		found[p.TableName][p.ColumnName] = true
+	}
+	return found, nil
+}
+
+// migrationStatus lists the expected tables and columns missing from found, in table order
+func migrationStatus(expected map[string][]string, found map[string]map[string]bool) MigrationStatus {
+	tables := make([]string, 0, len(expected))
+	for table := range expected {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	status := MigrationStatus{MissingTables: []string{}, MissingColumns: []string{}}
+	for _, table := range tables {
+		columns, ok := found[table]
+		if !ok {
+			status.MissingTables = append(status.MissingTables, table)
+			continue
+		}
+		for _, column := range expected[table] {
+			if !columns[column] {
+				status.MissingColumns = append(status.MissingColumns, table+"."+column)
+			}
+		}
+	}
+	status.UpToDate = len(status.MissingTables) == 0 && len(status.MissingColumns) == 0
+	return status
+}
+
+// largestTables returns the largest tables first, by size when known and by row count otherwise
+func largestTables(tables []TableDiagnostics) []TableDiagnostics {
+	largest := append([]TableDiagnostics(nil), tables...)
+	sort.SliceStable(largest, func(i, j int) bool {
+		a, b := largest[i], largest[j]
+		if a.SizeBytes != nil && b.SizeBytes != nil && *a.SizeBytes != *b.SizeBytes {
+			return *a.SizeBytes > *b.SizeBytes
+		}
+		return a.RowCount > b.RowCount
+	})
+	if len(largest) > largestTablesLimit {
+		largest = largest[:largestTablesLimit]
+	}
+	return largest
+}
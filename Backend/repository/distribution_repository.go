@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// distributionTrigger returns the trigger keeping ClusterDistribution current: every stock write
+// moves one count and its final score between (cluster, action, rating_to) cells
+func distributionTrigger() counterTrigger {
+	upsert := func(row string, delta int) []string {
+		return []string{fmt.Sprintf(`INSERT INTO %[1]s (cluster, action, rating_to, count, score_sum)
+			VALUES (%[2]s.cluster, COALESCE(%[2]s.action, ''), COALESCE(%[2]s.rating_to, ''), %[3]d, %[3]d * %[2]s.final_score)
+			ON CONFLICT (cluster, action, rating_to) DO UPDATE SET count = %[1]s.count + excluded.count, score_sum = %[1]s.score_sum + excluded.score_sum;`,
+			(&models.ClusterDistribution{}).TableName(), row, delta)}
+	}
+	return counterTrigger{
+		table:    (&models.StockDataPoint{}).TableName(),
+		function: "summarize_cluster_distribution",
+		inserted: upsert("NEW", 1),
+		deleted:  upsert("OLD", -1),
+		changed: "OLD.cluster <> NEW.cluster OR OLD.action IS DISTINCT FROM NEW.action OR " +
+			"OLD.rating_to IS DISTINCT FROM NEW.rating_to OR OLD.final_score <> NEW.final_score",
+	}
+}
+
+// refreshDistributions rebuilds ClusterDistribution from the stock table in one transaction
+func refreshDistributions(db *gorm.DB) error {
+	distributions := (&models.ClusterDistribution{}).TableName()
+	stocks := (&models.StockDataPoint{}).TableName()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"DELETE FROM " + distributions,
+			fmt.Sprintf(`INSERT INTO %s (cluster, action, rating_to, count, score_sum)
+				SELECT cluster, COALESCE(action, ''), COALESCE(rating_to, ''), COUNT(*), SUM(final_score)
+				FROM %s GROUP BY 1, 2, 3`, distributions, stocks),
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to refresh cluster distributions: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// useDistributions installs the distribution trigger and rebuilds the summary. It reports whether
+// the summary can be read; on failure it logs a warning and the heatmap groups the stock table.
+func useDistributions(db *gorm.DB) bool {
+	if err := installTrigger(db, distributionTrigger()); err != nil {
+		slog.Warn("Cluster distributions unavailable; the heatmap scans the stock table", "error", err)
+		return false
+	}
+	if err := refreshDistributions(db); err != nil {
+		slog.Warn("Cluster distributions unavailable; the heatmap scans the stock table", "error", err)
+		return false
+	}
+	slog.Info("Cluster distributions configured: the heatmap is read from cluster_distributions")
+	return true
+}
+
+// distributionHeatmap is GetClusterHeatmap answered from ClusterDistribution, summing the cells of
+// the other dimension
+func (r *CockroachDBRepository) distributionHeatmap(dimension string) ([]HeatmapCell, error) {
+	if !validateColumnName(dimension, HeatmapDimensions) {
+		return nil, apperrors.Validation("invalid heatmap dimension: %s. Allowed dimensions: %v", dimension, HeatmapDimensions)
+	}
+
+	var cells []HeatmapCell
+	if err := r.db.Model(&models.ClusterDistribution{}).
+		Select(strings.Join([]string{
+			"cluster",
+			dimension + " AS value",
+			"SUM(count) AS count",
+			"(SUM(score_sum) / SUM(count))::FLOAT AS avg_score",
+		}, ", ")).
+		Where("count > 0").
+		Group("cluster, " + dimension).
+		Order("cluster, " + dimension).
+		Scan(&cells).Error; err != nil {
+		return nil, fmt.Errorf("failed to build %s heatmap: %w", dimension, err)
+	}
+	return cells, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// QueryPlan is the EXPLAIN ANALYZE output of a query, one line per entry, with the explained SQL
+// (arguments inlined)
+type QueryPlan struct {
+	SQL  string   `json:"sql"`
+	Plan []string `json:"plan"`
+}
+
+// ExplainStocksByClusterAndGroup runs EXPLAIN ANALYZE on the page query GetStocksByClusterAndGroup
+// builds for the same arguments, instead of returning its rows. The query runs in a read-only
+// transaction; the count and the relation preloads are not explained.
+func (r *CockroachDBRepository) ExplainStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) (QueryPlan, error) {
+	_, query, err := r.clusterPageQuery(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges)
+	if err != nil {
+		return QueryPlan{}, err
+	}
+
+	var stocks []models.StockDataPoint
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&stocks).Statement
+	sql, vars := stmt.SQL.String(), stmt.Vars
+
+	plan := QueryPlan{SQL: r.db.Dialector.Explain(sql, vars...), Plan: []string{}}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return err
+		}
+		rows, err := tx.Raw("EXPLAIN ANALYZE "+sql, vars...).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				return err
+			}
+			plan.Plan = append(plan.Plan, line)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return QueryPlan{}, fmt.Errorf("failed to explain filter query: %w", err)
+	}
+	return plan, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateExportJob registers a new export job
+func (r *CockroachDBRepository) CreateExportJob(job *models.ExportJob) error {
+	if err := r.db.Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+	return nil
+}
+
+// UpdateExportJob saves the status, counters and file location of an export job
+func (r *CockroachDBRepository) UpdateExportJob(job *models.ExportJob) error {
+	if err := r.db.Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update export job %d: %w", job.ID, err)
+	}
+	return nil
+}
+
+// GetExportJob returns a single export job
+func (r *CockroachDBRepository) GetExportJob(id uint) (*models.ExportJob, error) {
+	var job models.ExportJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("export job %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get export job %d: %w", id, err)
+	}
+	return &job, nil
+}
+
+// GetExpiredExportJobs returns the export jobs whose retention ended before the cutoff
+func (r *CockroachDBRepository) GetExpiredExportJobs(before time.Time) ([]models.ExportJob, error) {
+	var jobs []models.ExportJob
+	if err := r.db.Where("expires_at < ?", before).Order("id").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expired export jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// DeleteExportJob removes an export job record
+func (r *CockroachDBRepository) DeleteExportJob(id uint) error {
+	if err := r.db.Delete(&models.ExportJob{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete export job %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExtractionPageSortColumns are the sort columns of the extraction page history
+var ExtractionPageSortColumns = []string{"recorded_at", "page_number"}
+
+// SaveExtractionPage appends a page key to the extraction history
+func (r *CockroachDBRepository) SaveExtractionPage(page *models.ExtractionPage) error {
+	if err := r.db.Create(page).Error; err != nil {
+		return fmt.Errorf("failed to save extraction page %q: %w", page.PageKey, err)
+	}
+	return nil
+}
+
+// GetExtractionPages returns one page of the extraction history, optionally restricted to a status,
+// along with the total number of matching entries
+func (r *CockroachDBRepository) GetExtractionPages(status string, opts ListOptions) ([]models.ExtractionPage, int64, error) {
+	query := r.db.Model(&models.ExtractionPage{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	terms, err := orderTerms(opts.SortBy, opts.Order, "recorded_at", ExtractionPageSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count extraction pages: %w", err)
+	}
+
+	query = query.Order(orderWithTiebreaker(terms, "id", opts.Order))
+	if opts.PerPage > 0 {
+		page := opts.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * opts.PerPage).Limit(opts.PerPage)
+	}
+
+	var pages []models.ExtractionPage
+	if err := query.Find(&pages).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get extraction pages: %w", err)
+	}
+	return pages, total, nil
+}
+
+// GetFailedExtractionPageKeys returns the distinct page keys recorded with status error, in the
+// order they first failed
+func (r *CockroachDBRepository) GetFailedExtractionPageKeys() ([]string, error) {
+	var keys []string
+	err := r.db.Model(&models.ExtractionPage{}).
+		Where("status = ?", models.ExtractionPageError).
+		Group("page_key").
+		Order("MIN(recorded_at), MIN(id)").
+		Pluck("page_key", &keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed extraction pages: %w", err)
+	}
+	return keys, nil
+}
+
+// MarkExtractionPageRetried moves the error entries of a page key to status retried
+func (r *CockroachDBRepository) MarkExtractionPageRetried(pageKey string) error {
+	err := r.db.Model(&models.ExtractionPage{}).
+		Where("page_key = ? AND status = ?", pageKey, models.ExtractionPageError).
+		Update("status", models.ExtractionPageRetried).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark extraction page %q as retried: %w", pageKey, err)
+	}
+	return nil
+}
+
+// PruneExtractionPages deletes extraction history recorded before the cutoff and returns the number of removed entries
+func (r *CockroachDBRepository) PruneExtractionPages(before time.Time) (int64, error) {
+	result := r.db.Where("recorded_at < ?", before).Delete(&models.ExtractionPage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to prune extraction pages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// RecordAPIRequest counts one upstream request made with the key identified by keyHash on day
+func (r *CockroachDBRepository) RecordAPIRequest(keyHash string, day time.Time) error {
+	usage := models.APIUsage{KeyHash: keyHash, Day: day, Requests: 1}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key_hash"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"requests":   gorm.Expr("api_usage.requests + 1"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&usage).Error
+	if err != nil {
+		return fmt.Errorf("failed to record upstream API request: %w", err)
+	}
+	return nil
+}
+
+// GetAPIUsage returns the number of upstream requests made with the key identified by keyHash on day
+func (r *CockroachDBRepository) GetAPIUsage(keyHash string, day time.Time) (int, error) {
+	var requests int
+	err := r.db.Model(&models.APIUsage{}).
+		Where("key_hash = ? AND day = ?", keyHash, day).
+		Select("COALESCE(SUM(requests), 0)").
+		Scan(&requests).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get upstream API usage: %w", err)
+	}
+	return requests, nil
+}
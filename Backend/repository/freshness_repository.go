@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/models"
+)
+
+// DataFreshness records when the stock data was last refreshed. LastImportedAt and DatasetVersion
+// come from the latest complete dataset version and LastExtractedAt from the latest successful
+// extraction page; each is nil when there is none. LastModified is max(updated_at) of the stock
+// table, nil when it is empty.
+type DataFreshness struct {
+	LastImportedAt  *time.Time
+	DatasetVersion  *uint
+	LastExtractedAt *time.Time
+	LastModified    *time.Time
+	TotalRows       int64
+}
+
+// GetDataFreshness returns the last import, extraction and modification times and the row count
+func (r *CockroachDBRepository) GetDataFreshness() (DataFreshness, error) {
+	var freshness DataFreshness
+
+	var versions []models.DatasetVersion
+	if err := r.db.Where("status = ?", models.DatasetComplete).Order("id DESC").Limit(1).Find(&versions).Error; err != nil {
+		return DataFreshness{}, fmt.Errorf("failed to get latest dataset version: %w", err)
+	}
+	if len(versions) > 0 {
+		importedAt := versions[0].CreatedAt
+		if versions[0].CompletedAt != nil {
+			importedAt = *versions[0].CompletedAt
+		}
+		importedAt = importedAt.UTC()
+		freshness.LastImportedAt = &importedAt
+		freshness.DatasetVersion = &versions[0].ID
+	}
+
+	if err := r.db.Model(&models.ExtractionPage{}).Where("status = ?", models.ExtractionPageSuccess).
+		Select("MAX(recorded_at)").Row().Scan(&freshness.LastExtractedAt); err != nil {
+		return DataFreshness{}, fmt.Errorf("failed to get last extraction time: %w", err)
+	}
+	if freshness.LastExtractedAt != nil {
+		extractedAt := freshness.LastExtractedAt.UTC()
+		freshness.LastExtractedAt = &extractedAt
+	}
+
+	version, err := r.GetDataVersion()
+	if err != nil {
+		return DataFreshness{}, err
+	}
+	freshness.TotalRows = version.RowCount
+	if !version.LastModified.IsZero() {
+		freshness.LastModified = &version.LastModified
+	}
+	return freshness, nil
+}
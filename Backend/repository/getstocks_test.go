@@ -2,10 +2,12 @@ package repository
 
 import (
 	"log"
+	"os"
 	"testing"
 	"time"
 
 	"dataextractor/models"
+	"dataextractor/validators"
 )
 
 // TestGetStocksByClusterAndGroup tests the GetStocksByClusterAndGroup method
@@ -33,7 +35,7 @@ func TestGetStocksByClusterAndGroup(t *testing.T) {
 		{
 			name:           "Basic test - cluster only",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "desc",
@@ -53,7 +55,7 @@ func TestGetStocksByClusterAndGroup(t *testing.T) {
 		{
 			name:           "Test with numerical weights",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "asc",
@@ -67,7 +69,7 @@ func TestGetStocksByClusterAndGroup(t *testing.T) {
 		{
 			name:           "Test with rating weights",
 			cluster:        0,
-			groupingColumn: "None",
+			groupingColumn: validators.GroupingNone,
 			groupingValue:  "",
 			sortByColumn:   "date",
 			order:          "desc",
@@ -102,7 +104,7 @@ func TestGetStocksByClusterAndGroup(t *testing.T) {
 			startTime := time.Now()
 
 			// Execute the method
-					stocks, _, err := repo.GetStocksByClusterAndGroup(
+			stocks, _, err := repo.GetStocksByClusterAndGroup(
 				tc.cluster,
 				tc.groupingColumn,
 				tc.groupingValue,
@@ -112,6 +114,9 @@ func TestGetStocksByClusterAndGroup(t *testing.T) {
 				tc.perPage,
 				tc.numericalWeights,
 				tc.ratingWeights,
+				nil,
+				nil,
+				PreloadFull,
 			)
 
 			latency := time.Since(startTime)
@@ -223,13 +228,27 @@ func verifySorting(t *testing.T, stocks []models.StockDataPoint, sortByColumn st
 	}
 }
 
-// BenchmarkGetStocksByClusterAndGroup benchmarks the method performance
+// weightedScoreBudget is the default per-call latency budget for GetStocksByClusterAndGroup;
+// override it with WEIGHTED_SCORE_BUDGET (e.g. "25ms") to match the benchmark hardware
+const weightedScoreBudget = 50 * time.Millisecond
+
+// BenchmarkGetStocksByClusterAndGroup benchmarks the method performance for each preload mode and
+// sort, failing when the average call exceeds the latency budget so query regressions are caught
 func BenchmarkGetStocksByClusterAndGroup(b *testing.B) {
 	repo := NewCockroachDBRepository(nil)
 	if err := repo.Connect(); err != nil {
 		b.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	budget := weightedScoreBudget
+	if raw := os.Getenv("WEIGHTED_SCORE_BUDGET"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			b.Fatalf("Invalid WEIGHTED_SCORE_BUDGET %q: %v", raw, err)
+		}
+		budget = parsed
+	}
+
 	numericalWeights := []NumericalWeightEntry{
 		{IndicatorName: "atr", Weight: 0.4},
 		{IndicatorName: "obv", Weight: 0.2},
@@ -238,21 +257,67 @@ func BenchmarkGetStocksByClusterAndGroup(b *testing.B) {
 		{IndicatorName: "action", Weight: 0.4},
 	}
 
+	for _, sortBy := range []string{"date", "weighted_score"} {
+		for _, mode := range []PreloadMode{PreloadFull, PreloadScoring, PreloadNone} {
+			b.Run(sortBy+"/"+string(mode), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _, err := repo.GetStocksByClusterAndGroup(
+						0,                       // cluster
+						validators.GroupingNone, // groupingColumn
+						"",                      // groupingValue
+						sortBy,                  // sortByColumn
+						"desc",                  // order
+						1,                       // page
+						20,                      // perPage
+						numericalWeights,
+						ratingWeights,
+						nil, // tags
+						nil, // ranges
+						mode,
+					)
+					if err != nil {
+						b.Errorf("Benchmark failed: %v", err)
+					}
+				}
+				if perCall := b.Elapsed() / time.Duration(b.N); perCall > budget {
+					b.Errorf("average latency %v exceeds the %v budget", perCall, budget)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkUpdateOrCreate benchmarks the per-row upsert used by the CSV import path.
+// It repeatedly upserts a single BENCH ticker and removes it afterwards.
+func BenchmarkUpdateOrCreate(b *testing.B) {
+	repo := NewCockroachDBRepository(nil)
+	if err := repo.Connect(); err != nil {
+		b.Fatalf("Failed to connect to database: %v", err)
+	}
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, err := repo.GetStocksByClusterAndGroup(
-			0,      // cluster
-			"None", // groupingColumn
-			"",     // groupingValue
-			"date", // sortByColumn
-			"desc", // order
-			1,      // page
-			20,     // perPage
-			numericalWeights,
-			ratingWeights,
-		)
-		if err != nil {
+		stock := &models.StockDataPoint{
+			Ticker:     "BENCH",
+			Company:    "Benchmark Inc.",
+			Action:     "target raised by",
+			RatingFrom: "Buy",
+			RatingTo:   "Buy",
+			TargetFrom: 10,
+			TargetTo:   12,
+			Date:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			RatingSentiments: []models.RatingSentiment{
+				{Name: "action", Rating: "target raised by", RatingScore: 1, NormRatingScore: 1},
+			},
+			NumericalIndicators: []models.NumericalIndicator{
+				{Name: "atr", Value: 1.5, NormValue: 0.5},
+			},
+		}
+		if _, err := repo.UpdateOrCreate(stock); err != nil {
 			b.Errorf("Benchmark failed: %v", err)
 		}
 	}
+	b.StopTimer()
+
+	repo.db.Where("ticker = ?", "BENCH").Delete(&models.StockDataPoint{})
 }
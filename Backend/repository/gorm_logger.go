@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"dataextractor/logging"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold is the statement duration GORM's own logger reports as slow
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger writes GORM's failed statements, slow statements and (at debug level) every statement
+// through the structured logger, with the request ID of the statement's context
+type gormLogger struct {
+	level gormlogger.LogLevel
+}
+
+// newGormLogger creates a GORM logger for DB_LOG_LEVEL (silent, error, warn or info)
+func newGormLogger(level string) gormlogger.Interface {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "silent":
+		return &gormLogger{level: gormlogger.Silent}
+	case "error":
+		return &gormLogger{level: gormlogger.Error}
+	case "warn", "warning":
+		return &gormLogger{level: gormlogger.Warn}
+	}
+	return &gormLogger{level: gormlogger.Info}
+}
+
+// LogMode returns a copy of the logger at level
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &gormLogger{level: level}
+}
+
+// Info logs a GORM message at info level
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logging.FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn logs a GORM message at warn level
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logging.FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error logs a GORM message at error level
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logging.FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs a finished statement: failures (other than record not found) at error level, slow
+// statements at warn level and the rest at debug level
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+
+	var level slog.Level
+	var msg string
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		level, msg = slog.LevelError, "Query failed"
+	case elapsed > slowQueryThreshold && l.level >= gormlogger.Warn:
+		level, msg = slog.LevelWarn, "Slow query"
+	case l.level >= gormlogger.Info:
+		level, msg = slog.LevelDebug, "Query"
+	default:
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if failed {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
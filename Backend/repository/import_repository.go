@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetImportFingerprint returns the import record of the file with the given SHA-256 digest
+func (r *CockroachDBRepository) GetImportFingerprint(sha256 string) (*models.ImportFingerprint, error) {
+	var fingerprint models.ImportFingerprint
+	if err := r.db.Where("sha256 = ?", sha256).First(&fingerprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("no import recorded for file %s", sha256)
+		}
+		return nil, fmt.Errorf("failed to get import fingerprint: %w", err)
+	}
+	return &fingerprint, nil
+}
+
+// SaveImportFingerprint records an imported file, replacing the metadata of an earlier (forced) import of the same file
+func (r *CockroachDBRepository) SaveImportFingerprint(fingerprint *models.ImportFingerprint) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sha256"}},
+		DoUpdates: clause.AssignmentColumns([]string{"source", "size_bytes", "row_count", "imported_by", "imported_at"}),
+	}).Create(fingerprint).Error
+	if err != nil {
+		return fmt.Errorf("failed to save import fingerprint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// DeleteIndicator removes the named indicator of a stock and saves the stock, whose indicators and
+// scores the caller has already updated, in one transaction
+func (r *CockroachDBRepository) DeleteIndicator(stock *models.StockDataPoint, name string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stock_data_point_id = ? AND name = ?", stock.ID, name).Delete(&models.NumericalIndicator{}).Error; err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(stock).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete indicator %q of stock %d: %w", name, stock.ID, err)
+	}
+	return nil
+}
+
+// DeleteSentiment is the sentiment counterpart of DeleteIndicator
+func (r *CockroachDBRepository) DeleteSentiment(stock *models.StockDataPoint, name string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stock_data_point_id = ? AND name = ?", stock.ID, name).Delete(&models.RatingSentiment{}).Error; err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(stock).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sentiment %q of stock %d: %w", name, stock.ID, err)
+	}
+	return nil
+}
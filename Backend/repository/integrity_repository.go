@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// Keys of the integrity problem counts
+const (
+	IntegrityOrphanedSentiments      = "orphaned_sentiments"
+	IntegrityOrphanedIndicators      = "orphaned_indicators"
+	IntegrityStocksWithoutSentiments = "stocks_without_sentiments"
+	IntegrityStocksWithoutIndicators = "stocks_without_indicators"
+	IntegrityIncompleteStocks        = "incomplete_stocks"
+)
+
+// orphanedCondition matches the rows of a child table whose data point no longer exists
+func orphanedCondition(child string) string {
+	stocks := (&models.StockDataPoint{}).TableName()
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.id = %[2]s.stock_data_point_id)", stocks, child)
+}
+
+// childlessCondition matches the data points without any row in a child table
+func childlessCondition(child string) string {
+	stocks := (&models.StockDataPoint{}).TableName()
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.stock_data_point_id = %[2]s.id)", child, stocks)
+}
+
+// incompleteCondition matches the data points missing their sentiments or their indicators
+func incompleteCondition() string {
+	return childlessCondition((&models.RatingSentiment{}).TableName()) + " OR " +
+		childlessCondition((&models.NumericalIndicator{}).TableName())
+}
+
+// CountIntegrityProblems counts sentiments and indicators whose data point is gone, and data points
+// without sentiments, without indicators, or without either (incomplete_stocks). Orphans survive
+// only where the cascading foreign keys were bypassed, e.g. by an interrupted import or manual SQL.
+func (r *CockroachDBRepository) CountIntegrityProblems() (map[string]int64, error) {
+	sentiments := (&models.RatingSentiment{}).TableName()
+	indicators := (&models.NumericalIndicator{}).TableName()
+	checks := []struct {
+		key       string
+		model     interface{}
+		condition string
+	}{
+		{IntegrityOrphanedSentiments, &models.RatingSentiment{}, orphanedCondition(sentiments)},
+		{IntegrityOrphanedIndicators, &models.NumericalIndicator{}, orphanedCondition(indicators)},
+		{IntegrityStocksWithoutSentiments, &models.StockDataPoint{}, childlessCondition(sentiments)},
+		{IntegrityStocksWithoutIndicators, &models.StockDataPoint{}, childlessCondition(indicators)},
+		{IntegrityIncompleteStocks, &models.StockDataPoint{}, incompleteCondition()},
+	}
+
+	counts := make(map[string]int64, len(checks))
+	for _, check := range checks {
+		var count int64
+		if err := r.db.Model(check.model).Where(check.condition).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", check.key, err)
+		}
+		counts[check.key] = count
+	}
+	return counts, nil
+}
+
+// DeleteOrphans removes the orphaned sentiments and indicators in one transaction and, with
+// incomplete, the data points missing their sentiments or indicators. It returns the deleted
+// row counts under the keys of CountIntegrityProblems.
+func (r *CockroachDBRepository) DeleteOrphans(incomplete bool) (map[string]int64, error) {
+	deleted := map[string]int64{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(orphanedCondition((&models.RatingSentiment{}).TableName())).Delete(&models.RatingSentiment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted[IntegrityOrphanedSentiments] = result.RowsAffected
+
+		result = tx.Where(orphanedCondition((&models.NumericalIndicator{}).TableName())).Delete(&models.NumericalIndicator{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted[IntegrityOrphanedIndicators] = result.RowsAffected
+
+		if incomplete {
+			result = tx.Where(incompleteCondition()).Delete(&models.StockDataPoint{})
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted[IntegrityIncompleteStocks] = result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete orphaned rows: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateJob registers a new background job
+func (r *CockroachDBRepository) CreateJob(job *models.Job) error {
+	if err := r.db.Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// UpdateJob saves the status and progress of a job
+func (r *CockroachDBRepository) UpdateJob(job *models.Job) error {
+	if err := r.db.Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update job %d: %w", job.ID, err)
+	}
+	return nil
+}
+
+// GetJob returns a single job
+func (r *CockroachDBRepository) GetJob(id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.First(&job, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("job %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get job %d: %w", id, err)
+	}
+	return &job, nil
+}
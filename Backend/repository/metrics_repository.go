@@ -0,0 +1,584 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dataextractor/metrics"
+	"dataextractor/models"
+)
+
+// MetricsRepository decorates a DataRepositoryInterface, recording the call count, error count
+// and latency of every method. Methods that take a callback (StreamAll, ArchiveStocks) include
+// the time spent in it.
+type MetricsRepository struct {
+	next    DataRepositoryInterface
+	metrics *metrics.MethodMetrics
+}
+
+// NewRepositoryMetrics creates the per-method repository metrics (dataextractor_repository_*)
+func NewRepositoryMetrics() *metrics.MethodMetrics {
+	return metrics.NewMethodMetrics("dataextractor", "repository", "repository", nil)
+}
+
+// NewMetricsRepository wraps next so that its calls are recorded in m
+func NewMetricsRepository(next DataRepositoryInterface, m *metrics.MethodMetrics) *MetricsRepository {
+	return &MetricsRepository{next: next, metrics: m}
+}
+
+// observe records one call of method started at start; err points at the method's error result
+func (r *MetricsRepository) observe(method string, start time.Time, err *error) {
+	r.metrics.Observe(method, time.Since(start), *err)
+}
+
+// WithContext wraps the context-bound repository so its calls are recorded as well
+func (r *MetricsRepository) WithContext(ctx context.Context) DataRepositoryInterface {
+	return &MetricsRepository{next: r.next.WithContext(ctx), metrics: r.metrics}
+}
+
+// Connected is a state check rather than a query, so it is not recorded
+func (r *MetricsRepository) Connected() bool {
+	return r.next.Connected()
+}
+
+// The remaining methods delegate to the wrapped repository and record the call
+
+func (r *MetricsRepository) Connect() (err error) {
+	defer r.observe("Connect", time.Now(), &err)
+	return r.next.Connect()
+}
+
+func (r *MetricsRepository) Ping(ctx context.Context) (err error) {
+	defer r.observe("Ping", time.Now(), &err)
+	return r.next.Ping(ctx)
+}
+
+func (r *MetricsRepository) ReadById(id uint) (_ *models.StockDataPoint, err error) {
+	defer r.observe("ReadById", time.Now(), &err)
+	return r.next.ReadById(id)
+}
+
+func (r *MetricsRepository) GetIDByUUID(uuid string) (_ uint, err error) {
+	defer r.observe("GetIDByUUID", time.Now(), &err)
+	return r.next.GetIDByUUID(uuid)
+}
+
+func (r *MetricsRepository) GetAll() (_ []models.StockDataPoint, err error) {
+	defer r.observe("GetAll", time.Now(), &err)
+	return r.next.GetAll()
+}
+
+func (r *MetricsRepository) StreamAll(scope StockScope, batchSize int, fn func(batch []models.StockDataPoint) error) (err error) {
+	defer r.observe("StreamAll", time.Now(), &err)
+	return r.next.StreamAll(scope, batchSize, fn)
+}
+
+func (r *MetricsRepository) Create(entity *models.StockDataPoint) (_ *models.StockDataPoint, err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(entity)
+}
+
+func (r *MetricsRepository) CreateBatch(entities []*models.StockDataPoint) (err error) {
+	defer r.observe("CreateBatch", time.Now(), &err)
+	return r.next.CreateBatch(entities)
+}
+
+func (r *MetricsRepository) Update(entity *models.StockDataPoint) (_ *models.StockDataPoint, err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(entity)
+}
+
+func (r *MetricsRepository) Delete(entity *models.StockDataPoint) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(entity)
+}
+
+func (r *MetricsRepository) UpdateIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) (_ *models.StockDataPoint, err error) {
+	defer r.observe("UpdateIfUnchanged", time.Now(), &err)
+	return r.next.UpdateIfUnchanged(entity, updatedAt)
+}
+
+func (r *MetricsRepository) DeleteIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) (err error) {
+	defer r.observe("DeleteIfUnchanged", time.Now(), &err)
+	return r.next.DeleteIfUnchanged(entity, updatedAt)
+}
+
+func (r *MetricsRepository) UpdateOrCreate(entity *models.StockDataPoint) (_ *models.StockDataPoint, err error) {
+	defer r.observe("UpdateOrCreate", time.Now(), &err)
+	return r.next.UpdateOrCreate(entity)
+}
+
+func (r *MetricsRepository) GetTotalCount() (_ int64, err error) {
+	defer r.observe("GetTotalCount", time.Now(), &err)
+	return r.next.GetTotalCount()
+}
+
+func (r *MetricsRepository) GetUniqueTickers() (_ []string, err error) {
+	defer r.observe("GetUniqueTickers", time.Now(), &err)
+	return r.next.GetUniqueTickers()
+}
+
+func (r *MetricsRepository) GetUniqueCompanies() (_ []string, err error) {
+	defer r.observe("GetUniqueCompanies", time.Now(), &err)
+	return r.next.GetUniqueCompanies()
+}
+
+func (r *MetricsRepository) GetStocksByCompany(company string, opts ListOptions) (_ []models.StockDataPoint, _ int64, err error) {
+	defer r.observe("GetStocksByCompany", time.Now(), &err)
+	return r.next.GetStocksByCompany(company, opts)
+}
+
+func (r *MetricsRepository) GetDataByTicker(ticker string) (_ *models.StockDataPoint, err error) {
+	defer r.observe("GetDataByTicker", time.Now(), &err)
+	return r.next.GetDataByTicker(ticker)
+}
+
+func (r *MetricsRepository) GetLatestData(limit int) (_ []models.StockDataPoint, err error) {
+	defer r.observe("GetLatestData", time.Now(), &err)
+	return r.next.GetLatestData(limit)
+}
+
+func (r *MetricsRepository) GetDataByTimeRange(startTime string, endTime string) (_ []models.StockDataPoint, err error) {
+	defer r.observe("GetDataByTimeRange", time.Now(), &err)
+	return r.next.GetDataByTimeRange(startTime, endTime)
+}
+
+func (r *MetricsRepository) GetTickerStats(ticker string) (_ TickerStats, err error) {
+	defer r.observe("GetTickerStats", time.Now(), &err)
+	return r.next.GetTickerStats(ticker)
+}
+
+func (r *MetricsRepository) GetTickerHistory(ticker string, from *time.Time, to *time.Time) (_ []models.StockSnapshot, err error) {
+	defer r.observe("GetTickerHistory", time.Now(), &err)
+	return r.next.GetTickerHistory(ticker, from, to)
+}
+
+func (r *MetricsRepository) GetTopTickersByCount(limit int) (_ []map[string]interface{}, err error) {
+	defer r.observe("GetTopTickersByCount", time.Now(), &err)
+	return r.next.GetTopTickersByCount(limit)
+}
+
+func (r *MetricsRepository) GetDatabaseStats() (_ DatabaseStats, err error) {
+	defer r.observe("GetDatabaseStats", time.Now(), &err)
+	return r.next.GetDatabaseStats()
+}
+
+func (r *MetricsRepository) GetDataVersion() (_ DataVersion, err error) {
+	defer r.observe("GetDataVersion", time.Now(), &err)
+	return r.next.GetDataVersion()
+}
+
+func (r *MetricsRepository) GetDataFreshness() (_ DataFreshness, err error) {
+	defer r.observe("GetDataFreshness", time.Now(), &err)
+	return r.next.GetDataFreshness()
+}
+
+func (r *MetricsRepository) GetRatingRubric(kind string) (_ []models.RatingRubric, err error) {
+	defer r.observe("GetRatingRubric", time.Now(), &err)
+	return r.next.GetRatingRubric(kind)
+}
+
+func (r *MetricsRepository) ReadRatingRubric(id uint) (_ *models.RatingRubric, err error) {
+	defer r.observe("ReadRatingRubric", time.Now(), &err)
+	return r.next.ReadRatingRubric(id)
+}
+
+func (r *MetricsRepository) CreateRatingRubric(entry *models.RatingRubric) (_ *models.RatingRubric, err error) {
+	defer r.observe("CreateRatingRubric", time.Now(), &err)
+	return r.next.CreateRatingRubric(entry)
+}
+
+func (r *MetricsRepository) UpdateRatingRubric(entry *models.RatingRubric) (_ *models.RatingRubric, err error) {
+	defer r.observe("UpdateRatingRubric", time.Now(), &err)
+	return r.next.UpdateRatingRubric(entry)
+}
+
+func (r *MetricsRepository) DeleteRatingRubric(entry *models.RatingRubric) (err error) {
+	defer r.observe("DeleteRatingRubric", time.Now(), &err)
+	return r.next.DeleteRatingRubric(entry)
+}
+
+func (r *MetricsRepository) ImportScoringConfig(rubric []models.RatingRubric, preferences []models.UserPreference, replace bool) (_ ScoringConfigImport, err error) {
+	defer r.observe("ImportScoringConfig", time.Now(), &err)
+	return r.next.ImportScoringConfig(rubric, preferences, replace)
+}
+
+func (r *MetricsRepository) GetUserPreference(userID string) (_ *models.UserPreference, err error) {
+	defer r.observe("GetUserPreference", time.Now(), &err)
+	return r.next.GetUserPreference(userID)
+}
+
+func (r *MetricsRepository) GetUserPreferences() (_ []models.UserPreference, err error) {
+	defer r.observe("GetUserPreferences", time.Now(), &err)
+	return r.next.GetUserPreferences()
+}
+
+func (r *MetricsRepository) SaveUserPreference(preference *models.UserPreference) (_ *models.UserPreference, err error) {
+	defer r.observe("SaveUserPreference", time.Now(), &err)
+	return r.next.SaveUserPreference(preference)
+}
+
+func (r *MetricsRepository) ReassignClusters(tickers []string, cluster int, reason string) (_ []models.ClusterAssignment, err error) {
+	defer r.observe("ReassignClusters", time.Now(), &err)
+	return r.next.ReassignClusters(tickers, cluster, reason)
+}
+
+func (r *MetricsRepository) GetClusterAssignments(stockID uint) (_ []models.ClusterAssignment, err error) {
+	defer r.observe("GetClusterAssignments", time.Now(), &err)
+	return r.next.GetClusterAssignments(stockID)
+}
+
+func (r *MetricsRepository) RecomputeCentroids() (_ []models.ClusterCentroid, err error) {
+	defer r.observe("RecomputeCentroids", time.Now(), &err)
+	return r.next.RecomputeCentroids()
+}
+
+func (r *MetricsRepository) GetCentroids() (_ []models.ClusterCentroid, err error) {
+	defer r.observe("GetCentroids", time.Now(), &err)
+	return r.next.GetCentroids()
+}
+
+func (r *MetricsRepository) GetUniqueClusters() (_ []int, err error) {
+	defer r.observe("GetUniqueClusters", time.Now(), &err)
+	return r.next.GetUniqueClusters()
+}
+
+func (r *MetricsRepository) GetStocksByCluster(cluster int, opts ListOptions) (_ []models.StockDataPoint, _ int64, err error) {
+	defer r.observe("GetStocksByCluster", time.Now(), &err)
+	return r.next.GetStocksByCluster(cluster, opts)
+}
+
+func (r *MetricsRepository) QueryStocks(query StockQuery, opts ListOptions) (_ []models.StockDataPoint, _ int64, err error) {
+	defer r.observe("QueryStocks", time.Now(), &err)
+	return r.next.QueryStocks(query, opts)
+}
+
+func (r *MetricsRepository) GetStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page int, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter, preload PreloadMode) (_ []models.StockDataPoint, _ int64, err error) {
+	defer r.observe("GetStocksByClusterAndGroup", time.Now(), &err)
+	return r.next.GetStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges, preload)
+}
+
+func (r *MetricsRepository) ExplainStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page int, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) (_ QueryPlan, err error) {
+	defer r.observe("ExplainStocksByClusterAndGroup", time.Now(), &err)
+	return r.next.ExplainStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges)
+}
+
+func (r *MetricsRepository) GetClusterGroupAggregates(cluster int, groupingColumn string, groupingValue string, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) (_ []GroupAggregate, err error) {
+	defer r.observe("GetClusterGroupAggregates", time.Now(), &err)
+	return r.next.GetClusterGroupAggregates(cluster, groupingColumn, groupingValue, numericalWeights, ratingWeights, tags, ranges)
+}
+
+func (r *MetricsRepository) GetUniqueActions() (_ []string, err error) {
+	defer r.observe("GetUniqueActions", time.Now(), &err)
+	return r.next.GetUniqueActions()
+}
+
+func (r *MetricsRepository) GetStocksByAction(action string, opts ListOptions) (_ []models.StockDataPoint, _ int64, err error) {
+	defer r.observe("GetStocksByAction", time.Now(), &err)
+	return r.next.GetStocksByAction(action, opts)
+}
+
+func (r *MetricsRepository) GetUniqueRatings() (_ []string, err error) {
+	defer r.observe("GetUniqueRatings", time.Now(), &err)
+	return r.next.GetUniqueRatings()
+}
+
+func (r *MetricsRepository) GetPercentileRanks(stockID uint, cluster int) (_ *StockPercentiles, err error) {
+	defer r.observe("GetPercentileRanks", time.Now(), &err)
+	return r.next.GetPercentileRanks(stockID, cluster)
+}
+
+func (r *MetricsRepository) GetMovingAverages(ticker string, names []string) (_ []MovingAveragePoint, err error) {
+	defer r.observe("GetMovingAverages", time.Now(), &err)
+	return r.next.GetMovingAverages(ticker, names)
+}
+
+func (r *MetricsRepository) GetSimilarStocks(ticker string, cluster int, limit int) (_ []SimilarStock, err error) {
+	defer r.observe("GetSimilarStocks", time.Now(), &err)
+	return r.next.GetSimilarStocks(ticker, cluster, limit)
+}
+
+func (r *MetricsRepository) GetClusterHeatmap(dimension string) (_ []HeatmapCell, err error) {
+	defer r.observe("GetClusterHeatmap", time.Now(), &err)
+	return r.next.GetClusterHeatmap(dimension)
+}
+
+func (r *MetricsRepository) GetClusterDispersion(cluster *int) (_ []ClusterDispersion, err error) {
+	defer r.observe("GetClusterDispersion", time.Now(), &err)
+	return r.next.GetClusterDispersion(cluster)
+}
+
+func (r *MetricsRepository) GetTopMovers(metric string, up bool, from *time.Time, to *time.Time, limit int) (_ []models.StockDataPoint, err error) {
+	defer r.observe("GetTopMovers", time.Now(), &err)
+	return r.next.GetTopMovers(metric, up, from, to, limit)
+}
+
+func (r *MetricsRepository) GetConsensusRatings(ticker string) (_ []ConsensusRating, err error) {
+	defer r.observe("GetConsensusRatings", time.Now(), &err)
+	return r.next.GetConsensusRatings(ticker)
+}
+
+func (r *MetricsRepository) SearchStocks(query string, limit int) (_ []SearchResult, err error) {
+	defer r.observe("SearchStocks", time.Now(), &err)
+	return r.next.SearchStocks(query, limit)
+}
+
+func (r *MetricsRepository) GetUniqueTags() (_ []string, err error) {
+	defer r.observe("GetUniqueTags", time.Now(), &err)
+	return r.next.GetUniqueTags()
+}
+
+func (r *MetricsRepository) AddTags(stock *models.StockDataPoint, names []string) (err error) {
+	defer r.observe("AddTags", time.Now(), &err)
+	return r.next.AddTags(stock, names)
+}
+
+func (r *MetricsRepository) RemoveTags(stock *models.StockDataPoint, names []string) (err error) {
+	defer r.observe("RemoveTags", time.Now(), &err)
+	return r.next.RemoveTags(stock, names)
+}
+
+func (r *MetricsRepository) GetImportFingerprint(sha256 string) (_ *models.ImportFingerprint, err error) {
+	defer r.observe("GetImportFingerprint", time.Now(), &err)
+	return r.next.GetImportFingerprint(sha256)
+}
+
+func (r *MetricsRepository) SaveImportFingerprint(fingerprint *models.ImportFingerprint) (err error) {
+	defer r.observe("SaveImportFingerprint", time.Now(), &err)
+	return r.next.SaveImportFingerprint(fingerprint)
+}
+
+func (r *MetricsRepository) CreateDatasetVersion(version *models.DatasetVersion) (err error) {
+	defer r.observe("CreateDatasetVersion", time.Now(), &err)
+	return r.next.CreateDatasetVersion(version)
+}
+
+func (r *MetricsRepository) UpdateDatasetVersion(version *models.DatasetVersion) (err error) {
+	defer r.observe("UpdateDatasetVersion", time.Now(), &err)
+	return r.next.UpdateDatasetVersion(version)
+}
+
+func (r *MetricsRepository) GetDatasetVersions() (_ []models.DatasetVersion, err error) {
+	defer r.observe("GetDatasetVersions", time.Now(), &err)
+	return r.next.GetDatasetVersions()
+}
+
+func (r *MetricsRepository) GetDatasetVersion(id uint) (_ *models.DatasetVersion, err error) {
+	defer r.observe("GetDatasetVersion", time.Now(), &err)
+	return r.next.GetDatasetVersion(id)
+}
+
+func (r *MetricsRepository) SaveDatasetRecord(stock *models.StockDataPoint) (err error) {
+	defer r.observe("SaveDatasetRecord", time.Now(), &err)
+	return r.next.SaveDatasetRecord(stock)
+}
+
+func (r *MetricsRepository) RollbackDataset(target uint) (_ DatasetRollback, err error) {
+	defer r.observe("RollbackDataset", time.Now(), &err)
+	return r.next.RollbackDataset(target)
+}
+
+func (r *MetricsRepository) SaveExtractionPage(page *models.ExtractionPage) (err error) {
+	defer r.observe("SaveExtractionPage", time.Now(), &err)
+	return r.next.SaveExtractionPage(page)
+}
+
+func (r *MetricsRepository) GetExtractionPages(status string, opts ListOptions) (_ []models.ExtractionPage, _ int64, err error) {
+	defer r.observe("GetExtractionPages", time.Now(), &err)
+	return r.next.GetExtractionPages(status, opts)
+}
+
+func (r *MetricsRepository) PruneExtractionPages(before time.Time) (_ int64, err error) {
+	defer r.observe("PruneExtractionPages", time.Now(), &err)
+	return r.next.PruneExtractionPages(before)
+}
+
+func (r *MetricsRepository) GetFailedExtractionPageKeys() (_ []string, err error) {
+	defer r.observe("GetFailedExtractionPageKeys", time.Now(), &err)
+	return r.next.GetFailedExtractionPageKeys()
+}
+
+func (r *MetricsRepository) MarkExtractionPageRetried(pageKey string) (err error) {
+	defer r.observe("MarkExtractionPageRetried", time.Now(), &err)
+	return r.next.MarkExtractionPageRetried(pageKey)
+}
+
+func (r *MetricsRepository) RecordAPIRequest(keyHash string, day time.Time) (err error) {
+	defer r.observe("RecordAPIRequest", time.Now(), &err)
+	return r.next.RecordAPIRequest(keyHash, day)
+}
+
+func (r *MetricsRepository) GetAPIUsage(keyHash string, day time.Time) (_ int, err error) {
+	defer r.observe("GetAPIUsage", time.Now(), &err)
+	return r.next.GetAPIUsage(keyHash, day)
+}
+
+func (r *MetricsRepository) RecordClientUsage(rows []models.ClientUsage) (err error) {
+	defer r.observe("RecordClientUsage", time.Now(), &err)
+	return r.next.RecordClientUsage(rows)
+}
+
+func (r *MetricsRepository) GetClientUsage(from time.Time, to time.Time) (_ []ClientRouteUsage, err error) {
+	defer r.observe("GetClientUsage", time.Now(), &err)
+	return r.next.GetClientUsage(from, to)
+}
+
+func (r *MetricsRepository) CreateExportJob(job *models.ExportJob) (err error) {
+	defer r.observe("CreateExportJob", time.Now(), &err)
+	return r.next.CreateExportJob(job)
+}
+
+func (r *MetricsRepository) UpdateExportJob(job *models.ExportJob) (err error) {
+	defer r.observe("UpdateExportJob", time.Now(), &err)
+	return r.next.UpdateExportJob(job)
+}
+
+func (r *MetricsRepository) GetExportJob(id uint) (_ *models.ExportJob, err error) {
+	defer r.observe("GetExportJob", time.Now(), &err)
+	return r.next.GetExportJob(id)
+}
+
+func (r *MetricsRepository) GetExpiredExportJobs(before time.Time) (_ []models.ExportJob, err error) {
+	defer r.observe("GetExpiredExportJobs", time.Now(), &err)
+	return r.next.GetExpiredExportJobs(before)
+}
+
+func (r *MetricsRepository) DeleteExportJob(id uint) (err error) {
+	defer r.observe("DeleteExportJob", time.Now(), &err)
+	return r.next.DeleteExportJob(id)
+}
+
+func (r *MetricsRepository) CreateJob(job *models.Job) (err error) {
+	defer r.observe("CreateJob", time.Now(), &err)
+	return r.next.CreateJob(job)
+}
+
+func (r *MetricsRepository) UpdateJob(job *models.Job) (err error) {
+	defer r.observe("UpdateJob", time.Now(), &err)
+	return r.next.UpdateJob(job)
+}
+
+func (r *MetricsRepository) GetJob(id uint) (_ *models.Job, err error) {
+	defer r.observe("GetJob", time.Now(), &err)
+	return r.next.GetJob(id)
+}
+
+func (r *MetricsRepository) ArchiveStocks(scope StockScope, batchSize int, jobID uint, progress func(batches, archived int)) (_ int, err error) {
+	defer r.observe("ArchiveStocks", time.Now(), &err)
+	return r.next.ArchiveStocks(scope, batchSize, jobID, progress)
+}
+
+func (r *MetricsRepository) GetArchivedStocks(ticker string) (_ []models.ArchivedStock, err error) {
+	defer r.observe("GetArchivedStocks", time.Now(), &err)
+	return r.next.GetArchivedStocks(ticker)
+}
+
+func (r *MetricsRepository) CreateWebhookSubscription(subscription *models.WebhookSubscription) (err error) {
+	defer r.observe("CreateWebhookSubscription", time.Now(), &err)
+	return r.next.CreateWebhookSubscription(subscription)
+}
+
+func (r *MetricsRepository) GetWebhookSubscriptions() (_ []models.WebhookSubscription, err error) {
+	defer r.observe("GetWebhookSubscriptions", time.Now(), &err)
+	return r.next.GetWebhookSubscriptions()
+}
+
+func (r *MetricsRepository) DeleteWebhookSubscription(id uint) (err error) {
+	defer r.observe("DeleteWebhookSubscription", time.Now(), &err)
+	return r.next.DeleteWebhookSubscription(id)
+}
+
+func (r *MetricsRepository) GetNotes(stockID uint) (_ []models.Note, err error) {
+	defer r.observe("GetNotes", time.Now(), &err)
+	return r.next.GetNotes(stockID)
+}
+
+func (r *MetricsRepository) ReadNote(stockID uint, noteID uint) (_ *models.Note, err error) {
+	defer r.observe("ReadNote", time.Now(), &err)
+	return r.next.ReadNote(stockID, noteID)
+}
+
+func (r *MetricsRepository) CreateNote(note *models.Note) (_ *models.Note, err error) {
+	defer r.observe("CreateNote", time.Now(), &err)
+	return r.next.CreateNote(note)
+}
+
+func (r *MetricsRepository) UpdateNote(note *models.Note) (_ *models.Note, err error) {
+	defer r.observe("UpdateNote", time.Now(), &err)
+	return r.next.UpdateNote(note)
+}
+
+func (r *MetricsRepository) DeleteNote(note *models.Note) (err error) {
+	defer r.observe("DeleteNote", time.Now(), &err)
+	return r.next.DeleteNote(note)
+}
+
+func (r *MetricsRepository) DeleteIndicator(stock *models.StockDataPoint, name string) (err error) {
+	defer r.observe("DeleteIndicator", time.Now(), &err)
+	return r.next.DeleteIndicator(stock, name)
+}
+
+func (r *MetricsRepository) DeleteSentiment(stock *models.StockDataPoint, name string) (err error) {
+	defer r.observe("DeleteSentiment", time.Now(), &err)
+	return r.next.DeleteSentiment(stock, name)
+}
+
+func (r *MetricsRepository) GetIndicatorSummaries() (_ []IndicatorSummary, err error) {
+	defer r.observe("GetIndicatorSummaries", time.Now(), &err)
+	return r.next.GetIndicatorSummaries()
+}
+
+func (r *MetricsRepository) GetSentimentSummaries() (_ []SentimentSummary, err error) {
+	defer r.observe("GetSentimentSummaries", time.Now(), &err)
+	return r.next.GetSentimentSummaries()
+}
+
+func (r *MetricsRepository) GetUniqueByGroupSelectColumn(cluster int, columnName string) (_ []string, err error) {
+	defer r.observe("GetUniqueByGroupSelectColumn", time.Now(), &err)
+	return r.next.GetUniqueByGroupSelectColumn(cluster, columnName)
+}
+
+func (r *MetricsRepository) EmptyAllTables() (err error) {
+	defer r.observe("EmptyAllTables", time.Now(), &err)
+	return r.next.EmptyAllTables()
+}
+
+func (r *MetricsRepository) CountAllTables() (_ map[string]int64, err error) {
+	defer r.observe("CountAllTables", time.Now(), &err)
+	return r.next.CountAllTables()
+}
+
+func (r *MetricsRepository) CountStocksInScope(scope StockScope) (_ int64, err error) {
+	defer r.observe("CountStocksInScope", time.Now(), &err)
+	return r.next.CountStocksInScope(scope)
+}
+
+func (r *MetricsRepository) DeleteStocksInScope(scope StockScope) (_ int64, err error) {
+	defer r.observe("DeleteStocksInScope", time.Now(), &err)
+	return r.next.DeleteStocksInScope(scope)
+}
+
+func (r *MetricsRepository) CountIntegrityProblems() (_ map[string]int64, err error) {
+	defer r.observe("CountIntegrityProblems", time.Now(), &err)
+	return r.next.CountIntegrityProblems()
+}
+
+func (r *MetricsRepository) GetDiagnostics() (_ Diagnostics, err error) {
+	defer r.observe("GetDiagnostics", time.Now(), &err)
+	return r.next.GetDiagnostics()
+}
+
+func (r *MetricsRepository) GetMigrationStatus() (_ MigrationStatus, err error) {
+	defer r.observe("GetMigrationStatus", time.Now(), &err)
+	return r.next.GetMigrationStatus()
+}
+
+func (r *MetricsRepository) GetPoolStats() (_ PoolStats, err error) {
+	defer r.observe("GetPoolStats", time.Now(), &err)
+	return r.next.GetPoolStats()
+}
+
+func (r *MetricsRepository) DeleteOrphans(incomplete bool) (_ map[string]int64, err error) {
+	defer r.observe("DeleteOrphans", time.Now(), &err)
+	return r.next.DeleteOrphans(incomplete)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// GetNotes returns the notes of a stock, newest first
+func (r *CockroachDBRepository) GetNotes(stockID uint) ([]models.Note, error) {
+	var notes []models.Note
+	if err := r.db.Where("stock_data_point_id = ?", stockID).Order("created_at DESC, id DESC").Find(&notes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notes for stock %d: %w", stockID, err)
+	}
+	return notes, nil
+}
+
+// ReadNote retrieves a single note belonging to a stock
+func (r *CockroachDBRepository) ReadNote(stockID, noteID uint) (*models.Note, error) {
+	var note models.Note
+	if err := r.db.Where("stock_data_point_id = ?", stockID).First(&note, noteID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("note %d not found for stock %d", noteID, stockID)
+		}
+		return nil, fmt.Errorf("failed to get note %d: %w", noteID, err)
+	}
+	return &note, nil
+}
+
+// CreateNote inserts a new note
+func (r *CockroachDBRepository) CreateNote(note *models.Note) (*models.Note, error) {
+	if err := r.db.Create(note).Error; err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+	return note, nil
+}
+
+// UpdateNote saves changes to an existing note
+func (r *CockroachDBRepository) UpdateNote(note *models.Note) (*models.Note, error) {
+	if err := r.db.Save(note).Error; err != nil {
+		return nil, fmt.Errorf("failed to update note %d: %w", note.ID, err)
+	}
+	return note, nil
+}
+
+// DeleteNote removes a note
+func (r *CockroachDBRepository) DeleteNote(note *models.Note) error {
+	if err := r.db.Delete(note).Error; err != nil {
+		return fmt.Errorf("failed to delete note %d: %w", note.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetUserPreference returns the stored preferences of a user
+func (r *CockroachDBRepository) GetUserPreference(userID string) (*models.UserPreference, error) {
+	var preference models.UserPreference
+	if err := r.db.Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("no preferences stored for user %s", userID)
+		}
+		return nil, fmt.Errorf("failed to get preferences for user %s: %w", userID, err)
+	}
+	return &preference, nil
+}
+
+// GetUserPreferences returns the preferences of every user, ordered by user ID
+func (r *CockroachDBRepository) GetUserPreferences() ([]models.UserPreference, error) {
+	var preferences []models.UserPreference
+	if err := r.db.Order("user_id").Find(&preferences).Error; err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+// SaveUserPreference creates or replaces the preferences of a user
+func (r *CockroachDBRepository) SaveUserPreference(preference *models.UserPreference) (*models.UserPreference, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"default_cluster", "numerical_weights", "rating_weights", "updated_at"}),
+	}).Create(preference).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to save preferences for user %s: %w", preference.UserID, err)
+	}
+	return preference, nil
+}
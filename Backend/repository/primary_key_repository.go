@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// uuidKeyedTables are the tables DB_UUID_PRIMARY_KEYS keys by uuid
+func uuidKeyedTables() []string {
+	return []string{
+		(&models.StockDataPoint{}).TableName(),
+		(&models.RatingSentiment{}).TableName(),
+		(&models.NumericalIndicator{}).TableName(),
+	}
+}
+
+// usePrimaryKeys keys the stock, sentiment and indicator tables by uuid, or by id when uuid is
+// false, altering the tables whose primary key differs. CockroachDB keeps the previous primary key
+// as a unique secondary index, so id stays unique and the child foreign keys keep working.
+func usePrimaryKeys(db *gorm.DB, uuid bool) error {
+	column := "id"
+	if uuid {
+		column = "uuid"
+	}
+	for _, table := range uuidKeyedTables() {
+		current, err := primaryKeyColumn(db, table)
+		if err != nil {
+			return err
+		}
+		if current == column {
+			continue
+		}
+		slog.Info("Changing primary key", "table", table, "from", current, "to", column)
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ALTER PRIMARY KEY USING COLUMNS (%s)", table, column)).Error; err != nil {
+			return fmt.Errorf("failed to key %s by %s: %w", table, column, err)
+		}
+	}
+	return nil
+}
+
+// primaryKeyColumn returns the primary key column of table
+func primaryKeyColumn(db *gorm.DB, table string) (string, error) {
+	var columns []string
+	if err := db.Raw(`SELECT kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.table_name = tc.table_name AND kcu.constraint_name = tc.constraint_name
+		WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = current_schema() AND tc.table_name = ?`, table).
+		Scan(&columns).Error; err != nil {
+		return "", fmt.Errorf("failed to read the primary key of %s: %w", table, err)
+	}
+	if len(columns) != 1 {
+		return "", fmt.Errorf("%s has %d primary key columns, want 1", table, len(columns))
+	}
+	return columns[0], nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// StockScope selects data points by cluster, dataset version and record date range, for scoped
+// deletes, exports and archival; set criteria are combined with AND. DatasetBefore selects the rows
+// last written by a dataset version older than it (superseded imports).
+type StockScope struct {
+	Cluster       *int
+	Dataset       uint
+	DatasetBefore uint
+	From          *time.Time
+	To            *time.Time
+}
+
+// IsEmpty reports whether no criterion is set
+func (s StockScope) IsEmpty() bool {
+	return s.Cluster == nil && s.Dataset == 0 && s.DatasetBefore == 0 && s.From == nil && s.To == nil
+}
+
+// String is the canonical form of the scope, used to bind confirmation tokens to it
+func (s StockScope) String() string {
+	var parts []string
+	if s.Cluster != nil {
+		parts = append(parts, fmt.Sprintf("cluster=%d", *s.Cluster))
+	}
+	if s.Dataset > 0 {
+		parts = append(parts, fmt.Sprintf("dataset=%d", s.Dataset))
+	}
+	if s.DatasetBefore > 0 {
+		parts = append(parts, fmt.Sprintf("dataset_before=%d", s.DatasetBefore))
+	}
+	if s.From != nil {
+		parts = append(parts, "from="+s.From.UTC().Format(time.RFC3339))
+	}
+	if s.To != nil {
+		parts = append(parts, "to="+s.To.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(parts, "&")
+}
+
+// apply restricts query to the data points in scope
+func (s StockScope) apply(query *gorm.DB) *gorm.DB {
+	if s.Cluster != nil {
+		query = query.Where("cluster = ?", *s.Cluster)
+	}
+	if s.Dataset > 0 {
+		query = query.Where("dataset_version_id = ?", s.Dataset)
+	}
+	if s.DatasetBefore > 0 {
+		query = query.Where("dataset_version_id < ?", s.DatasetBefore)
+	}
+	if s.From != nil {
+		query = query.Where("date >= ?", *s.From)
+	}
+	if s.To != nil {
+		query = query.Where("date <= ?", *s.To)
+	}
+	return query
+}
+
+// CountStocksInScope returns the number of data points a scoped delete would remove
+func (r *CockroachDBRepository) CountStocksInScope(scope StockScope) (int64, error) {
+	var count int64
+	if err := scope.apply(r.db.Model(&models.StockDataPoint{})).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count data points in scope %s: %w", scope, err)
+	}
+	return count, nil
+}
+
+// DeleteStocksInScope deletes the data points in scope; their sentiments, indicators, notes and tag
+// links go with them through the cascading foreign keys, while ticker history snapshots are kept
+func (r *CockroachDBRepository) DeleteStocksInScope(scope StockScope) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := scope.apply(tx).Delete(&models.StockDataPoint{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete data points in scope %s: %w", scope, err)
+	}
+	return deleted, nil
+}
+
+// CountAllTables returns the row count of every table emptied by EmptyAllTables
+func (r *CockroachDBRepository) CountAllTables() (map[string]int64, error) {
+	counts := make(map[string]int64, len(wipeTables()))
+	for _, table := range wipeTables() {
+		var count int64
+		if err := r.db.Table(table).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		counts[table] = count
+	}
+	return counts, nil
+}
+
+// wipeTables lists the tables emptied by EmptyAllTables, children before parents
+func wipeTables() []string {
+	return []string{
+		(&models.RatingSentiment{}).TableName(),
+		(&models.NumericalIndicator{}).TableName(),
+		(&models.ClusterAssignment{}).TableName(),
+		(&models.IndicatorSnapshot{}).TableName(),
+		(&models.StockSnapshot{}).TableName(),
+		(&models.Note{}).TableName(),
+		models.StockTagsJoinTable,
+		(&models.StockDataPoint{}).TableName(),
+		(&models.DatasetRecord{}).TableName(),
+		(&models.DatasetVersion{}).TableName(),
+		(&models.ImportFingerprint{}).TableName(),
+		(&models.ClusterCentroid{}).TableName(),
+		(&models.RowCounter{}).TableName(),
+		(&models.ClusterDistribution{}).TableName(),
+		(&models.ArchivedStock{}).TableName(),
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/models"
+)
+
+// StockQuery combines criteria on the stock columns with AND; zero fields do not filter. It backs
+// the GraphQL stocks query, which would otherwise need one route per combination.
+type StockQuery struct {
+	Cluster       *int
+	Company       string
+	Action        string
+	RatingTo      string
+	Brokerage     string
+	Tags          []string
+	From          *time.Time
+	To            *time.Time
+	MinFinalScore *float64
+	MaxFinalScore *float64
+}
+
+// QueryStocks returns one page of the data points matching query and their total count
+func (r *CockroachDBRepository) QueryStocks(query StockQuery, opts ListOptions) ([]models.StockDataPoint, int64, error) {
+	scope := r.db.Model(&models.StockDataPoint{})
+	for _, eq := range []struct{ column, value string }{
+		{"company", query.Company},
+		{"action", query.Action},
+		{"rating_to", query.RatingTo},
+		{"brokerage", query.Brokerage},
+	} {
+		if eq.value != "" {
+			scope = scope.Where(eq.column+" = ?", eq.value)
+		}
+	}
+	if query.Cluster != nil {
+		scope = scope.Where("cluster = ?", *query.Cluster)
+	}
+	if query.From != nil {
+		scope = scope.Where("date >= ?", *query.From)
+	}
+	if query.To != nil {
+		scope = scope.Where("date <= ?", *query.To)
+	}
+	if query.MinFinalScore != nil {
+		scope = scope.Where("final_score >= ?", *query.MinFinalScore)
+	}
+	if query.MaxFinalScore != nil {
+		scope = scope.Where("final_score <= ?", *query.MaxFinalScore)
+	}
+	scope = r.withAnyTag(scope, query.Tags)
+
+	stocks, total, err := r.listScope(scope, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query stocks: %w", err)
+	}
+
+	// Tags are part of the GraphQL stock type, so the page loads them in one extra query
+	if len(stocks) > 0 {
+		ids := make([]uint, len(stocks))
+		for i, stock := range stocks {
+			ids[i] = stock.ID
+		}
+		var tagged []models.StockDataPoint
+		if err := r.db.Select("id").Preload("Tags").Find(&tagged, ids).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to load tags: %w", err)
+		}
+		tags := make(map[uint][]models.Tag, len(tagged))
+		for _, stock := range tagged {
+			tags[stock.ID] = stock.Tags
+		}
+		for i := range stocks {
+			stocks[i].Tags = tags[stocks[i].ID]
+		}
+	}
+	return stocks, total, nil
+}
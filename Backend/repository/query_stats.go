@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryStats collects the SQL statements a single request runs; it is safe for concurrent use
+type QueryStats struct {
+	mu          sync.Mutex
+	count       int
+	total       time.Duration
+	slowest     string
+	slowestTime time.Duration
+}
+
+// QueryStatsSnapshot is a point-in-time copy of QueryStats
+type QueryStatsSnapshot struct {
+	Count       int           `json:"count"`
+	Total       time.Duration `json:"total"`
+	Slowest     string        `json:"slowest,omitempty"`
+	SlowestTime time.Duration `json:"slowest_time"`
+}
+
+// record adds one executed statement
+func (s *QueryStats) record(sql string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	s.total += elapsed
+	if s.count == 1 || elapsed > s.slowestTime {
+		s.slowest = sql
+		s.slowestTime = elapsed
+	}
+}
+
+// Snapshot returns the statements recorded so far
+func (s *QueryStats) Snapshot() QueryStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return QueryStatsSnapshot{Count: s.count, Total: s.total, Slowest: s.slowest, SlowestTime: s.slowestTime}
+}
+
+type queryStatsKey struct{}
+
+// WithQueryStats returns a context whose queries (through a repository bound with WithContext) are recorded in stats
+func WithQueryStats(ctx context.Context, stats *QueryStats) context.Context {
+	return context.WithValue(ctx, queryStatsKey{}, stats)
+}
+
+// QueryStatsFromContext returns the collector stored in ctx, or nil when diagnostics are off
+func QueryStatsFromContext(ctx context.Context) *QueryStats {
+	if ctx == nil {
+		return nil
+	}
+	stats, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	return stats
+}
+
+// queryStartKey is the statement setting holding the start time of the running callback chain
+const queryStartKey = "query_stats:start"
+
+// registerQueryStatsCallbacks times every create, query, update, delete, row and raw statement and
+// records it in the QueryStats of the statement context. Statements without a collector are skipped,
+// so the cost when diagnostics are off is a context lookup. The timing stops before preloads and
+// association saves, which run (and are recorded) as statements of their own. The recorded SQL
+// keeps its placeholders so no parameter values leak into headers.
+func registerQueryStatsCallbacks(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		if QueryStatsFromContext(tx.Statement.Context) != nil {
+			tx.InstanceSet(queryStartKey, time.Now())
+		}
+	}
+	after := func(tx *gorm.DB) {
+		stats := QueryStatsFromContext(tx.Statement.Context)
+		if stats == nil {
+			return
+		}
+		start, ok := tx.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		stats.record(strings.Join(strings.Fields(tx.Statement.SQL.String()), " "), time.Since(start.(time.Time)))
+	}
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("query_stats:before_create", before),
+		callbacks.Create().After("gorm:create").Before("gorm:save_after_associations").Register("query_stats:after_create", after),
+		callbacks.Query().Before("gorm:query").Register("query_stats:before_query", before),
+		callbacks.Query().After("gorm:query").Before("gorm:preload").Register("query_stats:after_query", after),
+		callbacks.Update().Before("gorm:update").Register("query_stats:before_update", before),
+		callbacks.Update().After("gorm:update").Before("gorm:save_after_associations").Register("query_stats:after_update", after),
+		callbacks.Delete().Before("gorm:delete").Register("query_stats:before_delete", before),
+		callbacks.Delete().After("gorm:delete").Register("query_stats:after_delete", after),
+		callbacks.Row().Before("gorm:row").Register("query_stats:before_row", before),
+		callbacks.Row().After("gorm:row").Register("query_stats:after_row", after),
+		callbacks.Raw().Before("gorm:raw").Register("query_stats:before_raw", before),
+		callbacks.Raw().After("gorm:raw").Register("query_stats:after_raw", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose reads (through a repository bound with WithContext) go to the
+// primary even when a read replica is configured, so a request that writes reads its own writes
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// usesPrimary reports whether ctx pins reads to the primary
+func usesPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
+
+// openReadReplica connects to the read replica at dsn with the same GORM settings as the primary
+func openReadReplica(dsn string, config *gorm.Config) (*gorm.DB, error) {
+	replica, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		NamingStrategy:         config.NamingStrategy,
+		PrepareStmt:            config.PrepareStmt,
+		SkipDefaultTransaction: config.SkipDefaultTransaction,
+		Logger:                 config.Logger,
+		TranslateError:         config.TranslateError,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	return replica, nil
+}
+
+// registerReplicaCallbacks sends the query and row statements of db to the connection pool of
+// replica. Writes, raw statements, transactions, locking reads (SELECT ... FOR UPDATE) and
+// statements whose context is pinned with WithPrimary stay on the primary.
+func registerReplicaCallbacks(db *gorm.DB, replica *gorm.DB) error {
+	route := func(tx *gorm.DB) {
+		if _, inTransaction := tx.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+			return
+		}
+		if _, locking := tx.Statement.Clauses["FOR"]; locking {
+			return
+		}
+		if usesPrimary(tx.Statement.Context) {
+			return
+		}
+		tx.Statement.ConnPool = replica.ConnPool
+	}
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Query().Before("gorm:query").Register("replica:query", route),
+		callbacks.Row().Before("gorm:row").Register("replica:row", route),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// useReadReplica routes the reads of db to the replica at dsn. A replica that cannot be reached is
+// logged and skipped, leaving every statement on the primary.
+func useReadReplica(db *gorm.DB, dsn string) {
+	replica, err := openReadReplica(dsn, db.Config)
+	if err != nil {
+		slog.Warn("Read replica unavailable; reads stay on the primary", "error", err)
+		return
+	}
+	if err := registerReplicaCallbacks(db, replica); err != nil {
+		closeDB(replica)
+		slog.Warn("Failed to register read replica callbacks; reads stay on the primary", "error", err)
+		return
+	}
+	slog.Info("Read replica configured: read-only statements use the replica pool")
+}
@@ -1,19 +1,85 @@
 package repository
 
+import (
+	"log/slog"
+	"time"
+
+	"dataextractor/config"
+	"dataextractor/metrics"
+)
+
 // RepositoryFactory handles repository creation and management
 type RepositoryFactory struct {
+	config config.DatabaseConfig
 }
 
 // NewRepositoryFactory creates a new repository factory
-func NewRepositoryFactory() *RepositoryFactory {
-	return &RepositoryFactory{}
+func NewRepositoryFactory(cfg *config.AppConfig) *RepositoryFactory {
+	return &RepositoryFactory{config: cfg.Database}
 }
 
-// CreateDataRepository creates a new data repository instance
-func (f *RepositoryFactory) CreateDataRepository() DataRepositoryInterface {
-	// Create CockroachDB repository - it will handle its own connection
+// CreateDataRepository creates a new data repository instance and connects it, retrying with
+// exponential backoff while the database comes up. When it is still unreachable after
+// DB_CONNECT_ATTEMPTS, the repository is returned unconnected (Connected reports false) and
+// keeps reconnecting in the background. The returned channel is closed once the connection is up.
+// With DB_METRICS the repository is wrapped in a MetricsRepository registered on metrics.Default.
+func (f *RepositoryFactory) CreateDataRepository() (DataRepositoryInterface, <-chan struct{}) {
 	repo := NewCockroachDBRepository(nil)
-	// Connect to the database
-	repo.Connect()
-	return repo
+	connected := make(chan struct{})
+	backoff := f.config.ConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := repo.Connect()
+		if err == nil {
+			close(connected)
+			return f.instrument(repo), connected
+		}
+		if attempt >= f.config.ConnectAttempts {
+			slog.Warn("Database unavailable, starting in degraded mode", "attempts", attempt, "error", err)
+			go f.reconnect(repo, backoff, connected)
+			return f.instrument(repo), connected
+		}
+
+		slog.Warn("Database connection attempt failed", "attempt", attempt, "max_attempts", f.config.ConnectAttempts, "retry_in", backoff.String(), "error", err)
+		time.Sleep(backoff)
+		backoff = f.nextBackoff(backoff)
+	}
+}
+
+// instrument wraps repo in the metrics decorator when DB_METRICS is enabled
+func (f *RepositoryFactory) instrument(repo DataRepositoryInterface) DataRepositoryInterface {
+	if !f.config.Metrics {
+		return repo
+	}
+	m := NewRepositoryMetrics()
+	metrics.Default.Register(m)
+	return NewMetricsRepository(repo, m)
+}
+
+// reconnect retries Connect until it succeeds, then closes connected
+func (f *RepositoryFactory) reconnect(repo *CockroachDBRepository, backoff time.Duration, connected chan struct{}) {
+	for {
+		time.Sleep(backoff)
+		if err := repo.Connect(); err != nil {
+			backoff = f.nextBackoff(backoff)
+			slog.Warn("Database reconnect failed", "retry_in", backoff.String(), "error", err)
+			continue
+		}
+
+		slog.Info("Database connection established, leaving degraded mode")
+		close(connected)
+		return
+	}
+}
+
+// nextBackoff doubles the wait between connection attempts up to DB_CONNECT_MAX_BACKOFF
+func (f *RepositoryFactory) nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if f.config.ConnectMaxBackoff > 0 && backoff > f.config.ConnectMaxBackoff {
+		backoff = f.config.ConnectMaxBackoff
+	}
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	return backoff
 }
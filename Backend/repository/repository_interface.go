@@ -1,45 +1,178 @@
 package repository
 
-import "dataextractor/models"
+import (
+	"context"
+	"time"
+
+	"dataextractor/models"
+)
 
 // DataRepositoryInterface defines the contract for data repository operations
 type DataRepositoryInterface interface {
 	// Connection management
 	Connect() error
+	Connected() bool
+	Ping(ctx context.Context) error
+
+	// WithContext returns a repository whose queries run with ctx (cancellation, write attribution)
+	WithContext(ctx context.Context) DataRepositoryInterface
 
 	// Basic CRUD operations
 	ReadById(id uint) (*models.StockDataPoint, error)
+	GetIDByUUID(uuid string) (uint, error)
 	GetAll() ([]models.StockDataPoint, error)
+	StreamAll(scope StockScope, batchSize int, fn func(batch []models.StockDataPoint) error) error
 	Create(entity *models.StockDataPoint) (*models.StockDataPoint, error)
+	CreateBatch(entities []*models.StockDataPoint) error
 	Update(entity *models.StockDataPoint) (*models.StockDataPoint, error)
 	Delete(entity *models.StockDataPoint) error
+	UpdateIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) (*models.StockDataPoint, error)
+	DeleteIfUnchanged(entity *models.StockDataPoint, updatedAt time.Time) error
 	UpdateOrCreate(entity *models.StockDataPoint) (*models.StockDataPoint, error)
 
 	// Database exploration methods
 	GetTotalCount() (int64, error)
 	GetUniqueTickers() ([]string, error)
 	GetUniqueCompanies() ([]string, error)
-	GetStocksByCompany(company string) ([]models.StockDataPoint, error)
+	GetStocksByCompany(company string, opts ListOptions) ([]models.StockDataPoint, int64, error)
 	GetDataByTicker(ticker string) (*models.StockDataPoint, error)
 	GetLatestData(limit int) ([]models.StockDataPoint, error)
 	GetDataByTimeRange(startTime, endTime string) ([]models.StockDataPoint, error)
-	GetTickerStats(ticker string) (map[string]interface{}, error)
+	GetTickerStats(ticker string) (TickerStats, error)
+	GetTickerHistory(ticker string, from, to *time.Time) ([]models.StockSnapshot, error)
 	GetTopTickersByCount(limit int) ([]map[string]interface{}, error)
-	GetDatabaseStats() (map[string]interface{}, error)
+	GetDatabaseStats() (DatabaseStats, error)
+	GetDataVersion() (DataVersion, error)
+	GetDataFreshness() (DataFreshness, error)
+
+	// Rating rubric
+	GetRatingRubric(kind string) ([]models.RatingRubric, error)
+	ReadRatingRubric(id uint) (*models.RatingRubric, error)
+	CreateRatingRubric(entry *models.RatingRubric) (*models.RatingRubric, error)
+	UpdateRatingRubric(entry *models.RatingRubric) (*models.RatingRubric, error)
+	DeleteRatingRubric(entry *models.RatingRubric) error
+	ImportScoringConfig(rubric []models.RatingRubric, preferences []models.UserPreference, replace bool) (ScoringConfigImport, error)
+
+	// User preferences
+	GetUserPreference(userID string) (*models.UserPreference, error)
+	GetUserPreferences() ([]models.UserPreference, error)
+	SaveUserPreference(preference *models.UserPreference) (*models.UserPreference, error)
 
 	// Cluster queries
+	ReassignClusters(tickers []string, cluster int, reason string) ([]models.ClusterAssignment, error)
+	GetClusterAssignments(stockID uint) ([]models.ClusterAssignment, error)
+	RecomputeCentroids() ([]models.ClusterCentroid, error)
+	GetCentroids() ([]models.ClusterCentroid, error)
 	GetUniqueClusters() ([]int, error)
-	GetStocksByCluster(cluster int) ([]models.StockDataPoint, error)
+	GetStocksByCluster(cluster int, opts ListOptions) ([]models.StockDataPoint, int64, error)
+	QueryStocks(query StockQuery, opts ListOptions) ([]models.StockDataPoint, int64, error)
 	GetStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string,
-		page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry) ([]models.StockDataPoint, int64, error)
+		page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter, preload PreloadMode) ([]models.StockDataPoint, int64, error)
+	ExplainStocksByClusterAndGroup(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string,
+		page, perPage int, numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) (QueryPlan, error)
+	GetClusterGroupAggregates(cluster int, groupingColumn string, groupingValue string,
+		numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry, tags []string, ranges []RangeFilter) ([]GroupAggregate, error)
 
 	// Action queries
 	GetUniqueActions() ([]string, error)
-	GetStocksByAction(action string) ([]models.StockDataPoint, error)
+	GetStocksByAction(action string, opts ListOptions) ([]models.StockDataPoint, int64, error)
+
+	// Rating queries
+	GetUniqueRatings() ([]string, error)
+
+	// Analytics queries
+	GetPercentileRanks(stockID uint, cluster int) (*StockPercentiles, error)
+	GetMovingAverages(ticker string, names []string) ([]MovingAveragePoint, error)
+	GetSimilarStocks(ticker string, cluster int, limit int) ([]SimilarStock, error)
+	GetClusterHeatmap(dimension string) ([]HeatmapCell, error)
+	GetClusterDispersion(cluster *int) ([]ClusterDispersion, error)
+	GetTopMovers(metric string, up bool, from, to *time.Time, limit int) ([]models.StockDataPoint, error)
+	GetConsensusRatings(ticker string) ([]ConsensusRating, error)
+
+	// Search queries
+	SearchStocks(query string, limit int) ([]SearchResult, error)
+
+	// Tag operations
+	GetUniqueTags() ([]string, error)
+	AddTags(stock *models.StockDataPoint, names []string) error
+	RemoveTags(stock *models.StockDataPoint, names []string) error
+
+	// Import fingerprints
+	GetImportFingerprint(sha256 string) (*models.ImportFingerprint, error)
+	SaveImportFingerprint(fingerprint *models.ImportFingerprint) error
+
+	// Dataset versions
+	CreateDatasetVersion(version *models.DatasetVersion) error
+	UpdateDatasetVersion(version *models.DatasetVersion) error
+	GetDatasetVersions() ([]models.DatasetVersion, error)
+	GetDatasetVersion(id uint) (*models.DatasetVersion, error)
+	SaveDatasetRecord(stock *models.StockDataPoint) error
+	RollbackDataset(target uint) (DatasetRollback, error)
+
+	// Extraction history
+	SaveExtractionPage(page *models.ExtractionPage) error
+	GetExtractionPages(status string, opts ListOptions) ([]models.ExtractionPage, int64, error)
+	PruneExtractionPages(before time.Time) (int64, error)
+	GetFailedExtractionPageKeys() ([]string, error)
+	MarkExtractionPageRetried(pageKey string) error
+
+	// Upstream API usage
+	RecordAPIRequest(keyHash string, day time.Time) error
+	GetAPIUsage(keyHash string, day time.Time) (int, error)
+
+	// Client usage metering
+	RecordClientUsage(rows []models.ClientUsage) error
+	GetClientUsage(from, to time.Time) ([]ClientRouteUsage, error)
+
+	// Export jobs
+	CreateExportJob(job *models.ExportJob) error
+	UpdateExportJob(job *models.ExportJob) error
+	GetExportJob(id uint) (*models.ExportJob, error)
+	GetExpiredExportJobs(before time.Time) ([]models.ExportJob, error)
+	DeleteExportJob(id uint) error
+
+	// Background jobs
+	CreateJob(job *models.Job) error
+	UpdateJob(job *models.Job) error
+	GetJob(id uint) (*models.Job, error)
+
+	// Archival
+	ArchiveStocks(scope StockScope, batchSize int, jobID uint, progress func(batches, archived int)) (int, error)
+	GetArchivedStocks(ticker string) ([]models.ArchivedStock, error)
+
+	// Webhook subscriptions
+	CreateWebhookSubscription(subscription *models.WebhookSubscription) error
+	GetWebhookSubscriptions() ([]models.WebhookSubscription, error)
+	DeleteWebhookSubscription(id uint) error
+
+	// Note operations
+	GetNotes(stockID uint) ([]models.Note, error)
+	ReadNote(stockID, noteID uint) (*models.Note, error)
+	CreateNote(note *models.Note) (*models.Note, error)
+	UpdateNote(note *models.Note) (*models.Note, error)
+	DeleteNote(note *models.Note) error
+
+	// Indicator and sentiment operations
+	DeleteIndicator(stock *models.StockDataPoint, name string) error
+	DeleteSentiment(stock *models.StockDataPoint, name string) error
+
+	// Data dictionary queries
+	GetIndicatorSummaries() ([]IndicatorSummary, error)
+	GetSentimentSummaries() ([]SentimentSummary, error)
 
 	// Group select column queries
 	GetUniqueByGroupSelectColumn(cluster int, columnName string) ([]string, error)
 
 	// Table management
 	EmptyAllTables() error
+	CountAllTables() (map[string]int64, error)
+	CountStocksInScope(scope StockScope) (int64, error)
+	DeleteStocksInScope(scope StockScope) (int64, error)
+	CountIntegrityProblems() (map[string]int64, error)
+
+	// Diagnostics
+	GetDiagnostics() (Diagnostics, error)
+	GetMigrationStatus() (MigrationStatus, error)
+	GetPoolStats() (PoolStats, error)
+	DeleteOrphans(incomplete bool) (map[string]int64, error)
 }
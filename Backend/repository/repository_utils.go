@@ -3,12 +3,41 @@ package repository
 import (
 	"fmt"
 	"strings"
+
+	"dataextractor/apperrors"
+	"dataextractor/scoring"
+	"dataextractor/validators"
+
+	"gorm.io/gorm"
+)
+
+// PreloadMode selects how much of the child tables is loaded with each data point
+type PreloadMode string
+
+const (
+	// PreloadFull loads every column of the sentiments and indicators (the default)
+	PreloadFull PreloadMode = "full"
+	// PreloadScoring loads only the columns read by scoring: name and normalized score/value
+	PreloadScoring PreloadMode = "scoring"
+	// PreloadNone skips the child tables, for paths that only read data point columns
+	PreloadNone PreloadMode = "none"
 )
 
-// weightEntry represents a generic weight entry structure
-type weightEntry struct {
-	IndicatorName string
-	Weight        float64
+// preloadRelations adds the sentiment and indicator preloads for mode to query
+func preloadRelations(query *gorm.DB, mode PreloadMode) *gorm.DB {
+	switch mode {
+	case PreloadNone:
+		return query
+	case PreloadScoring:
+		return query.
+			Preload("RatingSentiments", func(db *gorm.DB) *gorm.DB {
+				return db.Select("stock_data_point_id", "name", "norm_rating_score")
+			}).
+			Preload("NumericalIndicators", func(db *gorm.DB) *gorm.DB {
+				return db.Select("stock_data_point_id", "name", "norm_value")
+			})
+	}
+	return query.Preload("RatingSentiments").Preload("NumericalIndicators")
 }
 
 // validateColumnName checks if a column name is in the allowed whitelist
@@ -22,96 +51,93 @@ func validateColumnName(colName string, allowedCols []string) bool {
 	return false
 }
 
+// orderTerms parses a sort_by list against the allowed columns (see validators.ParseSortKeys) into
+// ORDER BY terms such as "date DESC"; an empty list sorts by defaultColumn
+func orderTerms(sortBy, order, defaultColumn string, allowed []string) ([]string, error) {
+	if strings.TrimSpace(sortBy) == "" {
+		sortBy = defaultColumn
+	}
+	keys, err := validators.ParseSortKeys(sortBy, order, allowed)
+	if err != nil {
+		return nil, apperrors.Validation("invalid sort column: %v", err)
+	}
+	terms := make([]string, len(keys))
+	for i, key := range keys {
+		terms[i] = key.Column + " " + strings.ToUpper(key.Order)
+	}
+	return terms, nil
+}
+
+// orderWithTiebreaker joins ORDER BY terms and appends idColumn in the given direction, so rows that
+// tie on every term keep the same relative order and a page never repeats or skips rows
+func orderWithTiebreaker(terms []string, idColumn, order string) string {
+	direction := "DESC"
+	if strings.EqualFold(order, "asc") {
+		direction = "ASC"
+	}
+	return strings.Join(append(terms[:len(terms):len(terms)], idColumn+" "+direction), ", ")
+}
+
 // escapeSQLString escapes a string for safe SQL usage (PostgreSQL/CockroachDB compatible)
 func escapeSQLString(s string) string {
 	// Replace single quotes with escaped quotes
 	return strings.ReplaceAll(s, "'", "''")
 }
 
-// convertNumericalWeights converts NumericalWeightEntry slice to weightEntry slice
-func convertNumericalWeights(weights []NumericalWeightEntry) []weightEntry {
-	if len(weights) == 0 {
-		return nil
-	}
-	result := make([]weightEntry, len(weights))
-	for i := range weights {
-		result[i] = weightEntry{
-			IndicatorName: weights[i].IndicatorName,
-			Weight:        weights[i].Weight,
-		}
-	}
-	return result
-}
-
-// convertRatingWeights converts RatingWeightEntry slice to weightEntry slice
-func convertRatingWeights(weights []RatingWeightEntry) []weightEntry {
-	if len(weights) == 0 {
-		return nil
+// ScoringWeights converts the weights of a filter to the form of the scoring specification, which
+// both the SQL weighted_score expression and scoring.Score evaluate
+func ScoringWeights(numericalWeights []NumericalWeightEntry, ratingWeights []RatingWeightEntry) (indicators, sentiments []scoring.Weight) {
+	for _, w := range numericalWeights {
+		indicators = append(indicators, scoring.Weight{Name: w.IndicatorName, Value: w.Weight})
 	}
-	result := make([]weightEntry, len(weights))
-	for i := range weights {
-		result[i] = weightEntry{
-			IndicatorName: weights[i].IndicatorName,
-			Weight:        weights[i].Weight,
-		}
+	for _, w := range ratingWeights {
+		sentiments = append(sentiments, scoring.Weight{Name: w.IndicatorName, Value: w.Weight})
 	}
-	return result
+	return indicators, sentiments
 }
 
-// buildWeightedScoreSubquery builds a subquery for calculating weighted scores
+// buildWeightedScoreSubquery builds a correlated scalar subquery returning the weighted score of
+// the current stock_data_points row from one child table. It only reads the weighted names, so it
+// is answered from the (stock_data_point_id, name) covering index instead of aggregating the whole table.
 // tableName: the table to query (e.g., "numerical_indicators" or "rating_sentiments")
 // valueColumn: the column containing the values to weight (e.g., "norm_value" or "norm_rating_score")
-// scoreAlias: the alias for the calculated score (e.g., "new_indicator_score" or "new_rating_score")
 // tableAlias: the alias for the table in the subquery (e.g., "ni_sub" or "rs_sub")
-// weights: slice of weight entries with IndicatorName and Weight
-func buildWeightedScoreSubquery(tableName, valueColumn, scoreAlias, tableAlias string, weights []weightEntry) string {
+// weights: the weights of the table's names, embedded with scoring.SQLLiteral
+func buildWeightedScoreSubquery(tableName, valueColumn, tableAlias string, weights []scoring.Weight) string {
 	if len(weights) == 0 {
 		return ""
 	}
 
-	// Build CASE expression with all weights
-	caseExpr := "COALESCE(SUM(CASE"
-	for _, weight := range weights {
-		escapedName := escapeSQLString(weight.IndicatorName)
-		caseExpr += fmt.Sprintf(" WHEN %s.name = '%s' THEN %s.%s * %.6f", tableAlias, escapedName, tableAlias, valueColumn, weight.Weight)
+	// Build CASE expression with all weights, and the name list that bounds the index scan
+	caseExpr := "SUM(CASE"
+	names := make([]string, len(weights))
+	for i, weight := range weights {
+		escapedName := escapeSQLString(weight.Name)
+		caseExpr += fmt.Sprintf(" WHEN %s.name = '%s' THEN %s.%s * %s", tableAlias, escapedName, tableAlias, valueColumn, scoring.SQLLiteral(weight.Value))
+		names[i] = fmt.Sprintf("'%s'", escapedName)
 	}
-	caseExpr += fmt.Sprintf(" ELSE 0 END), 0) AS %s", scoreAlias)
+	caseExpr += " ELSE 0 END)"
 
-	// Build and return the subquery
-	return fmt.Sprintf(`(
-		SELECT %s.stock_data_point_id, %s
+	return fmt.Sprintf(`COALESCE((
+		SELECT %s
 		FROM %s %s
-		GROUP BY %s.stock_data_point_id
-	)`, tableAlias, caseExpr, tableName, tableAlias, tableAlias)
+		WHERE %s.stock_data_point_id = stock_data_points.id AND %s.name IN (%s)
+	), 0)`, caseExpr, tableName, tableAlias, tableAlias, tableAlias, strings.Join(names, ", "))
 }
 
-// combineWeightedScoreSubqueries combines indicator and rating subqueries into a single combined subquery
+// combineWeightedScoreSubqueries sums the indicator and rating scalar subqueries into the weighted_score expression
 // indicatorSubquery: subquery for numerical indicators (can be empty)
 // ratingSubquery: subquery for rating sentiments (can be empty)
-// Returns the combined subquery that sums both scores, or returns a single subquery if only one exists
 func combineWeightedScoreSubqueries(indicatorSubquery, ratingSubquery string) string {
-	if indicatorSubquery != "" && ratingSubquery != "" {
-		// Both exist: join them and sum
-		return fmt.Sprintf(`(
-			SELECT 
-				COALESCE(i.stock_data_point_id, r.stock_data_point_id) AS stock_data_point_id,
-				COALESCE(i.new_indicator_score, 0) + COALESCE(r.new_rating_score, 0) AS weighted_score
-			FROM %s i
-			FULL OUTER JOIN %s r ON i.stock_data_point_id = r.stock_data_point_id
-		)`, indicatorSubquery, ratingSubquery)
-	} else if indicatorSubquery != "" {
-		// Only indicator exists
-		return fmt.Sprintf(`(
-			SELECT stock_data_point_id, new_indicator_score AS weighted_score
-			FROM %s
-		)`, indicatorSubquery)
-	} else if ratingSubquery != "" {
-		// Only rating exists
-		return fmt.Sprintf(`(
-			SELECT stock_data_point_id, new_rating_score AS weighted_score
-			FROM %s
-		)`, ratingSubquery)
+	terms := make([]string, 0, 2)
+	for _, subquery := range []string{indicatorSubquery, ratingSubquery} {
+		if subquery != "" {
+			terms = append(terms, subquery)
+		}
+	}
+	if len(terms) == 0 {
+		// Neither exists (shouldn't happen if called correctly)
+		return "0"
 	}
-	// Neither exists (shouldn't happen if called correctly)
-	return ""
+	return strings.Join(terms, " + ")
 }
@@ -0,0 +1,580 @@
+// Package repositorytest is the behavioral contract of repository.DataRepositoryInterface. Every
+// implementation (and every decorator around one) runs Run from its own tests, so a new backend
+// cannot silently diverge from the others (or from the scoring specification) in upsert
+// semantics, filtering, weighted scoring or pagination:
+//
+//	func TestContract(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) repository.DataRepositoryInterface {
+//			return newEmptyRepository(t)
+//		})
+//	}
+//
+// Repositories report failures as returned errors carrying an apperrors kind. A panic fails the
+// suite: it is turned into an internal error, which no check accepts.
+package repositorytest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+	"dataextractor/scoring"
+	"dataextractor/validators"
+)
+
+// Factory returns a connected repository with no data points; it is called once per subtest
+type Factory func(t *testing.T) repository.DataRepositoryInterface
+
+// scoreTolerance absorbs the decimal(18,6) rounding of stored scores
+const scoreTolerance = 1e-6
+
+// Run runs the contract against the repositories returned by newRepo
+func Run(t *testing.T, newRepo Factory) {
+	t.Run("CreateAndRead", func(t *testing.T) { testCreateAndRead(t, newRepo(t)) })
+	t.Run("UpsertSemantics", func(t *testing.T) { testUpsertSemantics(t, newRepo(t)) })
+	t.Run("Filtering", func(t *testing.T) { testFiltering(t, newRepo(t)) })
+	t.Run("Query", func(t *testing.T) { testQuery(t, newRepo(t)) })
+	t.Run("WeightedScore", func(t *testing.T) { testWeightedScore(t, newRepo(t)) })
+	t.Run("ScoreParity", func(t *testing.T) { testScoreParity(t, newRepo(t)) })
+	t.Run("PaginationDeterminism", func(t *testing.T) { testPaginationDeterminism(t, newRepo(t)) })
+	t.Run("Stats", func(t *testing.T) { testStats(t, newRepo(t)) })
+	t.Run("Freshness", func(t *testing.T) { testFreshness(t, newRepo(t)) })
+}
+
+// testCreateAndRead checks that a created data point reads back by ID, UUID and ticker with its
+// relations, and that missing rows are reported as not found
+func testCreateAndRead(t *testing.T, repo repository.DataRepositoryInterface) {
+	stock := newStock("CRTA", 0)
+	stock.NumericalIndicators = []models.NumericalIndicator{{Name: "atr", Value: 1.5, NormValue: 0.25}}
+	stock.RatingSentiments = []models.RatingSentiment{{Name: "action", Rating: "upgraded by", RatingScore: 1, NormRatingScore: 0.5}}
+	created := mustCreate(t, repo, stock)
+	if created.ID == 0 || created.UUID == "" {
+		t.Fatalf("Create() left ID %d, UUID %q unset", created.ID, created.UUID)
+	}
+
+	var byID, byTicker *models.StockDataPoint
+	var idByUUID uint
+	mustDo(t, "ReadById", func() (err error) { byID, err = repo.ReadById(created.ID); return })
+	mustDo(t, "GetIDByUUID", func() (err error) { idByUUID, err = repo.GetIDByUUID(created.UUID); return })
+	mustDo(t, "GetDataByTicker", func() (err error) { byTicker, err = repo.GetDataByTicker("CRTA"); return })
+	if idByUUID != created.ID {
+		t.Errorf("GetIDByUUID() = %d, want %d", idByUUID, created.ID)
+	}
+	for name, read := range map[string]*models.StockDataPoint{"ReadById": byID, "GetDataByTicker": byTicker} {
+		if read.ID != created.ID || read.Ticker != "CRTA" || read.Company != stock.Company || !read.Date.Equal(stock.Date) {
+			t.Errorf("%s() = %+v, want the created stock", name, read)
+		}
+		if len(read.NumericalIndicators) != 1 || len(read.RatingSentiments) != 1 {
+			t.Errorf("%s() loaded %d indicators and %d sentiments, want 1 and 1", name, len(read.NumericalIndicators), len(read.RatingSentiments))
+		}
+	}
+
+	err := do(func() (err error) { _, err = repo.ReadById(created.ID + 1000); return })
+	if apperrors.KindOf(err) != apperrors.KindNotFound {
+		t.Errorf("ReadById(missing) error = %v, want a not found error", err)
+	}
+	err = do(func() (err error) { _, err = repo.GetIDByUUID("00000000-0000-0000-0000-000000000000"); return })
+	if apperrors.KindOf(err) != apperrors.KindNotFound {
+		t.Errorf("GetIDByUUID(missing) error = %v, want a not found error", err)
+	}
+	err = do(func() (err error) { _, err = repo.GetDataByTicker("MISSING"); return })
+	if apperrors.KindOf(err) != apperrors.KindNotFound {
+		t.Errorf("GetDataByTicker(missing) error = %v, want a not found error", err)
+	}
+}
+
+// testUpsertSemantics checks that tickers are unique: Create rejects a duplicate as a conflict,
+// and UpdateOrCreate updates the row holding the ticker in place instead of adding one
+func testUpsertSemantics(t *testing.T, repo repository.DataRepositoryInterface) {
+	first := newStock("UPST", 0)
+	var created *models.StockDataPoint
+	mustDo(t, "UpdateOrCreate(new)", func() (err error) { created, err = repo.UpdateOrCreate(first); return })
+	if created.ID == 0 {
+		t.Fatal("UpdateOrCreate(new) did not assign an ID")
+	}
+
+	duplicate := newStock("UPST", 0)
+	err := do(func() (err error) { _, err = repo.Create(duplicate); return })
+	if apperrors.KindOf(err) != apperrors.KindConflict {
+		t.Errorf("Create(duplicate ticker) error = %v, want a conflict", err)
+	}
+
+	changed := newStock("UPST", 1)
+	changed.Company = "Upserted Corp"
+	changed.FinalScore = 7.5
+	var updated *models.StockDataPoint
+	mustDo(t, "UpdateOrCreate(existing)", func() (err error) { updated, err = repo.UpdateOrCreate(changed); return })
+	if updated.ID != created.ID {
+		t.Errorf("UpdateOrCreate(existing) ID = %d, want the existing %d", updated.ID, created.ID)
+	}
+
+	var read *models.StockDataPoint
+	mustDo(t, "ReadById", func() (err error) { read, err = repo.ReadById(created.ID); return })
+	if read.Company != "Upserted Corp" || read.Cluster != 1 || !near(read.FinalScore, 7.5) {
+		t.Errorf("after UpdateOrCreate the stock is %+v, want the new company, cluster and score", read)
+	}
+	if read.UUID != created.UUID || !read.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("UpdateOrCreate(existing) changed UUID %q -> %q or created_at %s -> %s", created.UUID, read.UUID, created.CreatedAt, read.CreatedAt)
+	}
+
+	mustDo(t, "UpdateOrCreate(other)", func() (err error) { _, err = repo.UpdateOrCreate(newStock("UPSU", 0)); return })
+	var count int64
+	mustDo(t, "GetTotalCount", func() (err error) { count, err = repo.GetTotalCount(); return })
+	if count != 2 {
+		t.Errorf("GetTotalCount() = %d, want 2 (one row per ticker)", count)
+	}
+}
+
+// testFiltering checks the cluster, grouping value, tag and range filters and their totals
+func testFiltering(t *testing.T, repo repository.DataRepositoryInterface) {
+	seed := []struct {
+		ticker string
+		action string
+		score  float64
+		tag    string
+	}{
+		{"FLTA", "upgraded by", 1, "watch"},
+		{"FLTB", "upgraded by", 5, ""},
+		{"FLTC", "downgraded by", 3, "watch"},
+		{"FLTD", "downgraded by", 9, ""},
+	}
+	for _, s := range seed {
+		stock := newStock(s.ticker, 0)
+		stock.Action = s.action
+		stock.FinalScore = s.score
+		mustCreate(t, repo, stock)
+		if s.tag != "" {
+			mustDo(t, "AddTags", func() error { return repo.AddTags(stock, []string{s.tag}) })
+		}
+	}
+	mustCreate(t, repo, newStock("FLTX", 1))
+
+	min, max := 2.0, 8.0
+	tests := []struct {
+		name     string
+		grouping string
+		value    string
+		tags     []string
+		ranges   []repository.RangeFilter
+		want     []string
+	}{
+		{name: "cluster", grouping: validators.GroupingNone, want: []string{"FLTA", "FLTB", "FLTC", "FLTD"}},
+		{name: "grouping value", grouping: "action", value: "upgraded by", want: []string{"FLTA", "FLTB"}},
+		{name: "empty grouping value", grouping: "action", want: []string{"FLTA", "FLTB", "FLTC", "FLTD"}},
+		{name: "tags", grouping: validators.GroupingNone, tags: []string{"watch"}, want: []string{"FLTA", "FLTC"}},
+		{name: "range", grouping: validators.GroupingNone, ranges: []repository.RangeFilter{{Column: "final_score", Min: &min, Max: &max}}, want: []string{"FLTB", "FLTC"}},
+		{name: "combined", grouping: "action", value: "downgraded by", tags: []string{"watch"}, ranges: []repository.RangeFilter{{Column: "final_score", Min: &min}}, want: []string{"FLTC"}},
+	}
+	for _, tt := range tests {
+		stocks, total := mustFilter(t, repo, filterArgs{cluster: 0, grouping: tt.grouping, value: tt.value, sortBy: "ticker", order: "asc", page: 1, perPage: 50, tags: tt.tags, ranges: tt.ranges})
+		if got := tickers(stocks); fmt.Sprint(got) != fmt.Sprint(tt.want) || total != int64(len(tt.want)) {
+			t.Errorf("%s: got %v (total %d), want %v", tt.name, got, total, tt.want)
+		}
+	}
+
+	err := do(func() (err error) {
+		_, _, err = repo.GetStocksByClusterAndGroup(0, "company; DROP TABLE x", "a", "ticker", "asc", 1, 10, nil, nil, nil, nil, repository.PreloadNone)
+		return
+	})
+	if apperrors.KindOf(err) != apperrors.KindValidation {
+		t.Errorf("GetStocksByClusterAndGroup(invalid grouping column) error = %v, want a validation error", err)
+	}
+}
+
+// testQuery checks that QueryStocks combines its criteria with AND, counts every match and loads
+// the tags of the page
+func testQuery(t *testing.T, repo repository.DataRepositoryInterface) {
+	seed := []struct {
+		ticker  string
+		cluster int
+		action  string
+		score   float64
+		tag     string
+	}{
+		{"QRYA", 0, "upgraded by", 1, "watch"},
+		{"QRYB", 0, "upgraded by", 5, ""},
+		{"QRYC", 0, "downgraded by", 3, "watch"},
+		{"QRYD", 1, "upgraded by", 4, "watch"},
+	}
+	for _, s := range seed {
+		stock := newStock(s.ticker, s.cluster)
+		stock.Action = s.action
+		stock.FinalScore = s.score
+		mustCreate(t, repo, stock)
+		if s.tag != "" {
+			mustDo(t, "AddTags", func() error { return repo.AddTags(stock, []string{s.tag}) })
+		}
+	}
+
+	cluster, min, max := 0, 2.0, 8.0
+	tests := []struct {
+		name  string
+		query repository.StockQuery
+		want  []string
+	}{
+		{name: "no criteria", want: []string{"QRYA", "QRYB", "QRYC", "QRYD"}},
+		{name: "cluster", query: repository.StockQuery{Cluster: &cluster}, want: []string{"QRYA", "QRYB", "QRYC"}},
+		{name: "action", query: repository.StockQuery{Action: "upgraded by"}, want: []string{"QRYA", "QRYB", "QRYD"}},
+		{name: "tags", query: repository.StockQuery{Tags: []string{"watch"}}, want: []string{"QRYA", "QRYC", "QRYD"}},
+		{name: "score range", query: repository.StockQuery{MinFinalScore: &min, MaxFinalScore: &max}, want: []string{"QRYB", "QRYC", "QRYD"}},
+		{name: "combined", query: repository.StockQuery{Cluster: &cluster, Tags: []string{"watch"}, MinFinalScore: &min}, want: []string{"QRYC"}},
+	}
+	for _, tt := range tests {
+		var stocks []models.StockDataPoint
+		var total int64
+		mustDo(t, "QueryStocks", func() (err error) {
+			stocks, total, err = repo.QueryStocks(tt.query, repository.ListOptions{Page: 1, PerPage: 50, SortBy: "ticker", Order: "asc"})
+			return
+		})
+		if got := tickers(stocks); fmt.Sprint(got) != fmt.Sprint(tt.want) || total != int64(len(tt.want)) {
+			t.Errorf("%s: got %v (total %d), want %v", tt.name, got, total, tt.want)
+		}
+	}
+
+	var page []models.StockDataPoint
+	var total int64
+	mustDo(t, "QueryStocks", func() (err error) {
+		page, total, err = repo.QueryStocks(repository.StockQuery{}, repository.ListOptions{Page: 2, PerPage: 1, SortBy: "ticker", Order: "asc"})
+		return
+	})
+	if got := tickers(page); fmt.Sprint(got) != "[QRYB]" || total != 4 {
+		t.Errorf("QueryStocks(page 2 of 1) = %v (total %d), want [QRYB] (total 4)", got, total)
+	}
+	mustDo(t, "QueryStocks", func() (err error) {
+		page, _, err = repo.QueryStocks(repository.StockQuery{Action: "downgraded by"}, repository.ListOptions{Page: 1, PerPage: 1, SortBy: "ticker", Order: "asc"})
+		return
+	})
+	if len(page) != 1 || len(page[0].Tags) != 1 || page[0].Tags[0].Name != "watch" {
+		t.Errorf("QueryStocks did not load the tags of QRYC: %+v", page)
+	}
+}
+
+// testWeightedScore checks that weighted_score is the sum of weight x normalized value over the
+// weighted indicators and sentiments, that unweighted names and stocks without weighted rows
+// contribute 0, and that sorting by it orders the page
+func testWeightedScore(t *testing.T, repo repository.DataRepositoryInterface) {
+	type child struct {
+		name string
+		norm float64
+	}
+	seed := []struct {
+		ticker     string
+		indicators []child
+		sentiments []child
+	}{
+		{"WGTA", []child{{"atr", 0.5}, {"obv", 1}, {"rsi", 0.75}}, []child{{"action", 0.25}}},
+		{"WGTB", []child{{"atr", 1}}, []child{{"action", 1}, {"rating", 0.5}}},
+		{"WGTC", nil, nil},
+	}
+	for _, s := range seed {
+		stock := newStock(s.ticker, 0)
+		for _, c := range s.indicators {
+			stock.NumericalIndicators = append(stock.NumericalIndicators, models.NumericalIndicator{Name: c.name, Value: c.norm * 10, NormValue: c.norm})
+		}
+		for _, c := range s.sentiments {
+			stock.RatingSentiments = append(stock.RatingSentiments, models.RatingSentiment{Name: c.name, Rating: "buy", RatingScore: c.norm, NormRatingScore: c.norm})
+		}
+		mustCreate(t, repo, stock)
+	}
+
+	numerical := []repository.NumericalWeightEntry{{IndicatorName: "atr", Weight: 2}, {IndicatorName: "obv", Weight: 0.5}}
+	rating := []repository.RatingWeightEntry{{IndicatorName: "action", Weight: 4}}
+	want := map[string]float64{
+		"WGTA": 2*0.5 + 0.5*1 + 4*0.25, // 2.5; rsi is not weighted
+		"WGTB": 2*1 + 4*1,              // 6; rating is not weighted
+		"WGTC": 0,                      // no child rows, still listed
+	}
+
+	stocks, total := mustFilter(t, repo, filterArgs{cluster: 0, grouping: validators.GroupingNone, sortBy: "weighted_score", order: "desc", page: 1, perPage: 10, numerical: numerical, rating: rating})
+	if total != 3 || fmt.Sprint(tickers(stocks)) != "[WGTB WGTA WGTC]" {
+		t.Fatalf("sorted by weighted_score desc: got %v (total %d), want [WGTB WGTA WGTC]", tickers(stocks), total)
+	}
+	for _, stock := range stocks {
+		if stock.WeightedScore == nil || !near(*stock.WeightedScore, want[stock.Ticker]) {
+			t.Errorf("%s weighted_score = %v, want %g", stock.Ticker, stock.WeightedScore, want[stock.Ticker])
+		}
+	}
+
+	// One kind of weight alone still scores, without reordering by weighted_score
+	stocks, _ = mustFilter(t, repo, filterArgs{cluster: 0, grouping: validators.GroupingNone, sortBy: "ticker", order: "asc", page: 1, perPage: 10, numerical: numerical})
+	for _, stock := range stocks {
+		wantScore := map[string]float64{"WGTA": 1.5, "WGTB": 2, "WGTC": 0}[stock.Ticker]
+		if stock.WeightedScore == nil || !near(*stock.WeightedScore, wantScore) {
+			t.Errorf("%s indicator-only weighted_score = %v, want %g", stock.Ticker, stock.WeightedScore, wantScore)
+		}
+	}
+}
+
+// testScoreParity cross-validates the repository's weighted_score against scoring.Score, the Go
+// evaluation of the same specification: for random values and weights, including products that land
+// exactly on a rounding tie, both must agree to the last bit and order the stocks the same way, with
+// both weight sets and with either one alone
+func testScoreParity(t *testing.T, repo repository.DataRepositoryInterface) {
+	rng := rand.New(rand.NewSource(1017))
+	indicatorNames := []string{"atr", "obv", "vwap"}
+	sentimentNames := []string{"action", "rating_to"}
+	for i := 0; i < 30; i++ {
+		stock := newStock(fmt.Sprintf("PAR%02d", i), 0)
+		for _, name := range indicatorNames {
+			if rng.Intn(4) > 0 {
+				norm := float64(rng.Intn(2_000_001)-1_000_000) / 1e6 // decimal(18,6)
+				stock.NumericalIndicators = append(stock.NumericalIndicators, models.NumericalIndicator{Name: name, Value: norm, NormValue: norm})
+			}
+		}
+		for _, name := range sentimentNames {
+			if rng.Intn(4) > 0 {
+				norm := float64(rng.Intn(10_001)) / 1e4 // decimal(10,4)
+				stock.RatingSentiments = append(stock.RatingSentiments, models.RatingSentiment{Name: name, Rating: "buy", RatingScore: norm, NormRatingScore: norm})
+			}
+		}
+		mustCreate(t, repo, stock)
+	}
+	// 0.5 x 0.000001 is exactly half of the last kept decimal place
+	tie := newStock("PARTIE", 0)
+	tie.NumericalIndicators = []models.NumericalIndicator{{Name: "atr", Value: 0.5, NormValue: 0.5}}
+	mustCreate(t, repo, tie)
+
+	weightSets := [][]float64{{0.000001, 0, 0, 0, 0}}
+	for i := 0; i < 5; i++ {
+		weights := make([]float64, len(indicatorNames)+len(sentimentNames))
+		for j := range weights {
+			weights[j] = scoring.RoundWeight(rng.Float64()*2 - 0.5)
+		}
+		weightSets = append(weightSets, weights)
+	}
+
+	for _, weights := range weightSets {
+		var numerical []repository.NumericalWeightEntry
+		var rating []repository.RatingWeightEntry
+		for j, name := range indicatorNames {
+			numerical = append(numerical, repository.NumericalWeightEntry{IndicatorName: name, Weight: weights[j]})
+		}
+		for j, name := range sentimentNames {
+			rating = append(rating, repository.RatingWeightEntry{IndicatorName: name, Weight: weights[len(indicatorNames)+j]})
+		}
+		parts := []struct {
+			name      string
+			numerical []repository.NumericalWeightEntry
+			rating    []repository.RatingWeightEntry
+		}{{"both", numerical, rating}, {"numerical only", numerical, nil}, {"rating only", nil, rating}}
+
+		for _, part := range parts {
+			indicators, sentiments := repository.ScoringWeights(part.numerical, part.rating)
+
+			stocks, _ := mustFilter(t, repo, filterArgs{cluster: 0, grouping: validators.GroupingNone, sortBy: "weighted_score", order: "desc", page: 1, perPage: 100, numerical: part.numerical, rating: part.rating})
+			for i, stock := range stocks {
+				want := scoring.Score(&stocks[i], indicators, sentiments)
+				if stock.WeightedScore == nil || *stock.WeightedScore != want {
+					t.Errorf("weights %v (%s): %s weighted_score = %v, scoring.Score = %v", weights, part.name, stock.Ticker, stock.WeightedScore, want)
+				}
+				if i > 0 {
+					prev := scoring.Score(&stocks[i-1], indicators, sentiments)
+					if prev < want || (prev == want && stocks[i-1].ID < stock.ID) {
+						t.Errorf("weights %v (%s): %s is ordered before %s", weights, part.name, stocks[i-1].Ticker, stock.Ticker)
+					}
+				}
+				if stock.Ticker == "PARTIE" && part.numerical != nil && weights[0] == 0.000001 && want != 0.000001 {
+					t.Errorf("tie scored %v, want 0.000001 (half away from zero)", want)
+				}
+			}
+		}
+	}
+}
+
+// testPaginationDeterminism checks that pages over tied sort keys neither overlap nor skip rows,
+// and that repeating a query returns the same order
+func testPaginationDeterminism(t *testing.T, repo repository.DataRepositoryInterface) {
+	const count, perPage = 23, 5
+	for i := 0; i < count; i++ {
+		stock := newStock(fmt.Sprintf("PAG%02d", i), 0)
+		stock.FinalScore = float64(i % 3) // heavy ties on the sort key
+		mustCreate(t, repo, stock)
+	}
+
+	for _, sortBy := range []string{"final_score", "date", "final_score desc, ticker asc"} {
+		collect := func() []uint {
+			var ids []uint
+			for page := 1; ; page++ {
+				stocks, total := mustFilter(t, repo, filterArgs{cluster: 0, grouping: validators.GroupingNone, sortBy: sortBy, order: "desc", page: page, perPage: perPage})
+				if total != count {
+					t.Fatalf("sort %q page %d total = %d, want %d", sortBy, page, total, count)
+				}
+				for _, stock := range stocks {
+					ids = append(ids, stock.ID)
+				}
+				if len(stocks) < perPage {
+					return ids
+				}
+			}
+		}
+
+		first := collect()
+		seen := make(map[uint]bool)
+		for _, id := range first {
+			if seen[id] {
+				t.Errorf("sort %q: stock %d appears on more than one page", sortBy, id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != count {
+			t.Errorf("sort %q: pages hold %d distinct stocks, want %d", sortBy, len(seen), count)
+		}
+		if second := collect(); fmt.Sprint(second) != fmt.Sprint(first) {
+			t.Errorf("sort %q: repeated paging returned a different order", sortBy)
+		}
+	}
+
+	// Past the last page: empty, with the total still reported
+	stocks, total := mustFilter(t, repo, filterArgs{cluster: 0, grouping: validators.GroupingNone, sortBy: "date", order: "asc", page: 100, perPage: perPage})
+	if len(stocks) != 0 || total != count {
+		t.Errorf("page past the end = %d stocks (total %d), want none (total %d)", len(stocks), total, count)
+	}
+}
+
+// testStats checks the ticker and database statistics on an empty repository, where a ticker has
+// no times and there are no clusters, and after data points are created
+func testStats(t *testing.T, repo repository.DataRepositoryInterface) {
+	var ticker repository.TickerStats
+	var database repository.DatabaseStats
+	mustDo(t, "GetTickerStats(empty)", func() (err error) { ticker, err = repo.GetTickerStats("STTA"); return })
+	if ticker.Count != 0 || ticker.EarliestTime != nil || ticker.LatestTime != nil {
+		t.Errorf("GetTickerStats(empty) = %+v, want a count of 0 and nil times", ticker)
+	}
+	mustDo(t, "GetDatabaseStats(empty)", func() (err error) { database, err = repo.GetDatabaseStats(); return })
+	if database.TotalRecords != 0 || database.ClusterCounts == nil || len(database.ClusterCounts) != 0 {
+		t.Errorf("GetDatabaseStats(empty) = %+v, want no records and an empty cluster map", database)
+	}
+
+	stock := mustCreate(t, repo, newStock("STTA", 1))
+	mustCreate(t, repo, newStock("STTB", 2))
+	mustDo(t, "GetTickerStats", func() (err error) { ticker, err = repo.GetTickerStats("STTA"); return })
+	if ticker.Count != 1 || ticker.EarliestTime == nil || ticker.LatestTime == nil || !ticker.EarliestTime.Equal(stock.Date) || !ticker.LatestTime.Equal(stock.Date) {
+		t.Errorf("GetTickerStats() = %+v, want one data point dated %v", ticker, stock.Date)
+	}
+	mustDo(t, "GetDatabaseStats", func() (err error) { database, err = repo.GetDatabaseStats(); return })
+	if database.TotalRecords != 2 || database.UniqueTickers != 2 || database.ClusterCounts[1] != 1 || database.ClusterCounts[2] != 1 {
+		t.Errorf("GetDatabaseStats() = %+v, want 2 records in clusters 1 and 2", database)
+	}
+}
+
+// testFreshness checks that an empty table reports no times, that the row count and last
+// modification follow the data, and that only a complete dataset version counts as an import
+func testFreshness(t *testing.T, repo repository.DataRepositoryInterface) {
+	var freshness repository.DataFreshness
+	mustDo(t, "GetDataFreshness(empty)", func() (err error) { freshness, err = repo.GetDataFreshness(); return })
+	if freshness.LastImportedAt != nil || freshness.DatasetVersion != nil || freshness.LastModified != nil || freshness.TotalRows != 0 {
+		t.Errorf("GetDataFreshness(empty) = %+v, want no rows and nil times", freshness)
+	}
+
+	mustCreate(t, repo, newStock("FRTA", 0))
+	version := &models.DatasetVersion{Source: "contract.csv", Status: models.DatasetImporting}
+	mustDo(t, "CreateDatasetVersion", func() error { return repo.CreateDatasetVersion(version) })
+	mustDo(t, "GetDataFreshness(importing)", func() (err error) { freshness, err = repo.GetDataFreshness(); return })
+	if freshness.LastModified == nil || freshness.TotalRows != 1 {
+		t.Errorf("GetDataFreshness() = %+v, want one row and a last modification", freshness)
+	}
+	if freshness.LastImportedAt != nil || freshness.DatasetVersion != nil {
+		t.Errorf("GetDataFreshness(importing) = %+v, want no import until the version completes", freshness)
+	}
+
+	completedAt := time.Now().UTC().Truncate(time.Second)
+	version.Status, version.RowCount, version.CompletedAt = models.DatasetComplete, 1, &completedAt
+	mustDo(t, "UpdateDatasetVersion", func() error { return repo.UpdateDatasetVersion(version) })
+	mustDo(t, "GetDataFreshness(complete)", func() (err error) { freshness, err = repo.GetDataFreshness(); return })
+	if freshness.LastImportedAt == nil || !freshness.LastImportedAt.Equal(completedAt) {
+		t.Errorf("GetDataFreshness().LastImportedAt = %v, want %v", freshness.LastImportedAt, completedAt)
+	}
+	if freshness.DatasetVersion == nil || *freshness.DatasetVersion != version.ID {
+		t.Errorf("GetDataFreshness().DatasetVersion = %v, want %d", freshness.DatasetVersion, version.ID)
+	}
+}
+
+// filterArgs are the GetStocksByClusterAndGroup arguments the suite varies
+type filterArgs struct {
+	cluster   int
+	grouping  string
+	value     string
+	sortBy    string
+	order     string
+	page      int
+	perPage   int
+	numerical []repository.NumericalWeightEntry
+	rating    []repository.RatingWeightEntry
+	tags      []string
+	ranges    []repository.RangeFilter
+}
+
+// mustFilter calls GetStocksByClusterAndGroup and fails the test on error
+func mustFilter(t *testing.T, repo repository.DataRepositoryInterface, args filterArgs) ([]models.StockDataPoint, int64) {
+	t.Helper()
+	var stocks []models.StockDataPoint
+	var total int64
+	mustDo(t, "GetStocksByClusterAndGroup", func() (err error) {
+		stocks, total, err = repo.GetStocksByClusterAndGroup(args.cluster, args.grouping, args.value, args.sortBy, args.order,
+			args.page, args.perPage, args.numerical, args.rating, args.tags, args.ranges, repository.PreloadFull)
+		return
+	})
+	return stocks, total
+}
+
+// newStock returns a valid data point; dates are distinct per ticker so date sorts are total
+func newStock(ticker string, cluster int) *models.StockDataPoint {
+	var offset time.Duration
+	for _, r := range ticker {
+		offset = offset*31 + time.Duration(r)
+	}
+	return &models.StockDataPoint{
+		Ticker:    ticker,
+		Company:   ticker + " Inc.",
+		Action:    "target raised by",
+		Date:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset % (24 * 365) * time.Hour),
+		Cluster:   cluster,
+		TargetTo:  10,
+		Brokerage: "Contract Securities",
+	}
+}
+
+// mustCreate creates stock and fails the test on error
+func mustCreate(t *testing.T, repo repository.DataRepositoryInterface, stock *models.StockDataPoint) *models.StockDataPoint {
+	t.Helper()
+	var created *models.StockDataPoint
+	mustDo(t, "Create("+stock.Ticker+")", func() (err error) { created, err = repo.Create(stock); return })
+	return created
+}
+
+// mustDo runs call and fails the test when it returns an error or panics
+func mustDo(t *testing.T, name string, call func() error) {
+	t.Helper()
+	if err := do(call); err != nil {
+		t.Fatalf("%s error = %v", name, err)
+	}
+}
+
+// do runs call, turning a panic into an internal error so the test reports it instead of crashing
+func do(call func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = apperrors.Internal("repository panicked instead of returning an error: %v", recovered)
+		}
+	}()
+	return call()
+}
+
+// tickers lists the tickers of stocks in order
+func tickers(stocks []models.StockDataPoint) []string {
+	names := make([]string, len(stocks))
+	for i, stock := range stocks {
+		names[i] = stock.Ticker
+	}
+	return names
+}
+
+// near compares scores within the storage precision
+func near(got, want float64) bool {
+	return math.Abs(got-want) <= scoreTolerance
+}
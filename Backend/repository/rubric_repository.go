@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+)
+
+// GetRatingRubric returns the rubric entries, optionally of one kind, ordered by kind and term
+func (r *CockroachDBRepository) GetRatingRubric(kind string) ([]models.RatingRubric, error) {
+	query := r.db.Order("kind, term")
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	var entries []models.RatingRubric
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rating rubric: %w", err)
+	}
+	return entries, nil
+}
+
+// ReadRatingRubric retrieves a single rubric entry
+func (r *CockroachDBRepository) ReadRatingRubric(id uint) (*models.RatingRubric, error) {
+	var entry models.RatingRubric
+	if err := r.db.First(&entry, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NotFound("rating rubric entry %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get rating rubric entry %d: %w", id, err)
+	}
+	return &entry, nil
+}
+
+// CreateRatingRubric inserts a rubric entry; a duplicate kind/term is reported as a conflict
+func (r *CockroachDBRepository) CreateRatingRubric(entry *models.RatingRubric) (*models.RatingRubric, error) {
+	if err := r.db.Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to create rating rubric entry %s/%s: %w", entry.Kind, entry.Term, err)
+	}
+	return entry, nil
+}
+
+// UpdateRatingRubric saves changes to an existing rubric entry
+func (r *CockroachDBRepository) UpdateRatingRubric(entry *models.RatingRubric) (*models.RatingRubric, error) {
+	if err := r.db.Save(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to update rating rubric entry %d: %w", entry.ID, err)
+	}
+	return entry, nil
+}
+
+// DeleteRatingRubric removes a rubric entry
+func (r *CockroachDBRepository) DeleteRatingRubric(entry *models.RatingRubric) error {
+	if err := r.db.Delete(entry).Error; err != nil {
+		return fmt.Errorf("failed to delete rating rubric entry %d: %w", entry.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScoringConfigImport counts what ImportScoringConfig wrote and, in replace mode, removed
+type ScoringConfigImport struct {
+	RubricEntries   int   `json:"rubric_entries"`
+	WeightProfiles  int   `json:"weight_profiles"`
+	RubricDeleted   int64 `json:"rubric_deleted"`
+	ProfilesDeleted int64 `json:"profiles_deleted"`
+}
+
+// ImportScoringConfig upserts rubric entries by kind and term and weight profiles by user in one
+// transaction. With replace, rubric entries and profiles missing from the import are deleted, so
+// the tables end up matching it exactly.
+func (r *CockroachDBRepository) ImportScoringConfig(rubric []models.RatingRubric, preferences []models.UserPreference, replace bool) (ScoringConfigImport, error) {
+	result := ScoringConfigImport{RubricEntries: len(rubric), WeightProfiles: len(preferences)}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			deleted, err := deleteRubricExcept(tx, rubric)
+			if err != nil {
+				return err
+			}
+			result.RubricDeleted = deleted
+
+			users := make([]string, len(preferences))
+			for i, preference := range preferences {
+				users[i] = preference.UserID
+			}
+			query := tx.Session(&gorm.Session{AllowGlobalUpdate: true})
+			if len(users) > 0 {
+				query = query.Where("user_id NOT IN ?", users)
+			}
+			deletion := query.Delete(&models.UserPreference{})
+			if deletion.Error != nil {
+				return fmt.Errorf("failed to delete weight profiles: %w", deletion.Error)
+			}
+			result.ProfilesDeleted = deletion.RowsAffected
+		}
+
+		for i := range rubric {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "kind"}, {Name: "term"}},
+				DoUpdates: clause.AssignmentColumns([]string{"score", "norm_score", "updated_at", "updated_by"}),
+			}).Create(&rubric[i]).Error
+			if err != nil {
+				return fmt.Errorf("failed to import rating rubric entry %s/%s: %w", rubric[i].Kind, rubric[i].Term, err)
+			}
+		}
+		for i := range preferences {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"default_cluster", "numerical_weights", "rating_weights", "updated_at"}),
+			}).Create(&preferences[i]).Error
+			if err != nil {
+				return fmt.Errorf("failed to import weight profile of user %s: %w", preferences[i].UserID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return ScoringConfigImport{}, err
+	}
+	return result, nil
+}
+
+// deleteRubricExcept deletes the rubric entries whose kind and term are not in keep
+func deleteRubricExcept(tx *gorm.DB, keep []models.RatingRubric) (int64, error) {
+	var existing []models.RatingRubric
+	if err := tx.Find(&existing).Error; err != nil {
+		return 0, fmt.Errorf("failed to get rating rubric: %w", err)
+	}
+	kept := make(map[[2]string]bool, len(keep))
+	for _, entry := range keep {
+		kept[[2]string{entry.Kind, entry.Term}] = true
+	}
+	var stale []uint
+	for _, entry := range existing {
+		if !kept[[2]string{entry.Kind, entry.Term}] {
+			stale = append(stale, entry.ID)
+		}
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	deletion := tx.Delete(&models.RatingRubric{}, stale)
+	if deletion.Error != nil {
+		return 0, fmt.Errorf("failed to delete rating rubric entries: %w", deletion.Error)
+	}
+	return deletion.RowsAffected, nil
+}
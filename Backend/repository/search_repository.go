@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"dataextractor/models"
+)
+
+// SearchResult is a stock matched by the global search, with its match rank (lower is better)
+type SearchResult struct {
+	ID         uint    `json:"-"`
+	UUID       string  `json:"uuid"`
+	Ticker     string  `json:"ticker"`
+	Company    string  `json:"company"`
+	Cluster    int     `json:"cluster"`
+	FinalScore float64 `json:"final_score"`
+	MatchRank  int     `json:"match_rank"`
+}
+
+// searchRankExpr ranks a match: exact ticker, ticker prefix, company prefix, company word prefix,
+// then any substring of ticker or company. Its arguments are the lower-cased query, the prefix
+// pattern and the word-prefix pattern.
+const searchRankExpr = `CASE
+		WHEN lower(ticker) = ? THEN 0
+		WHEN lower(ticker) LIKE ? THEN 1
+		WHEN lower(company) LIKE ? THEN 2
+		WHEN lower(company) LIKE ? THEN 3
+		ELSE 4
+	END`
+
+// SearchStocks finds stocks whose ticker or company matches query, best matches first. Prefix matches
+// on lower(ticker) and lower(company) are served by the idx_sdp_lower_* expression indexes.
+func (r *CockroachDBRepository) SearchStocks(query string, limit int) ([]SearchResult, error) {
+	q := strings.ToLower(query)
+	escaped := escapeLike(q)
+	prefix := escaped + "%"
+	wordPrefix := "% " + escaped + "%"
+	contains := "%" + escaped + "%"
+
+	var results []SearchResult
+	if err := r.db.Model(&models.StockDataPoint{}).
+		Select("id, uuid, ticker, company, cluster, final_score, "+searchRankExpr+" AS match_rank", q, prefix, prefix, wordPrefix).
+		Where("lower(ticker) LIKE ? OR lower(company) LIKE ?", contains, contains).
+		Order(orderWithTiebreaker([]string{"match_rank", "length(ticker)", "ticker"}, "id", "asc")).
+		Limit(limit).
+		Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to search stocks for %q: %w", query, err)
+	}
+	return results, nil
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied pattern fragment
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
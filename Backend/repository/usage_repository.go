@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClientRouteUsage is the traffic of one API client on one route over a range of days
+type ClientRouteUsage struct {
+	KeyHash  string `json:"key_hash"`
+	Method   string `json:"method"`
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+	BytesIn  int64  `json:"bytes_in"`
+	BytesOut int64  `json:"bytes_out"`
+}
+
+// RecordClientUsage adds the counters of rows to the stored usage of the same client, day, method
+// and route. Rows must not repeat a (key_hash, day, method, route) combination.
+func (r *CockroachDBRepository) RecordClientUsage(rows []models.ClientUsage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	table := (&models.ClientUsage{}).TableName()
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key_hash"}, {Name: "day"}, {Name: "method"}, {Name: "route"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"requests":   gorm.Expr(table + ".requests + excluded.requests"),
+			"errors":     gorm.Expr(table + ".errors + excluded.errors"),
+			"bytes_in":   gorm.Expr(table + ".bytes_in + excluded.bytes_in"),
+			"bytes_out":  gorm.Expr(table + ".bytes_out + excluded.bytes_out"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&rows).Error
+	if err != nil {
+		return fmt.Errorf("failed to record client usage: %w", err)
+	}
+	return nil
+}
+
+// GetClientUsage returns the traffic of every client and route between the days from and to
+// (inclusive), busiest first
+func (r *CockroachDBRepository) GetClientUsage(from, to time.Time) ([]ClientRouteUsage, error) {
+	var usage []ClientRouteUsage
+	err := r.db.Model(&models.ClientUsage{}).
+		Select("key_hash, method, route, SUM(requests) AS requests, SUM(errors) AS errors, SUM(bytes_in) AS bytes_in, SUM(bytes_out) AS bytes_out").
+		Where("day >= ? AND day <= ?", from, to).
+		Group("key_hash, method, route").
+		Order("requests DESC, key_hash, route, method").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client usage: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+)
+
+// CreateWebhookSubscription stores a new webhook subscription
+func (r *CockroachDBRepository) CreateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	if err := r.db.Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookSubscriptions returns every webhook subscription, oldest first
+func (r *CockroachDBRepository) GetWebhookSubscriptions() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription removes a webhook subscription
+func (r *CockroachDBRepository) DeleteWebhookSubscription(id uint) error {
+	result := r.db.Delete(&models.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("webhook subscription %d not found", id)
+	}
+	return nil
+}
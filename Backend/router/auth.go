@@ -0,0 +1,77 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"dataextractor/auth"
+	"dataextractor/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware resolves the caller's identity and role and stores them under ActorContextKey and
+// RoleContextKey. Credentials are, in order: an "Authorization: Bearer" HS256 token (sub and role
+// claims), an X-API-Key listed in AUTH_API_KEYS, the X-Actor/X-Role headers when trustHeader is
+// enabled, and finally the anonymous role. Invalid credentials are rejected with 401 rather than
+// downgraded to anonymous access.
+func AuthMiddleware(cfg config.AuthConfig, trustHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, role, err := authenticate(c, cfg)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if role == "" && trustHeader {
+			role = strings.TrimSpace(c.GetHeader(RoleHeader))
+		}
+		if role == "" && actor == "" {
+			role = cfg.AnonymousRole
+		}
+		if actor != "" {
+			c.Set(ActorContextKey, actor)
+		}
+		if role != "" {
+			c.Set(RoleContextKey, role)
+		}
+		c.Next()
+	}
+}
+
+// authenticate checks the bearer token or the API key of the request; both results are empty when
+// it carries neither
+func authenticate(c *gin.Context, cfg config.AuthConfig) (actor, role string, err error) {
+	return auth.Authenticate(c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader), cfg.JWTSecret, cfg.APIKeys, time.Now())
+}
+
+// AccessControlMiddleware enforces the policy of the route group a request matched: the path
+// segment after prefix names the group, and groups without a policy use reader:writer. Routes in
+// readRoutes ("METHOD /path" patterns) only read despite their method (e.g. filters taking a POST
+// body) and are held to the read role. Routes needing more (admin operations) add RequireRole.
+func AccessControlMiddleware(prefix string, policies map[string]auth.AccessPolicy, readRoutes map[string]bool, trustHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		group, _, _ := strings.Cut(strings.TrimPrefix(route, prefix+"/"), "/")
+		policy := auth.PolicyFor(policies, group)
+
+		required := policy.Write
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead:
+			required = policy.Read
+		default:
+			if readRoutes[c.Request.Method+" "+route] {
+				required = policy.Read
+			}
+		}
+
+		abortUnlessRole(c, callerRole(c, trustHeader), required)
+		if !c.IsAborted() {
+			c.Next()
+		}
+	}
+}
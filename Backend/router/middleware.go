@@ -0,0 +1,607 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"dataextractor/apispec"
+	"dataextractor/auth"
+	"dataextractor/logging"
+	"dataextractor/models"
+	"dataextractor/repository"
+	"dataextractor/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware enforces a maximum request body size and, for JSON bodies, a maximum nesting
+// depth. defaultMaxBytes applies to every route; overrides (keyed by "METHOD /route/pattern") raise
+// or lower the limit for specific endpoints such as batch/import. Nothing is buffered here: both
+// limits are checked while the handler reads the body, which then fails with *http.MaxBytesError
+// (answered with 413) or *JSONDepthError (answered with 400).
+func BodyLimitMiddleware(defaultMaxBytes int64, maxJSONDepth int, overrides map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		maxBytes := defaultMaxBytes
+		if limit, ok := overrides[c.Request.Method+" "+c.FullPath()]; ok {
+			maxBytes = limit
+		}
+
+		// Reject early when the client declares an oversized body
+		if maxBytes > 0 && c.Request.ContentLength > maxBytes {
+			abortBodyTooLarge(c, maxBytes)
+			return
+		}
+
+		if maxBytes > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		// Guard against deeply nested JSON payloads as the decoder reads them
+		if maxJSONDepth > 0 && isJSONContentType(c.ContentType()) {
+			c.Request.Body = &jsonDepthReader{ReadCloser: c.Request.Body, max: maxJSONDepth}
+		}
+		c.Next()
+	}
+}
+
+// abortBodyTooLarge writes the 413 error envelope
+func abortBodyTooLarge(c *gin.Context, maxBytes int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":   "Request body too large",
+		"details": fmt.Sprintf("Request body must not exceed %d bytes", maxBytes),
+	})
+}
+
+// isJSONContentType reports whether a media type (without parameters) is JSON
+func isJSONContentType(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// JSONDepthError is returned by reads of a JSON request body nested deeper than Limit
+type JSONDepthError struct {
+	Limit int
+}
+
+func (e *JSONDepthError) Error() string {
+	return fmt.Sprintf("JSON nesting depth exceeds the maximum of %d", e.Limit)
+}
+
+// jsonDepthReader tracks the object/array nesting of the JSON read through it and fails once it
+// exceeds max. It only tracks brackets outside string literals; syntax errors are left to the decoder.
+type jsonDepthReader struct {
+	io.ReadCloser
+	max               int
+	depth             int
+	inString, escaped bool
+	err               error
+}
+
+func (r *jsonDepthReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.ReadCloser.Read(p)
+	for _, b := range p[:n] {
+		if r.inString {
+			switch {
+			case r.escaped:
+				r.escaped = false
+			case b == '\\':
+				r.escaped = true
+			case b == '"':
+				r.inString = false
+			}
+			continue
+		}
+		switch b {
+		case '"':
+			r.inString = true
+		case '{', '[':
+			r.depth++
+			if r.depth > r.max {
+				r.err = &JSONDepthError{Limit: r.max}
+				return 0, r.err
+			}
+		case '}', ']':
+			r.depth--
+		}
+	}
+	return n, err
+}
+
+// RequestIDHeader carries the request ID; a valid incoming value (for example from a proxy) is
+// kept, otherwise one is generated, and it is echoed on the response
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDContextKey is the gin context key of the request ID
+const RequestIDContextKey = "request_id"
+
+// RequestIDMiddleware assigns every request an ID and stores it in the request context, where
+// logging.FromContext picks it up for access, service and repository log lines
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+		if !logging.ValidRequestID(id) {
+			id = logging.NewRequestID()
+		}
+		c.Set(RequestIDContextKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// AccessLogMiddleware logs one structured line per request with its method, path, route, status,
+// latency, response size and request ID, never logging skipPaths and logging requests to
+// sampledRoutes (matched against the route pattern) only at sampleRate. Error responses
+// (status >= 400) are always logged so sampling never hides failures.
+func AccessLogMiddleware(skipPaths []string, sampledRoutes []string, sampleRate float64) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = true
+	}
+	sampled := make(map[string]bool, len(sampledRoutes))
+	for _, r := range sampledRoutes {
+		sampled[r] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		status := c.Writer.Status()
+		if skip[path] {
+			return
+		}
+		if status < http.StatusBadRequest && sampled[c.FullPath()] && sampleRate < 1 && rand.Float64() >= sampleRate {
+			return
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		logging.FromContext(c.Request.Context()).LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// ActorContextKey is the gin context key an authentication middleware sets to the caller's
+// user or API key ID; ActorMiddleware propagates it to the request context for write attribution.
+const ActorContextKey = "actor"
+
+// ActorHeader carries the caller identity when requests arrive through a trusted authenticating proxy
+const ActorHeader = "X-Actor"
+
+// ActorMiddleware stores the authenticated actor in the request context so GORM hooks can stamp
+// created_by/updated_by. The X-Actor header is only honored when trustHeader is enabled.
+func ActorMiddleware(trustHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetString(ActorContextKey)
+		if actor == "" && trustHeader {
+			actor = strings.TrimSpace(c.GetHeader(ActorHeader))
+		}
+		if actor != "" {
+			c.Request = c.Request.WithContext(models.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
+
+// APIKeyHeader identifies the API client a request is metered under
+const APIKeyHeader = "X-API-Key"
+
+// UsageMeteringMiddleware hands every /api request to record once it has been answered, with the
+// client's key fingerprint (models.AnonymousClient without X-API-Key), the route pattern
+// ("unmatched" for unknown paths), the request and response body sizes and the status
+func UsageMeteringMiddleware(record func(keyHash, method, route string, bytesIn, bytesOut int64, status int)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+		c.Next()
+
+		keyHash := models.AnonymousClient
+		if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" {
+			keyHash = models.APIKeyFingerprint(key)
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		bytesIn := c.Request.ContentLength
+		if bytesIn < 0 {
+			bytesIn = 0
+		}
+		bytesOut := int64(c.Writer.Size())
+		if bytesOut < 0 {
+			bytesOut = 0
+		}
+		record(keyHash, c.Request.Method, route, bytesIn, bytesOut, c.Writer.Status())
+	}
+}
+
+// RequireDatabase answers 503 until connected reports true, so requests made while the server
+// runs degraded (database unreachable at startup, reconnecting in the background) fail cleanly
+func RequireDatabase(connected func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !connected() {
+			c.Header("Retry-After", "5")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "Service unavailable",
+				"details": "The database is not connected yet; see /health",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// PrimaryReadsMiddleware pins the reads of write requests (anything but GET, HEAD and OPTIONS) to
+// the primary database, so they see their own writes when reads are otherwise served by a replica
+func PrimaryReadsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			c.Request = c.Request.WithContext(repository.WithPrimary(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+// RoleContextKey is the gin context key an authentication middleware sets to the caller's role
+const RoleContextKey = "role"
+
+// RoleHeader carries the caller role when requests arrive through a trusted authenticating proxy
+const RoleHeader = "X-Role"
+
+// Roles, from least to most privileged (see auth.HasRole)
+const (
+	RoleReader = auth.RoleReader
+	RoleWriter = auth.RoleWriter
+	RoleAdmin  = auth.RoleAdmin
+)
+
+// RequireRole rejects the request unless the caller has role or a more privileged one: 401 when
+// the caller has no role at all, 403 otherwise. The role comes from RoleContextKey, or from the
+// X-Role header when trustHeader is enabled.
+func RequireRole(role string, trustHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		abortUnlessRole(c, callerRole(c, trustHeader), role)
+		if !c.IsAborted() {
+			c.Next()
+		}
+	}
+}
+
+// abortUnlessRole aborts the request when caller is not granted required
+func abortUnlessRole(c *gin.Context, caller, required string) {
+	if auth.HasRole(caller, required) {
+		return
+	}
+	if caller == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"details": "Authentication required (Authorization: Bearer token or X-API-Key)",
+		})
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"details": fmt.Sprintf("This operation requires the %s role", required),
+	})
+}
+
+// RequireRoleForFlag applies RequireRole only to requests that set the boolean query flag, for
+// modes of an otherwise open endpoint that expose internals (e.g. explain=true on the filter)
+func RequireRoleForFlag(flag, role string, trustHeader bool) gin.HandlerFunc {
+	require := RequireRole(role, trustHeader)
+	return func(c *gin.Context) {
+		if enabled, err := strconv.ParseBool(c.Query(flag)); err == nil && enabled {
+			require(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// callerRole returns the role set by an authentication middleware, falling back to the X-Role
+// header when trustHeader is enabled
+func callerRole(c *gin.Context, trustHeader bool) string {
+	role := c.GetString(RoleContextKey)
+	if role == "" && trustHeader {
+		role = strings.TrimSpace(c.GetHeader(RoleHeader))
+	}
+	return role
+}
+
+// DebugHeader opts a request into query diagnostics, like the debug=1 query parameter
+const DebugHeader = "X-Debug"
+
+// Query diagnostics response headers
+const (
+	QueryCountHeader       = "X-Query-Count"
+	QueryTimeHeader        = "X-Query-Time"
+	QuerySlowestHeader     = "X-Query-Slowest"
+	QuerySlowestTimeHeader = "X-Query-Slowest-Time"
+)
+
+// maxSlowestStatementLength truncates the slowest statement so the header stays within proxy limits
+const maxSlowestStatementLength = 512
+
+// QueryDiagnosticsMiddleware records the SQL statements run for admin requests that send debug=1
+// (or the X-Debug header) and reports their count, total DB time and the slowest statement in the
+// X-Query-* response headers, to catch N+1 regressions. Non-admin debug requests are served normally.
+// Headers are set when the response starts, so streamed responses only report the queries run up to
+// their first write.
+func QueryDiagnosticsMiddleware(trustHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !debugRequested(c) || callerRole(c, trustHeader) != RoleAdmin {
+			c.Next()
+			return
+		}
+
+		stats := &repository.QueryStats{}
+		c.Request = c.Request.WithContext(repository.WithQueryStats(c.Request.Context(), stats))
+		c.Writer = &queryStatsWriter{ResponseWriter: c.Writer, stats: stats}
+		c.Next()
+	}
+}
+
+// debugRequested reports whether the request opts into diagnostics
+func debugRequested(c *gin.Context) bool {
+	for _, value := range []string{c.Query("debug"), c.GetHeader(DebugHeader)} {
+		if value == "1" || strings.EqualFold(value, "true") {
+			return true
+		}
+	}
+	return false
+}
+
+// queryStatsWriter sets the query diagnostics headers just before the response is written
+type queryStatsWriter struct {
+	gin.ResponseWriter
+	stats   *repository.QueryStats
+	written bool
+}
+
+// setHeaders writes the diagnostics headers once, while headers can still be changed
+func (w *queryStatsWriter) setHeaders() {
+	if w.written || w.ResponseWriter.Written() {
+		return
+	}
+	w.written = true
+
+	snapshot := w.stats.Snapshot()
+	header := w.ResponseWriter.Header()
+	header.Set(QueryCountHeader, fmt.Sprintf("%d", snapshot.Count))
+	header.Set(QueryTimeHeader, snapshot.Total.String())
+	if snapshot.Slowest != "" {
+		slowest := snapshot.Slowest
+		if len(slowest) > maxSlowestStatementLength {
+			slowest = slowest[:maxSlowestStatementLength] + "..."
+		}
+		header.Set(QuerySlowestHeader, slowest)
+		header.Set(QuerySlowestTimeHeader, snapshot.SlowestTime.String())
+	}
+}
+
+func (w *queryStatsWriter) WriteHeaderNow() {
+	w.setHeaders()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *queryStatsWriter) Write(data []byte) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *queryStatsWriter) WriteString(s string) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Spec validation modes (SERVER_SPEC_VALIDATION)
+const (
+	SpecValidationOff     = "off"
+	SpecValidationLog     = "log"
+	SpecValidationEnforce = "enforce"
+)
+
+// maxValidatedResponseBytes caps how much of a response body is kept for validation; larger
+// (e.g. streamed) bodies only get their status checked
+const maxValidatedResponseBytes = 1 << 20
+
+// SpecValidationMiddleware checks API requests and responses against the OpenAPI document and logs
+// every mismatch, including API routes missing from the document. In enforce mode requests that do
+// not match are rejected with 400 before reaching the handler; responses are only ever logged.
+func SpecValidationMiddleware(spec *apispec.Spec, mode string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if !strings.HasPrefix(route, "/api/") {
+			c.Next()
+			return
+		}
+		op := spec.Operation(c.Request.Method, route)
+		if op == nil {
+			logging.FromContext(c.Request.Context()).Warn("OpenAPI drift: undocumented route", "method", c.Request.Method, "route", route)
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.Next()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		if violations := op.ValidateRequest(c.Request, pathParams, body); len(violations) > 0 {
+			logging.FromContext(c.Request.Context()).Warn("OpenAPI drift: request", "method", c.Request.Method, "route", route, "violations", violations)
+			if mode == SpecValidationEnforce {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error":   "Request does not match the API specification",
+					"details": strings.Join(violations, "; "),
+				})
+				return
+			}
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		responseBody := writer.body.Bytes()
+		if writer.truncated {
+			responseBody = nil
+		}
+		if violations := op.ValidateResponse(writer.Status(), writer.Header().Get("Content-Type"), responseBody); len(violations) > 0 {
+			logging.FromContext(c.Request.Context()).Warn("OpenAPI drift: response", "method", c.Request.Method, "route", route, "violations", violations)
+		}
+	}
+}
+
+// capturingWriter keeps a copy of the first maxValidatedResponseBytes of the response body
+type capturingWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *capturingWriter) capture(data []byte) {
+	if w.truncated || w.body.Len()+len(data) > maxValidatedResponseBytes {
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// CacheMiddleware adds Cache-Control, Last-Modified and ETag headers to GET responses and answers
+// conditional requests with 304 Not Modified while the data is unchanged. Validators come from
+// version (max(updated_at), the row count and the monotonic revision, so deletes and replaced rows
+// also invalidate). A zero ttl still emits validators but asks clients to revalidate on every use.
+// Responses are public only when shared is set, i.e. anyone may read them; otherwise proxies and
+// CDNs must not store them. When the version cannot be read the request is served uncached.
+func CacheMiddleware(ttl time.Duration, shared bool, version func() (repository.DataVersion, error)) gin.HandlerFunc {
+	scope := "private"
+	if shared {
+		scope = "public"
+	}
+	cacheControl := scope + ", no-cache"
+	if ttl > 0 {
+		cacheControl = fmt.Sprintf("%s, max-age=%d", scope, int(ttl.Seconds()))
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		current, err := version()
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		// HTTP dates have second precision
+		lastModified := current.LastModified.UTC().Truncate(time.Second)
+		etag := fmt.Sprintf(`W/"%d-%d-%d"`, current.LastModified.UnixMicro(), current.RowCount, current.Revision)
+
+		c.Header("Cache-Control", cacheControl)
+		c.Header("ETag", etag)
+		if !lastModified.IsZero() {
+			c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+		}
+
+		if notModified(c.Request, etag, lastModified) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+		c.Next()
+	}
+}
+
+// notModified evaluates If-None-Match, falling back to If-Modified-Since when it is absent (RFC 9110 13.2.2)
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if since, err := http.ParseTime(ims); err == nil {
+			return !lastModified.After(since)
+		}
+	}
+	return false
+}
+
+// PageParamsMiddleware parses and validates the page, per_page, sort_by and order query parameters
+// once for a list route, enforcing the per_page cap, and stores the result in the gin context under
+// validators.PageParamsContextKey (with the rules under validators.PageRulesContextKey)
+func PageParamsMiddleware(rules validators.PageRules) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params, err := validators.ParsePageParams(c.Query("page"), c.Query("per_page"), c.Query("sort_by"), c.Query("order"), rules)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid parameters",
+				"details": err.Error(),
+			})
+			return
+		}
+		c.Set(validators.PageParamsContextKey, params)
+		c.Set(validators.PageRulesContextKey, rules)
+		c.Next()
+	}
+}
@@ -1,103 +1,66 @@
 package router
 
 import (
-	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
+	"dataextractor/apispec"
+	"dataextractor/apperrors"
+	"dataextractor/auth"
+	"dataextractor/config"
 	"dataextractor/controller"
+	"dataextractor/logging"
+	"dataextractor/metrics"
+	"dataextractor/repository"
+	"dataextractor/validators"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/gorm"
+	"github.com/swaggo/swag"
 )
 
-// SetupRoutes configures all the API routes
-func SetupRoutes() *gin.Engine {
+// multipartMemory is how much of a multipart upload is kept in memory; the rest of the file is
+// written to a temporary file while it is received
+const multipartMemory = 1 << 20
+
+// SetupRoutes configures all the API routes using the provided controller and configuration
+func SetupRoutes(stockController *controller.StockController, cfg *config.AppConfig) *gin.Engine {
+	// Reject unknown JSON fields when strict decoding is enabled
+	binding.EnableDecoderDisallowUnknownFields = cfg.Server.StrictJSON
+
 	// Create Gin router without default middleware
 	router := gin.New()
+	// Uploads beyond this are spooled to temporary files instead of memory
+	router.MaxMultipartMemory = multipartMemory
+
+	// Tag every request with an ID that its access, service and repository log lines share
+	router.Use(RequestIDMiddleware())
 
-	// Add logger middleware
-	router.Use(gin.Logger())
+	// Add logger middleware, excluding probe traffic and sampling high-volume routes
+	router.Use(AccessLogMiddleware(cfg.Server.LogSkipPaths, cfg.Server.LogSampledRoutes, cfg.Server.LogSampleRate))
 
-	// Add custom recovery middleware to handle panics gracefully
+	// Add custom recovery middleware as a last resort: handlers return errors through
+	// respondError, so a panic here is a bug and is reported as an internal error unless it
+	// carries a typed application error
 	router.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		fmt.Printf("=== RECOVERY MIDDLEWARE TRIGGERED ===\n")
-		fmt.Printf("Recovered value: %v (type: %T)\n", recovered, recovered)
-		statusCode := http.StatusInternalServerError
-		errorType := "Internal server error"
-		details := "An unexpected error occurred"
-
-		// Handle different types of recovered values
-		switch err := recovered.(type) {
-		case error:
-			// Check for specific GORM errors first
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				statusCode = http.StatusNotFound
-				errorType = "Resource not found"
-				details = err.Error()
-			} else if errors.Is(err, gorm.ErrInvalidData) {
-				statusCode = http.StatusBadRequest
-				errorType = "Invalid data"
-				details = err.Error()
-			} else if errors.Is(err, gorm.ErrInvalidTransaction) {
-				statusCode = http.StatusBadRequest
-				errorType = "Invalid transaction"
-				details = err.Error()
-			} else {
-				// Check error message for common patterns
-				errMsg := err.Error()
-				if contains(errMsg, "not found") || contains(errMsg, "record not found") {
-					statusCode = http.StatusNotFound
-					errorType = "Resource not found"
-				} else if contains(errMsg, "invalid") || contains(errMsg, "validation") {
-					statusCode = http.StatusBadRequest
-					errorType = "Invalid request"
-				} else if contains(errMsg, "unauthorized") || contains(errMsg, "forbidden") {
-					statusCode = http.StatusUnauthorized
-					errorType = "Unauthorized"
-				}
-				details = errMsg
-			}
-		case string:
-			// Handle string errors
-			errMsg := err
-			if contains(errMsg, "not found") || contains(errMsg, "record not found") {
-				statusCode = http.StatusNotFound
-				errorType = "Resource not found"
-			} else if contains(errMsg, "invalid") || contains(errMsg, "validation") {
-				statusCode = http.StatusBadRequest
-				errorType = "Invalid request"
-			} else if contains(errMsg, "unauthorized") || contains(errMsg, "forbidden") {
-				statusCode = http.StatusUnauthorized
-				errorType = "Unauthorized"
-			}
-			details = errMsg
-		default:
-			// Handle any other type by converting to string
-			errMsg := fmt.Sprintf("%v", recovered)
-			if contains(errMsg, "not found") || contains(errMsg, "record not found") {
-				statusCode = http.StatusNotFound
-				errorType = "Resource not found"
-			} else if contains(errMsg, "invalid") || contains(errMsg, "validation") {
-				statusCode = http.StatusBadRequest
-				errorType = "Invalid request"
-			} else if contains(errMsg, "unauthorized") || contains(errMsg, "forbidden") {
-				statusCode = http.StatusUnauthorized
-				errorType = "Unauthorized"
-			}
-			details = errMsg
+		err, ok := recovered.(error)
+		if !ok {
+			err = fmt.Errorf("%v", recovered)
 		}
+		statusCode := apperrors.HTTPStatus(err)
+		errorType := apperrors.Title(err)
 
 		// Log the error for debugging
-		fmt.Printf("Recovery middleware caught panic: %v\n", recovered)
-		fmt.Printf("Status code: %d, Error type: %s, Details: %s\n", statusCode, errorType, details)
+		logging.FromContext(c.Request.Context()).Error("Recovered from panic",
+			"status", statusCode, "error_type", errorType, "error", err.Error())
 
 		c.JSON(statusCode, gin.H{
 			"error":   errorType,
-			"details": details,
+			"details": err.Error(),
 		})
 		c.Abort()
 	}))
@@ -106,7 +69,8 @@ func SetupRoutes() *gin.Engine {
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, If-None-Match, If-Modified-Since, If-Match, If-Unmodified-Since, X-Confirmation-Token, X-Debug, X-API-Key, X-Request-ID")
+		c.Header("Access-Control-Expose-Headers", "ETag, Last-Modified, X-Query-Count, X-Query-Time, X-Query-Slowest, X-Query-Slowest-Time, X-Request-ID")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusOK)
@@ -116,79 +80,369 @@ func SetupRoutes() *gin.Engine {
 		c.Next()
 	})
 
-	// Create stock controller
-	stockController := controller.NewStockController()
+	// Resolve the caller's identity and role from a bearer token or API key
+	router.Use(AuthMiddleware(cfg.Auth, cfg.Server.TrustActorHeader))
+
+	// Propagate the caller identity for created_by/updated_by attribution
+	router.Use(ActorMiddleware(cfg.Server.TrustActorHeader))
+
+	// Count requests, bytes and errors per API client and route for the usage report
+	if cfg.Server.UsageMetering {
+		router.Use(UsageMeteringMiddleware(stockController.RecordClientRequest))
+	}
+
+	// Keep the reads of write requests on the primary when a read replica serves the rest
+	router.Use(PrimaryReadsMiddleware())
+
+	// Report SQL query count and timing to admins that opt in with debug=1
+	router.Use(QueryDiagnosticsMiddleware(cfg.Server.TrustActorHeader))
+
+	// Conditional-request caching for read endpoints, validated against the data version. Shared
+	// caches may only store responses when every caller sees the same data without credentials.
+	sharedCache := cfg.Auth.AnonymousRole != "" && cfg.Auth.JWTSecret == "" && len(cfg.Auth.APIKeys) == 0 && !cfg.Server.TrustActorHeader
+	uniqueValuesCache := CacheMiddleware(cfg.Cache.UniqueValuesTTL, sharedCache, stockController.DataVersion)
+	statsCache := CacheMiddleware(cfg.Cache.StatsTTL, sharedCache, stockController.DataVersion)
+	clustersCache := CacheMiddleware(cfg.Cache.ClustersTTL, sharedCache, stockController.DataVersion)
+
+	// Shared page/sort parsing with per_page caps for list routes
+	listRules := validators.PageRules{
+		DefaultPerPage: cfg.Server.DefaultPerPage,
+		MaxPerPage:     cfg.Server.MaxPerPage,
+		DefaultSortBy:  "date",
+		SortColumns:    repository.ListSortColumns,
+	}
+	listParams := PageParamsMiddleware(listRules)
+	filterParams := PageParamsMiddleware(validators.PageRules{
+		DefaultPerPage: cfg.Server.DefaultPerPage,
+		MaxPerPage:     cfg.Server.MaxPerPage,
+		DefaultSortBy:  "date",
+		SortColumns:    repository.AllowedSortColumns,
+	})
+	extractionParams := PageParamsMiddleware(validators.PageRules{
+		DefaultPerPage: cfg.Server.DefaultPerPage,
+		MaxPerPage:     cfg.Server.MaxPerPage,
+		DefaultSortBy:  "recorded_at",
+		SortColumns:    repository.ExtractionPageSortColumns,
+	})
+
+	// explain=true on the cluster filter returns query plans, so it is reserved for admins
+	adminExplain := RequireRoleForFlag("explain", RoleAdmin, cfg.Server.TrustActorHeader)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
+		// JSON Schemas for request bodies
+		v1.GET("/schema", stockController.GetJSONSchemas) // GET /api/v1/schema
+
+		// Raw OpenAPI document of this version, the input of the client generator (cmd/sdkgen)
+		v1.GET("/openapi.json", openAPIDocument("v1")) // GET /api/v1/openapi.json
+
+		// Everything registered below needs the database: answer 503 while the server is degraded.
+		// Use only applies to routes added after it, so the documents above stay available.
+		v1.Use(RequireDatabase(stockController.DatabaseConnected))
+
+		// Role checks per route group: reads need reader and writes writer unless AUTH_GROUP_ROLES
+		// says otherwise; POST routes that only query are held to the read role
+		v1.Use(AccessControlMiddleware("/api/v1", auth.AccessPolicies(cfg.Auth.GroupRoles, map[string]auth.AccessPolicy{
+			"me": {Read: RoleReader, Write: RoleReader},
+		}), map[string]bool{
+			"POST /api/v1/exports":                               true,
+			"POST /api/v1/graphql":                               true,
+			"POST /api/v1/stocks/cluster/:cluster/filter":        true,
+			"POST /api/v1/stocks/cluster/:cluster/filter/export": true,
+		}, cfg.Server.TrustActorHeader))
+
+		// Enforce request body size and JSON depth limits once the caller passed the role checks;
+		// batch and upload endpoints get a larger budget
+		v1.Use(BodyLimitMiddleware(cfg.Server.MaxBodyBytes, cfg.Server.MaxJSONDepth, map[string]int64{
+			"POST /api/v1/stocks/batch":  cfg.Server.ImportMaxBodyBytes,
+			"POST /api/v1/stocks/import": cfg.Server.ImportMaxBodyBytes,
+		}))
+
+		// Report drift between the swagger annotations and the handlers outside production. It
+		// reads the whole body, so it runs behind the body limit.
+		if cfg.Server.SpecValidation != SpecValidationOff && cfg.AppEnv != "production" {
+			if spec, err := loadSpec("v1"); err != nil {
+				slog.Warn("OpenAPI validation disabled", "error", err)
+			} else {
+				v1.Use(SpecValidationMiddleware(spec, cfg.Server.SpecValidation))
+			}
+		}
+
+		// Global search (omnibox autocomplete)
+		v1.GET("/search", stockController.Search) // GET /api/v1/search
+
+		// Dataset versions (one per import run)
+		datasets := v1.Group("/datasets")
+		{
+			datasets.GET("", stockController.GetDatasetVersions)                 // GET /api/v1/datasets
+			datasets.POST("/:version/rollback", stockController.RollbackDataset) // POST /api/v1/datasets/:version/rollback
+		}
+
+		// Asynchronous export jobs, downloaded through signed links
+		exports := v1.Group("/exports")
+		{
+			exports.POST("", stockController.StartExport)                // POST /api/v1/exports
+			exports.GET("/:id", stockController.GetExportJob)            // GET /api/v1/exports/:id
+			exports.GET("/:id/download", stockController.DownloadExport) // GET /api/v1/exports/:id/download
+		}
+
+		// Archival of old or superseded records to the stock_archive table
+		archive := v1.Group("/archive")
+		{
+			archive.POST("", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.StartArchive) // POST /api/v1/archive
+			archive.GET("/:ticker", stockController.GetArchivedStocks)                                          // GET /api/v1/archive/:ticker
+		}
+
+		// GraphQL queries over the stock model; the stocks query is paged like the list routes
+		graphQL := stockController.GraphQLHandler(listRules)
+		v1.GET("/graphql", controller.GraphQLQuery(graphQL)) // GET /api/v1/graphql
+		v1.POST("/graphql", controller.GraphQLPost(graphQL)) // POST /api/v1/graphql
+
+		// Background jobs (extractions, archival)
+		v1.GET("/jobs/:id", stockController.GetJob) // GET /api/v1/jobs/:id
+
+		// WebSocket push of stock create/update/delete events
+		v1.GET("/ws", stockController.StreamStockEvents) // GET /api/v1/ws
+
+		// Preferences of the authenticated caller
+		me := v1.Group("/me")
+		{
+			me.GET("/preferences", stockController.GetPreferences)  // GET /api/v1/me/preferences
+			me.PUT("/preferences", stockController.SavePreferences) // PUT /api/v1/me/preferences
+		}
+
+		// Email/Slack channels that report failed jobs
+		v1.POST("/notifications/test", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.SendTestNotification) // POST /api/v1/notifications/test
+
+		// Signed callbacks for extraction jobs
+		webhooks := v1.Group("/webhooks", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader))
+		{
+			webhooks.POST("", stockController.CreateWebhookSubscription)       // POST /api/v1/webhooks
+			webhooks.GET("", stockController.GetWebhookSubscriptions)          // GET /api/v1/webhooks
+			webhooks.DELETE("/:id", stockController.DeleteWebhookSubscription) // DELETE /api/v1/webhooks/:id
+		}
+
+		// Database schema and size diagnostics for support
+		v1.GET("/admin/diagnostics", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.GetDiagnostics) // GET /api/v1/admin/diagnostics
+
+		// Requests, bytes and top endpoints per API client
+		v1.GET("/admin/usage", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.GetUsageReport) // GET /api/v1/admin/usage
+
+		// Rating rubric used to score sentiments
+		rubric := v1.Group("/rating-rubric")
+		{
+			rubric.GET("", stockController.GetRatingRubric)           // GET /api/v1/rating-rubric
+			rubric.POST("", stockController.CreateRatingRubric)       // POST /api/v1/rating-rubric
+			rubric.PUT("/:id", stockController.UpdateRatingRubric)    // PUT /api/v1/rating-rubric/:id
+			rubric.DELETE("/:id", stockController.DeleteRatingRubric) // DELETE /api/v1/rating-rubric/:id
+		}
+
+		// Rubric and weight profiles, promoted between environments as one JSON document
+		scoringConfig := v1.Group("/scoring-config", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader))
+		{
+			scoringConfig.GET("", stockController.ExportScoringConfig)         // GET /api/v1/scoring-config
+			scoringConfig.POST("/import", stockController.ImportScoringConfig) // POST /api/v1/scoring-config/import
+		}
+
 		// Stock routes
 		stocks := v1.Group("/stocks")
 		{
 			// CRUD operations
-			stocks.POST("", stockController.CreateStock)       // POST /api/v1/stocks
-			stocks.GET("", stockController.GetAllStocks)       // GET /api/v1/stocks
-			
+			stocks.POST("", stockController.CreateStock)             // POST /api/v1/stocks
+			stocks.POST("/batch", stockController.CreateStocksBatch) // POST /api/v1/stocks/batch
+			stocks.GET("", stockController.GetAllStocks)             // GET /api/v1/stocks
+			stocks.GET("/export", stockController.ExportAllStocks)   // GET /api/v1/stocks/export
+
 			// Table management operations - must come before /:id routes to avoid conflicts
-			stocks.DELETE("/tables", stockController.EmptyAllTables) // DELETE /api/v1/stocks/tables
-			
+			stocks.DELETE("/tables", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.EmptyAllTables) // DELETE /api/v1/stocks/tables
+			stocks.DELETE("/purge", stockController.PurgeStocks)                                                          // DELETE /api/v1/stocks/purge
+			stocks.GET("/integrity", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.GetIntegrity)   // GET /api/v1/stocks/integrity
+			stocks.DELETE("/orphans", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.DeleteOrphans) // DELETE /api/v1/stocks/orphans
+
 			// CRUD operations with ID - placed after specific routes
 			stocks.GET("/:id", stockController.GetStockByID)   // GET /api/v1/stocks/:id
 			stocks.PUT("/:id", stockController.UpdateStock)    // PUT /api/v1/stocks/:id
 			stocks.DELETE("/:id", stockController.DeleteStock) // DELETE /api/v1/stocks/:id
 
+			// Manual cluster overrides
+			stocks.PUT("/cluster", stockController.ReassignClusters)                  // PUT /api/v1/stocks/cluster
+			stocks.PUT("/:id/cluster", stockController.ReassignCluster)               // PUT /api/v1/stocks/:id/cluster
+			stocks.GET("/:id/cluster/history", stockController.GetClusterAssignments) // GET /api/v1/stocks/:id/cluster/history
+
+			// Cluster centroids used to place new stocks
+			stocks.GET("/clusters/centroids", stockController.GetCentroids)        // GET /api/v1/stocks/clusters/centroids
+			stocks.POST("/clusters/centroids", stockController.RecomputeCentroids) // POST /api/v1/stocks/clusters/centroids
+
+			// Analytics operations
+			stocks.GET("/analytics/heatmap", statsCache, stockController.GetClusterHeatmap)       // GET /api/v1/stocks/analytics/heatmap
+			stocks.GET("/analytics/dispersion", statsCache, stockController.GetClusterDispersion) // GET /api/v1/stocks/analytics/dispersion
+			stocks.GET("/movers", statsCache, stockController.GetTopMovers)                       // GET /api/v1/stocks/movers
+			stocks.GET("/:id/percentile", stockController.GetPercentiles)                         // GET /api/v1/stocks/:id/percentile
+
+			// Note operations
+			stocks.GET("/:id/notes", stockController.GetNotes)               // GET /api/v1/stocks/:id/notes
+			stocks.POST("/:id/notes", stockController.CreateNote)            // POST /api/v1/stocks/:id/notes
+			stocks.PUT("/:id/notes/:note_id", stockController.UpdateNote)    // PUT /api/v1/stocks/:id/notes/:note_id
+			stocks.DELETE("/:id/notes/:note_id", stockController.DeleteNote) // DELETE /api/v1/stocks/:id/notes/:note_id
+
+			// Indicator and sentiment operations
+			stocks.GET("/:id/indicators", stockController.GetIndicators)            // GET /api/v1/stocks/:id/indicators
+			stocks.POST("/:id/indicators", stockController.AddIndicator)            // POST /api/v1/stocks/:id/indicators
+			stocks.PUT("/:id/indicators/:name", stockController.UpdateIndicator)    // PUT /api/v1/stocks/:id/indicators/:name
+			stocks.DELETE("/:id/indicators/:name", stockController.DeleteIndicator) // DELETE /api/v1/stocks/:id/indicators/:name
+			stocks.GET("/:id/sentiments", stockController.GetSentiments)            // GET /api/v1/stocks/:id/sentiments
+			stocks.POST("/:id/sentiments", stockController.AddSentiment)            // POST /api/v1/stocks/:id/sentiments
+			stocks.PUT("/:id/sentiments/:name", stockController.UpdateSentiment)    // PUT /api/v1/stocks/:id/sentiments/:name
+			stocks.DELETE("/:id/sentiments/:name", stockController.DeleteSentiment) // DELETE /api/v1/stocks/:id/sentiments/:name
+
+			// Tag operations
+			stocks.GET("/tags", uniqueValuesCache, stockController.GetUniqueTags) // GET /api/v1/stocks/tags
+			stocks.POST("/:id/tags", stockController.TagStock)                    // POST /api/v1/stocks/:id/tags
+			stocks.DELETE("/:id/tags/:tag", stockController.UntagStock)           // DELETE /api/v1/stocks/:id/tags/:tag
+
 			// Find operations
-			stocks.GET("/ticker/:ticker", stockController.GetStockByTicker)                // GET /api/v1/stocks/ticker/:ticker
-			stocks.GET("/company/:company", stockController.GetStocksByCompany)            // GET /api/v1/stocks/company/:company
-			stocks.GET("/clusters", stockController.GetUniqueClusters)                     // GET /api/v1/stocks/clusters
-			stocks.GET("/cluster/:cluster", stockController.GetStocksByCluster)                  // GET /api/v1/stocks/cluster/:cluster
-			stocks.GET("/cluster/:cluster/filter", stockController.FilterByClusterGrouped)       // GET /api/v1/stocks/cluster/:cluster/filter
-			stocks.GET("/cluster/:cluster/unique/:column_name", stockController.GetUniqueByGroupSelectColumn) // GET /api/v1/stocks/cluster/:cluster/unique/:column_name
-			stocks.GET("/actions", stockController.GetUniqueActions)                             // GET /api/v1/stocks/actions
-			stocks.GET("/action/:action", stockController.GetStocksByAction)                     // GET /api/v1/stocks/action/:action
+			stocks.GET("/ticker/:ticker", stockController.GetStockByTicker)                                                      // GET /api/v1/stocks/ticker/:ticker
+			stocks.GET("/ticker/:ticker/moving-averages", stockController.GetMovingAverages)                                     // GET /api/v1/stocks/ticker/:ticker/moving-averages
+			stocks.GET("/ticker/:ticker/similar", stockController.GetSimilarStocks)                                              // GET /api/v1/stocks/ticker/:ticker/similar
+			stocks.GET("/ticker/:ticker/history", stockController.GetTickerHistory)                                              // GET /api/v1/stocks/ticker/:ticker/history
+			stocks.GET("/ticker/:ticker/consensus", stockController.GetConsensus)                                                // GET /api/v1/stocks/ticker/:ticker/consensus
+			stocks.GET("/company/:company", listParams, stockController.GetStocksByCompany)                                      // GET /api/v1/stocks/company/:company
+			stocks.GET("/companies", uniqueValuesCache, stockController.GetUniqueCompanies)                                      // GET /api/v1/stocks/companies
+			stocks.GET("/clusters", uniqueValuesCache, stockController.GetUniqueClusters)                                        // GET /api/v1/stocks/clusters
+			stocks.GET("/cluster/:cluster", clustersCache, listParams, stockController.GetStocksByCluster)                       // GET /api/v1/stocks/cluster/:cluster
+			stocks.GET("/cluster/:cluster/filter", filterParams, adminExplain, stockController.FilterByClusterGrouped)           // GET /api/v1/stocks/cluster/:cluster/filter
+			stocks.POST("/cluster/:cluster/filter", filterParams, adminExplain, stockController.FilterByClusterGroupedPost)      // POST /api/v1/stocks/cluster/:cluster/filter
+			stocks.GET("/cluster/:cluster/filter/export", stockController.ExportFilterByClusterGrouped)                          // GET /api/v1/stocks/cluster/:cluster/filter/export
+			stocks.POST("/cluster/:cluster/filter/export", stockController.ExportFilterByClusterGroupedPost)                     // POST /api/v1/stocks/cluster/:cluster/filter/export
+			stocks.GET("/cluster/:cluster/unique/:column_name", uniqueValuesCache, stockController.GetUniqueByGroupSelectColumn) // GET /api/v1/stocks/cluster/:cluster/unique/:column_name
+			stocks.GET("/actions", uniqueValuesCache, stockController.GetUniqueActions)                                          // GET /api/v1/stocks/actions
+			stocks.GET("/enums", uniqueValuesCache, stockController.GetEnumerations)                                             // GET /api/v1/stocks/enums
+			stocks.GET("/dictionary", stockController.GetDataDictionary)                                                         // GET /api/v1/stocks/dictionary
+			stocks.GET("/action/:action", listParams, stockController.GetStocksByAction)                                         // GET /api/v1/stocks/action/:action
 
 			// Statistics operations
-			stocks.GET("/stats/:ticker", stockController.GetStockStats)     // GET /api/v1/stocks/stats/:ticker
-			stocks.GET("/database/stats", stockController.GetDatabaseStats) // GET /api/v1/stocks/database/stats
+			stocks.GET("/stats/:ticker", statsCache, stockController.GetStockStats)     // GET /api/v1/stocks/stats/:ticker
+			stocks.GET("/database/stats", statsCache, stockController.GetDatabaseStats) // GET /api/v1/stocks/database/stats
+			stocks.GET("/meta", stockController.GetDataMeta)                            // GET /api/v1/stocks/meta
 
 			// Data extraction operations
-			stocks.POST("/extract", stockController.ExtractDataFromApi)        // POST /api/v1/stocks/extract
-			stocks.POST("/import-enriched", stockController.ImportEnrichedCSV) // POST /api/v1/stocks/import-enriched
+			stocks.POST("/extract", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.ExtractDataFromApi)                      // POST /api/v1/stocks/extract
+			stocks.GET("/extract/pages", extractionParams, stockController.GetExtractionPages)                                                    // GET /api/v1/stocks/extract/pages
+			stocks.GET("/extract/budget", stockController.GetExtractionBudget)                                                                    // GET /api/v1/stocks/extract/budget
+			stocks.POST("/extract/retry-failed", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.RetryFailedExtractionPages) // POST /api/v1/stocks/extract/retry-failed
+			stocks.GET("/extract/:job_id/events", stockController.StreamExtractionEvents)                                                         // GET /api/v1/stocks/extract/:job_id/events
+			stocks.POST("/import", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.ImportUploadedCSV)                        // POST /api/v1/stocks/import
+			stocks.POST("/import-enriched", RequireRole(RoleAdmin, cfg.Server.TrustActorHeader), stockController.ImportEnrichedCSV)               // POST /api/v1/stocks/import-enriched
 		}
 	}
 
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"message": "Stock API is running",
-		})
-	})
+	router.GET("/health", stockController.HealthCheck)
+	router.GET("/health/ready", stockController.ReadinessCheck)
+
+	// Prometheus scrape endpoint (repository call counts, errors and latencies)
+	router.GET("/metrics", gin.WrapH(metrics.Default.Handler()))
 
-	// Swagger documentation endpoint
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Swagger documentation, one generated document per API version (see docs/<version>)
+	swagger := router.Group("/swagger")
+	{
+		swagger.GET("/v1/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName("v1")))
+
+		// Keep the unversioned entry point working by sending it to the current version
+		swagger.GET("/index.html", func(c *gin.Context) {
+			c.Redirect(http.StatusMovedPermanently, "/swagger/v1/index.html")
+		})
+	}
 
-	// Root endpoint
-	router.GET("/", func(c *gin.Context) {
+	// API info endpoint
+	apiInfo := func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Stock Data Extractor API",
 			"version": "1.0.0",
 			"endpoints": gin.H{
-				"health":  "/health",
-				"api":     "/api/v1/stocks",
-				"extract": "/api/v1/stocks/extract",
-				"swagger": "/swagger/index.html",
+				"health":           "/health",
+				"readiness":        "/health/ready",
+				"metrics":          "/metrics",
+				"api":              "/api/v1/stocks",
+				"meta":             "/api/v1/stocks/meta",
+				"extract":          "/api/v1/stocks/extract",
+				"extraction_pages": "/api/v1/stocks/extract/pages",
+				"jobs":             "/api/v1/jobs/:id",
+				"ws":               "/api/v1/ws",
+				"webhooks":         "/api/v1/webhooks",
+				"swagger":          "/swagger/v1/index.html",
+				"openapi":          "/api/v1/openapi.json",
 			},
 		})
-	})
+	}
+	router.GET("/api", apiInfo)
+
+	// Return 405 (with the Allow header) for known paths requested with the wrong method
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(noMethodHandler)
+
+	// Root endpoint: the SPA when a frontend build is configured, otherwise the API info
+	if cfg.Server.StaticDir != "" {
+		registerStaticRoutes(router, cfg.Server.StaticDir)
+	} else {
+		router.GET("/", apiInfo)
+		router.NoRoute(noRouteHandler)
+	}
 
 	return router
 }
 
-// NewRouter creates a new router with the provided controller
-func NewRouter(stockController *controller.StockController) http.Handler { return SetupRoutes() }
+// NewRouter creates a new router with the provided controller and configuration
+func NewRouter(stockController *controller.StockController, cfg *config.AppConfig) http.Handler {
+	return SetupRoutes(stockController, cfg)
+}
+
+// noRouteHandler returns the standard JSON error envelope for unknown routes
+func noRouteHandler(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "Resource not found",
+		"details": fmt.Sprintf("No route matches %s %s", c.Request.Method, c.Request.URL.Path),
+	})
+}
+
+// loadSpec decodes the generated OpenAPI document registered under instanceName
+func loadSpec(instanceName string) (*apispec.Spec, error) {
+	doc, err := swag.ReadDoc(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	return apispec.Load([]byte(doc))
+}
 
-// contains checks if a string contains a substring (case-insensitive)
-func contains(s, substr string) bool {
-	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+// openAPIDocument serves the generated OpenAPI document registered under instanceName
+// @Summary Get the OpenAPI document
+// @Description The raw OpenAPI (Swagger 2.0) document of this API version, as used by the client generator (cmd/sdkgen) and the spec validation middleware
+// @Tags docs
+// @Produce json
+// @Success 200 {object} map[string]interface{} "OpenAPI document"
+// @Router /api/v1/openapi.json [get]
+func openAPIDocument(instanceName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, err := swag.ReadDoc(instanceName)
+		if err != nil {
+			err = apperrors.Wrap(err, "failed to read OpenAPI document")
+			c.JSON(apperrors.HTTPStatus(err), gin.H{
+				"error":   apperrors.Title(err),
+				"details": err.Error(),
+			})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
+	}
+}
+
+// noMethodHandler returns the standard JSON error envelope for unsupported methods on a known route
+func noMethodHandler(c *gin.Context) {
+	allowed := c.Writer.Header().Get("Allow")
+	c.JSON(http.StatusMethodNotAllowed, gin.H{
+		"error":           "Method not allowed",
+		"details":         fmt.Sprintf("Method %s is not allowed for %s", c.Request.Method, c.Request.URL.Path),
+		"allowed_methods": strings.Split(allowed, ", "),
+	})
 }
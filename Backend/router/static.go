@@ -0,0 +1,70 @@
+package router
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiPathPrefixes lists path prefixes owned by the API; they never fall back to the SPA
+var apiPathPrefixes = []string{"/api/", "/swagger/", "/health"}
+
+// isAPIPath reports whether the request path belongs to the API rather than the frontend
+func isAPIPath(path string) bool {
+	for _, prefix := range apiPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// staticHandler serves the built SPA from dir, falling back to index.html for
+// client-side (history mode) routes. It returns false when the request is not
+// a frontend request so the caller can respond with the API error instead.
+func staticHandler(dir string) func(c *gin.Context) bool {
+	root, _ := filepath.Abs(dir)
+	index := filepath.Join(root, "index.html")
+
+	return func(c *gin.Context) bool {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			return false
+		}
+		if isAPIPath(c.Request.URL.Path) {
+			return false
+		}
+
+		// Serve the requested asset if it exists inside the build directory
+		requested := filepath.Join(root, filepath.FromSlash(filepath.Clean("/"+c.Request.URL.Path)))
+		if strings.HasPrefix(requested, root) {
+			if info, err := os.Stat(requested); err == nil && !info.IsDir() {
+				c.File(requested)
+				return true
+			}
+		}
+
+		// Missing asset files (with an extension) are real 404s, not SPA routes
+		if filepath.Ext(c.Request.URL.Path) != "" {
+			return false
+		}
+
+		c.File(index)
+		return true
+	}
+}
+
+// registerStaticRoutes serves the SPA at / with history-mode fallback for unknown routes
+func registerStaticRoutes(router *gin.Engine, dir string) {
+	serve := staticHandler(dir)
+
+	router.GET("/", func(c *gin.Context) { serve(c) })
+	// Unmatched non-API GET requests fall through to the SPA; anything else gets the JSON 404
+	router.NoRoute(func(c *gin.Context) {
+		if !serve(c) {
+			noRouteHandler(c)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+// Package scoring is the single specification of the weighted score, shared by the SQL expression
+// the repository sorts and filters by and the Go evaluation the service ranks and explains with.
+//
+// The weighted score of a stock is the sum of weight x normalized value (norm_value of numerical
+// indicators, norm_rating_score of rating sentiments) over its child rows whose name equals a
+// weighted name, rounded to ScoreDecimals places:
+//   - names match exactly; callers canonicalize weight names with CanonicalName, and stored names
+//     are the lowercase registry names;
+//   - a child row matches the first weight with its name, so a name weighted twice keeps its first
+//     weight (the SQL CASE takes the first matching branch);
+//   - stocks without weighted child rows score 0;
+//   - the arithmetic is exact decimal arithmetic on the shortest decimal representation of each
+//     weight and value, as CockroachDB evaluates the DECIMAL columns times the numeric literals
+//     SQLLiteral renders, so the order the terms are added in does not matter;
+//   - the sum is rounded half away from zero, as ROUND(x::DECIMAL, n) does, and then converted to
+//     the nearest float64.
+//
+// Weights are rounded to WeightDecimals places once they are validated and normalized.
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"dataextractor/models"
+)
+
+// WeightDecimals is the number of decimal places weights are rounded to
+const WeightDecimals = 6
+
+// ScoreDecimals is the number of decimal places weighted scores are rounded to
+const ScoreDecimals = 6
+
+// Weight is the weight of one numerical indicator or rating sentiment
+type Weight struct {
+	Name  string
+	Value float64
+}
+
+// CanonicalName is the form weight names are matched in: trimmed and lowercase
+func CanonicalName(name string) string {
+	return strings.TrimSpace(strings.ToLower(name))
+}
+
+// RoundWeight rounds a weight to WeightDecimals places
+func RoundWeight(weight float64) float64 {
+	return Round(weight, WeightDecimals)
+}
+
+// RoundScore rounds a weighted score to ScoreDecimals places
+func RoundScore(score float64) float64 {
+	return Round(score, ScoreDecimals)
+}
+
+// Round rounds x to decimals places, half away from zero, working on the shortest decimal
+// representation of x rather than its binary value (0.1234565 rounds up to 0.123457 although the
+// nearest float64 is slightly below the tie). NaN and infinities are returned unchanged.
+func Round(x float64, decimals int) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	return roundDecimal(decimal(x), decimals)
+}
+
+// decimal is the exact value of the shortest decimal representation of x, which is the value
+// CockroachDB reads a DECIMAL column or numeric literal rendered from x as. NaN and infinities,
+// which neither can hold, count as 0.
+func decimal(x float64) *big.Rat {
+	value, ok := new(big.Rat).SetString(strconv.FormatFloat(x, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return value
+}
+
+// roundDecimal rounds an exact decimal value to decimals places, half away from zero, and returns
+// the nearest float64
+func roundDecimal(value *big.Rat, decimals int) float64 {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(scale))
+
+	// Truncate toward zero, then step away from zero when the dropped fraction is at least 1/2
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(scaled.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Num().Sign())))
+	}
+
+	rounded, _ := new(big.Rat).SetFrac(quotient, scale).Float64()
+	if rounded == 0 {
+		return 0 // no negative zero
+	}
+	return rounded
+}
+
+// Score is the weighted score of a stock from its preloaded children
+func Score(stock *models.StockDataPoint, indicators, sentiments []Weight) float64 {
+	sum := new(big.Rat)
+	for _, ni := range stock.NumericalIndicators {
+		if weight, ok := find(indicators, ni.Name); ok {
+			sum.Add(sum, term(weight, ni.NormValue))
+		}
+	}
+	for _, rs := range stock.RatingSentiments {
+		if weight, ok := find(sentiments, rs.Name); ok {
+			sum.Add(sum, term(weight, rs.NormRatingScore))
+		}
+	}
+	return roundDecimal(sum, ScoreDecimals)
+}
+
+// Contributions breaks the weighted score of a stock down per weighted name, each rounded to
+// ScoreDecimals places like the score. Names the stock has no child row for are left out.
+func Contributions(stock *models.StockDataPoint, indicators, sentiments []Weight) map[string]float64 {
+	sums := make(map[string]*big.Rat, len(indicators)+len(sentiments))
+	add := func(name string, value *big.Rat) {
+		if sum, ok := sums[name]; ok {
+			sum.Add(sum, value)
+		} else {
+			sums[name] = value
+		}
+	}
+	for _, ni := range stock.NumericalIndicators {
+		if weight, ok := find(indicators, ni.Name); ok {
+			add(ni.Name, term(weight, ni.NormValue))
+		}
+	}
+	for _, rs := range stock.RatingSentiments {
+		if weight, ok := find(sentiments, rs.Name); ok {
+			add(rs.Name, term(weight, rs.NormRatingScore))
+		}
+	}
+
+	contributions := make(map[string]float64, len(sums))
+	for name, sum := range sums {
+		contributions[name] = roundDecimal(sum, ScoreDecimals)
+	}
+	return contributions
+}
+
+// term is the exact product of a weight and a normalized value
+func term(weight, value float64) *big.Rat {
+	return new(big.Rat).Mul(decimal(weight), decimal(value))
+}
+
+// find returns the first weight of name
+func find(weights []Weight, name string) (float64, bool) {
+	for _, w := range weights {
+		if w.Name == name {
+			return w.Value, true
+		}
+	}
+	return 0, false
+}
+
+// SQLLiteral renders a weight as a SQL numeric literal: its shortest decimal representation
+func SQLLiteral(weight float64) string {
+	return strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+// SQLRoundScore wraps a SQL weighted score expression in the rounding Score applies
+func SQLRoundScore(expr string) string {
+	return fmt.Sprintf("ROUND((%s)::DECIMAL, %d)::FLOAT8", expr, ScoreDecimals)
+}
@@ -0,0 +1,87 @@
+package scoring
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"dataextractor/models"
+)
+
+// TestRound checks half-away-from-zero rounding on the shortest decimal representation
+func TestRound(t *testing.T) {
+	tests := []struct {
+		x        float64
+		decimals int
+		want     float64
+	}{
+		{0.1234565, 6, 0.123457}, // the float64 is just below the tie; its decimal form is the tie
+		{-0.1234565, 6, -0.123457},
+		{0.1234564999, 6, 0.123456},
+		{2.5, 0, 3},
+		{-2.5, 0, -3},
+		{1.0000005, 6, 1.000001},
+		{0.1 + 0.2, 6, 0.3},
+		{-0.0000001, 6, 0},
+		{123456789.1234567, 6, 123456789.123457},
+		{1e-300, 6, 0},
+		{42, 6, 42},
+	}
+	for _, tt := range tests {
+		if got := Round(tt.x, tt.decimals); got != tt.want {
+			t.Errorf("Round(%v, %d) = %v, want %v", tt.x, tt.decimals, got, tt.want)
+		}
+	}
+	if got := Round(-0.0000001, 6); math.Signbit(got) {
+		t.Error("Round returned negative zero")
+	}
+	if got := Round(math.NaN(), 6); !math.IsNaN(got) {
+		t.Errorf("Round(NaN) = %v", got)
+	}
+	if got := Round(math.Inf(-1), 6); !math.IsInf(got, -1) {
+		t.Errorf("Round(-Inf) = %v", got)
+	}
+}
+
+// TestScore checks exact name matching, first-weight-wins, unweighted rows and rounding
+func TestScore(t *testing.T) {
+	stock := &models.StockDataPoint{
+		NumericalIndicators: []models.NumericalIndicator{
+			{Name: "atr", NormValue: 0.5},
+			{Name: "obv", NormValue: 1},
+			{Name: "vwap", NormValue: 0.9}, // not weighted
+			{Name: "ATR", NormValue: 1},    // names match exactly
+		},
+		RatingSentiments: []models.RatingSentiment{
+			{Name: "action", NormRatingScore: 0.25},
+		},
+	}
+	indicators := []Weight{{Name: "atr", Value: 2}, {Name: "obv", Value: 0.1234567}, {Name: "atr", Value: 100}}
+	sentiments := []Weight{{Name: "action", Value: 4}}
+
+	if got, want := Score(stock, indicators, sentiments), 2.123457; got != want {
+		t.Errorf("Score() = %v, want %v", got, want)
+	}
+	if got := Score(&models.StockDataPoint{}, indicators, sentiments); got != 0 {
+		t.Errorf("Score(no children) = %v, want 0", got)
+	}
+
+	contributions := Contributions(stock, indicators, sentiments)
+	if len(contributions) != 3 || contributions["atr"] != 1 || contributions["obv"] != 0.123457 || contributions["action"] != 1 {
+		t.Errorf("Contributions() = %v", contributions)
+	}
+}
+
+// TestSQLLiteral checks that every weight is embedded in SQL as the same float64 Score multiplies by
+func TestSQLLiteral(t *testing.T) {
+	for _, w := range []float64{0.1, 1.0 / 3, RoundWeight(1.0 / 3), -0.5, 1e-7, 123456.654321, 0} {
+		literal := SQLLiteral(w)
+		if got, err := strconv.ParseFloat(literal, 64); err != nil || got != w {
+			t.Errorf("SQLLiteral(%v) = %q, parses back to %v (%v)", w, literal, got, err)
+		}
+	}
+	if got := SQLRoundScore("x + y"); !strings.HasPrefix(got, "ROUND((x + y)::DECIMAL, 6)") {
+		t.Errorf("SQLRoundScore() = %q", got)
+	}
+}
@@ -14,21 +14,84 @@
 // @BasePath /
 // @schemes http https
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT signed with AUTH_JWT_SECRET, sent as "Bearer <token>"; its role claim is checked against the route group's role
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description One of the AUTH_API_KEYS, with the role configured for it
+
+// Docs are generated per API version: swag init -g server.go -o docs/v1 --instanceName v1
+// go generate runs gqlgen for the GraphQL schema (graph/), then swag, then regenerates the
+// TypeScript and Go API clients from the document.
+
+//go:generate go run github.com/99designs/gqlgen generate
+//go:generate swag init -g server.go -o docs/v1 --instanceName v1
+//go:generate go run ./cmd/sdkgen
+
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	_ "dataextractor/docs"
+	"dataextractor/config"
+	"dataextractor/controller"
+	_ "dataextractor/docs/v1"
+	"dataextractor/grpcserver"
+	"dataextractor/logging"
+	"dataextractor/repository"
 	"dataextractor/router"
-	"dataextractor/utils"
+	"dataextractor/service"
 )
 
 func main() {
+	// Load configuration
+	cfg := config.LoadConfig()
+
+	// Log structured lines from here on; the standard log package is routed through the same logger
+	if err := logging.Setup(os.Stderr, cfg.AppLogFormat, cfg.AppLogLevel); err != nil {
+		log.Fatalf("Invalid logging configuration: %v", err)
+	}
+
+	// Wire dependencies: a single repository (and connection pool) shared by the service layer.
+	// If the database is not up yet the server starts degraded and the repository reconnects
+	// in the background.
+	repoFactory := repository.NewRepositoryFactory(cfg)
+	repo, connected := repoFactory.CreateDataRepository()
+	stockService := service.NewStockService(repo, cfg)
+	seedEnumerations := func() {
+		if err := stockService.LoadEnumerations(); err != nil {
+			slog.Warn("Could not seed enumerations from data", "error", err)
+		}
+		if err := stockService.WarmUp(); err != nil {
+			slog.Warn("Read cache warm-up failed", "error", err)
+		}
+	}
+	select {
+	case <-connected:
+		seedEnumerations()
+	default:
+		go func() {
+			<-connected
+			seedEnumerations()
+		}()
+	}
+	stockController := controller.NewStockController(stockService)
+
 	// Create routes
-	routes := router.SetupRoutes()
+	routes := router.NewRouter(stockController, cfg)
 
 	// Get port from environment variable or use default
 	port := os.Getenv("PORT")
@@ -42,11 +105,51 @@ func main() {
 		Handler: routes,
 	}
 
-	log.Printf("Starting server on port %s", port)
-	log.Printf("API Documentation available at: http://localhost:%s", port)
-	log.Printf("Health check available at: http://localhost:%s/health", port)
+	slog.Info("Starting server", "port", port,
+		"docs", "http://localhost:"+port,
+		"health", "http://localhost:"+port+"/health")
 
 	// Start server
-	err := server.ListenAndServe()
-	utils.ErrorPanic(err, "Failed to start server")
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// The gRPC API shares the service layer (and its caches) with the HTTP routes
+	grpcServer := grpcserver.NewServer(stockService, cfg)
+	if cfg.Server.GRPCPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		slog.Info("Starting gRPC server", "port", cfg.Server.GRPCPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
+	// Stop both servers on SIGINT/SIGTERM, letting in-flight requests finish
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+	slog.Info("Shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Warn("HTTP server shutdown", "error", err)
+	}
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
 }
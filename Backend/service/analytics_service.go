@@ -0,0 +1,243 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// Bounds for the number of neighbors returned by GetSimilarStocks
+const (
+	DefaultSimilarLimit = 10
+	MaxSimilarLimit     = 100
+)
+
+// Bounds for the number of stocks returned by GetTopMovers
+const (
+	DefaultMoversLimit = 20
+	MaxMoversLimit     = 100
+)
+
+// GetPercentiles ranks a stock's final_score and normalized indicators within a cluster;
+// when cluster is nil the stock's own cluster is used
+func (s *StockService) GetPercentiles(id uint, cluster *int) (*repository.StockPercentiles, error) {
+	stock, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+
+	target := stock.Cluster
+	if cluster != nil {
+		if *cluster < models.NoiseCluster {
+			return nil, apperrors.Validation("invalid cluster %d", *cluster)
+		}
+		target = *cluster
+	}
+
+	percentiles, err := s.repository.GetPercentileRanks(id, target)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to compute percentiles")
+	}
+	return percentiles, nil
+}
+
+// GetMovingAverages returns the 7/30/90 day rolling means of a ticker's indicators, keyed by
+// indicator name; all known indicators are used when none are requested
+func (s *StockService) GetMovingAverages(ticker string, indicators []string) (map[string][]repository.MovingAveragePoint, error) {
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
+
+	if len(indicators) == 0 {
+		indicators = models.NumericalIndicatorNames
+	}
+	for _, name := range indicators {
+		if !models.IsKnownNumericalIndicator(name) {
+			return nil, apperrors.Validation("unknown indicator %q: allowed values are %v", name, models.NumericalIndicatorNames)
+		}
+	}
+
+	points, err := s.repository.GetMovingAverages(ticker, indicators)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("failed to get moving averages for ticker %s", ticker))
+	}
+
+	series := make(map[string][]repository.MovingAveragePoint, len(indicators))
+	for _, point := range points {
+		series[point.Name] = append(series[point.Name], point)
+	}
+	return series, nil
+}
+
+// GetSimilarStocks finds the stocks in the same cluster as ticker whose normalized indicators are
+// closest by cosine distance; limit defaults to DefaultSimilarLimit when zero
+func (s *StockService) GetSimilarStocks(ticker string, limit int) ([]repository.SimilarStock, error) {
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
+	if limit == 0 {
+		limit = DefaultSimilarLimit
+	}
+	if limit < 1 || limit > MaxSimilarLimit {
+		return nil, apperrors.Validation("limit must be between 1 and %d", MaxSimilarLimit)
+	}
+
+	stock, err := s.repository.GetDataByTicker(ticker)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ticker %s not found", ticker))
+	}
+
+	neighbors, err := s.repository.GetSimilarStocks(stock.Ticker, stock.Cluster, limit)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to find similar stocks")
+	}
+	return neighbors, nil
+}
+
+// GetClusterHeatmap builds the cluster x dimension (action or rating_to) matrix of stock counts and
+// average final scores; empty combinations are zero
+func (s *StockService) GetClusterHeatmap(dimension string) (*Heatmap, error) {
+	if dimension == "" {
+		dimension = "action"
+	}
+
+	cells, err := s.repository.GetClusterHeatmap(dimension)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to build heatmap")
+	}
+
+	// Index the distinct clusters and values, both sorted (cells arrive ordered by cluster, value)
+	heatmap := &Heatmap{Dimension: dimension, Clusters: []int{}, Columns: []string{}, Cells: cells}
+	rowOf := map[int]int{}
+	colOf := map[string]int{}
+	for _, cell := range cells {
+		if _, ok := rowOf[cell.Cluster]; !ok {
+			rowOf[cell.Cluster] = len(heatmap.Clusters)
+			heatmap.Clusters = append(heatmap.Clusters, cell.Cluster)
+		}
+		if _, ok := colOf[cell.Value]; !ok {
+			colOf[cell.Value] = 0
+			heatmap.Columns = append(heatmap.Columns, cell.Value)
+		}
+	}
+	sort.Strings(heatmap.Columns)
+	for j, value := range heatmap.Columns {
+		colOf[value] = j
+	}
+
+	heatmap.Counts = make([][]int64, len(heatmap.Clusters))
+	heatmap.AvgScores = make([][]float64, len(heatmap.Clusters))
+	for i := range heatmap.Clusters {
+		heatmap.Counts[i] = make([]int64, len(heatmap.Columns))
+		heatmap.AvgScores[i] = make([]float64, len(heatmap.Columns))
+	}
+	for _, cell := range cells {
+		i, j := rowOf[cell.Cluster], colOf[cell.Value]
+		heatmap.Counts[i][j] = cell.Count
+		heatmap.AvgScores[i][j] = cell.AvgScore
+	}
+	return heatmap, nil
+}
+
+// GetClusterDispersion returns the median, standard deviation and IQR of target_delta and final_score
+// for every cluster, or only for cluster when it is set
+func (s *StockService) GetClusterDispersion(cluster *int) ([]repository.ClusterDispersion, error) {
+	if cluster != nil && *cluster < models.NoiseCluster {
+		return nil, apperrors.Validation("invalid cluster %d", *cluster)
+	}
+	if cluster == nil {
+		if dispersions, ok := s.cachedDispersion(); ok {
+			return dispersions, nil
+		}
+	}
+
+	dispersions, err := s.repository.GetClusterDispersion(cluster)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to compute cluster dispersion")
+	}
+
+	if cluster != nil && len(dispersions) == 0 {
+		return nil, apperrors.NotFound("cluster %d has no stocks", *cluster)
+	}
+	return dispersions, nil
+}
+
+// GetTopMovers returns the stocks whose metric (target_delta by default) moved furthest up or down,
+// optionally within a from/to (YYYY-MM-DD) date window; limit defaults to DefaultMoversLimit when zero
+func (s *StockService) GetTopMovers(metric, direction, from, to string, limit int) ([]models.StockDataPoint, error) {
+	if metric == "" {
+		metric = "target_delta"
+	}
+	if direction == "" {
+		direction = "up"
+	}
+	if direction != "up" && direction != "down" {
+		return nil, apperrors.Validation("invalid direction %q: allowed values are up, down", direction)
+	}
+	if limit == 0 {
+		limit = DefaultMoversLimit
+	}
+	if limit < 1 || limit > MaxMoversLimit {
+		return nil, apperrors.Validation("limit must be between 1 and %d", MaxMoversLimit)
+	}
+
+	fromDate, toDate, err := parseDateWindow(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	movers, err := s.repository.GetTopMovers(metric, direction == "up", fromDate, toDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top movers: %w", err)
+	}
+	return movers, nil
+}
+
+// consensusBuckets maps lower-cased analyst ratings to the Buy/Hold/Sell consensus buckets
+var consensusBuckets = map[string]string{
+	"buy": "buy", "strong-buy": "buy", "strong buy": "buy", "outperform": "buy", "market outperform": "buy",
+	"sector outperform": "buy", "overweight": "buy", "positive": "buy", "speculative buy": "buy", "accumulate": "buy",
+	"hold": "hold", "neutral": "hold", "equal weight": "hold", "equal-weight": "hold", "market perform": "hold",
+	"sector perform": "hold", "sector weight": "hold", "peer perform": "hold", "in-line": "hold", "inline": "hold",
+	"sell": "sell", "strong sell": "sell", "strong-sell": "sell", "underperform": "sell", "market underperform": "sell",
+	"sector underperform": "sell", "underweight": "sell", "negative": "sell", "reduce": "sell",
+}
+
+// GetConsensus aggregates the latest rating of each brokerage covering ticker into Buy/Hold/Sell
+// counts and a mean price target
+func (s *StockService) GetConsensus(ticker string) (*Consensus, error) {
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
+
+	ratings, err := s.repository.GetConsensusRatings(ticker)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("failed to get consensus for ticker %s", ticker))
+	}
+	if len(ratings) == 0 {
+		return nil, apperrors.NotFound("no ratings recorded for ticker %s", ticker)
+	}
+
+	consensus := &Consensus{Ticker: ticker, Ratings: ratings}
+	var targetSum float64
+	for _, rating := range ratings {
+		consensus.Brokerages += rating.Count
+		targetSum += rating.MeanTarget * float64(rating.Count)
+		switch consensusBuckets[strings.ToLower(strings.TrimSpace(rating.Rating))] {
+		case "buy":
+			consensus.Buy += rating.Count
+		case "hold":
+			consensus.Hold += rating.Count
+		case "sell":
+			consensus.Sell += rating.Count
+		default:
+			consensus.Other += rating.Count
+		}
+	}
+	consensus.MeanTarget = targetSum / float64(consensus.Brokerages)
+	return consensus, nil
+}
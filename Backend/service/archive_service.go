@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// StartArchive moves the records dated before the YYYY-MM-DD day before, in cluster, and/or last
+// written by dataset version dataset or by a version older than datasetBefore, to the stock archive
+// in the background; poll the job with GetJob. Without criteria the records older than
+// ARCHIVE_MAX_AGE are archived. The service should be bound to a context that outlives the request
+// (context.WithoutCancel).
+func (s *StockService) StartArchive(before string, cluster *int, dataset, datasetBefore uint) (*models.Job, error) {
+	cutoff, err := parseDateParam("before", before)
+	if err != nil {
+		return nil, err
+	}
+	scope := repository.StockScope{Cluster: cluster, Dataset: dataset, DatasetBefore: datasetBefore}
+	if cutoff == nil && scope.IsEmpty() {
+		if s.config.Archive.MaxAge <= 0 {
+			return nil, apperrors.Validation("at least one of before, cluster, dataset or dataset_before is required (ARCHIVE_MAX_AGE is not set)")
+		}
+		oldest := models.UsageDay(time.Now().Add(-s.config.Archive.MaxAge))
+		cutoff = &oldest
+	}
+	if cutoff != nil {
+		// Records dated strictly before the cutoff day
+		end := cutoff.Add(-time.Nanosecond)
+		scope.To = &end
+	}
+
+	job := &models.Job{Kind: models.JobArchive, Status: models.JobPending}
+	if err := s.repository.CreateJob(job); err != nil {
+		return nil, err
+	}
+	queued := *job
+
+	go s.runArchive(job, scope)
+	return &queued, nil
+}
+
+// runArchive moves the records in scope to the archive for an archival job, saving its progress
+// (batches as pages, records as items) after every batch
+func (s *StockService) runArchive(job *models.Job, scope repository.StockScope) {
+	startedAt := time.Now().UTC()
+	job.Status = models.JobRunning
+	job.StartedAt = &startedAt
+	s.saveJob(job)
+
+	s.logger.Info("Starting archival job", "job_id", job.ID, "scope", scope.String())
+	archived, err := s.archive(job, scope)
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.ItemsWritten = archived
+	if err != nil {
+		err = fmt.Errorf("error during archival: %w", err)
+		job.Status = models.JobFailed
+		job.Error = err.Error()
+		s.notifyFailure(fmt.Sprintf("Archival job %d failed", job.ID), err)
+	} else {
+		job.Status = models.JobComplete
+		s.logger.Info("Archival job completed", "job_id", job.ID, "archived", archived)
+	}
+	s.saveJob(job)
+
+	if archived > 0 {
+		s.refreshEnumerations()
+		s.publishReload("archive", int64(archived))
+	}
+}
+
+// archive runs the batched move, turning a panic into an error
+func (s *StockService) archive(job *models.Job, scope repository.StockScope) (archived int, err error) {
+	defer recoverJobPanic(&err)
+	return s.repository.ArchiveStocks(scope, s.config.Archive.BatchSize, job.ID, func(batches, total int) {
+		job.PagesProcessed = batches
+		job.ItemsWritten = total
+		s.saveJob(job)
+	})
+}
+
+// GetArchivedStocks returns the archived records of ticker, most recently archived first
+func (s *StockService) GetArchivedStocks(ticker string) ([]models.ArchivedStock, error) {
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
+	return s.repository.GetArchivedStocks(ticker)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+)
+
+// ReassignCluster overrides the cluster of one stock, recording an audit entry when it changes
+func (s *StockService) ReassignCluster(id uint, request *validators.ClusterAssignmentRequest) ([]models.ClusterAssignment, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+
+	assignments, err := s.repository.ReassignClusters([]string{stock.Ticker}, *request.Cluster, request.Reason)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to reassign cluster")
+	}
+	s.publishAssignments(assignments)
+	return assignments, nil
+}
+
+// ReassignClusters moves a list of tickers to a cluster in one transaction
+func (s *StockService) ReassignClusters(request *validators.BulkClusterAssignmentRequest) ([]models.ClusterAssignment, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	assignments, err := s.repository.ReassignClusters(request.Tickers, *request.Cluster, request.Reason)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to reassign clusters")
+	}
+	s.publishAssignments(assignments)
+	return assignments, nil
+}
+
+// publishAssignments publishes an update of every stock moved to another cluster
+func (s *StockService) publishAssignments(assignments []models.ClusterAssignment) {
+	for _, assignment := range assignments {
+		s.stockEvents.publish(StockEvent{Type: StockUpdated, Ticker: assignment.Ticker})
+	}
+}
+
+// GetClusterAssignments returns the manual cluster overrides of a stock, newest first
+func (s *StockService) GetClusterAssignments(id uint) ([]models.ClusterAssignment, error) {
+	_, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+
+	assignments, err := s.repository.GetClusterAssignments(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get cluster history")
+	}
+	return assignments, nil
+}
+
+// RecomputeCentroids recomputes and stores the centroid of every cluster from the current stocks
+func (s *StockService) RecomputeCentroids() ([]models.ClusterCentroid, error) {
+	centroids, err := s.repository.RecomputeCentroids()
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to recompute cluster centroids")
+	}
+	return centroids, nil
+}
+
+// GetCentroids returns the stored cluster centroids
+func (s *StockService) GetCentroids() ([]models.ClusterCentroid, error) {
+	centroids, err := s.repository.GetCentroids()
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get cluster centroids")
+	}
+	return centroids, nil
+}
+
+// centroidIndex maps a cluster to its centroid coordinates keyed by indicator name
+type centroidIndex map[int]map[string]float64
+
+// indexCentroids groups centroid rows by cluster
+func indexCentroids(centroids []models.ClusterCentroid) centroidIndex {
+	index := make(centroidIndex)
+	for _, centroid := range centroids {
+		if index[centroid.Cluster] == nil {
+			index[centroid.Cluster] = make(map[string]float64)
+		}
+		index[centroid.Cluster][centroid.Name] = centroid.NormValue
+	}
+	return index
+}
+
+// nearest returns the cluster whose centroid is closest to the normalized indicators, by mean
+// squared distance over the indicators both have (so centroids missing an indicator are not
+// favored). ok is false when no centroid shares an indicator with the stock. Ties go to the
+// lowest cluster id.
+func (index centroidIndex) nearest(indicators []models.NumericalIndicator) (cluster int, ok bool) {
+	clusters := make([]int, 0, len(index))
+	for c := range index {
+		clusters = append(clusters, c)
+	}
+	sort.Ints(clusters)
+
+	best := 0.0
+	for _, c := range clusters {
+		sum, shared := 0.0, 0
+		for _, indicator := range indicators {
+			if value, found := index[c][indicator.Name]; found {
+				diff := indicator.NormValue - value
+				sum += diff * diff
+				shared++
+			}
+		}
+		if shared == 0 {
+			continue
+		}
+		if distance := sum / float64(shared); !ok || distance < best {
+			cluster, best, ok = c, distance, true
+		}
+	}
+	return cluster, ok
+}
+
+// assign sets the stock's cluster to the nearest centroid, or to models.NoiseCluster when it
+// cannot be placed (no centroids yet, or no indicators in common)
+func (index centroidIndex) assign(stock *models.StockDataPoint) {
+	if cluster, ok := index.nearest(stock.NumericalIndicators); ok {
+		stock.Cluster = cluster
+		return
+	}
+	stock.Cluster = models.NoiseCluster
+}
+
+// loadCentroidIndex reads the stored centroids for nearest-centroid assignment
+func (s *StockService) loadCentroidIndex() (centroidIndex, error) {
+	centroids, err := s.repository.GetCentroids()
+	if err != nil {
+		return nil, err
+	}
+	return indexCentroids(centroids), nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// GetDatasetVersions lists the import runs, newest first
+func (s *StockService) GetDatasetVersions() ([]models.DatasetVersion, error) {
+	return s.repository.GetDatasetVersions()
+}
+
+// RollbackDataset reverts the data written by every import newer than version, restoring each
+// affected data point to its state as of version. Only complete versions can be rolled back to.
+func (s *StockService) RollbackDataset(version uint) (repository.DatasetRollback, error) {
+	target, err := s.repository.GetDatasetVersion(version)
+	if err != nil {
+		return repository.DatasetRollback{}, err
+	}
+	if target.Status != models.DatasetComplete {
+		return repository.DatasetRollback{}, apperrors.Validation("dataset version %d is %s; only complete versions can be rolled back to", version, target.Status)
+	}
+
+	result, err := s.repository.RollbackDataset(version)
+	if err != nil {
+		return repository.DatasetRollback{}, fmt.Errorf("failed to roll back to dataset version %d: %w", version, err)
+	}
+	s.refreshEnumerations()
+	s.publishReload("rollback", result.Restored+result.Deleted)
+	return result, nil
+}
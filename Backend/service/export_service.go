@@ -0,0 +1,162 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+)
+
+// ExportEncoder writes the stocks produced by run into w and returns the number of rows written
+type ExportEncoder func(w io.Writer, run func(emit func(models.StockDataPoint) error) (int, error)) (int, error)
+
+// StartExport registers an export job for the whole stock table and writes the file to the export
+// spool in the background; poll the job with GetExportJob. Expired jobs are pruned first. The
+// service should be bound to a context that outlives the request (context.WithoutCancel).
+func (s *StockService) StartExport(format string, encode ExportEncoder) (*models.ExportJob, error) {
+	s.pruneExportJobs()
+
+	if err := os.MkdirAll(s.config.Export.SpoolDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export spool %s: %w", s.config.Export.SpoolDir, err)
+	}
+	job := &models.ExportJob{Format: format, Status: models.ExportPending}
+	if err := s.repository.CreateExportJob(job); err != nil {
+		return nil, err
+	}
+	queued := *job
+
+	go s.runExport(job, encode)
+	return &queued, nil
+}
+
+// runExport writes the file of an export job and records the outcome; a failed job keeps no file
+func (s *StockService) runExport(job *models.ExportJob, encode ExportEncoder) {
+	job.Status = models.ExportRunning
+	job.Path = filepath.Join(s.config.Export.SpoolDir, fmt.Sprintf("export-%d.%s", job.ID, job.Format))
+	if err := s.repository.UpdateExportJob(job); err != nil {
+		s.logger.Warn("Failed to save export job", "export_id", job.ID, "error", err)
+	}
+
+	count, size, err := s.writeExportFile(job.Path, encode)
+	completedAt := time.Now().UTC()
+	expiresAt := completedAt.Add(s.config.Export.Retention)
+	job.RowCount = count
+	job.SizeBytes = size
+	job.CompletedAt = &completedAt
+	job.ExpiresAt = &expiresAt
+	if err != nil {
+		job.Status = models.ExportFailed
+		job.Error = err.Error()
+		if removeErr := os.Remove(job.Path); removeErr != nil && !os.IsNotExist(removeErr) {
+			s.logger.Warn("Failed to remove partial export", "path", job.Path, "error", removeErr)
+		}
+		job.Path = ""
+		s.notifyFailure(fmt.Sprintf("Export job %d failed", job.ID), fmt.Errorf("stopped after %d rows: %w", count, err))
+	} else {
+		job.Status = models.ExportComplete
+	}
+	if err := s.repository.UpdateExportJob(job); err != nil {
+		s.logger.Warn("Failed to save export job", "export_id", job.ID, "error", err)
+	}
+}
+
+// writeExportFile encodes every stock into path and returns the row count and file size
+func (s *StockService) writeExportFile(path string, encode ExportEncoder) (int, int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	count, err := encode(file, s.StreamAll)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", closeErr)
+	}
+	if err != nil {
+		return count, 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return count, 0, fmt.Errorf("failed to stat export file: %w", err)
+	}
+	return count, info.Size(), nil
+}
+
+// GetExportJob returns an export job
+func (s *StockService) GetExportJob(id uint) (*models.ExportJob, error) {
+	return s.repository.GetExportJob(id)
+}
+
+// SignExportDownload returns the expiry (unix seconds) and signature of a download link for a
+// finished export job. The link lives for the configured URL TTL, but never past the job's retention.
+func (s *StockService) SignExportDownload(job *models.ExportJob) (int64, string, error) {
+	if job.Status != models.ExportComplete {
+		return 0, "", apperrors.Conflict("export job %d is %s", job.ID, job.Status)
+	}
+	expiresAt := time.Now().Add(s.config.Export.URLTTL)
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(expiresAt) {
+		expiresAt = *job.ExpiresAt
+	}
+	return expiresAt.Unix(), s.signExportDownload(job.ID, expiresAt.Unix()), nil
+}
+
+// OpenExportDownload checks a signed download link and returns the finished job it points to
+func (s *StockService) OpenExportDownload(id uint, expires int64, signature string) (*models.ExportJob, error) {
+	if !hmac.Equal([]byte(signature), []byte(s.signExportDownload(id, expires))) {
+		return nil, apperrors.New(apperrors.KindUnauthorized, "unauthorized: invalid download signature")
+	}
+	if time.Now().Unix() > expires {
+		return nil, apperrors.New(apperrors.KindUnauthorized, "unauthorized: download link expired; request a new one from GET /api/v1/exports/%d", id)
+	}
+
+	job, err := s.repository.GetExportJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.ExportComplete {
+		return nil, apperrors.Conflict("export job %d is %s", job.ID, job.Status)
+	}
+	if _, err := os.Stat(job.Path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, apperrors.NotFound("export file of job %d no longer exists", job.ID)
+		}
+		return nil, fmt.Errorf("failed to open export file of job %d: %w", job.ID, err)
+	}
+	return job, nil
+}
+
+// signExportDownload renders the hex HMAC-SHA256 of an export job id and link expiry
+func (s *StockService) signExportDownload(id uint, expires int64) string {
+	mac := hmac.New(sha256.New, s.confirmSecret)
+	fmt.Fprintf(mac, "export|%d|%d", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// pruneExportJobs deletes the export jobs (and files) whose retention has ended
+func (s *StockService) pruneExportJobs() {
+	expired, err := s.repository.GetExpiredExportJobs(time.Now())
+	if err != nil {
+		s.logger.Warn("Failed to list expired export jobs", "error", err)
+		return
+	}
+	for _, job := range expired {
+		if job.Path != "" {
+			if err := os.Remove(job.Path); err != nil && !os.IsNotExist(err) {
+				s.logger.Warn("Failed to remove export file", "path", job.Path, "error", err)
+				continue
+			}
+		}
+		if err := s.repository.DeleteExportJob(job.ID); err != nil {
+			s.logger.Warn("Failed to delete expired export job", "export_id", job.ID, "error", err)
+		}
+	}
+	if len(expired) > 0 {
+		s.logger.Info("Pruned expired export jobs", "count", len(expired))
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/data_extractor"
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// GetExtractionPages returns one page of the extraction page-key history; status filters to
+// success or error entries when set
+func (s *StockService) GetExtractionPages(status string, opts repository.ListOptions) (PagedExtractionPages, error) {
+	if status != "" && status != models.ExtractionPageSuccess && status != models.ExtractionPageError && status != models.ExtractionPageRetried {
+		return PagedExtractionPages{}, apperrors.Validation("status must be %s, %s or %s", models.ExtractionPageSuccess, models.ExtractionPageError, models.ExtractionPageRetried)
+	}
+
+	pages, total, err := s.repository.GetExtractionPages(status, opts)
+	if err != nil {
+		return PagedExtractionPages{}, fmt.Errorf("failed to get extraction pages: %w", err)
+	}
+	return PagedExtractionPages{Items: pages, Pagination: newPagination(len(pages), total, opts.Page, opts.PerPage, opts.SortBy, opts.Order)}, nil
+}
+
+// ExtractionBudget is the upstream request budget of the configured API key for the current UTC day
+type ExtractionBudget struct {
+	Unlimited bool      `json:"unlimited"`
+	Quota     int       `json:"quota"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// QuotaExceededError refuses an extraction that could exceed the daily upstream request quota
+type QuotaExceededError struct {
+	Requested int
+	Budget    ExtractionBudget
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Requested == 0 {
+		return fmt.Sprintf("the daily upstream request quota of %d is used up; it resets at %s", e.Budget.Quota, e.Budget.ResetsAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("extracting %d pages needs up to %d upstream requests but only %d of the daily quota of %d remain", e.Requested, e.Requested, e.Budget.Remaining, e.Budget.Quota)
+}
+
+// Unwrap classifies the refusal as a 429
+func (e *QuotaExceededError) Unwrap() error {
+	return apperrors.ErrTooManyRequests
+}
+
+// GetExtractionBudget reports today's upstream requests for the configured API key against the quota
+func (s *StockService) GetExtractionBudget() (ExtractionBudget, error) {
+	today := models.UsageDay(time.Now())
+	used, err := s.repository.GetAPIUsage(models.APIKeyFingerprint(s.config.APIKey), today)
+	if err != nil {
+		return ExtractionBudget{}, err
+	}
+
+	budget := ExtractionBudget{Quota: s.config.Import.DailyRequestQuota, Used: used, ResetsAt: today.AddDate(0, 0, 1)}
+	if budget.Quota <= 0 {
+		budget.Unlimited = true
+		return budget, nil
+	}
+	budget.Remaining = max(budget.Quota-used, 0)
+	return budget, nil
+}
+
+// extractionPageLimit checks an extraction of maxPages pages (one upstream request each, 0 for all
+// pages) against the remaining daily budget. Unbounded runs are capped at the remaining budget;
+// bounded runs that could exceed it are refused.
+func (s *StockService) extractionPageLimit(maxPages int) (int, error) {
+	if s.config.Import.DailyRequestQuota <= 0 {
+		return maxPages, nil
+	}
+	budget, err := s.GetExtractionBudget()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case budget.Remaining == 0:
+		return 0, &QuotaExceededError{Budget: budget}
+	case maxPages > budget.Remaining:
+		return 0, &QuotaExceededError{Requested: maxPages, Budget: budget}
+	case maxPages == 0:
+		s.logger.Info("Limiting extraction to the pages left in today's upstream request quota", "pages", budget.Remaining)
+		return budget.Remaining, nil
+	}
+	return maxPages, nil
+}
+
+// ExtractionReplay summarizes a replay of the failed extraction pages
+type ExtractionReplay struct {
+	Pages      int      `json:"pages"`
+	Recovered  int      `json:"recovered"`
+	Items      int      `json:"items"`
+	FailedKeys []string `json:"failed_keys"`
+}
+
+// RetryFailedExtractionPages fetches again only the pages recorded with status error and appends
+// their items to the extraction CSV, instead of re-running the whole extraction. Recovered pages
+// are marked retried; pages that fail again keep status error for the next replay. Each page is
+// one upstream request, so replays that could exceed the daily quota are refused.
+func (s *StockService) RetryFailedExtractionPages() (ExtractionReplay, error) {
+	keys, err := s.repository.GetFailedExtractionPageKeys()
+	if err != nil {
+		return ExtractionReplay{}, err
+	}
+	replay := ExtractionReplay{Pages: len(keys), FailedKeys: []string{}}
+	if len(keys) == 0 {
+		return replay, nil
+	}
+	if _, err := s.extractionPageLimit(len(keys)); err != nil {
+		return ExtractionReplay{}, err
+	}
+
+	extractor := data_extractor.NewDataExtractor(s.config.APIBaseURL, s.config.APIKey, s.config.Import.Provider, s.repository)
+	extractor.Logger = s.logger
+	var lastErr error
+	for _, key := range keys {
+		written, err := extractor.ReplayPage(key)
+		if err != nil {
+			s.logger.Warn("Failed to replay extraction page", "page_key", key, "error", err)
+			replay.FailedKeys = append(replay.FailedKeys, key)
+			lastErr = err
+			continue
+		}
+		if err := s.repository.MarkExtractionPageRetried(key); err != nil {
+			s.logger.Warn("Failed to mark extraction page retried", "page_key", key, "error", err)
+		}
+		replay.Recovered++
+		replay.Items += written
+	}
+
+	s.logger.Info("Replayed failed extraction pages", "pages", replay.Pages, "recovered", replay.Recovered, "items", replay.Items)
+	if lastErr != nil {
+		s.notifyFailure("Extraction page replay failed", fmt.Errorf("%d of %d pages failed again, last error: %w", len(replay.FailedKeys), replay.Pages, lastErr))
+	}
+	return replay, nil
+}
+
+// pruneExtractionPages drops page-key history older than the configured retention. Failures are
+// only logged so they never fail the extraction run that triggered them.
+func (s *StockService) pruneExtractionPages() {
+	retention := s.config.Import.PageHistoryRetention
+	if retention <= 0 {
+		return
+	}
+	removed, err := s.repository.PruneExtractionPages(time.Now().Add(-retention))
+	if err != nil {
+		s.logger.Warn("Failed to prune extraction pages", "error", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.Info("Pruned extraction page entries", "count", removed, "older_than", retention.String())
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/utils"
+	"dataextractor/validators"
+)
+
+// maxGroupingSuggestions caps the suggestions returned for an unknown grouping value
+const maxGroupingSuggestions = 3
+
+// UnknownGroupingValueError rejects a grouping_value that no stock of the cluster has, with the
+// known values closest to it
+type UnknownGroupingValueError struct {
+	Cluster     int
+	Column      string
+	Value       string
+	Suggestions []string
+}
+
+func (e *UnknownGroupingValueError) Error() string {
+	message := fmt.Sprintf("grouping_value %q is not a known %s in cluster %d", e.Value, e.Column, e.Cluster)
+	if len(e.Suggestions) == 0 {
+		return message
+	}
+	quoted := make([]string, len(e.Suggestions))
+	for i, s := range e.Suggestions {
+		quoted[i] = "'" + s + "'"
+	}
+	return fmt.Sprintf("%s; did you mean %s?", message, strings.Join(quoted, " or "))
+}
+
+// Unwrap classifies the rejection as a 400
+func (e *UnknownGroupingValueError) Unwrap() error {
+	return apperrors.ErrValidation
+}
+
+type groupingValuesKey struct {
+	cluster int
+	column  string
+}
+
+type groupingValuesEntry struct {
+	values    []string
+	fetchedAt time.Time
+}
+
+// groupingValuesCache keeps the unique values of a grouping column per cluster for ttl, shared by
+// the request-scoped copies of the service
+type groupingValuesCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[groupingValuesKey]groupingValuesEntry
+}
+
+func newGroupingValuesCache(ttl time.Duration) *groupingValuesCache {
+	return &groupingValuesCache{ttl: ttl, entries: map[groupingValuesKey]groupingValuesEntry{}}
+}
+
+// get returns the cached values of key, if still fresh
+func (c *groupingValuesCache) get(key groupingValuesKey) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		return nil, false
+	}
+	return entry.values, true
+}
+
+// put caches values under key
+func (c *groupingValuesCache) put(key groupingValuesKey, values []string) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = groupingValuesEntry{values: values, fetchedAt: time.Now()}
+}
+
+// validateGroupingValue checks a filter's grouping value against the values present in the cluster.
+// Cached values are trusted for matches only: a value missing from them is checked against a fresh
+// read, so values added since the cache was filled are accepted.
+func (s *StockService) validateGroupingValue(cluster int, groupingColumn, groupingValue string) error {
+	if groupingColumn == "" || groupingColumn == validators.GroupingNone || groupingValue == "" {
+		return nil
+	}
+
+	key := groupingValuesKey{cluster: cluster, column: groupingColumn}
+	if values, ok := s.groupingValues.get(key); ok && containsString(values, groupingValue) {
+		return nil
+	}
+
+	values, err := s.GetUniqueByGroupSelectColumn(cluster, groupingColumn)
+	if err != nil {
+		return err
+	}
+	s.groupingValues.put(key, values)
+	if containsString(values, groupingValue) {
+		return nil
+	}
+	return &UnknownGroupingValueError{
+		Cluster:     cluster,
+		Column:      groupingColumn,
+		Value:       groupingValue,
+		Suggestions: utils.Suggest(groupingValue, values, maxGroupingSuggestions),
+	}
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
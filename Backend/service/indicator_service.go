@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+)
+
+// GetIndicators returns the numerical indicators of a stock
+func (s *StockService) GetIndicators(stockID uint) ([]models.NumericalIndicator, error) {
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	return stock.NumericalIndicators, nil
+}
+
+// AddIndicator adds a numerical indicator to a stock; a name the stock already has is a conflict
+func (s *StockService) AddIndicator(stockID uint, request *validators.NumericalIndicatorRequest) (*models.StockDataPoint, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	if indexOfIndicator(stock, request.Name) >= 0 {
+		return nil, apperrors.Conflict("stock %d already has indicator %q", stockID, request.Name)
+	}
+
+	stock.NumericalIndicators = append(stock.NumericalIndicators, models.NumericalIndicator{
+		Name:      request.Name,
+		Value:     request.Value,
+		NormValue: request.NormValue,
+	})
+	return s.saveScored(stock)
+}
+
+// UpdateIndicator replaces the values of the named indicator of a stock
+func (s *StockService) UpdateIndicator(stockID uint, name string, request *validators.NumericalIndicatorUpdateRequest) (*models.StockDataPoint, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	i := indexOfIndicator(stock, name)
+	if i < 0 {
+		return nil, apperrors.NotFound("indicator %q not found for stock %d", name, stockID)
+	}
+
+	stock.NumericalIndicators[i].Value = request.Value
+	stock.NumericalIndicators[i].NormValue = request.NormValue
+	return s.saveScored(stock)
+}
+
+// DeleteIndicator removes the named indicator of a stock and rescores it
+func (s *StockService) DeleteIndicator(stockID uint, name string) (*models.StockDataPoint, error) {
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	i := indexOfIndicator(stock, name)
+	if i < 0 {
+		return nil, apperrors.NotFound("indicator %q not found for stock %d", name, stockID)
+	}
+
+	stock.NumericalIndicators = append(stock.NumericalIndicators[:i], stock.NumericalIndicators[i+1:]...)
+	if err := s.rescore(stock); err != nil {
+		return nil, err
+	}
+	if err := s.repository.DeleteIndicator(stock, name); err != nil {
+		return nil, apperrors.Wrap(err, "failed to delete indicator")
+	}
+
+	s.logger.Info("Deleted indicator", "indicator", name, "ticker", stock.Ticker)
+	s.publishStock(StockUpdated, stock)
+	return stock, nil
+}
+
+// GetSentiments returns the rating sentiments of a stock
+func (s *StockService) GetSentiments(stockID uint) ([]models.RatingSentiment, error) {
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	return stock.RatingSentiments, nil
+}
+
+// AddSentiment adds a rating sentiment to a stock; a name the stock already has is a conflict
+func (s *StockService) AddSentiment(stockID uint, request *validators.RatingSentimentRequest) (*models.StockDataPoint, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	if indexOfSentiment(stock, request.Name) >= 0 {
+		return nil, apperrors.Conflict("stock %d already has sentiment %q", stockID, request.Name)
+	}
+
+	stock.RatingSentiments = append(stock.RatingSentiments, models.RatingSentiment{
+		Name:            request.Name,
+		Rating:          request.Rating,
+		RatingScore:     request.RatingScore,
+		NormRatingScore: request.NormRatingScore,
+	})
+	return s.saveScored(stock)
+}
+
+// UpdateSentiment replaces the rating and scores of the named sentiment of a stock
+func (s *StockService) UpdateSentiment(stockID uint, name string, request *validators.RatingSentimentUpdateRequest) (*models.StockDataPoint, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	i := indexOfSentiment(stock, name)
+	if i < 0 {
+		return nil, apperrors.NotFound("sentiment %q not found for stock %d", name, stockID)
+	}
+
+	stock.RatingSentiments[i].Rating = request.Rating
+	stock.RatingSentiments[i].RatingScore = request.RatingScore
+	stock.RatingSentiments[i].NormRatingScore = request.NormRatingScore
+	return s.saveScored(stock)
+}
+
+// DeleteSentiment removes the named sentiment of a stock and rescores it
+func (s *StockService) DeleteSentiment(stockID uint, name string) (*models.StockDataPoint, error) {
+	stock, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+	i := indexOfSentiment(stock, name)
+	if i < 0 {
+		return nil, apperrors.NotFound("sentiment %q not found for stock %d", name, stockID)
+	}
+
+	stock.RatingSentiments = append(stock.RatingSentiments[:i], stock.RatingSentiments[i+1:]...)
+	if err := s.rescore(stock); err != nil {
+		return nil, err
+	}
+	if err := s.repository.DeleteSentiment(stock, name); err != nil {
+		return nil, apperrors.Wrap(err, "failed to delete sentiment")
+	}
+
+	s.logger.Info("Deleted sentiment", "sentiment", name, "ticker", stock.Ticker)
+	s.publishStock(StockUpdated, stock)
+	return stock, nil
+}
+
+// rescore applies the rating rubric to the sentiments of stock and recalculates its final score,
+// as Update does after a full payload
+func (s *StockService) rescore(stock *models.StockDataPoint) error {
+	rubric, err := s.loadRatingRubric()
+	if err != nil {
+		return apperrors.Wrap(err, "failed to score sentiments")
+	}
+	rubric.score(stock.RatingSentiments)
+	s.recalculateFinalScore(stock)
+	return nil
+}
+
+// saveScored rescores stock and saves it with its sentiments and indicators
+func (s *StockService) saveScored(stock *models.StockDataPoint) (*models.StockDataPoint, error) {
+	if err := s.rescore(stock); err != nil {
+		return nil, err
+	}
+	updatedStock, err := s.repository.Update(stock)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to update stock")
+	}
+	s.publishStock(StockUpdated, updatedStock)
+	return updatedStock, nil
+}
+
+// indexOfIndicator returns the position of the named indicator of stock, or -1
+func indexOfIndicator(stock *models.StockDataPoint, name string) int {
+	for i := range stock.NumericalIndicators {
+		if stock.NumericalIndicators[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexOfSentiment returns the position of the named sentiment of stock, or -1
+func indexOfSentiment(stock *models.StockDataPoint, name string) int {
+	for i := range stock.RatingSentiments {
+		if stock.RatingSentiments[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
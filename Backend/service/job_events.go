@@ -0,0 +1,74 @@
+package service
+
+import (
+	"sync"
+
+	"dataextractor/data_extractor"
+)
+
+// jobEventBuffer is the number of events a slow subscriber may fall behind before events are dropped
+const jobEventBuffer = 64
+
+// JobEvent is a progress event of a running job; Type is the SSE event name ("page" or "error")
+type JobEvent struct {
+	Type     string
+	Progress data_extractor.ExtractionProgress
+}
+
+// jobEventHub fans out the progress events of running jobs to their subscribers. It is shared by
+// the request-scoped copies of the service. Events are not kept: a subscriber only sees what is
+// published after it subscribed, and reads the job itself for the state before that.
+type jobEventHub struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan JobEvent]struct{}
+}
+
+func newJobEventHub() *jobEventHub {
+	return &jobEventHub{subscribers: map[uint]map[chan JobEvent]struct{}{}}
+}
+
+// subscribe registers a subscriber to the events of job id. The channel is closed when the job
+// finishes; the returned function unsubscribes early.
+func (h *jobEventHub) subscribe(id uint) (<-chan JobEvent, func()) {
+	events := make(chan JobEvent, jobEventBuffer)
+	h.mu.Lock()
+	if h.subscribers[id] == nil {
+		h.subscribers[id] = map[chan JobEvent]struct{}{}
+	}
+	h.subscribers[id][events] = struct{}{}
+	h.mu.Unlock()
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[id][events]; ok {
+			delete(h.subscribers[id], events)
+			close(events)
+			if len(h.subscribers[id]) == 0 {
+				delete(h.subscribers, id)
+			}
+		}
+	}
+}
+
+// publish hands event to every subscriber of job id, dropping it for subscribers whose buffer is full
+func (h *jobEventHub) publish(id uint, event JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers[id] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// finish closes the channels of every subscriber of job id
+func (h *jobEventHub) finish(id uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers[id] {
+		close(events)
+	}
+	delete(h.subscribers, id)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"dataextractor/data_extractor"
+	"dataextractor/models"
+)
+
+// StartExtraction checks an extraction of maxPages pages (0 for all) against the daily upstream
+// request budget, registers it as a job and runs it in the background; poll the job with GetJob.
+// Runs that could exceed the budget are refused before a job is created. The service should be
+// bound to a context that outlives the request (context.WithoutCancel).
+func (s *StockService) StartExtraction(maxPages int) (*models.Job, error) {
+	maxPages, err := s.extractionPageLimit(maxPages)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{Kind: models.JobExtraction, Status: models.JobPending, MaxPages: maxPages}
+	if err := s.repository.CreateJob(job); err != nil {
+		return nil, err
+	}
+	queued := *job
+
+	go s.runExtraction(job)
+	return &queued, nil
+}
+
+// runExtraction crawls the upstream API for an extraction job, saving its progress after every
+// page and the outcome at the end
+func (s *StockService) runExtraction(job *models.Job) {
+	startedAt := time.Now().UTC()
+	job.Status = models.JobRunning
+	job.StartedAt = &startedAt
+	s.saveJob(job)
+
+	extractor := data_extractor.NewDataExtractor(s.config.APIBaseURL, s.config.APIKey, s.config.Import.Provider, s.repository)
+	extractor.Logger = s.logger
+	extractor.OnProgress = func(progress data_extractor.ExtractionProgress) {
+		if progress.Error != "" {
+			s.jobEvents.publish(job.ID, JobEvent{Type: "error", Progress: progress})
+			return
+		}
+		job.PagesProcessed = progress.Page
+		job.ItemsWritten = progress.TotalWritten
+		s.saveJob(job)
+		s.jobEvents.publish(job.ID, JobEvent{Type: "page", Progress: progress})
+	}
+
+	s.logger.Info("Starting data extraction job", "job_id", job.ID, "max_pages", job.MaxPages)
+	err := s.extract(extractor, job.MaxPages)
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	if err != nil {
+		err = fmt.Errorf("error during data extraction: %w", err)
+		job.Status = models.JobFailed
+		job.Error = err.Error()
+		s.notifyFailure(fmt.Sprintf("Data extraction job %d failed", job.ID), err)
+	} else {
+		job.Status = models.JobComplete
+		s.logger.Info("Data extraction job completed", "job_id", job.ID, "items", job.ItemsWritten, "pages", job.PagesProcessed)
+	}
+	s.saveJob(job)
+	s.jobEvents.finish(job.ID)
+
+	event := models.WebhookExtractionCompleted
+	if job.Status == models.JobFailed {
+		event = models.WebhookExtractionFailed
+	}
+	s.dispatchWebhook(event, job)
+}
+
+// extract runs the extractor, turning an unexpected panic into an error, since no recovery
+// middleware covers a background run
+func (s *StockService) extract(extractor *data_extractor.DataExtractor, maxPages int) (err error) {
+	defer s.pruneExtractionPages()
+	defer recoverJobPanic(&err)
+	return extractor.ExtractAndProcessAllPages(maxPages)
+}
+
+// recoverJobPanic, deferred by a background run, turns a panic into the run's error
+func recoverJobPanic(err *error) {
+	if recovered := recover(); recovered != nil {
+		if recoveredErr, ok := recovered.(error); ok {
+			*err = recoveredErr
+			return
+		}
+		*err = fmt.Errorf("%v", recovered)
+	}
+}
+
+// saveJob records the status and progress of a running job; failures are only logged so the run
+// itself carries on
+func (s *StockService) saveJob(job *models.Job) {
+	if err := s.repository.UpdateJob(job); err != nil {
+		s.logger.Warn("Failed to save job", "job_id", job.ID, "error", err)
+	}
+}
+
+// GetJob returns a background job
+func (s *StockService) GetJob(id uint) (*models.Job, error) {
+	return s.repository.GetJob(id)
+}
+
+// SubscribeJob returns the current state of a job and, while it is still pending or running, a
+// channel of its progress events that is closed when it finishes. The subscription is made before
+// the job is read, so no event between the two is lost. Call unsubscribe when done listening.
+func (s *StockService) SubscribeJob(id uint) (job *models.Job, events <-chan JobEvent, unsubscribe func(), err error) {
+	events, unsubscribe = s.jobEvents.subscribe(id)
+	job, err = s.repository.GetJob(id)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, nil, err
+	}
+	if job.Status == models.JobComplete || job.Status == models.JobFailed {
+		unsubscribe()
+		return job, nil, func() {}, nil
+	}
+	return job, events, unsubscribe, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+)
+
+// GetNotes returns the notes recorded for a stock, newest first
+func (s *StockService) GetNotes(stockID uint) ([]models.Note, error) {
+	_, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+
+	notes, err := s.repository.GetNotes(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get notes")
+	}
+	return notes, nil
+}
+
+// CreateNote adds a note to a stock
+func (s *StockService) CreateNote(stockID uint, request *validators.NoteRequest) (*models.Note, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	_, err := s.repository.ReadById(stockID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", stockID))
+	}
+
+	note, err := s.repository.CreateNote(&models.Note{
+		StockDataPointID: stockID,
+		Author:           request.Author,
+		Body:             request.Body,
+	})
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to create note")
+	}
+	return note, nil
+}
+
+// UpdateNote replaces the body of a stock note; the original author is kept
+func (s *StockService) UpdateNote(stockID, noteID uint, request *validators.NoteRequest) (*models.Note, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	note, err := s.repository.ReadNote(stockID, noteID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("note %d not found", noteID))
+	}
+
+	note.Body = request.Body
+	note, err = s.repository.UpdateNote(note)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to update note")
+	}
+	return note, nil
+}
+
+// DeleteNote removes a note from a stock
+func (s *StockService) DeleteNote(stockID, noteID uint) error {
+	note, err := s.repository.ReadNote(stockID, noteID)
+	if err != nil {
+		return apperrors.Wrap(err, fmt.Sprintf("note %d not found", noteID))
+	}
+
+	if err := s.repository.DeleteNote(note); err != nil {
+		return apperrors.Wrap(err, "failed to delete note")
+	}
+	return nil
+}
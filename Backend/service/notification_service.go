@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/notifications"
+	"dataextractor/validators"
+)
+
+// notifyFailure reports a failed job on every configured notification channel without blocking the caller
+func (s *StockService) notifyFailure(subject string, err error) {
+	s.notifier.Notify(notifications.Message{
+		Subject: subject,
+		Body:    fmt.Sprintf("%s at %s\n\n%v", subject, time.Now().UTC().Format(time.RFC3339), err),
+	})
+}
+
+// SendTestNotification sends a test message on the requested channel, or on every configured
+// channel when none is requested, and reports the outcome of each delivery
+func (s *StockService) SendTestNotification(request *validators.NotificationTestRequest) ([]notifications.Delivery, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	if len(s.notifier.Channels()) == 0 {
+		return nil, apperrors.Validation("no notification channel is configured")
+	}
+
+	deliveries, err := s.notifier.Send(context.Background(), request.Channel, notifications.Message{
+		Subject: "Test notification",
+		Body:    fmt.Sprintf("Test notification sent at %s. Failed imports and extractions will be reported here.", time.Now().UTC().Format(time.RFC3339)),
+	})
+	if errors.Is(err, notifications.ErrUnknownChannel) {
+		return nil, apperrors.Validation("%v (configured: %v)", err, s.notifier.Channels())
+	}
+	return deliveries, err
+}
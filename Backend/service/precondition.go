@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+)
+
+// WritePrecondition carries the If-Match and If-Unmodified-Since headers of a conditional write
+type WritePrecondition struct {
+	IfMatch           string
+	IfUnmodifiedSince string
+}
+
+// IsEmpty reports whether the write is unconditional
+func (p WritePrecondition) IsEmpty() bool {
+	return p.IfMatch == "" && p.IfUnmodifiedSince == ""
+}
+
+// StockETag is the strong entity tag of a stock, derived from its updated_at at the microsecond
+// precision the database stores
+func StockETag(stock *models.StockDataPoint) string {
+	return fmt.Sprintf(`"%d-%d"`, stock.ID, stock.UpdatedAt.UnixMicro())
+}
+
+// StockLastModified is the Last-Modified value of a stock (HTTP dates have second precision)
+func StockLastModified(stock *models.StockDataPoint) string {
+	return stock.UpdatedAt.UTC().Truncate(time.Second).Format(http.TimeFormat)
+}
+
+// check evaluates the precondition against the stored stock as in RFC 9110 13.2.2: If-Match when
+// present (strong comparison, * matches any stock), otherwise If-Unmodified-Since, which is ignored
+// when it is not a valid HTTP date
+func (p WritePrecondition) check(stock *models.StockDataPoint) error {
+	if p.IfMatch != "" {
+		etag := StockETag(stock)
+		for _, candidate := range strings.Split(p.IfMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || candidate == etag {
+				return nil
+			}
+		}
+		return apperrors.PreconditionFailed("stock %d does not match If-Match: its current ETag is %s", stock.ID, etag)
+	}
+
+	if p.IfUnmodifiedSince != "" {
+		since, err := http.ParseTime(p.IfUnmodifiedSince)
+		if err == nil && stock.UpdatedAt.UTC().Truncate(time.Second).After(since) {
+			return apperrors.PreconditionFailed("stock %d was modified at %s, after If-Unmodified-Since", stock.ID, StockLastModified(stock))
+		}
+	}
+	return nil
+}
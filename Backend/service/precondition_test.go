@@ -0,0 +1,96 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+)
+
+// TestWritePreconditionCheck checks If-Match (strong comparison, lists and *) and
+// If-Unmodified-Since (second precision, invalid dates ignored) against a stored stock
+func TestWritePreconditionCheck(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 3, 12, 0, 0, 500_000_000, time.UTC)
+	stock := &models.StockDataPoint{ID: 7, UpdatedAt: updatedAt}
+	etag := StockETag(stock)
+	httpDate := func(ts time.Time) string { return ts.Format(http.TimeFormat) }
+
+	tests := []struct {
+		name         string
+		precondition WritePrecondition
+		wantStatus   int // 0 when the write may proceed
+	}{
+		{
+			name:         "unconditional",
+			precondition: WritePrecondition{},
+		},
+		{
+			name:         "if-match current etag",
+			precondition: WritePrecondition{IfMatch: etag},
+		},
+		{
+			name:         "if-match stale etag",
+			precondition: WritePrecondition{IfMatch: `"7-1"`},
+			wantStatus:   http.StatusPreconditionFailed,
+		},
+		{
+			name:         "if-match any",
+			precondition: WritePrecondition{IfMatch: "*"},
+		},
+		{
+			name:         "if-match weak tag never matches",
+			precondition: WritePrecondition{IfMatch: "W/" + etag},
+			wantStatus:   http.StatusPreconditionFailed,
+		},
+		{
+			name:         "if-match list containing the etag",
+			precondition: WritePrecondition{IfMatch: `"7-1", ` + etag + ` , "8-2"`},
+		},
+		{
+			name:         "if-match list without the etag",
+			precondition: WritePrecondition{IfMatch: `"7-1", "8-2"`},
+			wantStatus:   http.StatusPreconditionFailed,
+		},
+		{
+			name:         "if-match takes precedence over if-unmodified-since",
+			precondition: WritePrecondition{IfMatch: etag, IfUnmodifiedSince: httpDate(updatedAt.Add(-time.Hour))},
+		},
+		{
+			name:         "unmodified since before updated_at",
+			precondition: WritePrecondition{IfUnmodifiedSince: httpDate(updatedAt.Add(-time.Second))},
+			wantStatus:   http.StatusPreconditionFailed,
+		},
+		{
+			name:         "unmodified since equal to updated_at truncated to seconds",
+			precondition: WritePrecondition{IfUnmodifiedSince: httpDate(updatedAt)},
+		},
+		{
+			name:         "unmodified since after updated_at",
+			precondition: WritePrecondition{IfUnmodifiedSince: httpDate(updatedAt.Add(time.Hour))},
+		},
+		{
+			name:         "invalid unmodified since is ignored",
+			precondition: WritePrecondition{IfUnmodifiedSince: "yesterday"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.precondition.check(stock)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("check() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("check() = nil, want status %d", tt.wantStatus)
+			}
+			if status := apperrors.HTTPStatus(err); status != tt.wantStatus {
+				t.Errorf("HTTPStatus(check()) = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/scoring"
+	"dataextractor/validators"
+)
+
+// ErrUnauthenticated is returned for per-user operations on requests without an authenticated actor
+var ErrUnauthenticated = apperrors.New(apperrors.KindUnauthorized, "unauthorized: an authenticated user is required")
+
+// Preferences are a user's dashboard defaults
+type Preferences struct {
+	UserID           string                     `json:"user_id"`
+	DefaultCluster   *int                       `json:"default_cluster"`
+	NumericalWeights []validators.WeightRequest `json:"numerical_weights"`
+	RatingWeights    []validators.WeightRequest `json:"rating_weights"`
+	UpdatedAt        *time.Time                 `json:"updated_at,omitempty"`
+}
+
+// GetPreferences returns the stored defaults of a user; a user without stored preferences gets
+// empty ones
+func (s *StockService) GetPreferences(userID string) (*Preferences, error) {
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	stored, err := s.repository.GetUserPreference(userID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return &Preferences{UserID: userID, NumericalWeights: []validators.WeightRequest{}, RatingWeights: []validators.WeightRequest{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preferencesFrom(stored)
+}
+
+// SavePreferences replaces the defaults of a user after checking the weights against the indicator
+// registry and the configured weight range
+func (s *StockService) SavePreferences(userID string, request *validators.PreferencesRequest) (*Preferences, error) {
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	numerical, err := s.canonicalWeights(request.NumericalWeights, models.IsKnownNumericalIndicator)
+	if err != nil {
+		return nil, fmt.Errorf("%w: numerical_weights: %v", ErrInvalidWeights, err)
+	}
+	rating, err := s.canonicalWeights(request.RatingWeights, models.IsKnownRatingSentiment)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rating_weights: %v", ErrInvalidWeights, err)
+	}
+
+	numericalJSON, err := json.Marshal(numerical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode numerical weights: %w", err)
+	}
+	ratingJSON, err := json.Marshal(rating)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rating weights: %w", err)
+	}
+
+	stored, err := s.repository.SaveUserPreference(&models.UserPreference{
+		UserID:           userID,
+		DefaultCluster:   request.DefaultCluster,
+		NumericalWeights: string(numericalJSON),
+		RatingWeights:    string(ratingJSON),
+	})
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to save preferences")
+	}
+	return preferencesFrom(stored)
+}
+
+// canonicalWeights validates weights and canonicalizes their names; the weights themselves are
+// stored as given and normalized (when configured) at query time like request weights
+func (s *StockService) canonicalWeights(weights []validators.WeightRequest, isKnown func(string) bool) ([]validators.WeightRequest, error) {
+	rules := s.weightRules()
+	canonical := make([]validators.WeightRequest, len(weights))
+	for i, w := range weights {
+		name := scoring.CanonicalName(w.IndicatorName)
+		if err := s.validator.ValidateWeight(name, w.Weight, isKnown, rules); err != nil {
+			return nil, err
+		}
+		canonical[i] = validators.WeightRequest{IndicatorName: name, Weight: w.Weight}
+	}
+	return canonical, nil
+}
+
+// preferencesFrom decodes the stored weight arrays
+func preferencesFrom(stored *models.UserPreference) (*Preferences, error) {
+	preferences := &Preferences{UserID: stored.UserID, DefaultCluster: stored.DefaultCluster, UpdatedAt: &stored.UpdatedAt}
+	if err := json.Unmarshal([]byte(stored.NumericalWeights), &preferences.NumericalWeights); err != nil {
+		return nil, fmt.Errorf("failed to decode numerical weights of user %s: %w", stored.UserID, err)
+	}
+	if err := json.Unmarshal([]byte(stored.RatingWeights), &preferences.RatingWeights); err != nil {
+		return nil, fmt.Errorf("failed to decode rating weights of user %s: %w", stored.UserID, err)
+	}
+	return preferences, nil
+}
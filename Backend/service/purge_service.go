@@ -0,0 +1,174 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/repository"
+)
+
+// wipeScope is the confirmation scope of EmptyAllTables
+const wipeScope = "all"
+
+// Confirmation scopes of DeleteOrphans, without and with the incomplete stocks
+const (
+	orphanScope           = "orphans"
+	orphanIncompleteScope = "orphans&incomplete"
+)
+
+// PurgeStocks deletes the data points in a cluster, dataset version and/or record date range.
+// At least one criterion is required. With dryRun only the matching rows are counted and a
+// confirmation token is issued; the delete itself must present a token for the same scope and
+// the same match count, so data that changed since the dry run is never deleted unseen.
+func (s *StockService) PurgeStocks(cluster *int, dataset uint, from, to string, dryRun bool, confirm string) (PurgeResult, error) {
+	fromDate, toDate, err := parseDateWindow(from, to)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	scope := repository.StockScope{Cluster: cluster, Dataset: dataset, From: fromDate, To: toDate}
+	if scope.IsEmpty() {
+		return PurgeResult{}, apperrors.Validation("at least one of cluster, dataset, from or to is required")
+	}
+
+	matched, err := s.repository.CountStocksInScope(scope)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	result := PurgeResult{Scope: scope.String(), DryRun: dryRun, Matched: matched}
+	if dryRun {
+		s.issueConfirmation(&result)
+		return result, nil
+	}
+	if err := s.checkConfirmation(confirm, result.Scope, matched); err != nil {
+		return PurgeResult{}, err
+	}
+
+	deleted, err := s.repository.DeleteStocksInScope(scope)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	s.logger.Info("Purged data points", "deleted", deleted, "scope", result.Scope)
+	result.Deleted = deleted
+	s.refreshEnumerations()
+	s.publishReload("purge", deleted)
+	return result, nil
+}
+
+// EmptyAllTables wipes every data table, under the same dry-run/confirmation protocol as PurgeStocks.
+// Matched is the total row count across the tables, which are listed individually in Tables.
+func (s *StockService) EmptyAllTables(dryRun bool, confirm string) (PurgeResult, error) {
+	tables, err := s.repository.CountAllTables()
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	var matched int64
+	for _, count := range tables {
+		matched += count
+	}
+	result := PurgeResult{Scope: wipeScope, DryRun: dryRun, Matched: matched, Tables: tables}
+	if dryRun {
+		s.issueConfirmation(&result)
+		return result, nil
+	}
+	if err := s.checkConfirmation(confirm, wipeScope, matched); err != nil {
+		return PurgeResult{}, err
+	}
+
+	if err := s.repository.EmptyAllTables(); err != nil {
+		return PurgeResult{}, fmt.Errorf("failed to empty all tables: %w", err)
+	}
+	result.Deleted = matched
+	s.publishReload("wipe", matched)
+	return result, nil
+}
+
+// CheckIntegrity counts orphaned sentiments and indicators and the stocks missing their children
+func (s *StockService) CheckIntegrity() (map[string]int64, error) {
+	return s.repository.CountIntegrityProblems()
+}
+
+// DeleteOrphans removes orphaned sentiments and indicators and, with incomplete, the stocks missing
+// their sentiments or indicators, under the same dry-run/confirmation protocol as PurgeStocks.
+// Tables lists the problem counts (after a delete, the deleted rows per kind).
+func (s *StockService) DeleteOrphans(incomplete, dryRun bool, confirm string) (PurgeResult, error) {
+	problems, err := s.repository.CountIntegrityProblems()
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	scope := orphanScope
+	matched := problems[repository.IntegrityOrphanedSentiments] + problems[repository.IntegrityOrphanedIndicators]
+	if incomplete {
+		scope = orphanIncompleteScope
+		matched += problems[repository.IntegrityIncompleteStocks]
+	}
+	result := PurgeResult{Scope: scope, DryRun: dryRun, Matched: matched, Tables: problems}
+	if dryRun {
+		s.issueConfirmation(&result)
+		return result, nil
+	}
+	if err := s.checkConfirmation(confirm, scope, matched); err != nil {
+		return PurgeResult{}, err
+	}
+
+	deleted, err := s.repository.DeleteOrphans(incomplete)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	result.Tables = deleted
+	for _, count := range deleted {
+		result.Deleted += count
+	}
+	s.logger.Info("Deleted orphaned or incomplete rows", "deleted", result.Deleted, "scope", scope)
+	if deleted[repository.IntegrityIncompleteStocks] > 0 {
+		s.refreshEnumerations()
+		s.publishReload("orphans", deleted[repository.IntegrityIncompleteStocks])
+	}
+	return result, nil
+}
+
+// issueConfirmation attaches a token binding the result's scope and match count until the configured TTL elapses
+func (s *StockService) issueConfirmation(result *PurgeResult) {
+	expiresAt := time.Now().Add(s.config.Server.ConfirmationTTL).UTC().Truncate(time.Second)
+	result.ConfirmationToken = s.signConfirmation(result.Scope, result.Matched, expiresAt.Unix())
+	result.ExpiresAt = &expiresAt
+}
+
+// checkConfirmation verifies a token issued by a dry run over the same scope and match count
+func (s *StockService) checkConfirmation(token, scope string, matched int64) error {
+	if token == "" {
+		return apperrors.Validation("a confirmation token is required; request a dry run first")
+	}
+	expiry, _, ok := strings.Cut(token, ".")
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if !ok || err != nil || !hmac.Equal([]byte(token), []byte(s.signConfirmation(scope, matched, expiresAt))) {
+		return apperrors.Validation("invalid confirmation token, or the matching data changed since the dry run")
+	}
+	if time.Now().Unix() > expiresAt {
+		return apperrors.Validation("confirmation token expired; request a new dry run")
+	}
+	return nil
+}
+
+// signConfirmation renders a token as "<unix expiry>.<hex HMAC-SHA256 of scope, count and expiry>"
+func (s *StockService) signConfirmation(scope string, matched int64, expiresAt int64) string {
+	mac := hmac.New(sha256.New, s.confirmSecret)
+	fmt.Fprintf(mac, "%s|%d|%d", scope, matched, expiresAt)
+	return strconv.FormatInt(expiresAt, 10) + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// confirmationSecret returns the configured signing key, or a random one when none is configured
+func confirmationSecret(configured string) []byte {
+	if configured != "" {
+		return []byte(configured)
+	}
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}
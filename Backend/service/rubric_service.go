@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+)
+
+// GetRatingRubric returns the rubric entries, optionally of one kind ("rating" or "action")
+func (s *StockService) GetRatingRubric(kind string) ([]models.RatingRubric, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind != "" && !isRubricKind(kind) {
+		return nil, apperrors.Validation("invalid rubric kind: %s. Allowed kinds: %v", kind, models.RubricKinds)
+	}
+	entries, err := s.repository.GetRatingRubric(kind)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get rating rubric")
+	}
+	return entries, nil
+}
+
+// CreateRatingRubric adds a term to the rubric
+func (s *StockService) CreateRatingRubric(request *validators.RatingRubricRequest) (*models.RatingRubric, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	entry, err := s.repository.CreateRatingRubric(&models.RatingRubric{
+		Kind:      request.Kind,
+		Term:      request.Term,
+		Score:     *request.Score,
+		NormScore: *request.NormScore,
+	})
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to create rating rubric entry")
+	}
+	return entry, nil
+}
+
+// UpdateRatingRubric replaces the kind, term and scores of a rubric entry
+func (s *StockService) UpdateRatingRubric(id uint, request *validators.RatingRubricRequest) (*models.RatingRubric, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	entry, err := s.repository.ReadRatingRubric(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("rating rubric entry %d not found", id))
+	}
+
+	entry.Kind = request.Kind
+	entry.Term = request.Term
+	entry.Score = *request.Score
+	entry.NormScore = *request.NormScore
+	entry, err = s.repository.UpdateRatingRubric(entry)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to update rating rubric entry")
+	}
+	return entry, nil
+}
+
+// DeleteRatingRubric removes a term from the rubric
+func (s *StockService) DeleteRatingRubric(id uint) error {
+	entry, err := s.repository.ReadRatingRubric(id)
+	if err != nil {
+		return apperrors.Wrap(err, fmt.Sprintf("rating rubric entry %d not found", id))
+	}
+
+	if err := s.repository.DeleteRatingRubric(entry); err != nil {
+		return apperrors.Wrap(err, "failed to delete rating rubric entry")
+	}
+	return nil
+}
+
+// isRubricKind reports whether kind is one of models.RubricKinds
+func isRubricKind(kind string) bool {
+	for _, k := range models.RubricKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ratingRubric indexes rubric entries by kind and term for sentiment scoring
+type ratingRubric map[string]map[string]models.RatingRubric
+
+// loadRatingRubric reads the whole rubric for scoring
+func (s *StockService) loadRatingRubric() (ratingRubric, error) {
+	entries, err := s.repository.GetRatingRubric("")
+	if err != nil {
+		return nil, err
+	}
+	rubric := make(ratingRubric)
+	for _, entry := range entries {
+		if rubric[entry.Kind] == nil {
+			rubric[entry.Kind] = make(map[string]models.RatingRubric)
+		}
+		rubric[entry.Kind][entry.Term] = entry
+	}
+	return rubric, nil
+}
+
+// score sets the raw and normalized score of every sentiment whose rating string is in the rubric.
+// The rubric is authoritative for the terms it lists; other sentiments keep the scores they carry.
+func (rubric ratingRubric) score(sentiments []models.RatingSentiment) {
+	for i := range sentiments {
+		terms := rubric[models.RubricKindOf(sentiments[i].Name)]
+		if entry, ok := terms[strings.ToLower(strings.TrimSpace(sentiments[i].Rating))]; ok {
+			sentiments[i].RatingScore = entry.Score
+			sentiments[i].NormRatingScore = entry.NormScore
+		}
+	}
+}
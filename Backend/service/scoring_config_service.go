@@ -0,0 +1,163 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+	"dataextractor/validators"
+)
+
+// scoringConfigVersion is the version of the scoring configuration document format
+const scoringConfigVersion = 1
+
+// DefaultWeightProfile is the weight profile configured through SCORING_DEFAULT_WEIGHT and
+// SCORING_DEFAULT_WEIGHTS. It is exported for comparison only; importing a document leaves it alone.
+type DefaultWeightProfile struct {
+	DefaultWeight float64            `json:"default_weight"`
+	Weights       map[string]float64 `json:"weights"`
+}
+
+// ScoringConfigDocument is the scoring configuration of an environment: the rating rubric, the
+// weight profiles of every user and the indicator names they refer to
+type ScoringConfigDocument struct {
+	Version        int                               `json:"version"`
+	ExportedAt     time.Time                         `json:"exported_at"`
+	Indicators     validators.ScoringIndicators      `json:"indicators"`
+	DefaultWeights DefaultWeightProfile              `json:"default_weights"`
+	RatingRubric   []validators.RatingRubricRequest  `json:"rating_rubric"`
+	WeightProfiles []validators.WeightProfileRequest `json:"weight_profiles"`
+}
+
+// ExportScoringConfig gathers the scoring configuration into a document that ImportScoringConfig
+// accepts on another environment
+func (s *StockService) ExportScoringConfig() (*ScoringConfigDocument, error) {
+	entries, err := s.repository.GetRatingRubric("")
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repository.GetUserPreferences()
+	if err != nil {
+		return nil, err
+	}
+
+	document := &ScoringConfigDocument{
+		Version:    scoringConfigVersion,
+		ExportedAt: time.Now().UTC(),
+		Indicators: validators.ScoringIndicators{
+			Numerical: models.NumericalIndicatorNames,
+			Rating:    models.RatingSentimentNames,
+		},
+		DefaultWeights: DefaultWeightProfile{
+			DefaultWeight: s.config.Scoring.DefaultWeight,
+			Weights:       s.config.Scoring.DefaultWeights,
+		},
+		RatingRubric:   make([]validators.RatingRubricRequest, len(entries)),
+		WeightProfiles: make([]validators.WeightProfileRequest, len(stored)),
+	}
+	if document.DefaultWeights.Weights == nil {
+		document.DefaultWeights.Weights = map[string]float64{}
+	}
+	for i, entry := range entries {
+		score, normScore := entry.Score, entry.NormScore
+		document.RatingRubric[i] = validators.RatingRubricRequest{Kind: entry.Kind, Term: entry.Term, Score: &score, NormScore: &normScore}
+	}
+	for i := range stored {
+		preferences, err := preferencesFrom(&stored[i])
+		if err != nil {
+			return nil, err
+		}
+		document.WeightProfiles[i] = validators.WeightProfileRequest{
+			UserID: preferences.UserID,
+			PreferencesRequest: validators.PreferencesRequest{
+				DefaultCluster:   preferences.DefaultCluster,
+				NumericalWeights: preferences.NumericalWeights,
+				RatingWeights:    preferences.RatingWeights,
+			},
+		}
+	}
+	return document, nil
+}
+
+// ImportScoringConfig validates a scoring configuration document as a whole, then writes its rubric
+// entries and weight profiles in one transaction, so a rejected document changes nothing. The
+// document must not name indicators this environment does not know. With replace, rubric entries
+// and weight profiles missing from the document are deleted.
+func (s *StockService) ImportScoringConfig(request *validators.ScoringConfigRequest, replace bool) (repository.ScoringConfigImport, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return repository.ScoringConfigImport{}, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	if unknown := unknownNames(request.Indicators.Numerical, models.IsKnownNumericalIndicator); len(unknown) > 0 {
+		return repository.ScoringConfigImport{}, apperrors.Validation("numerical indicators unknown to this environment: %s", strings.Join(unknown, ", "))
+	}
+	if unknown := unknownNames(request.Indicators.Rating, models.IsKnownRatingSentiment); len(unknown) > 0 {
+		return repository.ScoringConfigImport{}, apperrors.Validation("rating sentiments unknown to this environment: %s", strings.Join(unknown, ", "))
+	}
+
+	rubric := make([]models.RatingRubric, len(request.RatingRubric))
+	terms := make(map[string]bool, len(request.RatingRubric))
+	for i, entry := range request.RatingRubric {
+		key := entry.Kind + "/" + entry.Term
+		if terms[key] {
+			return repository.ScoringConfigImport{}, apperrors.Validation("rating_rubric[%d]: duplicate term %s", i, key)
+		}
+		terms[key] = true
+		rubric[i] = models.RatingRubric{Kind: entry.Kind, Term: entry.Term, Score: *entry.Score, NormScore: *entry.NormScore}
+	}
+
+	preferences := make([]models.UserPreference, len(request.WeightProfiles))
+	users := make(map[string]bool, len(request.WeightProfiles))
+	for i, profile := range request.WeightProfiles {
+		if users[profile.UserID] {
+			return repository.ScoringConfigImport{}, apperrors.Validation("weight_profiles[%d]: duplicate user %s", i, profile.UserID)
+		}
+		users[profile.UserID] = true
+
+		numerical, err := s.canonicalWeights(profile.NumericalWeights, models.IsKnownNumericalIndicator)
+		if err != nil {
+			return repository.ScoringConfigImport{}, fmt.Errorf("%w: weight_profiles[%d].numerical_weights: %v", ErrInvalidWeights, i, err)
+		}
+		rating, err := s.canonicalWeights(profile.RatingWeights, models.IsKnownRatingSentiment)
+		if err != nil {
+			return repository.ScoringConfigImport{}, fmt.Errorf("%w: weight_profiles[%d].rating_weights: %v", ErrInvalidWeights, i, err)
+		}
+		numericalJSON, err := json.Marshal(numerical)
+		if err != nil {
+			return repository.ScoringConfigImport{}, fmt.Errorf("failed to encode numerical weights: %w", err)
+		}
+		ratingJSON, err := json.Marshal(rating)
+		if err != nil {
+			return repository.ScoringConfigImport{}, fmt.Errorf("failed to encode rating weights: %w", err)
+		}
+		preferences[i] = models.UserPreference{
+			UserID:           profile.UserID,
+			DefaultCluster:   profile.DefaultCluster,
+			NumericalWeights: string(numericalJSON),
+			RatingWeights:    string(ratingJSON),
+		}
+	}
+
+	result, err := s.repository.ImportScoringConfig(rubric, preferences, replace)
+	if err != nil {
+		return repository.ScoringConfigImport{}, err
+	}
+	s.logger.Info("Imported scoring configuration", "rubric_entries", result.RubricEntries, "weight_profiles", result.WeightProfiles,
+		"replace", replace, "rubric_deleted", result.RubricDeleted, "profiles_deleted", result.ProfilesDeleted)
+	return result, nil
+}
+
+// unknownNames returns the names isKnown rejects
+func unknownNames(names []string, isKnown func(string) bool) []string {
+	var unknown []string
+	for _, name := range names {
+		if !isKnown(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
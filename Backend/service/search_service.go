@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"dataextractor/apperrors"
+	"dataextractor/repository"
+)
+
+// Bounds for the global search
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+	MaxSearchQueryLen  = 100
+)
+
+// Search matches query against tickers and companies for the dashboard omnibox, ranked by prefix
+// match quality; limit defaults to DefaultSearchLimit when zero
+func (s *StockService) Search(query string, limit int) ([]repository.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperrors.Validation("q is required")
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLen {
+		return nil, apperrors.Validation("q must be at most %d characters", MaxSearchQueryLen)
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		return nil, apperrors.Validation("limit must be between 1 and %d", MaxSearchLimit)
+	}
+
+	results, err := s.repository.SearchStocks(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search stocks: %w", err)
+	}
+	return results, nil
+}
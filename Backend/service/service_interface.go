@@ -1,57 +1,193 @@
 package service
 
 import (
+	"context"
+	"dataextractor/db_populate"
 	"dataextractor/models"
+	"dataextractor/notifications"
 	"dataextractor/repository"
 	"dataextractor/validators"
+	"dataextractor/webhooks"
 	"io"
+	"time"
 )
 
 // StockServiceInterface defines the contract for stock service operations
 type StockServiceInterface interface {
+	// WithContext returns a service whose repository calls run with ctx (e.g. to attribute writes)
+	WithContext(ctx context.Context) StockServiceInterface
+
+	// Database health: whether the startup connection is established, and a live round trip
+	DatabaseConnected() bool
+	PingDatabase(ctx context.Context) error
+	CheckReadiness(ctx context.Context) Readiness
+
+	// Identifier resolution (numeric ID or UUID)
+	ResolveID(identifier string) (uint, error)
+
 	// CRUD Operations
 	Create(request *validators.StockCreateRequest) (*models.StockDataPoint, error)
+	CreateBatch(requests []validators.StockCreateRequest) (BatchCreateResult, error)
 	GetByID(id uint) (*models.StockDataPoint, error)
 	GetAll() ([]models.StockDataPoint, error)
-	Update(request *validators.StockUpdateRequest) (*models.StockDataPoint, error)
-	Delete(id uint) error
+	StreamAll(emit func(models.StockDataPoint) error) (int, error)
+	StreamScope(cluster *int, dataset uint, from, to string, emit func(models.StockDataPoint) error) (int, error)
+	Update(request *validators.StockUpdateRequest, precondition WritePrecondition) (*models.StockDataPoint, error)
+	Delete(id uint, precondition WritePrecondition) error
+
+	// Note Operations
+	GetNotes(stockID uint) ([]models.Note, error)
+	CreateNote(stockID uint, request *validators.NoteRequest) (*models.Note, error)
+	UpdateNote(stockID, noteID uint, request *validators.NoteRequest) (*models.Note, error)
+	DeleteNote(stockID, noteID uint) error
+
+	// Indicator and Sentiment Operations
+	GetIndicators(stockID uint) ([]models.NumericalIndicator, error)
+	AddIndicator(stockID uint, request *validators.NumericalIndicatorRequest) (*models.StockDataPoint, error)
+	UpdateIndicator(stockID uint, name string, request *validators.NumericalIndicatorUpdateRequest) (*models.StockDataPoint, error)
+	DeleteIndicator(stockID uint, name string) (*models.StockDataPoint, error)
+	GetSentiments(stockID uint) ([]models.RatingSentiment, error)
+	AddSentiment(stockID uint, request *validators.RatingSentimentRequest) (*models.StockDataPoint, error)
+	UpdateSentiment(stockID uint, name string, request *validators.RatingSentimentUpdateRequest) (*models.StockDataPoint, error)
+	DeleteSentiment(stockID uint, name string) (*models.StockDataPoint, error)
 
 	// Find Operations
 	GetByTicker(ticker string) (*models.StockDataPoint, error)
-	GetByCompany(company string) ([]models.StockDataPoint, error)
-	GetStocksByCompany(company string) ([]models.StockDataPoint, error)
+	GetByCompany(company string, opts repository.ListOptions) (PagedGroupedResults, error)
+	GetStocksByCompany(company string, opts repository.ListOptions) (PagedGroupedResults, error)
 	GetUniqueCompanies() ([]string, error)
+	QueryStocks(query repository.StockQuery, opts repository.ListOptions) (PagedGroupedResults, error)
 
 	// Statistics Operations
-	GetStats(ticker string) (map[string]interface{}, error)
-	GetDatabaseStats() (map[string]interface{}, error)
+	GetStats(ticker string) (repository.TickerStats, error)
+	GetTickerHistory(ticker, from, to string) ([]models.StockSnapshot, error)
+
+	// Cluster Overrides
+	ReassignCluster(id uint, request *validators.ClusterAssignmentRequest) ([]models.ClusterAssignment, error)
+	ReassignClusters(request *validators.BulkClusterAssignmentRequest) ([]models.ClusterAssignment, error)
+	GetClusterAssignments(id uint) ([]models.ClusterAssignment, error)
+
+	// User Preferences
+	GetPreferences(userID string) (*Preferences, error)
+	SavePreferences(userID string, request *validators.PreferencesRequest) (*Preferences, error)
+	DefaultWeights() (numerical, rating []validators.WeightRequest)
+
+	// Rating Rubric
+	GetRatingRubric(kind string) ([]models.RatingRubric, error)
+	CreateRatingRubric(request *validators.RatingRubricRequest) (*models.RatingRubric, error)
+	UpdateRatingRubric(id uint, request *validators.RatingRubricRequest) (*models.RatingRubric, error)
+	DeleteRatingRubric(id uint) error
+
+	// Scoring configuration promotion (rubric and weight profiles)
+	ExportScoringConfig() (*ScoringConfigDocument, error)
+	ImportScoringConfig(request *validators.ScoringConfigRequest, replace bool) (repository.ScoringConfigImport, error)
+
+	// Cluster Centroids
+	RecomputeCentroids() ([]models.ClusterCentroid, error)
+	GetCentroids() ([]models.ClusterCentroid, error)
+
+	// Analytics Operations
+	GetPercentiles(id uint, cluster *int) (*repository.StockPercentiles, error)
+	GetMovingAverages(ticker string, indicators []string) (map[string][]repository.MovingAveragePoint, error)
+	GetSimilarStocks(ticker string, limit int) ([]repository.SimilarStock, error)
+	GetClusterHeatmap(dimension string) (*Heatmap, error)
+	GetClusterDispersion(cluster *int) ([]repository.ClusterDispersion, error)
+	GetTopMovers(metric, direction, from, to string, limit int) ([]models.StockDataPoint, error)
+	GetConsensus(ticker string) (*Consensus, error)
+
+	// Search Operations
+	Search(query string, limit int) ([]repository.SearchResult, error)
+	GetDatabaseStats() (repository.DatabaseStats, error)
+	GetDataVersion() (repository.DataVersion, error)
+	GetDataMeta() (DataMeta, error)
+	GetDiagnostics() (repository.Diagnostics, error)
+
+	// Archival
+	StartArchive(before string, cluster *int, dataset, datasetBefore uint) (*models.Job, error)
+	GetArchivedStocks(ticker string) ([]models.ArchivedStock, error)
+
+	// Webhook subscriptions
+	CreateWebhookSubscription(request *validators.WebhookSubscriptionRequest) (*WebhookSubscriptionCreated, error)
+	GetWebhookSubscriptions() ([]models.WebhookSubscription, error)
+	DeleteWebhookSubscription(id uint) error
+	WebhookVerification() webhooks.VerificationScheme
+
+	// Stock change events
+	SubscribeStockEvents() (<-chan StockEvent, func())
+
+	// Client usage metering
+	RecordClientRequest(keyHash, method, route string, bytesIn, bytesOut int64, status int)
+	GetUsageReport(days, top int) (*UsageReport, error)
 
 	// Data Extraction Operations
-	StoreDataFromApi(maxPages int) error
+	StartExtraction(maxPages int) (*models.Job, error)
+	GetExtractionBudget() (ExtractionBudget, error)
+	GetExtractionPages(status string, opts repository.ListOptions) (PagedExtractionPages, error)
+	RetryFailedExtractionPages() (ExtractionReplay, error)
+
+	// Export Job Operations
+	StartExport(format string, encode ExportEncoder) (*models.ExportJob, error)
+	GetExportJob(id uint) (*models.ExportJob, error)
+	SignExportDownload(job *models.ExportJob) (int64, string, error)
+	OpenExportDownload(id uint, expires int64, signature string) (*models.ExportJob, error)
+
+	// Background Job Operations
+	GetJob(id uint) (*models.Job, error)
+	SubscribeJob(id uint) (job *models.Job, events <-chan JobEvent, unsubscribe func(), err error)
+
+	// Notification Operations
+	SendTestNotification(request *validators.NotificationTestRequest) ([]notifications.Delivery, error)
 
 	// Cluster Operations
 	GetUniqueClusters() ([]int, error)
-	GetStocksByCluster(cluster int) ([]models.StockDataPoint, error)
+	GetStocksByCluster(cluster int, opts repository.ListOptions) (PagedGroupedResults, error)
 
 	// Action Operations
 	GetUniqueActions() ([]string, error)
-	GetStocksByAction(action string) ([]models.StockDataPoint, error)
+
+	// Tag Operations
+	GetUniqueTags() ([]string, error)
+	TagStock(id uint, request *validators.TagRequest) (*models.StockDataPoint, error)
+	UntagStock(id uint, tag string) (*models.StockDataPoint, error)
+	GetStocksByAction(action string, opts repository.ListOptions) (PagedGroupedResults, error)
+
+	// Enumerations of allowed action/rating values
+	LoadEnumerations() error
+
+	// Precomputes the dashboard's first reads into memory (CACHE_WARMUP)
+	WarmUp() error
+	GetEnumerations() map[string][]string
+
+	// Data dictionary
+	GetDataDictionary() (DataDictionary, error)
 
 	// CSV Import
 	ImportFromCSV(reader io.Reader) (int, error)
-	ImportFromEnrichedCSV() (int, error)
+	ImportFile(source string, file io.ReadSeeker, force bool) (ImportResult, error)
+	ImportUpload(filename string, file io.ReadSeeker, force bool) (ImportResult, error)
+	ImportFromEnrichedCSV(force bool) (ImportResult, error)
+
+	// Dataset Versions
+	GetDatasetVersions() ([]models.DatasetVersion, error)
+	RollbackDataset(version uint) (repository.DatasetRollback, error)
 
 	// Scoring Operations
 	RankByWeightedScore(cluster int, weights []WeightEntry) ([]RankedResult, error)
 
 	// Grouped, paginated, sortable filter by cluster
-	FilterByClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry) (PagedGroupedResults, error)
+	FilterByClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter, preload repository.PreloadMode, contributions bool) (PagedGroupedResults, error)
+	AggregateClusterGrouped(cluster int, groupingColumn string, groupingValue string, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter) (GroupedAggregates, error)
+	ExplainClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter) (repository.QueryPlan, error)
+	ExportClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter, emit func(models.StockDataPoint) error) (int, error)
 
 	// Group select column operations
 	GetUniqueByGroupSelectColumn(cluster int, columnName string) ([]string, error)
 
 	// Table management operations
-	EmptyAllTables() error
+	EmptyAllTables(dryRun bool, confirm string) (PurgeResult, error)
+	PurgeStocks(cluster *int, dataset uint, from, to string, dryRun bool, confirm string) (PurgeResult, error)
+	CheckIntegrity() (map[string]int64, error)
+	DeleteOrphans(incomplete, dryRun bool, confirm string) (PurgeResult, error)
 }
 
 // WeightEntry represents a weight for a given indicator/sentiment name
@@ -84,10 +220,148 @@ type GroupedStock struct {
 	Stock models.StockDataPoint `json:"stock"`
 }
 
-// PagedGroupedResults carries page data and total for pagination
+// Heatmap is a cluster x dimension matrix: Counts[i][j] and AvgScores[i][j] describe the stocks
+// in Clusters[i] whose dimension column equals Columns[j]
+type Heatmap struct {
+	Dimension string                   `json:"dimension"`
+	Clusters  []int                    `json:"clusters"`
+	Columns   []string                 `json:"columns"`
+	Counts    [][]int64                `json:"counts"`
+	AvgScores [][]float64              `json:"avg_scores"`
+	Cells     []repository.HeatmapCell `json:"cells"`
+}
+
+// Consensus aggregates the latest rating of every brokerage covering a ticker. Buy, Hold and Sell
+// bucket the raw ratings (Outperform counts as Buy, Neutral as Hold, and so on); ratings that fit no
+// bucket are counted in Other.
+type Consensus struct {
+	Ticker     string                       `json:"ticker"`
+	Brokerages int64                        `json:"brokerages"`
+	Buy        int64                        `json:"buy"`
+	Hold       int64                        `json:"hold"`
+	Sell       int64                        `json:"sell"`
+	Other      int64                        `json:"other"`
+	MeanTarget float64                      `json:"mean_target"`
+	Ratings    []repository.ConsensusRating `json:"ratings"`
+}
+
+// Pagination describes one page of a listing: its position within the total, and the ordering
+// and weights it was produced with. WeightsHash identifies the applied (validated, defaulted and
+// normalized) weight set and is empty for unweighted listings.
+type Pagination struct {
+	Count       int    `json:"count"`
+	TotalCount  int64  `json:"total_count"`
+	Page        int    `json:"page"`
+	PerPage     int    `json:"per_page"`
+	TotalPages  int    `json:"total_pages"`
+	HasNext     bool   `json:"has_next"`
+	SortBy      string `json:"sort_by,omitempty"`
+	Order       string `json:"order,omitempty"`
+	WeightsHash string `json:"weights_hash,omitempty"`
+	// WeightSets lists the weight sets (numerical, rating) weighted_score was computed from; a set
+	// that was not given contributes nothing
+	WeightSets []string `json:"weight_sets,omitempty"`
+}
+
+// Weight profiles the filter endpoint can score with: the weights sent with the request, the saved
+// weights of the caller or the configured default profile
+const (
+	WeightProfileRequest = "request"
+	WeightProfileUser    = "user"
+	WeightProfileDefault = "default"
+)
+
+// Readiness is the outcome of a deep health check: whether the database answers, and when it does,
+// the connection pool and migration status. Error describes the failed probe.
+type Readiness struct {
+	Ready     bool                        `json:"ready"`
+	Degraded  bool                        `json:"degraded"`
+	Pool      *repository.PoolStats       `json:"pool,omitempty"`
+	Migration *repository.MigrationStatus `json:"migration,omitempty"`
+	Error     string                      `json:"error,omitempty"`
+}
+
+// DataMeta tells when the stock data was last refreshed and whether it is stale. DataAsOf is the
+// later of the last import and extraction, or the last modification when neither is recorded;
+// StalenessHours is measured from it and is null for an empty table. Stale is never set when
+// StaleAfterHours is 0.
+type DataMeta struct {
+	LastImportedAt  *time.Time `json:"last_imported_at" extensions:"x-nullable"`
+	LastExtractedAt *time.Time `json:"last_extracted_at" extensions:"x-nullable"`
+	DataAsOf        *time.Time `json:"data_as_of" extensions:"x-nullable"`
+	DatasetVersion  *uint      `json:"dataset_version" extensions:"x-nullable"`
+	TotalRows       int64      `json:"total_rows"`
+	StalenessHours  *float64   `json:"staleness_hours" extensions:"x-nullable"`
+	StaleAfterHours float64    `json:"stale_after_hours"`
+	Stale           bool       `json:"stale"`
+}
+
+// PagedGroupedResults carries page data and its pagination
 type PagedGroupedResults struct {
-	Items      []models.StockDataPoint `json:"items"`
-	TotalCount int64                   `json:"total_count"`
-	Page       int                     `json:"page"`
-	PerPage    int                     `json:"per_page"`
+	Items []models.StockDataPoint `json:"items"`
+	Pagination
+}
+
+// ImportResult reports a file import; AlreadyImported is set (and nothing was written) when the
+// same file had been imported before, with Fingerprint describing that earlier import. Imports
+// that skip invalid rows count them in RowsSkipped and list the first maxReportedRowErrors.
+type ImportResult struct {
+	RowsIngested    int                       `json:"rows_ingested"`
+	RowsSkipped     int                       `json:"rows_skipped"`
+	RowErrors       []db_populate.RowError    `json:"row_errors,omitempty"`
+	AlreadyImported bool                      `json:"already_imported"`
+	Fingerprint     *models.ImportFingerprint `json:"fingerprint,omitempty"`
+	DatasetVersion  *models.DatasetVersion    `json:"dataset_version,omitempty"`
+}
+
+// BatchCreateResult reports a batch create: one result per submitted stock, in request order
+type BatchCreateResult struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Results []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult is the outcome of one stock of a batch: the created stock on success, the
+// validation error otherwise
+type BatchItemResult struct {
+	Index   int                    `json:"index"`
+	Success bool                   `json:"success"`
+	Data    *models.StockDataPoint `json:"data,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// PurgeResult reports a destructive delete. A dry run only counts the matching rows and returns the
+// confirmation token that the real request must echo back before it expires.
+type PurgeResult struct {
+	Scope             string           `json:"scope"`
+	DryRun            bool             `json:"dry_run"`
+	Matched           int64            `json:"matched"`
+	Deleted           int64            `json:"deleted"`
+	Tables            map[string]int64 `json:"tables,omitempty"`
+	ConfirmationToken string           `json:"confirmation_token,omitempty"`
+	ExpiresAt         *time.Time       `json:"expires_at,omitempty"`
+}
+
+// GroupedAggregates carries one summary per grouping value of a filtered cluster; WeightsHash
+// identifies the applied weights as in Pagination
+type GroupedAggregates struct {
+	GroupingColumn string                      `json:"grouping_column"`
+	Groups         []repository.GroupAggregate `json:"groups"`
+	WeightsHash    string                      `json:"weights_hash,omitempty"`
+}
+
+// PagedExtractionPages carries one page of the extraction page-key history and its pagination
+type PagedExtractionPages struct {
+	Items []models.ExtractionPage `json:"items"`
+	Pagination
+}
+
+// DataDictionary lists the indicator/sentiment names present in the data and the columns
+// accepted by the filter endpoints, so clients can build weight and sort controls dynamically
+type DataDictionary struct {
+	NumericalIndicators []repository.IndicatorSummary `json:"numerical_indicators"`
+	RatingSentiments    []repository.SentimentSummary `json:"rating_sentiments"`
+	GroupingColumns     []string                      `json:"grouping_columns"`
+	SortableColumns     []string                      `json:"sortable_columns"`
+	Enumerations        map[string][]string           `json:"enumerations"`
 }
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"dataextractor/models"
+)
+
+// Stock event types
+const (
+	StockCreated = "created"
+	StockUpdated = "updated"
+	StockDeleted = "deleted"
+
+	// StocksReloaded reports a bulk change (import, purge, rollback...) whose rows are not listed
+	// one by one; subscribers should refetch what they display
+	StocksReloaded = "reloaded"
+)
+
+// stockEventBuffer is the number of events a subscriber may fall behind before it is dropped
+const stockEventBuffer = 256
+
+// StockEvent is a change to the stock data points, published after the write succeeded. Stock is
+// the record as written for creates and updates made through the API; it is nil for deletes and
+// for updates that only know the ticker (e.g. cluster reassignments).
+type StockEvent struct {
+	Type   string                 `json:"type"`
+	UUID   string                 `json:"uuid,omitempty"`
+	Ticker string                 `json:"ticker,omitempty"`
+	Stock  *models.StockDataPoint `json:"data,omitempty"`
+	Reason string                 `json:"reason,omitempty"`
+	Count  int64                  `json:"count,omitempty"`
+	At     time.Time              `json:"at"`
+}
+
+// stockEventHub fans out stock events to every subscriber. It is shared by the request-scoped
+// copies of the service. A subscriber that falls stockEventBuffer events behind has its channel
+// closed instead of silently missing changes, so it can reconnect and refetch.
+type stockEventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan StockEvent]struct{}
+}
+
+func newStockEventHub() *stockEventHub {
+	return &stockEventHub{subscribers: map[chan StockEvent]struct{}{}}
+}
+
+// subscribe registers a subscriber; the returned function unsubscribes it
+func (h *stockEventHub) subscribe() (<-chan StockEvent, func()) {
+	events := make(chan StockEvent, stockEventBuffer)
+	h.mu.Lock()
+	h.subscribers[events] = struct{}{}
+	h.mu.Unlock()
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(events)
+	}
+}
+
+// publish hands event to every subscriber, dropping the subscribers whose buffer is full
+func (h *stockEventHub) publish(event StockEvent) {
+	event.At = time.Now().UTC()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers {
+		select {
+		case events <- event:
+		default:
+			h.drop(events)
+		}
+	}
+}
+
+// drop closes and removes a subscriber; callers hold mu
+func (h *stockEventHub) drop(events chan StockEvent) {
+	if _, ok := h.subscribers[events]; ok {
+		delete(h.subscribers, events)
+		close(events)
+	}
+}
+
+// SubscribeStockEvents returns the stream of stock changes made from now on and the function that
+// ends the subscription. The channel is closed when the subscriber falls too far behind.
+func (s *StockService) SubscribeStockEvents() (<-chan StockEvent, func()) {
+	return s.stockEvents.subscribe()
+}
+
+// publishStock publishes a create, update or delete of stock
+func (s *StockService) publishStock(eventType string, stock *models.StockDataPoint) {
+	event := StockEvent{Type: eventType, UUID: stock.UUID, Ticker: stock.Ticker}
+	if eventType != StockDeleted {
+		event.Stock = stock
+	}
+	s.stockEvents.publish(event)
+}
+
+// publishReload publishes a bulk change of count rows (0 when unknown) made by reason
+func (s *StockService) publishReload(reason string, count int64) {
+	s.stockEvents.publish(StockEvent{Type: StocksReloaded, Reason: reason, Count: count})
+}
@@ -1,100 +1,430 @@
 package service
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"dataextractor/apperrors"
 	"dataextractor/config"
-	"dataextractor/data_extractor"
 	"dataextractor/db_populate"
+	"dataextractor/logging"
 	"dataextractor/models"
+	"dataextractor/notifications"
 	"dataextractor/repository"
+	"dataextractor/scoring"
 	"dataextractor/utils"
 	"dataextractor/validators"
+	"dataextractor/webhooks"
 )
 
+// ErrInvalidWeights is returned when scoring weights fail validation
+var ErrInvalidWeights = apperrors.Validation("invalid weights")
+
+// ErrInvalidID is returned when a stock identifier is neither an accepted numeric ID nor a UUID
+var ErrInvalidID = apperrors.Validation("invalid ID")
+
 // StockService handles business logic for stock operations
 type StockService struct {
 	repository repository.DataRepositoryInterface
 	validator  *validators.StockValidator
+	config     *config.AppConfig
+
+	// Signing key of destructive-operation confirmation tokens
+	confirmSecret []byte
+
+	// Email/Slack channels that job failures are reported on
+	notifier *notifications.Notifier
+
+	// Signed callbacks to webhook subscribers
+	webhookSender *webhooks.Sender
+
+	// Known grouping values per cluster, checked before filtering by one
+	groupingValues *groupingValuesCache
+
+	// Warmed dashboard reads; nil unless CACHE_WARMUP is enabled
+	readCache *readCache
+
+	// Progress events of running jobs, streamed to SSE subscribers
+	jobEvents *jobEventHub
+
+	// Stock changes, pushed to WebSocket subscribers
+	stockEvents *stockEventHub
+
+	// Per-client request counters, flushed to client_usage periodically
+	usageMeter *usageMeter
+
+	// Structured logger; WithContext attaches the request ID of the calling request
+	logger *slog.Logger
 }
 
 // NewStockService creates a new StockService instance
-func NewStockService(repo repository.DataRepositoryInterface) *StockService {
+func NewStockService(repo repository.DataRepositoryInterface, cfg *config.AppConfig) *StockService {
+	enums := validators.NewEnumRegistry()
+	enums.Set(validators.EnumAction, cfg.Validation.AllowedActions)
+	enums.Set(validators.EnumRating, cfg.Validation.AllowedRatings)
+
+	var cache *readCache
+	if cfg.Cache.Warmup {
+		cache = newReadCache()
+	}
+
 	return &StockService{
-		repository: repo,
-		validator:  validators.NewStockValidator(),
+		repository:     repo,
+		validator:      validators.NewStockValidatorWithEnums(enums),
+		config:         cfg,
+		confirmSecret:  confirmationSecret(cfg.Server.ConfirmationSecret),
+		notifier:       notifications.New(cfg.Notifications),
+		webhookSender:  webhooks.NewSender(cfg.Notifications.Timeout),
+		groupingValues: newGroupingValuesCache(cfg.Cache.UniqueValuesTTL),
+		readCache:      cache,
+		jobEvents:      newJobEventHub(),
+		stockEvents:    newStockEventHub(),
+		usageMeter:     newUsageMeter(cfg.Server.UsageFlushInterval),
+		logger:         slog.Default(),
+	}
+}
+
+// WithContext returns a shallow copy of the service whose repository and log lines are bound to ctx
+func (s *StockService) WithContext(ctx context.Context) StockServiceInterface {
+	scoped := *s
+	scoped.repository = s.repository.WithContext(ctx)
+	scoped.logger = logging.FromContext(ctx)
+	return &scoped
+}
+
+// DatabaseConnected reports whether the repository has connected; it is false while the server
+// runs degraded after failing to reach the database at startup
+func (s *StockService) DatabaseConnected() bool {
+	return s.repository.Connected()
+}
+
+// PingDatabase checks that the database answers
+func (s *StockService) PingDatabase(ctx context.Context) error {
+	return s.repository.Ping(ctx)
+}
+
+// CheckReadiness pings the database and, when it answers, reports the connection pool and
+// migration status. A failed migration check is reported without making the service unready.
+func (s *StockService) CheckReadiness(ctx context.Context) Readiness {
+	readiness := Readiness{Degraded: !s.repository.Connected()}
+	if err := s.repository.Ping(ctx); err != nil {
+		readiness.Error = err.Error()
+		return readiness
+	}
+	readiness.Ready = true
+
+	if pool, err := s.repository.GetPoolStats(); err == nil {
+		readiness.Pool = &pool
+	}
+	migration, err := s.repository.GetMigrationStatus()
+	if err != nil {
+		readiness.Error = err.Error()
+		return readiness
+	}
+	readiness.Migration = &migration
+	return readiness
+}
+
+// LoadEnumerations seeds the allowed action and rating values from the data for any
+// enumeration that is not explicitly configured
+func (s *StockService) LoadEnumerations() error {
+	if len(s.config.Validation.AllowedActions) == 0 {
+		actions, err := s.repository.GetUniqueActions()
+		if err != nil {
+			return fmt.Errorf("failed to seed action enumeration: %w", err)
+		}
+		s.validator.Enums().Set(validators.EnumAction, actions)
+	}
+	if len(s.config.Validation.AllowedRatings) == 0 {
+		ratings, err := s.repository.GetUniqueRatings()
+		if err != nil {
+			return fmt.Errorf("failed to seed rating enumeration: %w", err)
+		}
+		s.validator.Enums().Set(validators.EnumRating, ratings)
+	}
+	return nil
+}
+
+// GetEnumerations returns the allowed values for action and rating fields
+func (s *StockService) GetEnumerations() map[string][]string {
+	return map[string][]string{
+		validators.EnumAction: s.validator.Enums().Allowed(validators.EnumAction),
+		validators.EnumRating: s.validator.Enums().Allowed(validators.EnumRating),
 	}
 }
 
 // Create creates a new stock record with validation
 func (s *StockService) Create(request *validators.StockCreateRequest) (*models.StockDataPoint, error) {
 	// Validate the request using the service validator
-	utils.ErrorPanic(s.validator.ValidateRequest(request), "validation failed")
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+	if err := s.checkDate(request.Date); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
 
 	// Convert request to Stock model
 	stock := request.ToStock()
 
+	// Place stocks created without a cluster at the nearest centroid
+	if request.Cluster == nil {
+		index, err := s.loadCentroidIndex()
+		if err != nil {
+			return nil, apperrors.Wrap(err, "failed to assign cluster")
+		}
+		index.assign(stock)
+	}
+	rubric, err := s.loadRatingRubric()
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to score sentiments")
+	}
+	rubric.score(stock.RatingSentiments)
+	s.recalculateFinalScore(stock)
+
 	// Create the stock record
 	createdStock, err := s.repository.Create(stock)
-	utils.ErrorPanic(err, "failed to create stock")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to create stock")
+	}
 
-	log.Printf("Successfully created stock record for ticker: %s", createdStock.Ticker)
+	s.logger.Info("Created stock record", "ticker", createdStock.Ticker)
+	s.publishStock(StockCreated, createdStock)
 	return createdStock, nil
 }
 
+// maxBatchCreate caps the rows accepted by one CreateBatch call
+const maxBatchCreate = 1000
+
+// CreateBatch validates every request and inserts the valid ones in a single transaction, prepared
+// like Create (cluster assignment, sentiment scoring, final_score). Rows failing validation are
+// reported in their result and skipped; a database error fails the whole batch.
+func (s *StockService) CreateBatch(requests []validators.StockCreateRequest) (BatchCreateResult, error) {
+	if len(requests) == 0 {
+		return BatchCreateResult{}, apperrors.Validation("batch is empty")
+	}
+	if len(requests) > maxBatchCreate {
+		return BatchCreateResult{}, apperrors.Validation("batch has %d stocks, at most %d are accepted per request", len(requests), maxBatchCreate)
+	}
+
+	result := BatchCreateResult{Results: make([]BatchItemResult, len(requests))}
+	var (
+		stocks    []*models.StockDataPoint
+		indexes   []int
+		centroids centroidIndex
+		loaded    bool
+	)
+	for i := range requests {
+		request := &requests[i]
+		result.Results[i].Index = i
+		err := s.validator.ValidateRequest(request)
+		if err == nil {
+			err = s.checkDate(request.Date)
+		}
+		if err != nil {
+			result.Results[i].Error = err.Error()
+			result.Failed++
+			continue
+		}
+
+		stock := request.ToStock()
+		if request.Cluster == nil {
+			if !loaded {
+				index, err := s.loadCentroidIndex()
+				if err != nil {
+					return BatchCreateResult{}, apperrors.Wrap(err, "failed to assign cluster")
+				}
+				centroids, loaded = index, true
+			}
+			centroids.assign(stock)
+		}
+		stocks = append(stocks, stock)
+		indexes = append(indexes, i)
+	}
+
+	if len(stocks) > 0 {
+		rubric, err := s.loadRatingRubric()
+		if err != nil {
+			return BatchCreateResult{}, apperrors.Wrap(err, "failed to score sentiments")
+		}
+		for _, stock := range stocks {
+			rubric.score(stock.RatingSentiments)
+			s.recalculateFinalScore(stock)
+		}
+		if err := s.repository.CreateBatch(stocks); err != nil {
+			return BatchCreateResult{}, apperrors.Wrap(err, "failed to create stocks")
+		}
+	}
+	for i, stock := range stocks {
+		result.Results[indexes[i]].Success = true
+		result.Results[indexes[i]].Data = stock
+		s.publishStock(StockCreated, stock)
+	}
+	result.Created = len(stocks)
+
+	s.logger.Info("Batch create finished", "created", result.Created, "rejected", result.Failed)
+	return result, nil
+}
+
 // GetByID retrieves a stock record by its ID
 func (s *StockService) GetByID(id uint) (*models.StockDataPoint, error) {
 	// Validate the ID using the service validator
-	utils.ErrorPanic(s.validator.ValidateID(id), "invalid ID")
+	if err := s.validator.ValidateID(id); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ID")
+	}
 
 	stock, err := s.repository.ReadById(id)
-	utils.ErrorPanic(err, fmt.Sprintf("failed to get stock by ID %d", id))
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("failed to get stock by ID %d", id))
+	}
 
 	return stock, nil
 }
 
+// ResolveID converts a path identifier (UUID, or numeric ID while those are accepted) to the internal ID
+func (s *StockService) ResolveID(identifier string) (uint, error) {
+	if id, err := strconv.ParseUint(identifier, 10, 32); err == nil {
+		if !s.config.Database.AcceptNumericIDs {
+			return 0, fmt.Errorf("%w: numeric IDs are no longer accepted, use the stock UUID", ErrInvalidID)
+		}
+		return uint(id), nil
+	}
+
+	if err := s.validator.ValidateUUID(identifier); err != nil {
+		return 0, fmt.Errorf("%w: must be a UUID or a numeric ID", ErrInvalidID)
+	}
+	return s.repository.GetIDByUUID(identifier)
+}
+
 // GetAll retrieves all stock records
 func (s *StockService) GetAll() ([]models.StockDataPoint, error) {
 	stocks, err := s.repository.GetAll()
-	utils.ErrorPanic(err, "failed to get all stocks")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get all stocks")
+	}
 
 	return stocks, nil
 }
 
+// streamBatchSize is the number of parent rows loaded (with their relations) per query by StreamAll
+const streamBatchSize = 1000
+
+// StreamAll hands every stock to emit in id order, loading streamBatchSize rows at a time so
+// full-table exports keep a flat memory profile. It returns the number of rows emitted.
+func (s *StockService) StreamAll(emit func(models.StockDataPoint) error) (int, error) {
+	return s.stream(repository.StockScope{}, emit)
+}
+
+// StreamScope is StreamAll restricted to a cluster, a dataset version (0 for any) and an optional
+// YYYY-MM-DD record date window
+func (s *StockService) StreamScope(cluster *int, dataset uint, from, to string, emit func(models.StockDataPoint) error) (int, error) {
+	fromDate, toDate, err := parseDateWindow(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return s.stream(repository.StockScope{Cluster: cluster, Dataset: dataset, From: fromDate, To: toDate}, emit)
+}
+
+func (s *StockService) stream(scope repository.StockScope, emit func(models.StockDataPoint) error) (int, error) {
+	emitted := 0
+	err := s.repository.StreamAll(scope, streamBatchSize, func(batch []models.StockDataPoint) error {
+		for _, stock := range batch {
+			if err := emit(stock); err != nil {
+				return fmt.Errorf("failed to write export row: %w", err)
+			}
+			emitted++
+		}
+		return nil
+	})
+	return emitted, err
+}
+
 // Update updates an existing stock record with validation
-func (s *StockService) Update(request *validators.StockUpdateRequest) (*models.StockDataPoint, error) {
+func (s *StockService) Update(request *validators.StockUpdateRequest, precondition WritePrecondition) (*models.StockDataPoint, error) {
 	// Validate the request using the service validator
-	utils.ErrorPanic(s.validator.ValidateRequest(request), "validation failed")
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+	if request.Date != nil {
+		if err := s.checkDate(*request.Date); err != nil {
+			return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+		}
+	}
 
-	// Convert request to Stock model
-	stock := request.ToStock()
+	// Load the existing record and apply only the fields present in the request
+	stock, err := s.repository.ReadById(request.ID)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", request.ID))
+	}
+	if err := precondition.check(stock); err != nil {
+		return nil, apperrors.Wrap(err, "failed to update stock")
+	}
+	readAt := stock.UpdatedAt
+	request.ApplyTo(stock)
+	rubric, err := s.loadRatingRubric()
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to score sentiments")
+	}
+	rubric.score(stock.RatingSentiments)
+	s.recalculateFinalScore(stock)
 
-	// Update the stock record
-	updatedStock, err := s.repository.Update(stock)
-	utils.ErrorPanic(err, "failed to update stock")
+	// Update the stock record; a conditional write also fails if the stock changed since it was read
+	updatedStock, err := s.updateStock(stock, readAt, precondition)
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to update stock")
+	}
 
-	log.Printf("Successfully updated stock record for ticker: %s", updatedStock.Ticker)
+	s.logger.Info("Updated stock record", "ticker", updatedStock.Ticker)
+	s.publishStock(StockUpdated, updatedStock)
 	return updatedStock, nil
 }
 
+// updateStock saves stock, unconditionally or, for a conditional write, only while it is unchanged since readAt
+func (s *StockService) updateStock(stock *models.StockDataPoint, readAt time.Time, precondition WritePrecondition) (*models.StockDataPoint, error) {
+	if precondition.IsEmpty() {
+		return s.repository.Update(stock)
+	}
+	return s.repository.UpdateIfUnchanged(stock, readAt)
+}
+
 // Delete deletes a stock record by ID
-func (s *StockService) Delete(id uint) error {
+func (s *StockService) Delete(id uint, precondition WritePrecondition) error {
 	// Validate the ID using the service validator
-	utils.ErrorPanic(s.validator.ValidateID(id), "invalid ID")
+	if err := s.validator.ValidateID(id); err != nil {
+		return apperrors.WrapAs(err, apperrors.KindValidation, "invalid ID")
+	}
 
 	// First, get the stock to ensure it exists
 	stock, err := s.repository.ReadById(id)
-	utils.ErrorPanic(err, fmt.Sprintf("stock with ID %d not found", id))
+	if err != nil {
+		return apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+	if err := precondition.check(stock); err != nil {
+		return apperrors.Wrap(err, "failed to delete stock")
+	}
 
 	// Delete the stock record
-	utils.ErrorPanic(s.repository.Delete(stock), "failed to delete stock")
+	if precondition.IsEmpty() {
+		err = s.repository.Delete(stock)
+	} else {
+		err = s.repository.DeleteIfUnchanged(stock, stock.UpdatedAt)
+	}
+	if err != nil {
+		return apperrors.Wrap(err, "failed to delete stock")
+	}
 
-	log.Printf("Successfully deleted stock record for ticker: %s", stock.Ticker)
+	s.logger.Info("Deleted stock record", "ticker", stock.Ticker)
+	s.publishStock(StockDeleted, stock)
 	return nil
 }
 
@@ -102,7 +432,7 @@ func (s *StockService) Delete(id uint) error {
 func (s *StockService) GetByTicker(ticker string) (*models.StockDataPoint, error) {
 	// Validate the ticker using the service validator
 	if err := s.validator.ValidateTicker(ticker); err != nil {
-		return nil, fmt.Errorf("invalid ticker: %w", err)
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
 	}
 
 	stock, err := s.repository.GetDataByTicker(ticker)
@@ -113,191 +443,707 @@ func (s *StockService) GetByTicker(ticker string) (*models.StockDataPoint, error
 	return stock, nil
 }
 
-// GetByCompany retrieves all stock records for a specific company
-func (s *StockService) GetByCompany(company string) ([]models.StockDataPoint, error) {
+// GetByCompany retrieves one page of the stock records for a specific company
+func (s *StockService) GetByCompany(company string, opts repository.ListOptions) (PagedGroupedResults, error) {
 	// Validate the company using the service validator
 	if err := s.validator.ValidateCompany(company); err != nil {
-		return nil, fmt.Errorf("invalid company: %w", err)
+		return PagedGroupedResults{}, apperrors.WrapAs(err, apperrors.KindValidation, "invalid company")
 	}
 
-	stocks, err := s.repository.GetStocksByCompany(company)
+	stocks, total, err := s.repository.GetStocksByCompany(company, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stocks by company %s: %w", company, err)
+		return PagedGroupedResults{}, fmt.Errorf("failed to get stocks by company %s: %w", company, err)
 	}
 
-	return stocks, nil
+	return PagedGroupedResults{Items: stocks, Pagination: newPagination(len(stocks), total, opts.Page, opts.PerPage, opts.SortBy, opts.Order)}, nil
 }
 
 // GetStocksByCompany is a convenience alias matching new naming
-func (s *StockService) GetStocksByCompany(company string) ([]models.StockDataPoint, error) {
-	return s.GetByCompany(company)
+func (s *StockService) GetStocksByCompany(company string, opts repository.ListOptions) (PagedGroupedResults, error) {
+	return s.GetByCompany(company, opts)
+}
+
+// QueryStocks returns one page of the stocks matching every criterion of query
+func (s *StockService) QueryStocks(query repository.StockQuery, opts repository.ListOptions) (PagedGroupedResults, error) {
+	if query.Cluster != nil && *query.Cluster < models.NoiseCluster {
+		return PagedGroupedResults{}, apperrors.Validation("invalid cluster: must be >= %d", models.NoiseCluster)
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return PagedGroupedResults{}, apperrors.Validation("from must not be after to")
+	}
+	if query.MinFinalScore != nil && query.MaxFinalScore != nil && *query.MinFinalScore > *query.MaxFinalScore {
+		return PagedGroupedResults{}, apperrors.Validation("minFinalScore must not exceed maxFinalScore")
+	}
+
+	stocks, total, err := s.repository.QueryStocks(query, opts)
+	if err != nil {
+		return PagedGroupedResults{}, apperrors.Wrap(err, "failed to query stocks")
+	}
+	return PagedGroupedResults{Items: stocks, Pagination: newPagination(len(stocks), total, opts.Page, opts.PerPage, opts.SortBy, opts.Order)}, nil
 }
 
 // GetUniqueClusters returns all unique clusters
 func (s *StockService) GetUniqueClusters() ([]int, error) {
+	if clusters, ok := s.cachedClusters(); ok {
+		return clusters, nil
+	}
 	clusters, err := s.repository.GetUniqueClusters()
-	utils.ErrorPanic(err, "failed to get unique clusters")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get unique clusters")
+	}
 	return clusters, nil
 }
 
-// GetStocksByCluster returns all stocks for a specific cluster
-func (s *StockService) GetStocksByCluster(cluster int) ([]models.StockDataPoint, error) {
-	if cluster < 0 {
-		return nil, fmt.Errorf("invalid cluster: must be >= 0")
+// GetStocksByCluster returns one page of the stocks for a specific cluster
+func (s *StockService) GetStocksByCluster(cluster int, opts repository.ListOptions) (PagedGroupedResults, error) {
+	if cluster < models.NoiseCluster {
+		return PagedGroupedResults{}, apperrors.Validation("invalid cluster: must be >= %d", models.NoiseCluster)
 	}
-	stocks, err := s.repository.GetStocksByCluster(cluster)
-	utils.ErrorPanic(err, fmt.Sprintf("failed to get stocks by cluster %d", cluster))
-	return stocks, nil
+	stocks, total, err := s.repository.GetStocksByCluster(cluster, opts)
+	if err != nil {
+		return PagedGroupedResults{}, apperrors.Wrap(err, fmt.Sprintf("failed to get stocks by cluster %d", cluster))
+	}
+	return PagedGroupedResults{Items: stocks, Pagination: newPagination(len(stocks), total, opts.Page, opts.PerPage, opts.SortBy, opts.Order)}, nil
 }
 
 // GetUniqueActions returns all unique actions
 func (s *StockService) GetUniqueActions() ([]string, error) {
+	if actions, ok := s.cachedActions(); ok {
+		return actions, nil
+	}
 	actions, err := s.repository.GetUniqueActions()
-	utils.ErrorPanic(err, "failed to get unique actions")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get unique actions")
+	}
 	return actions, nil
 }
 
+// GetUniqueTags returns all known tag names
+func (s *StockService) GetUniqueTags() ([]string, error) {
+	tags, err := s.repository.GetUniqueTags()
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get unique tags")
+	}
+	return tags, nil
+}
+
+// TagStock attaches the requested tags to a stock and returns the updated record
+func (s *StockService) TagStock(id uint, request *validators.TagRequest) (*models.StockDataPoint, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	stock, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+
+	if err := s.repository.AddTags(stock, request.Tags); err != nil {
+		return nil, apperrors.Wrap(err, "failed to tag stock")
+	}
+	return s.readUpdated(id)
+}
+
+// UntagStock detaches a tag from a stock and returns the updated record
+func (s *StockService) UntagStock(id uint, tag string) (*models.StockDataPoint, error) {
+	stock, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("stock with ID %d not found", id))
+	}
+
+	if err := s.repository.RemoveTags(stock, []string{validators.SanitizeTag(tag)}); err != nil {
+		return nil, apperrors.Wrap(err, "failed to untag stock")
+	}
+	return s.readUpdated(id)
+}
+
+// readUpdated reloads a stock after a write to its relations and publishes the update
+func (s *StockService) readUpdated(id uint) (*models.StockDataPoint, error) {
+	stock, err := s.repository.ReadById(id)
+	if err != nil {
+		return nil, err
+	}
+	s.publishStock(StockUpdated, stock)
+	return stock, nil
+}
+
 // GetUniqueCompanies returns all unique companies
 func (s *StockService) GetUniqueCompanies() ([]string, error) {
+	if companies, ok := s.cachedCompanies(); ok {
+		return companies, nil
+	}
 	companies, err := s.repository.GetUniqueCompanies()
-	utils.ErrorPanic(err, "failed to get unique companies")
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get unique companies")
+	}
 	return companies, nil
 }
 
-// GetStocksByAction returns all stocks for a specific action
-func (s *StockService) GetStocksByAction(action string) ([]models.StockDataPoint, error) {
+// GetStocksByAction returns one page of the stocks for a specific action
+func (s *StockService) GetStocksByAction(action string, opts repository.ListOptions) (PagedGroupedResults, error) {
 	if action == "" {
-		return nil, fmt.Errorf("invalid action: required")
+		return PagedGroupedResults{}, apperrors.Validation("invalid action: required")
 	}
-	stocks, err := s.repository.GetStocksByAction(action)
-	utils.ErrorPanic(err, fmt.Sprintf("failed to get stocks by action %s", action))
-	return stocks, nil
+	stocks, total, err := s.repository.GetStocksByAction(action, opts)
+	if err != nil {
+		return PagedGroupedResults{}, apperrors.Wrap(err, fmt.Sprintf("failed to get stocks by action %s", action))
+	}
+	return PagedGroupedResults{Items: stocks, Pagination: newPagination(len(stocks), total, opts.Page, opts.PerPage, opts.SortBy, opts.Order)}, nil
 }
 
 // (moved) ImportFromCSV now lives in package db_populate
 
 // GetStats retrieves statistics for a specific ticker
-func (s *StockService) GetStats(ticker string) (map[string]interface{}, error) {
+func (s *StockService) GetStats(ticker string) (repository.TickerStats, error) {
 	// Validate the ticker using the service validator
-	utils.ErrorPanic(s.validator.ValidateTicker(ticker), "invalid ticker")
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return repository.TickerStats{}, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
 
 	stats, err := s.repository.GetTickerStats(ticker)
-	utils.ErrorPanic(err, fmt.Sprintf("failed to get stats for ticker %s", ticker))
+	if err != nil {
+		return repository.TickerStats{}, apperrors.Wrap(err, fmt.Sprintf("failed to get stats for ticker %s", ticker))
+	}
 
 	return stats, nil
 }
 
+// GetTickerHistory returns the dated score/target/rating history of a ticker for charting.
+// from and to are optional inclusive YYYY-MM-DD bounds.
+func (s *StockService) GetTickerHistory(ticker, from, to string) ([]models.StockSnapshot, error) {
+	if err := s.validator.ValidateTicker(ticker); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "invalid ticker")
+	}
+
+	fromDate, toDate, err := parseDateWindow(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repository.GetTickerHistory(ticker, fromDate, toDate)
+	if err != nil {
+		return nil, apperrors.Wrap(err, fmt.Sprintf("failed to get history for ticker %s", ticker))
+	}
+	return history, nil
+}
+
+// parseDateWindow parses optional from/to YYYY-MM-DD parameters into an inclusive time window
+func parseDateWindow(from, to string) (*time.Time, *time.Time, error) {
+	fromDate, err := parseDateParam("from", from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDate, err := parseDateParam("to", to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if toDate != nil {
+		// Include the whole "to" day
+		end := toDate.Add(24*time.Hour - time.Nanosecond)
+		toDate = &end
+	}
+	if fromDate != nil && toDate != nil && fromDate.After(*toDate) {
+		return nil, nil, apperrors.Validation("from must not be after to")
+	}
+	return fromDate, toDate, nil
+}
+
+// parseDateParam parses an optional YYYY-MM-DD query parameter
+func parseDateParam(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, apperrors.Validation("%s must be a YYYY-MM-DD date", name)
+	}
+	return &date, nil
+}
+
 // GetDatabaseStats retrieves overall database statistics
-func (s *StockService) GetDatabaseStats() (map[string]interface{}, error) {
+func (s *StockService) GetDatabaseStats() (repository.DatabaseStats, error) {
 	stats, err := s.repository.GetDatabaseStats()
-	utils.ErrorPanic(err, "failed to get database stats")
+	if err != nil {
+		return repository.DatabaseStats{}, apperrors.Wrap(err, "failed to get database stats")
+	}
 
 	return stats, nil
 }
 
-// StoreDataFromApi handles the complete data extraction process from API
-func (s *StockService) StoreDataFromApi(maxPages int) error {
-	// Load configuration for API
-	cfg := config.LoadConfig()
+// GetDiagnostics returns the schema version, migration status, row counts, indexes and largest tables
+func (s *StockService) GetDiagnostics() (repository.Diagnostics, error) {
+	diagnostics, err := s.repository.GetDiagnostics()
+	if err != nil {
+		return repository.Diagnostics{}, apperrors.Wrap(err, "failed to get diagnostics")
+	}
+	return diagnostics, nil
+}
 
-	// Create data extractor and run it
-	extractor := data_extractor.NewDataExtractor(cfg.APIBaseURL, cfg.APIKey, s.repository)
+// GetDataVersion returns the last-modified time and row count used to validate cached read responses
+func (s *StockService) GetDataVersion() (repository.DataVersion, error) {
+	version, err := s.repository.GetDataVersion()
+	if err != nil {
+		return repository.DataVersion{}, fmt.Errorf("failed to get data version: %w", err)
+	}
+	return version, nil
+}
 
-	log.Printf("Starting data extraction with maxPages: %d", maxPages)
-	if err := extractor.ExtractAndProcessAllPages(maxPages); err != nil {
-		return fmt.Errorf("error during data extraction: %w", err)
+// GetDataMeta reports when the data was last imported or extracted, the current dataset version,
+// the row count and how many hours old the data is, against the configured staleness threshold
+func (s *StockService) GetDataMeta() (DataMeta, error) {
+	freshness, err := s.repository.GetDataFreshness()
+	if err != nil {
+		return DataMeta{}, apperrors.Wrap(err, "failed to get data freshness")
 	}
+	return dataMeta(freshness, s.config.Import.StaleAfter, time.Now()), nil
+}
 
-	log.Println("Data extraction completed successfully! Data written to CSV file.")
-	return nil
+// dataMeta derives the DataMeta of freshness at now; staleAfter 0 never reports the data stale
+func dataMeta(freshness repository.DataFreshness, staleAfter time.Duration, now time.Time) DataMeta {
+	meta := DataMeta{
+		LastImportedAt:  freshness.LastImportedAt,
+		LastExtractedAt: freshness.LastExtractedAt,
+		DatasetVersion:  freshness.DatasetVersion,
+		TotalRows:       freshness.TotalRows,
+		StaleAfterHours: staleAfter.Hours(),
+	}
+	for _, t := range []*time.Time{freshness.LastImportedAt, freshness.LastExtractedAt} {
+		if t != nil && (meta.DataAsOf == nil || t.After(*meta.DataAsOf)) {
+			meta.DataAsOf = t
+		}
+	}
+	if meta.DataAsOf == nil {
+		meta.DataAsOf = freshness.LastModified
+	}
+
+	if meta.DataAsOf != nil {
+		age := now.Sub(*meta.DataAsOf)
+		hours := math.Round(age.Hours()*100) / 100
+		meta.StalenessHours = &hours
+		meta.Stale = staleAfter > 0 && age > staleAfter
+	}
+	return meta
+}
+
+// GetDataDictionary describes the indicators, sentiments and columns available for filtering and scoring
+func (s *StockService) GetDataDictionary() (DataDictionary, error) {
+	indicators, err := s.repository.GetIndicatorSummaries()
+	if err != nil {
+		return DataDictionary{}, fmt.Errorf("failed to build data dictionary: %w", err)
+	}
+	sentiments, err := s.repository.GetSentimentSummaries()
+	if err != nil {
+		return DataDictionary{}, fmt.Errorf("failed to build data dictionary: %w", err)
+	}
+
+	return DataDictionary{
+		NumericalIndicators: indicators,
+		RatingSentiments:    sentiments,
+		GroupingColumns:     repository.AllowedGroupingColumns,
+		SortableColumns:     repository.AllowedSortColumns,
+		Enumerations:        s.GetEnumerations(),
+	}, nil
 }
 
 // ImportFromCSV delegates CSV import to db_populate, persisting with the repository
 func (s *StockService) ImportFromCSV(reader io.Reader) (int, error) {
-	return db_populate.ImportFromCSV(reader, s.repository)
+	return s.importCSV(reader, 0, nil)
 }
 
-// ImportFromEnrichedCSV opens the default CSV file and imports it
-func (s *StockService) ImportFromEnrichedCSV() (int, error) {
-	const defaultCSV = "./stock_data_enriched.csv"
-	f, err := os.Open(defaultCSV)
+// importCSV imports CSV rows, stamping and archiving them under datasetVersion when it is non-zero.
+// With onRowError set, invalid rows are reported to it and skipped instead of stopping the import.
+func (s *StockService) importCSV(reader io.Reader, datasetVersion uint, onRowError func(db_populate.RowError)) (int, error) {
+	delimiter, err := utils.ParseCSVDelimiter(s.config.Import.CSVDelimiter)
 	if err != nil {
-		return 0, fmt.Errorf("failed to open CSV file %s: %w", defaultCSV, err)
+		return 0, err
 	}
-	defer f.Close()
-	return db_populate.ImportFromCSV(f, s.repository)
-}
+	// Rows without a cluster (e.g. extracted from the API) go to the nearest centroid of the
+	// clusters as they were before this import
+	index, err := s.loadCentroidIndex()
+	if err != nil {
+		return 0, err
+	}
+	// Sentiments are scored from the rating rubric, so new vocabulary needs no pipeline change
+	rubric, err := s.loadRatingRubric()
+	if err != nil {
+		return 0, err
+	}
+	validate := func(sdp *models.StockDataPoint) error {
+		rubric.score(sdp.RatingSentiments)
+		return s.validateImportedRow(sdp)
+	}
+	count, err := db_populate.ImportFromCSV(reader, s.repository, validate, db_populate.ImportOptions{
+		Location:         s.config.Validation.DefaultLocation,
+		CSV:              utils.CSVOptions{Delimiter: delimiter, LazyQuotes: s.config.Import.CSVLazyQuotes},
+		DatasetVersionID: datasetVersion,
+		AssignCluster:    index.assign,
+		OnRowError:       onRowError,
+	})
+	if err != nil {
+		return count, err
+	}
+	s.refreshEnumerations()
+	s.publishReload("import", int64(count))
 
-// RankByWeightedScore computes weighted scores for all data points in a cluster and returns them sorted desc
-func (s *StockService) RankByWeightedScore(cluster int, weights []WeightEntry) ([]RankedResult, error) {
-	if cluster < 0 {
-		return nil, fmt.Errorf("invalid cluster: must be >= 0")
+	// The imported clustering moves the centroids
+	if _, err := s.repository.RecomputeCentroids(); err != nil {
+		s.logger.Warn("Failed to recompute cluster centroids", "error", err)
 	}
+	return count, nil
+}
+
+// maxReportedRowErrors caps the skipped rows listed in ImportResult.RowErrors
+const maxReportedRowErrors = 100
+
+// ImportFile imports a CSV source file unless a file with the same SHA-256 digest was imported
+// before, in which case the earlier import is reported and nothing is written. force re-imports
+// regardless. Each import runs as a new dataset version that can later be rolled back. The
+// fingerprint is only recorded once every row has been persisted, so a failed import can simply
+// be retried.
+func (s *StockService) ImportFile(source string, file io.ReadSeeker, force bool) (ImportResult, error) {
+	return s.importFile(source, file, force, false)
+}
+
+// ImportUpload imports an uploaded CSV file like ImportFile, except that rows that cannot be parsed
+// or fail validation are skipped and reported in the result instead of failing the import
+func (s *StockService) ImportUpload(filename string, file io.ReadSeeker, force bool) (ImportResult, error) {
+	return s.importFile("upload:"+filename, file, force, true)
+}
 
-	// Fetch data points for the cluster with preloaded associations
-	dataPoints, err := s.repository.GetStocksByCluster(cluster)
+// importFile implements ImportFile and ImportUpload; skipInvalidRows selects the upload behavior
+func (s *StockService) importFile(source string, file io.ReadSeeker, force, skipInvalidRows bool) (ImportResult, error) {
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stocks by cluster %d: %w", cluster, err)
+		return ImportResult{}, fmt.Errorf("failed to fingerprint %s: %w", source, err)
 	}
+	digest := hex.EncodeToString(hash.Sum(nil))
 
-	// Build weight map (case-insensitive on indicator/sentiment name)
-	weightByName := make(map[string]float64, len(weights))
-	for _, w := range weights {
-		key := strings.TrimSpace(strings.ToLower(w.IndicatorName))
-		if key == "" {
-			continue
+	if !force {
+		previous, err := s.repository.GetImportFingerprint(digest)
+		if err == nil {
+			s.logger.Info("Skipping import: file already imported", "source", source, "previous_source", previous.Source, "imported_at", previous.ImportedAt.Format(time.RFC3339))
+			return ImportResult{AlreadyImported: true, Fingerprint: previous}, nil
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return ImportResult{}, err
 		}
-		weightByName[key] = w.Weight
 	}
 
-	results := make([]RankedResult, 0, len(dataPoints))
-	for _, sdp := range dataPoints {
-		var score float64
-
-		// Rating sentiments -> use NormRatingScore
-		for _, rs := range sdp.RatingSentiments {
-			key := strings.TrimSpace(strings.ToLower(rs.Name))
-			if w, ok := weightByName[key]; ok {
-				score += w * rs.NormRatingScore
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to rewind %s: %w", source, err)
+	}
+
+	version := &models.DatasetVersion{Source: source, SHA256: digest, Status: models.DatasetImporting}
+	if err := s.repository.CreateDatasetVersion(version); err != nil {
+		return ImportResult{}, err
+	}
+	result := ImportResult{DatasetVersion: version}
+	var onRowError func(db_populate.RowError)
+	if skipInvalidRows {
+		onRowError = func(rowErr db_populate.RowError) {
+			result.RowsSkipped++
+			if len(result.RowErrors) < maxReportedRowErrors {
+				result.RowErrors = append(result.RowErrors, rowErr)
 			}
 		}
+	}
+	count, err := s.importCSV(file, version.ID, onRowError)
+	version.RowCount = count
+	result.RowsIngested = count
+	if err != nil {
+		version.Status = models.DatasetFailed
+		if updateErr := s.repository.UpdateDatasetVersion(version); updateErr != nil {
+			s.logger.Warn("Failed to mark dataset version failed", "dataset_version", version.ID, "error", updateErr)
+		}
+		s.notifyFailure(fmt.Sprintf("Import of %s failed", source), fmt.Errorf("dataset version %d stopped after %d rows: %w", version.ID, count, err))
+		return result, err
+	}
+	completedAt := time.Now().UTC()
+	version.Status = models.DatasetComplete
+	version.CompletedAt = &completedAt
+	if err := s.repository.UpdateDatasetVersion(version); err != nil {
+		return result, err
+	}
 
-		// Numerical indicators -> use NormValue
-		for _, ni := range sdp.NumericalIndicators {
-			key := strings.TrimSpace(strings.ToLower(ni.Name))
-			if w, ok := weightByName[key]; ok {
-				score += w * ni.NormValue
-			}
+	fingerprint := &models.ImportFingerprint{
+		SHA256:     digest,
+		Source:     source,
+		SizeBytes:  size,
+		RowCount:   count,
+		ImportedAt: time.Now().UTC(),
+	}
+	if err := s.repository.SaveImportFingerprint(fingerprint); err != nil {
+		return result, err
+	}
+	result.Fingerprint = fingerprint
+	return result, nil
+}
+
+// ImportFromEnrichedCSV opens the default CSV file and imports it (see ImportFile for force)
+func (s *StockService) ImportFromEnrichedCSV(force bool) (ImportResult, error) {
+	const defaultCSV = "./stock_data_enriched.csv"
+	f, err := os.Open(defaultCSV)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to open CSV file %s: %w", defaultCSV, err)
+	}
+	defer f.Close()
+	return s.ImportFile(defaultCSV, f, force)
+}
+
+// validateImportedRow sanitizes an imported data point and checks it against the enumerations and date bounds
+func (s *StockService) validateImportedRow(sdp *models.StockDataPoint) error {
+	validators.SanitizeStock(sdp)
+	if err := s.validator.ValidateEnums(sdp.Action, sdp.RatingTo, sdp.RatingFrom); err != nil {
+		return err
+	}
+	return s.checkDate(sdp.Date)
+}
+
+// checkDate validates a record date against the configured bounds. In lenient mode
+// out-of-range dates are logged and accepted instead of rejected.
+func (s *StockService) checkDate(date time.Time) error {
+	err := s.validator.ValidateDate(date, validators.DateRules{
+		MinDate:       s.config.Validation.MinDate,
+		MaxFutureSkew: s.config.Validation.MaxFutureSkew,
+	})
+	if err != nil && !s.config.Validation.StrictDates {
+		s.logger.Warn("Accepting out-of-range date (lenient mode)", "error", err)
+		return nil
+	}
+	return err
+}
+
+// refreshEnumerations re-seeds data-derived enumerations, and the warmed read cache, after new
+// data has been loaded
+func (s *StockService) refreshEnumerations() {
+	if err := s.LoadEnumerations(); err != nil {
+		s.logger.Warn("Failed to refresh enumerations", "error", err)
+	}
+	if err := s.WarmUp(); err != nil {
+		s.logger.Warn("Read cache warm-up failed", "error", err)
+	}
+}
+
+// RankByWeightedScore computes weighted scores for all data points in a cluster and returns them
+// sorted desc. Scores follow the scoring specification, so they equal the weighted_score the
+// cluster filter returns for the same weights, and ties are ordered by ID desc as the filter does.
+func (s *StockService) RankByWeightedScore(cluster int, weights []WeightEntry) ([]RankedResult, error) {
+	if cluster < models.NoiseCluster {
+		return nil, apperrors.Validation("invalid cluster: must be >= %d", models.NoiseCluster)
+	}
+
+	// One list weights both kinds; each name is scored against the kind the registry gives it
+	var numericalWeights []repository.NumericalWeightEntry
+	var ratingWeights []repository.RatingWeightEntry
+	for _, w := range weights {
+		if models.IsKnownRatingSentiment(scoring.CanonicalName(w.IndicatorName)) {
+			ratingWeights = append(ratingWeights, repository.RatingWeightEntry{IndicatorName: w.IndicatorName, Weight: w.Weight})
+		} else {
+			numericalWeights = append(numericalWeights, repository.NumericalWeightEntry{IndicatorName: w.IndicatorName, Weight: w.Weight})
 		}
+	}
+	numericalWeights, ratingWeights, err := s.prepareWeights(numericalWeights, ratingWeights)
+	if err != nil {
+		return nil, err
+	}
+	indicators, sentiments := repository.ScoringWeights(numericalWeights, ratingWeights)
+
+	// Fetch data points for the cluster with the scoring columns of their associations
+	dataPoints, _, err := s.repository.GetStocksByCluster(cluster, repository.ListOptions{Preload: repository.PreloadScoring})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks by cluster %d: %w", cluster, err)
+	}
 
-		results = append(results, RankedResult{Stock: sdp, Score: score})
+	results := make([]RankedResult, 0, len(dataPoints))
+	for i := range dataPoints {
+		results = append(results, RankedResult{Stock: dataPoints[i], Score: scoring.Score(&dataPoints[i], indicators, sentiments)})
 	}
 
-	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Stock.ID > results[j].Stock.ID
+	})
 	return results, nil
 }
 
 // FilterByClusterGrouped filters by cluster with grouping, pagination, sorting, and optional weighted scoring
-func (s *StockService) FilterByClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry) (PagedGroupedResults, error) {
+func (s *StockService) FilterByClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter, preload repository.PreloadMode, contributions bool) (PagedGroupedResults, error) {
+
+	numericalWeights, ratingWeights, err := s.prepareWeights(numericalWeights, ratingWeights)
+	if err != nil {
+		return PagedGroupedResults{}, err
+	}
+	if err := s.validateGroupingValue(cluster, groupingColumn, groupingValue); err != nil {
+		return PagedGroupedResults{}, err
+	}
+
+	// Contributions are assembled from the scoring columns of the children, so load at least those
+	withContributions := contributions && (len(numericalWeights) > 0 || len(ratingWeights) > 0)
+	load := preload
+	if withContributions && preload == repository.PreloadNone {
+		load = repository.PreloadScoring
+	}
 
 	// Get stocks from repository (returns stocks and total count)
-	stocks, totalCount, err := s.repository.GetStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights)
+	stocks, totalCount, err := s.repository.GetStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges, load)
 	if err != nil {
 		return PagedGroupedResults{}, fmt.Errorf("failed to filter stocks: %w", err)
 	}
 
-	return PagedGroupedResults{
-		Items:      stocks,
+	if withContributions {
+		indicators, sentiments := repository.ScoringWeights(numericalWeights, ratingWeights)
+		for i := range stocks {
+			stocks[i].Contributions = scoring.Contributions(&stocks[i], indicators, sentiments)
+			if load != preload {
+				stocks[i].RatingSentiments = nil
+				stocks[i].NumericalIndicators = nil
+			}
+		}
+	}
+
+	pagination := newPagination(len(stocks), totalCount, page, perPage, sortByColumn, order)
+	pagination.WeightsHash = weightsHash(numericalWeights, ratingWeights)
+	pagination.WeightSets = weightSets(numericalWeights, ratingWeights)
+	return PagedGroupedResults{Items: stocks, Pagination: pagination}, nil
+}
+
+// AggregateClusterGrouped summarizes the stocks matched by the cluster filter per value of
+// groupingColumn (count, average final_score and average weighted score) instead of listing them
+func (s *StockService) AggregateClusterGrouped(cluster int, groupingColumn string, groupingValue string, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter) (GroupedAggregates, error) {
+	if groupingColumn == "" || groupingColumn == validators.GroupingNone {
+		return GroupedAggregates{}, apperrors.Validation("aggregate requires a grouping_column: one of %s", strings.Join(repository.AllowedGroupingColumns, ", "))
+	}
+
+	numericalWeights, ratingWeights, err := s.prepareWeights(numericalWeights, ratingWeights)
+	if err != nil {
+		return GroupedAggregates{}, err
+	}
+	if err := s.validateGroupingValue(cluster, groupingColumn, groupingValue); err != nil {
+		return GroupedAggregates{}, err
+	}
+
+	groups, err := s.repository.GetClusterGroupAggregates(cluster, groupingColumn, groupingValue, numericalWeights, ratingWeights, tags, ranges)
+	if err != nil {
+		return GroupedAggregates{}, fmt.Errorf("failed to aggregate stocks: %w", err)
+	}
+	if groups == nil {
+		groups = []repository.GroupAggregate{}
+	}
+	return GroupedAggregates{
+		GroupingColumn: groupingColumn,
+		Groups:         groups,
+		WeightsHash:    weightsHash(numericalWeights, ratingWeights),
+	}, nil
+}
+
+// ExplainClusterGrouped returns the EXPLAIN ANALYZE plan of the page query FilterByClusterGrouped
+// runs for the same parameters, after the same weight and grouping value checks
+func (s *StockService) ExplainClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, page, perPage int, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter) (repository.QueryPlan, error) {
+	numericalWeights, ratingWeights, err := s.prepareWeights(numericalWeights, ratingWeights)
+	if err != nil {
+		return repository.QueryPlan{}, err
+	}
+	if err := s.validateGroupingValue(cluster, groupingColumn, groupingValue); err != nil {
+		return repository.QueryPlan{}, err
+	}
+	return s.repository.ExplainStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, perPage, numericalWeights, ratingWeights, tags, ranges)
+}
+
+// newPagination describes a page of count items out of totalCount. A perPage of 0 means the page
+// holds every matching row.
+func newPagination(count int, totalCount int64, page, perPage int, sortBy, order string) Pagination {
+	totalPages := 0
+	switch {
+	case perPage > 0:
+		totalPages = int((totalCount + int64(perPage) - 1) / int64(perPage))
+	case totalCount > 0:
+		totalPages = 1
+	}
+	return Pagination{
+		Count:      count,
 		TotalCount: totalCount,
 		Page:       page,
 		PerPage:    perPage,
-	}, nil
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		SortBy:     sortBy,
+		Order:      order,
+	}
+}
+
+// weightSets names the weight sets that were given, in the order numerical, rating
+func weightSets(numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry) []string {
+	var sets []string
+	if len(numericalWeights) > 0 {
+		sets = append(sets, "numerical")
+	}
+	if len(ratingWeights) > 0 {
+		sets = append(sets, "rating")
+	}
+	return sets
+}
+
+// weightsHash fingerprints an applied weight set independently of the order the weights were
+// given in, so clients can tell whether two pages were scored with the same weights. It is empty
+// when no weights apply.
+func weightsHash(numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry) string {
+	if len(numericalWeights) == 0 && len(ratingWeights) == 0 {
+		return ""
+	}
+	entries := make([]string, 0, len(numericalWeights)+len(ratingWeights))
+	for _, w := range numericalWeights {
+		entries = append(entries, "numerical:"+w.IndicatorName+"="+strconv.FormatFloat(w.Weight, 'g', -1, 64))
+	}
+	for _, w := range ratingWeights {
+		entries = append(entries, "rating:"+w.IndicatorName+"="+strconv.FormatFloat(w.Weight, 'g', -1, 64))
+	}
+	sort.Strings(entries)
+	sum := sha256.Sum256([]byte(strings.Join(entries, ";")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// exportPageSize is the number of rows fetched per query while walking a filtered result set for export
+const exportPageSize = 500
+
+// ExportClusterGrouped walks every page of the filtered, sorted, weighted result set and hands each stock
+// to emit in order, so an export matches exactly what the paged filter endpoint shows. It returns the number
+// of rows emitted.
+func (s *StockService) ExportClusterGrouped(cluster int, groupingColumn string, groupingValue string, sortByColumn string, order string, numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry, tags []string, ranges []repository.RangeFilter, emit func(models.StockDataPoint) error) (int, error) {
+	numericalWeights, ratingWeights, err := s.prepareWeights(numericalWeights, ratingWeights)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.validateGroupingValue(cluster, groupingColumn, groupingValue); err != nil {
+		return 0, err
+	}
+
+	emitted := 0
+	for page := 1; ; page++ {
+		stocks, totalCount, err := s.repository.GetStocksByClusterAndGroup(cluster, groupingColumn, groupingValue, sortByColumn, order, page, exportPageSize, numericalWeights, ratingWeights, tags, ranges, repository.PreloadNone)
+		if err != nil {
+			return emitted, fmt.Errorf("failed to export stocks (page %d): %w", page, err)
+		}
+
+		for _, stock := range stocks {
+			if err := emit(stock); err != nil {
+				return emitted, fmt.Errorf("failed to write export row: %w", err)
+			}
+			emitted++
+		}
+
+		if len(stocks) < exportPageSize || int64(page*exportPageSize) >= totalCount {
+			return emitted, nil
+		}
+	}
 }
 
 // GetUniqueByGroupSelectColumn returns unique values for a specified column filtered by cluster
 func (s *StockService) GetUniqueByGroupSelectColumn(cluster int, columnName string) ([]string, error) {
 	if columnName == "" {
-		return nil, fmt.Errorf("column name is required")
+		return nil, apperrors.Validation("column name is required")
 	}
 
 	values, err := s.repository.GetUniqueByGroupSelectColumn(cluster, columnName)
@@ -308,10 +1154,92 @@ func (s *StockService) GetUniqueByGroupSelectColumn(cluster int, columnName stri
 	return values, nil
 }
 
-// EmptyAllTables empties all tables by deleting all records
-func (s *StockService) EmptyAllTables() error {
-	if err := s.repository.EmptyAllTables(); err != nil {
-		return fmt.Errorf("failed to empty all tables: %w", err)
+// weightRules returns the configured weight validation rules
+func (s *StockService) weightRules() validators.WeightRules {
+	return validators.WeightRules{
+		MinWeight: s.config.Scoring.MinWeight,
+		MaxWeight: s.config.Scoring.MaxWeight,
 	}
-	return nil
+}
+
+// defaultWeight returns the weight of an indicator/sentiment in the default weight profile
+func (s *StockService) defaultWeight(name string) float64 {
+	if weight, ok := s.config.Scoring.DefaultWeights[scoring.CanonicalName(name)]; ok {
+		return weight
+	}
+	return s.config.Scoring.DefaultWeight
+}
+
+// DefaultWeights returns the default weight profile (SCORING_DEFAULT_WEIGHTS, with
+// SCORING_DEFAULT_WEIGHT for every name not listed) over every registered indicator and sentiment
+func (s *StockService) DefaultWeights() (numerical, rating []validators.WeightRequest) {
+	numerical = make([]validators.WeightRequest, len(models.NumericalIndicatorNames))
+	for i, name := range models.NumericalIndicatorNames {
+		numerical[i] = validators.WeightRequest{IndicatorName: name, Weight: s.defaultWeight(name)}
+	}
+	rating = make([]validators.WeightRequest, len(models.RatingSentimentNames))
+	for i, name := range models.RatingSentimentNames {
+		rating[i] = validators.WeightRequest{IndicatorName: name, Weight: s.defaultWeight(name)}
+	}
+	return numerical, rating
+}
+
+// recalculateFinalScore sets final_score to the weighted mean of the stock's normalized indicator
+// values and sentiment scores under the default weight profile, so API writes cannot leave a stale
+// or client-supplied score behind. With equal weights this is the plain average the enrichment
+// pipeline computes. A stock without any weighted children keeps the score it was given.
+func (s *StockService) recalculateFinalScore(stock *models.StockDataPoint) {
+	if !s.config.Scoring.RecalculateOnWrite {
+		return
+	}
+	var sum, total float64
+	for _, indicator := range stock.NumericalIndicators {
+		weight := s.defaultWeight(indicator.Name)
+		sum += indicator.NormValue * weight
+		total += weight
+	}
+	for _, sentiment := range stock.RatingSentiments {
+		weight := s.defaultWeight(sentiment.Name)
+		sum += sentiment.NormRatingScore * weight
+		total += weight
+	}
+	if total == 0 {
+		return
+	}
+	stock.FinalScore = sum / total
+}
+
+// prepareWeights validates numerical and rating weights against the indicator registry and configured
+// range, canonicalizes their names, normalizes them to sum to 1 when configured to do so and rounds
+// them to scoring.WeightDecimals places
+func (s *StockService) prepareWeights(numericalWeights []repository.NumericalWeightEntry, ratingWeights []repository.RatingWeightEntry) ([]repository.NumericalWeightEntry, []repository.RatingWeightEntry, error) {
+	rules := s.weightRules()
+	numerical := make([]repository.NumericalWeightEntry, len(numericalWeights))
+	rating := make([]repository.RatingWeightEntry, len(ratingWeights))
+	weightPtrs := make([]*float64, 0, len(numericalWeights)+len(ratingWeights))
+
+	for i, w := range numericalWeights {
+		name := scoring.CanonicalName(w.IndicatorName)
+		if err := s.validator.ValidateWeight(name, w.Weight, models.IsKnownNumericalIndicator, rules); err != nil {
+			return nil, nil, fmt.Errorf("%w: numerical_weights: %v", ErrInvalidWeights, err)
+		}
+		numerical[i] = repository.NumericalWeightEntry{IndicatorName: name, Weight: w.Weight}
+		weightPtrs = append(weightPtrs, &numerical[i].Weight)
+	}
+	for i, w := range ratingWeights {
+		name := scoring.CanonicalName(w.IndicatorName)
+		if err := s.validator.ValidateWeight(name, w.Weight, models.IsKnownRatingSentiment, rules); err != nil {
+			return nil, nil, fmt.Errorf("%w: rating_weights: %v", ErrInvalidWeights, err)
+		}
+		rating[i] = repository.RatingWeightEntry{IndicatorName: name, Weight: w.Weight}
+		weightPtrs = append(weightPtrs, &rating[i].Weight)
+	}
+
+	if s.config.Scoring.NormalizeWeights {
+		validators.NormalizeWeights(weightPtrs...)
+	}
+	for _, weight := range weightPtrs {
+		*weight = scoring.RoundWeight(*weight)
+	}
+	return numerical, rating, nil
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"dataextractor/repository"
+)
+
+// TestDataMeta checks the data_as_of selection, the last-modification fallback, the rounding of
+// staleness_hours and the staleness threshold
+func TestDataMeta(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) *time.Time {
+		ts := now.Add(-ago)
+		return &ts
+	}
+
+	tests := []struct {
+		name       string
+		freshness  repository.DataFreshness
+		staleAfter time.Duration
+		wantAsOf   *time.Time
+		wantHours  *float64
+		wantStale  bool
+	}{
+		{
+			name:       "empty table",
+			freshness:  repository.DataFreshness{},
+			staleAfter: 48 * time.Hour,
+		},
+		{
+			name:       "import later than extraction",
+			freshness:  repository.DataFreshness{LastImportedAt: at(2 * time.Hour), LastExtractedAt: at(5 * time.Hour), LastModified: at(time.Hour)},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(2 * time.Hour),
+			wantHours:  ptr(2.0),
+		},
+		{
+			name:       "extraction later than import",
+			freshness:  repository.DataFreshness{LastImportedAt: at(72 * time.Hour), LastExtractedAt: at(3 * time.Hour)},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(3 * time.Hour),
+			wantHours:  ptr(3.0),
+		},
+		{
+			name:       "last modification without import or extraction",
+			freshness:  repository.DataFreshness{LastModified: at(90 * time.Minute), TotalRows: 4},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(90 * time.Minute),
+			wantHours:  ptr(1.5),
+		},
+		{
+			name:       "hours rounded to two decimals",
+			freshness:  repository.DataFreshness{LastImportedAt: at(time.Hour + 20*time.Second)},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(time.Hour + 20*time.Second),
+			wantHours:  ptr(1.01),
+		},
+		{
+			name:       "older than the threshold",
+			freshness:  repository.DataFreshness{LastImportedAt: at(49 * time.Hour)},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(49 * time.Hour),
+			wantHours:  ptr(49.0),
+			wantStale:  true,
+		},
+		{
+			name:       "exactly at the threshold",
+			freshness:  repository.DataFreshness{LastImportedAt: at(48 * time.Hour)},
+			staleAfter: 48 * time.Hour,
+			wantAsOf:   at(48 * time.Hour),
+			wantHours:  ptr(48.0),
+		},
+		{
+			name:       "zero threshold is never stale",
+			freshness:  repository.DataFreshness{LastImportedAt: at(1000 * time.Hour)},
+			staleAfter: 0,
+			wantAsOf:   at(1000 * time.Hour),
+			wantHours:  ptr(1000.0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := dataMeta(tt.freshness, tt.staleAfter, now)
+			if (meta.DataAsOf == nil) != (tt.wantAsOf == nil) || (meta.DataAsOf != nil && !meta.DataAsOf.Equal(*tt.wantAsOf)) {
+				t.Errorf("DataAsOf = %v, want %v", meta.DataAsOf, tt.wantAsOf)
+			}
+			if (meta.StalenessHours == nil) != (tt.wantHours == nil) || (meta.StalenessHours != nil && *meta.StalenessHours != *tt.wantHours) {
+				t.Errorf("StalenessHours = %v, want %v", deref(meta.StalenessHours), deref(tt.wantHours))
+			}
+			if meta.Stale != tt.wantStale {
+				t.Errorf("Stale = %v, want %v", meta.Stale, tt.wantStale)
+			}
+			if meta.StaleAfterHours != tt.staleAfter.Hours() || meta.TotalRows != tt.freshness.TotalRows {
+				t.Errorf("StaleAfterHours, TotalRows = %v, %d, want %v, %d", meta.StaleAfterHours, meta.TotalRows, tt.staleAfter.Hours(), tt.freshness.TotalRows)
+			}
+		})
+	}
+}
+
+func ptr(f float64) *float64 { return &f }
+
+// deref prints a nil staleness as "<nil>" instead of a pointer address
+func deref(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/repository"
+)
+
+// Usage report bounds
+const (
+	DefaultUsageDays = 7
+	MaxUsageDays     = 90
+	DefaultUsageTopN = 5
+	MaxUsageTopN     = 50
+)
+
+// ClientUsageReport is the traffic of one API client over the report window, with its busiest
+// endpoints
+type ClientUsageReport struct {
+	KeyHash      string                        `json:"key_hash"`
+	Requests     int64                         `json:"requests"`
+	Errors       int64                         `json:"errors"`
+	BytesIn      int64                         `json:"bytes_in"`
+	BytesOut     int64                         `json:"bytes_out"`
+	TopEndpoints []repository.ClientRouteUsage `json:"top_endpoints"`
+}
+
+// UsageReport is the per-client traffic between two UTC days (inclusive), busiest client first
+type UsageReport struct {
+	From    string              `json:"from"`
+	To      string              `json:"to"`
+	Clients []ClientUsageReport `json:"clients"`
+}
+
+// usageKey identifies the counters of one client, day, method and route
+type usageKey struct {
+	keyHash string
+	day     time.Time
+	method  string
+	route   string
+}
+
+// usageMeter aggregates request samples in memory and writes them to the client_usage table at
+// most once per interval, so metering costs one upsert per flush instead of one per request. It is
+// shared by the request-scoped copies of the service; samples not yet flushed are lost on restart.
+type usageMeter struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	pending   map[usageKey]*models.ClientUsage
+	lastFlush time.Time
+	flushing  bool
+}
+
+func newUsageMeter(interval time.Duration) *usageMeter {
+	return &usageMeter{interval: interval, pending: map[usageKey]*models.ClientUsage{}, lastFlush: time.Now()}
+}
+
+// add counts one request and reports whether a flush is due
+func (m *usageMeter) add(key usageKey, bytesIn, bytesOut int64, failed bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.merge(key, &models.ClientUsage{Requests: 1, BytesIn: bytesIn, BytesOut: bytesOut, Errors: boolCount(failed)})
+	return !m.flushing && time.Since(m.lastFlush) >= m.interval
+}
+
+// merge adds counts to the pending counters of key; callers hold mu
+func (m *usageMeter) merge(key usageKey, counts *models.ClientUsage) {
+	row, ok := m.pending[key]
+	if !ok {
+		row = &models.ClientUsage{KeyHash: key.keyHash, Day: key.day, Method: key.method, Route: key.route}
+		m.pending[key] = row
+	}
+	row.Requests += counts.Requests
+	row.Errors += counts.Errors
+	row.BytesIn += counts.BytesIn
+	row.BytesOut += counts.BytesOut
+}
+
+// flush writes the pending counters with repo. Counters that fail to be written are put back and
+// retried with the next flush; concurrent flushes are skipped.
+func (m *usageMeter) flush(repo repository.DataRepositoryInterface) error {
+	m.mu.Lock()
+	if m.flushing {
+		m.mu.Unlock()
+		return nil
+	}
+	m.flushing = true
+	batch := m.pending
+	m.pending = map[usageKey]*models.ClientUsage{}
+	m.lastFlush = time.Now()
+	m.mu.Unlock()
+
+	rows := make([]models.ClientUsage, 0, len(batch))
+	for _, row := range batch {
+		rows = append(rows, *row)
+	}
+	err := repo.RecordClientUsage(rows)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flushing = false
+	if err != nil {
+		for key, row := range batch {
+			m.merge(key, row)
+		}
+	}
+	return err
+}
+
+// boolCount is 1 for true and 0 for false
+func boolCount(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// RecordClientRequest meters one request of the client identified by keyHash to route. Responses
+// with status >= 400 count as errors. Pending counters are flushed in the background once
+// SERVER_USAGE_FLUSH_INTERVAL has passed since the last flush.
+func (s *StockService) RecordClientRequest(keyHash, method, route string, bytesIn, bytesOut int64, status int) {
+	key := usageKey{keyHash: keyHash, day: models.UsageDay(time.Now()), method: method, route: route}
+	if !s.usageMeter.add(key, bytesIn, bytesOut, status >= 400) {
+		return
+	}
+	// The request context ends with the response, so the flush runs on a detached one
+	repo := s.repository.WithContext(context.Background())
+	go func() {
+		if err := s.usageMeter.flush(repo); err != nil {
+			s.logger.Warn("Usage flush failed; retrying with the next flush", "error", err)
+		}
+	}()
+}
+
+// GetUsageReport flushes the pending counters and returns the traffic of every API client over the
+// last days UTC days (today included), with the top busiest endpoints of each. Zero selects the
+// defaults.
+func (s *StockService) GetUsageReport(days, top int) (*UsageReport, error) {
+	if days == 0 {
+		days = DefaultUsageDays
+	}
+	if top == 0 {
+		top = DefaultUsageTopN
+	}
+	if days < 1 || days > MaxUsageDays {
+		return nil, apperrors.Validation("days must be between 1 and %d", MaxUsageDays)
+	}
+	if top < 1 || top > MaxUsageTopN {
+		return nil, apperrors.Validation("top must be between 1 and %d", MaxUsageTopN)
+	}
+	if err := s.usageMeter.flush(s.repository); err != nil {
+		s.logger.Warn("Usage flush failed; the report misses the latest requests", "error", err)
+	}
+
+	to := models.UsageDay(time.Now())
+	from := to.AddDate(0, 0, 1-days)
+	routes, err := s.repository.GetClientUsage(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Routes arrive busiest first, so each client's first routes are its top endpoints
+	clients := map[string]*ClientUsageReport{}
+	for _, route := range routes {
+		client, ok := clients[route.KeyHash]
+		if !ok {
+			client = &ClientUsageReport{KeyHash: route.KeyHash, TopEndpoints: []repository.ClientRouteUsage{}}
+			clients[route.KeyHash] = client
+		}
+		client.Requests += route.Requests
+		client.Errors += route.Errors
+		client.BytesIn += route.BytesIn
+		client.BytesOut += route.BytesOut
+		if len(client.TopEndpoints) < top {
+			client.TopEndpoints = append(client.TopEndpoints, route)
+		}
+	}
+
+	report := &UsageReport{From: from.Format("2006-01-02"), To: to.Format("2006-01-02"), Clients: make([]ClientUsageReport, 0, len(clients))}
+	for _, client := range clients {
+		report.Clients = append(report.Clients, *client)
+	}
+	sort.Slice(report.Clients, func(i, j int) bool {
+		if report.Clients[i].Requests != report.Clients[j].Requests {
+			return report.Clients[i].Requests > report.Clients[j].Requests
+		}
+		return report.Clients[i].KeyHash < report.Clients[j].KeyHash
+	})
+	return report, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"dataextractor/repository"
+)
+
+// Keys of the values kept by readCache
+const (
+	cacheKeyClusters   = "clusters"
+	cacheKeyActions    = "actions"
+	cacheKeyCompanies  = "companies"
+	cacheKeyDispersion = "dispersion"
+)
+
+type readCacheEntry struct {
+	value     interface{}
+	fetchedAt time.Time
+}
+
+// readCache keeps the values the dashboard loads first (unique clusters, actions and companies,
+// per-cluster summaries) in memory, shared by the request-scoped copies of the service. It is only
+// used with CACHE_WARMUP enabled; entries expire with the max-age of the matching endpoint.
+type readCache struct {
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+}
+
+func newReadCache() *readCache {
+	return &readCache{entries: map[string]readCacheEntry{}}
+}
+
+// get returns the value cached under key, if younger than ttl; a zero ttl never serves a value
+func (c *readCache) get(key string, ttl time.Duration) (interface{}, bool) {
+	if c == nil || ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) > ttl {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// put caches value under key
+func (c *readCache) put(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = readCacheEntry{value: value, fetchedAt: time.Now()}
+}
+
+// WarmUp precomputes the unique clusters, actions and companies and the per-cluster dispersion
+// summaries into the read cache, so the first dashboard loads after a deploy or an import do not
+// wait on the database. It does nothing unless CACHE_WARMUP is enabled, and skips the values whose
+// TTL is 0 since they could never be served.
+func (s *StockService) WarmUp() error {
+	uniqueValues, stats := s.config.Cache.UniqueValuesTTL > 0, s.config.Cache.StatsTTL > 0
+	if s.readCache == nil || (!uniqueValues && !stats) {
+		return nil
+	}
+	started := time.Now()
+
+	var clusters []int
+	var actions, companies []string
+	if uniqueValues {
+		var err error
+		if clusters, err = s.repository.GetUniqueClusters(); err != nil {
+			return fmt.Errorf("failed to warm up clusters: %w", err)
+		}
+		if actions, err = s.repository.GetUniqueActions(); err != nil {
+			return fmt.Errorf("failed to warm up actions: %w", err)
+		}
+		if companies, err = s.repository.GetUniqueCompanies(); err != nil {
+			return fmt.Errorf("failed to warm up companies: %w", err)
+		}
+	}
+	var dispersions []repository.ClusterDispersion
+	if stats {
+		var err error
+		if dispersions, err = s.repository.GetClusterDispersion(nil); err != nil {
+			return fmt.Errorf("failed to warm up cluster summaries: %w", err)
+		}
+	}
+
+	if uniqueValues {
+		s.readCache.put(cacheKeyClusters, clusters)
+		s.readCache.put(cacheKeyActions, actions)
+		s.readCache.put(cacheKeyCompanies, companies)
+	}
+	if stats {
+		s.readCache.put(cacheKeyDispersion, dispersions)
+	}
+	s.logger.Info("Warmed up read cache", "duration", time.Since(started).Round(time.Millisecond).String(), "clusters", len(clusters), "actions", len(actions), "companies", len(companies), "summaries", len(dispersions))
+	return nil
+}
+
+// cachedClusters returns the warmed unique clusters while they are fresh
+func (s *StockService) cachedClusters() ([]int, bool) {
+	value, ok := s.readCache.get(cacheKeyClusters, s.config.Cache.UniqueValuesTTL)
+	if !ok {
+		return nil, false
+	}
+	return value.([]int), true
+}
+
+// cachedActions is the unique actions counterpart of cachedClusters
+func (s *StockService) cachedActions() ([]string, bool) {
+	value, ok := s.readCache.get(cacheKeyActions, s.config.Cache.UniqueValuesTTL)
+	if !ok {
+		return nil, false
+	}
+	return value.([]string), true
+}
+
+// cachedCompanies is the unique companies counterpart of cachedClusters
+func (s *StockService) cachedCompanies() ([]string, bool) {
+	value, ok := s.readCache.get(cacheKeyCompanies, s.config.Cache.UniqueValuesTTL)
+	if !ok {
+		return nil, false
+	}
+	return value.([]string), true
+}
+
+// cachedDispersion returns the warmed dispersion summaries of all clusters while they are fresh
+func (s *StockService) cachedDispersion() ([]repository.ClusterDispersion, bool) {
+	value, ok := s.readCache.get(cacheKeyDispersion, s.config.Cache.StatsTTL)
+	if !ok {
+		return nil, false
+	}
+	return value.([]repository.ClusterDispersion), true
+}
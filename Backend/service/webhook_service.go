@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"dataextractor/apperrors"
+	"dataextractor/models"
+	"dataextractor/validators"
+	"dataextractor/webhooks"
+)
+
+// WebhookSubscriptionCreated is a new subscription with its signing secret, which is only
+// returned here, and the rules for verifying its deliveries
+type WebhookSubscriptionCreated struct {
+	Subscription *models.WebhookSubscription `json:"subscription"`
+	Secret       string                      `json:"secret"`
+	Verification webhooks.VerificationScheme `json:"verification"`
+}
+
+// CreateWebhookSubscription registers a callback URL for the requested events under a new secret
+func (s *StockService) CreateWebhookSubscription(request *validators.WebhookSubscriptionRequest) (*WebhookSubscriptionCreated, error) {
+	if err := s.validator.ValidateRequest(request); err != nil {
+		return nil, apperrors.WrapAs(err, apperrors.KindValidation, "validation failed")
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription := &models.WebhookSubscription{URL: request.URL, Events: strings.Join(request.Events, ","), Secret: secret}
+	if err := s.repository.CreateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+	return &WebhookSubscriptionCreated{Subscription: subscription, Secret: secret, Verification: s.WebhookVerification()}, nil
+}
+
+// GetWebhookSubscriptions lists the webhook subscriptions, without their secrets
+func (s *StockService) GetWebhookSubscriptions() ([]models.WebhookSubscription, error) {
+	return s.repository.GetWebhookSubscriptions()
+}
+
+// DeleteWebhookSubscription stops the deliveries of a subscription
+func (s *StockService) DeleteWebhookSubscription(id uint) error {
+	return s.repository.DeleteWebhookSubscription(id)
+}
+
+// WebhookVerification describes how subscribers verify deliveries and reject replays
+func (s *StockService) WebhookVerification() webhooks.VerificationScheme {
+	return webhooks.Describe(s.config.Notifications.WebhookTolerance)
+}
+
+// dispatchWebhook delivers event with data to every subscription of the event in the background.
+// Deliveries are attempted once; failures are only logged, so a job is never failed by a callback.
+func (s *StockService) dispatchWebhook(event string, data interface{}) {
+	subscriptions, err := s.repository.GetWebhookSubscriptions()
+	if err != nil {
+		s.logger.Warn("Failed to list webhook subscriptions; callbacks not sent", "event", event, "error", err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event) {
+			continue
+		}
+		go func(subscription models.WebhookSubscription) {
+			if err := s.webhookSender.Deliver(context.Background(), subscription.URL, subscription.Secret, event, data); err != nil {
+				s.logger.Warn("Webhook callback failed", "event", event, "subscription_id", subscription.ID, "error", err)
+			}
+		}(subscription)
+	}
+}
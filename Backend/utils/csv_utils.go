@@ -1,7 +1,13 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -31,19 +37,105 @@ func ParseInt(s string) int {
 	return v
 }
 
-// ParseTime attempts to parse time; tries RFC3339 from timeStr, then date-only (YYYY-MM-DD), else returns time.Now().
-func ParseTime(dateStr, timeStr string) time.Time {
-	if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
-		return t
+// timeLayouts are the layouts ParseTime accepts, most specific first. Layouts without a zone
+// are interpreted in the caller's default location.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime parses a timestamp from timeStr, falling back to dateStr. It accepts RFC3339, the
+// extractor's "2006-01-02 15:04:05", date-only (YYYY-MM-DD) and epoch milliseconds; values
+// without a zone are read in loc (UTC when nil). An error is returned when neither parses.
+func ParseTime(dateStr, timeStr string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
 	}
-	if dateStr != "" {
-		if d, err := time.Parse("2006-01-02", dateStr); err == nil {
-			return d
+	for _, value := range []string{strings.TrimSpace(timeStr), strings.TrimSpace(dateStr)} {
+		if value == "" {
+			continue
+		}
+		if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.UnixMilli(millis).UTC(), nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+				return t, nil
+			}
 		}
 	}
-	return time.Now()
+	return time.Time{}, fmt.Errorf("invalid time: cannot parse date %q / time %q", dateStr, timeStr)
 }
 
 
 
 
+// utf8BOM is the byte order mark Excel prepends to UTF-8 CSV exports
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// csvDelimiterCandidates are the delimiters DetectCSVDelimiter chooses between
+var csvDelimiterCandidates = []rune{',', ';', '\t'}
+
+// CSVOptions configures how CSV input is read
+type CSVOptions struct {
+	// Field delimiter; 0 detects it from the header line
+	Delimiter rune
+	// Accept bare quotes inside unquoted fields and non-doubled quotes in quoted fields
+	LazyQuotes bool
+}
+
+// ParseCSVDelimiter converts a configured delimiter ("auto", ",", ";", "tab" or "\t") to a rune; auto maps to 0
+func ParseCSVDelimiter(value string) (rune, error) {
+	switch strings.ToLower(value) {
+	case "", "auto":
+		return 0, nil
+	case "tab", `\t`, "\t":
+		return '\t', nil
+	case ",", ";", "|":
+		return rune(value[0]), nil
+	}
+	return 0, fmt.Errorf("invalid CSV delimiter %q: use auto, ',', ';', '|' or tab", value)
+}
+
+// DetectCSVDelimiter returns the candidate delimiter occurring most often in the header line (',' on ties or none)
+func DetectCSVDelimiter(header string) rune {
+	best, bestCount := ',', 0
+	for _, candidate := range csvDelimiterCandidates {
+		if count := strings.Count(header, string(candidate)); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+// NewCSVReader wraps r in a csv.Reader that strips a leading UTF-8 BOM and applies opts,
+// detecting the delimiter from the header line when none is configured
+func NewCSVReader(r io.Reader, opts CSVOptions) (*csv.Reader, error) {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		if _, err := br.Discard(len(utf8BOM)); err != nil {
+			return nil, fmt.Errorf("failed to skip BOM: %w", err)
+		}
+	}
+
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		// Peek (without consuming) up to the end of the header line
+		header, err := br.Peek(br.Size())
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		if i := bytes.IndexByte(header, '\n'); i >= 0 {
+			header = header[:i]
+		}
+		delimiter = DetectCSVDelimiter(string(header))
+	}
+
+	csvr := csv.NewReader(br)
+	csvr.Comma = delimiter
+	csvr.LazyQuotes = opts.LazyQuotes
+	return csvr, nil
+}
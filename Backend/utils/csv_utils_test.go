@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseTime checks the supported layouts, the default location and the error fallback
+func TestParseTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		date    string
+		time    string
+		loc     *time.Location
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "RFC3339 keeps its zone", time: "2024-05-01T14:30:00-04:00", loc: time.UTC, want: time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)},
+		{name: "extractor layout uses default location", time: "2024-05-01 14:30:00", loc: newYork, want: time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)},
+		{name: "epoch millis", time: "1714573800000", loc: newYork, want: time.Date(2024, 5, 1, 14, 30, 0, 0, time.UTC)},
+		{name: "falls back to date", date: "2024-05-01", time: "garbage", loc: nil, want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "unparseable", date: "05/01/2024", time: "", loc: time.UTC, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTime(tc.date, tc.time, tc.loc)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("ParseTime = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestNewCSVReader checks BOM stripping and delimiter detection on Excel-style exports
+func TestNewCSVReader(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		opts  CSVOptions
+	}{
+		{name: "comma", input: "ticker,company\nAAPL,\"Apple, Inc.\"\n"},
+		{name: "semicolon with BOM", input: "\xEF\xBB\xBFticker;company\nAAPL;\"Apple, Inc.\"\n"},
+		{name: "tab", input: "ticker\tcompany\nAAPL\tApple, Inc.\n"},
+		{name: "configured delimiter", input: "ticker;company\nAAPL;Apple, Inc.\n", opts: CSVOptions{Delimiter: ';'}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csvr, err := NewCSVReader(strings.NewReader(tc.input), tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rows, err := csvr.ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read CSV: %v", err)
+			}
+			if len(rows) != 2 || rows[0][0] != "ticker" || rows[1][1] != "Apple, Inc." {
+				t.Errorf("unexpected rows: %q", rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggest returns up to limit candidates close to value, closest first: case-insensitive equals,
+// then candidates within an edit distance of a third of value's length (at least 2), then candidates
+// containing value or contained in it
+func Suggest(value string, candidates []string, limit int) []string {
+	needle := strings.ToLower(strings.TrimSpace(value))
+	if needle == "" || limit <= 0 {
+		return nil
+	}
+	maxDistance := len([]rune(needle)) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	type match struct {
+		candidate string
+		rank      int
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		switch distance := editDistance(needle, lower); {
+		case distance <= maxDistance:
+			matches = append(matches, match{candidate, distance})
+		case strings.Contains(lower, needle) || strings.Contains(needle, lower):
+			matches = append(matches, match{candidate, maxDistance + 1})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.candidate
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b, counted in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
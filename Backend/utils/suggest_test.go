@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSuggest checks typo, case and substring matches and their ordering
+func TestSuggest(t *testing.T) {
+	actions := []string{"target raised by", "target lowered by", "upgraded by", "downgraded by", "reiterated by"}
+
+	testCases := []struct {
+		name  string
+		value string
+		limit int
+		want  []string
+	}{
+		{name: "typo", value: "target raise by", limit: 3, want: []string{"target raised by"}},
+		{name: "case", value: "Upgraded By", limit: 3, want: []string{"upgraded by"}},
+		{name: "substring", value: "lowered", limit: 3, want: []string{"target lowered by"}},
+		{name: "closest first", value: "graded by", limit: 3, want: []string{"upgraded by", "downgraded by"}},
+		{name: "limit", value: "graded by", limit: 1, want: []string{"upgraded by"}},
+		{name: "nothing close", value: "initiated", limit: 3, want: []string{}},
+		{name: "empty value", value: " ", limit: 3, want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Suggest(tc.value, actions, tc.limit)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
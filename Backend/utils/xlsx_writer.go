@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// XLSXWriter streams rows into a single-sheet Office Open XML workbook. It only covers what exports
+// need (inline strings and plain numbers, no styles) so no spreadsheet library has to be vendored.
+type XLSXWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+// NewXLSXWriter writes the workbook scaffolding to w and opens the single worksheet named sheetName.
+// Rows are then added with WriteRow; Close must be called to finish the archive.
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXML(sheetName))},
+	}
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(fw, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worksheet: %w", err)
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, fmt.Errorf("failed to write worksheet: %w", err)
+	}
+
+	return &XLSXWriter{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends one row. Values that parse as numbers are stored as numeric cells so they sort and
+// sum in spreadsheet tools; everything else is stored as an inline string.
+func (x *XLSXWriter) WriteRow(values []string) error {
+	x.rows++
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows); err != nil {
+		return err
+	}
+	for _, value := range values {
+		var err error
+		if isNumericCell(value) {
+			_, err = fmt.Fprintf(x.sheet, `<c><v>%s</v></c>`, value)
+		} else {
+			_, err = fmt.Fprintf(x.sheet, `<c t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, escapeXML(value))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(x.sheet, `</row>`)
+	return err
+}
+
+// Close terminates the worksheet and flushes the archive. It does not close the underlying writer.
+func (x *XLSXWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// escapeXML escapes value for use as XML character data
+func escapeXML(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// isNumericCell reports whether value should be written as a numeric cell
+func isNumericCell(value string) bool {
+	f, err := strconv.ParseFloat(value, 64)
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
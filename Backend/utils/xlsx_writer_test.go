@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestXLSXWriter checks the archive layout and that numbers and escaped strings land in the sheet
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	xw, err := NewXLSXWriter(&buf, "Cluster <1>")
+	if err != nil {
+		t.Fatalf("NewXLSXWriter: %v", err)
+	}
+	if err := xw.WriteRow([]string{"ticker", "final_score"}); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := xw.WriteRow([]string{"AT&T", "0.75"}); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := xw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Cluster &lt;1&gt;"`) {
+		t.Errorf("sheet name not escaped: %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{`<row r="2">`, `AT&amp;T`, `<c><v>0.75</v></c>`, `</sheetData></worksheet>`} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package validators
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateRules configures the accepted range for record dates
+type DateRules struct {
+	MinDate       time.Time
+	MaxFutureSkew time.Duration
+}
+
+// ValidateDate checks that a record date is neither before the configured floor nor in the future
+func (sv *StockValidator) ValidateDate(date time.Time, rules DateRules) error {
+	if !rules.MinDate.IsZero() && date.Before(rules.MinDate) {
+		return fmt.Errorf("date %s is before the minimum allowed date %s", date.Format(time.RFC3339), rules.MinDate.Format("2006-01-02"))
+	}
+	if latest := time.Now().Add(rules.MaxFutureSkew); date.After(latest) {
+		return fmt.Errorf("date %s is in the future", date.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Enumeration names known to the registry
+const (
+	EnumAction = "action"
+	EnumRating = "rating"
+)
+
+// EnumRegistry holds the allowed values for enumerated string fields (action, rating_to, rating_from).
+// A field with no registered values is unrestricted.
+type EnumRegistry struct {
+	mu     sync.RWMutex
+	values map[string]map[string]bool
+}
+
+// NewEnumRegistry creates an empty EnumRegistry
+func NewEnumRegistry() *EnumRegistry {
+	return &EnumRegistry{values: map[string]map[string]bool{}}
+}
+
+// Set replaces the allowed values for an enumeration; blank values are ignored
+func (er *EnumRegistry) Set(name string, allowed []string) {
+	set := make(map[string]bool, len(allowed))
+	for _, v := range allowed {
+		if v = strings.TrimSpace(v); v != "" {
+			set[v] = true
+		}
+	}
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	er.values[name] = set
+}
+
+// Allowed returns the sorted allowed values for an enumeration
+func (er *EnumRegistry) Allowed(name string) []string {
+	er.mu.RLock()
+	defer er.mu.RUnlock()
+	out := make([]string, 0, len(er.values[name]))
+	for v := range er.values[name] {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsAllowed reports whether value is permitted; empty values and unrestricted enumerations always pass
+func (er *EnumRegistry) IsAllowed(name, value string) bool {
+	if value == "" {
+		return true
+	}
+	er.mu.RLock()
+	defer er.mu.RUnlock()
+	set := er.values[name]
+	return len(set) == 0 || set[value]
+}
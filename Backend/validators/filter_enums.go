@@ -0,0 +1,33 @@
+package validators
+
+import "fmt"
+
+// Grouping columns of the cluster filter; GroupingNone leaves the results ungrouped
+const (
+	GroupingNone       = "none"
+	GroupingAction     = "action"
+	GroupingRatingTo   = "rating_to"
+	GroupingRatingFrom = "rating_from"
+)
+
+// GroupingColumns are the allowed grouping_column values
+var GroupingColumns = []string{GroupingNone, GroupingAction, GroupingRatingTo, GroupingRatingFrom}
+
+// Sort directions of the order parameter
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// SortOrders are the allowed order values
+var SortOrders = []string{OrderAsc, OrderDesc}
+
+// checkEnum rejects a value outside allowed, naming the field and listing the allowed values
+func checkEnum(field, value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %q: allowed values are %v", field, value, allowed)
+}
@@ -0,0 +1,164 @@
+package validators
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect produced by GenerateJSONSchema
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GenerateJSONSchema builds a JSON Schema document for a request struct from its json and
+// validate tags. enums supplies the allowed values for the action_enum/rating_enum rules.
+func GenerateJSONSchema(v interface{}, enums map[string][]string) map[string]interface{} {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	schema := structSchema(t, enums)
+	schema["$schema"] = JSONSchemaDraft
+	schema["title"] = t.Name()
+	return schema
+}
+
+// structSchema describes a struct type as an object schema, flattening embedded structs
+func structSchema(t reflect.Type, enums map[string][]string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	collectFields(t, enums, properties, &required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields adds the JSON properties of t (and its embedded structs) to properties
+func collectFields(t reflect.Type, enums map[string][]string, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectFields(field.Type, enums, properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		prop := typeSchema(field.Type, enums)
+		applyRules(prop, field.Type, rules, enums)
+		properties[name] = prop
+
+		if hasRule(rules, "required") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// typeSchema maps a Go type to its JSON Schema type description
+func typeSchema(t reflect.Type, enums map[string][]string) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var schema map[string]interface{}
+	switch {
+	case t == timeType:
+		schema = map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		schema = map[string]interface{}{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		schema = map[string]interface{}{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema = map[string]interface{}{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema = map[string]interface{}{"type": "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		schema = map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), enums)}
+	case t.Kind() == reflect.Struct:
+		schema = structSchema(t, enums)
+	default:
+		schema = map[string]interface{}{}
+	}
+
+	if nullable {
+		schema["type"] = []interface{}{schema["type"], "null"}
+	}
+	return schema
+}
+
+// applyRules translates validate tag rules into JSON Schema keywords on prop
+func applyRules(prop map[string]interface{}, t reflect.Type, rules []string, enums map[string][]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	isString := t.Kind() == reflect.String
+	isArray := t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+
+	for _, rule := range rules {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case isString && key == "min":
+				prop["minLength"] = n
+			case isString:
+				prop["maxLength"] = n
+			case isArray && key == "min":
+				prop["minItems"] = n
+			case isArray:
+				prop["maxItems"] = n
+			case key == "min":
+				prop["minimum"] = n
+			default:
+				prop["maximum"] = n
+			}
+		case "oneof":
+			prop["enum"] = strings.Fields(param)
+		case "alphanum":
+			prop["pattern"] = "^[a-zA-Z0-9]+$"
+		case "sort_keys":
+			prop["pattern"] = sortKeysPattern(FilterSortColumns)
+		case "action_enum":
+			if values := enums[EnumAction]; len(values) > 0 {
+				prop["enum"] = values
+			}
+		case "rating_enum":
+			if values := enums[EnumRating]; len(values) > 0 {
+				prop["enum"] = values
+			}
+		}
+	}
+}
+
+// hasRule reports whether rules contains the named rule
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
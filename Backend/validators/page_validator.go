@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PageParamsContextKey is the gin context key under which the list parameter middleware stores
+// the parsed PageParams; PageRulesContextKey holds the PageRules they were checked against
+const (
+	PageParamsContextKey = "page_params"
+	PageRulesContextKey  = "page_rules"
+)
+
+// PageParams are the page and sort parameters shared by the list endpoints
+type PageParams struct {
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+	SortBy  string `json:"sort_by"`
+	Order   string `json:"order"`
+}
+
+// PageRules configures the defaults and caps applied to PageParams
+type PageRules struct {
+	DefaultPerPage int
+	MaxPerPage     int
+	DefaultSortBy  string
+	SortColumns    []string
+}
+
+// DefaultPageRules are used by list handlers reached without the list parameter middleware
+var DefaultPageRules = PageRules{DefaultPerPage: 20, MaxPerPage: 200, DefaultSortBy: "date"}
+
+// ParsePageParams parses raw page, per_page, sort_by and order query values, filling unset values
+// with the rule defaults before validating them
+func ParsePageParams(page, perPage, sortBy, order string, rules PageRules) (PageParams, error) {
+	params := PageParams{SortBy: strings.TrimSpace(sortBy), Order: strings.ToLower(strings.TrimSpace(order))}
+
+	var err error
+	if page != "" {
+		if params.Page, err = strconv.Atoi(page); err != nil {
+			return PageParams{}, fmt.Errorf("page must be an integer")
+		}
+	}
+	if perPage != "" {
+		if params.PerPage, err = strconv.Atoi(perPage); err != nil {
+			return PageParams{}, fmt.Errorf("per_page must be an integer")
+		}
+	}
+
+	params.ApplyDefaults(rules)
+	if err := params.Validate(rules); err != nil {
+		return PageParams{}, err
+	}
+	return params, nil
+}
+
+// ApplyDefaults fills unset parameters: page 1, rules.DefaultPerPage, rules.DefaultSortBy, desc
+func (p *PageParams) ApplyDefaults(rules PageRules) {
+	if p.Page == 0 {
+		p.Page = 1
+	}
+	if p.PerPage == 0 {
+		p.PerPage = rules.DefaultPerPage
+	}
+	if p.SortBy == "" {
+		p.SortBy = rules.DefaultSortBy
+	}
+	p.Order = strings.ToLower(p.Order)
+	if p.Order == "" {
+		p.Order = OrderDesc
+	}
+}
+
+// Validate checks the page bounds, the per_page cap, the order and the sort_by list (see ParseSortKeys)
+func (p *PageParams) Validate(rules PageRules) error {
+	if p.Page < 1 {
+		return fmt.Errorf("page must be at least 1")
+	}
+	if p.PerPage < 1 || (rules.MaxPerPage > 0 && p.PerPage > rules.MaxPerPage) {
+		return fmt.Errorf("per_page must be between 1 and %d", rules.MaxPerPage)
+	}
+	if err := checkEnum("order", p.Order, SortOrders); err != nil {
+		return err
+	}
+	if p.SortBy != "" {
+		if _, err := ParseSortKeys(p.SortBy, p.Order, rules.SortColumns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package validators
+
+import "testing"
+
+// TestParsePageParams checks defaults, the per_page cap and sort/order validation
+func TestParsePageParams(t *testing.T) {
+	rules := PageRules{DefaultPerPage: 20, MaxPerPage: 200, DefaultSortBy: "date", SortColumns: []string{"date", "ticker"}}
+
+	testCases := []struct {
+		name    string
+		page    string
+		perPage string
+		sortBy  string
+		order   string
+		want    PageParams
+		wantErr bool
+	}{
+		{name: "defaults", want: PageParams{Page: 1, PerPage: 20, SortBy: "date", Order: "desc"}},
+		{name: "explicit values", page: "3", perPage: "50", sortBy: "ticker", order: "ASC", want: PageParams{Page: 3, PerPage: 50, SortBy: "ticker", Order: "asc"}},
+		{name: "at cap", perPage: "200", want: PageParams{Page: 1, PerPage: 200, SortBy: "date", Order: "desc"}},
+		{name: "over cap", perPage: "1000000", wantErr: true},
+		{name: "negative page", page: "-1", wantErr: true},
+		{name: "non-numeric per_page", perPage: "lots", wantErr: true},
+		{name: "unknown sort column", sortBy: "weighted_score", wantErr: true},
+		{name: "secondary sort keys", sortBy: "date desc, ticker asc", want: PageParams{Page: 1, PerPage: 20, SortBy: "date desc, ticker asc", Order: "desc"}},
+		{name: "unknown secondary sort column", sortBy: "date, weighted_score", wantErr: true},
+		{name: "repeated sort column", sortBy: "date asc, date desc", wantErr: true},
+		{name: "bad sort direction", sortBy: "date sideways", wantErr: true},
+		{name: "bad order", order: "sideways", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePageParams(tc.page, tc.perPage, tc.sortBy, tc.order, rules)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestFilterRequestValidateEnums checks grouping_column and order after defaults, and that a
+// mismatch lists the allowed values
+func TestFilterRequestValidateEnums(t *testing.T) {
+	testCases := []struct {
+		name         string
+		grouping     string
+		order        string
+		wantGrouping string
+		wantErr      string
+	}{
+		{name: "defaults", wantGrouping: GroupingNone},
+		{name: "legacy None", grouping: "None", order: "ASC", wantGrouping: GroupingNone},
+		{name: "grouping column", grouping: "rating_to", wantGrouping: GroupingRatingTo},
+		{name: "unknown grouping", grouping: "company", wantErr: `invalid grouping_column "company": allowed values are [none action rating_to rating_from]`},
+		{name: "unknown order", order: "sideways", wantErr: `invalid order "sideways": allowed values are [asc desc]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := FilterRequest{GroupingColumn: tc.grouping, Order: tc.order}
+			request.ApplyDefaults()
+			err := request.ValidateEnums()
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if request.GroupingColumn != tc.wantGrouping {
+				t.Errorf("grouping_column = %q, want %q", request.GroupingColumn, tc.wantGrouping)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package validators
+
+import (
+	"strings"
+	"unicode"
+
+	"dataextractor/models"
+)
+
+// Sanitizer is implemented by requests that normalize their string fields before validation
+type Sanitizer interface {
+	Sanitize()
+}
+
+// SanitizeString trims surrounding whitespace, strips control characters, and collapses
+// internal runs of whitespace to a single space ("Apple  Inc. " -> "Apple Inc.")
+func SanitizeString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	pendingSpace := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = b.Len() > 0
+		case unicode.IsControl(r):
+			// drop
+		default:
+			if pendingSpace {
+				b.WriteByte(' ')
+				pendingSpace = false
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// sanitizeStringPtr sanitizes an optional string in place
+func sanitizeStringPtr(s *string) {
+	if s != nil {
+		*s = SanitizeString(*s)
+	}
+}
+
+// sanitizeSentiments sanitizes sentiment names and ratings in place
+func sanitizeSentiments(reqs []RatingSentimentRequest) {
+	for i := range reqs {
+		reqs[i].Sanitize()
+	}
+}
+
+// sanitizeIndicators sanitizes indicator names in place
+func sanitizeIndicators(reqs []NumericalIndicatorRequest) {
+	for i := range reqs {
+		reqs[i].Sanitize()
+	}
+}
+
+// Sanitize normalizes the string fields of the shared stock fields
+func (sb *StockBase) Sanitize() {
+	sb.Ticker = SanitizeString(sb.Ticker)
+	sb.Company = SanitizeString(sb.Company)
+	sb.Action = SanitizeString(sb.Action)
+	sb.RatingTo = SanitizeString(sb.RatingTo)
+	sb.RatingFrom = SanitizeString(sb.RatingFrom)
+	sb.Brokerage = SanitizeString(sb.Brokerage)
+	sanitizeSentiments(sb.RatingSentiments)
+	sanitizeIndicators(sb.NumericalIndicators)
+}
+
+// Sanitize normalizes the string fields present in a StockUpdateRequest
+func (sur *StockUpdateRequest) Sanitize() {
+	sanitizeStringPtr(sur.Ticker)
+	sanitizeStringPtr(sur.Company)
+	sanitizeStringPtr(sur.Action)
+	sanitizeStringPtr(sur.RatingTo)
+	sanitizeStringPtr(sur.RatingFrom)
+	sanitizeStringPtr(sur.Brokerage)
+	sanitizeSentiments(sur.RatingSentiments)
+	sanitizeIndicators(sur.NumericalIndicators)
+}
+
+// SanitizeStock normalizes the string fields of a model built outside the request layer (e.g. CSV import)
+func SanitizeStock(stock *models.StockDataPoint) {
+	stock.Ticker = SanitizeString(stock.Ticker)
+	stock.Company = SanitizeString(stock.Company)
+	stock.Action = SanitizeString(stock.Action)
+	stock.RatingTo = SanitizeString(stock.RatingTo)
+	stock.RatingFrom = SanitizeString(stock.RatingFrom)
+	stock.Brokerage = SanitizeString(stock.Brokerage)
+	for i := range stock.RatingSentiments {
+		stock.RatingSentiments[i].Name = SanitizeString(stock.RatingSentiments[i].Name)
+		stock.RatingSentiments[i].Rating = SanitizeString(stock.RatingSentiments[i].Rating)
+	}
+	for i := range stock.NumericalIndicators {
+		stock.NumericalIndicators[i].Name = SanitizeString(stock.NumericalIndicators[i].Name)
+	}
+}
+
+// SanitizeTag normalizes a tag name: sanitized, lower-cased, with inner spaces turned into dashes
+func SanitizeTag(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(SanitizeString(tag)), " ", "-")
+}
+
+// SanitizeTags normalizes tag names and drops empty and duplicate entries
+func SanitizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	cleaned := tags[:0]
+	for _, tag := range tags {
+		tag = SanitizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned
+}
+
+// Sanitize normalizes the tag names of a tag request
+func (r *TagRequest) Sanitize() {
+	r.Tags = SanitizeTags(r.Tags)
+}
+
+// Sanitize normalizes the tag filter of a filter request
+func (fr *FilterRequest) Sanitize() {
+	fr.Tags = SanitizeTags(fr.Tags)
+}
+
+// Sanitize normalizes the author and trims the body of a note, keeping its line breaks
+func (r *NoteRequest) Sanitize() {
+	r.Author = SanitizeString(r.Author)
+	r.Body = strings.TrimSpace(r.Body)
+}
+
+// Sanitize lower-cases the kind and term of a rubric entry so lookups are case-insensitive
+func (r *RatingRubricRequest) Sanitize() {
+	r.Kind = strings.ToLower(SanitizeString(r.Kind))
+	r.Term = strings.ToLower(SanitizeString(r.Term))
+}
+
+// Sanitize normalizes the rubric terms and user IDs of a scoring configuration document
+func (r *ScoringConfigRequest) Sanitize() {
+	for i := range r.RatingRubric {
+		r.RatingRubric[i].Sanitize()
+	}
+	for i := range r.WeightProfiles {
+		r.WeightProfiles[i].UserID = SanitizeString(r.WeightProfiles[i].UserID)
+	}
+}
+
+// Sanitize normalizes the reason of a cluster override
+func (r *ClusterAssignmentRequest) Sanitize() {
+	r.Reason = SanitizeString(r.Reason)
+}
+
+// Sanitize normalizes the tickers and reason of a bulk cluster override
+func (r *BulkClusterAssignmentRequest) Sanitize() {
+	for i := range r.Tickers {
+		r.Tickers[i] = SanitizeString(r.Tickers[i])
+	}
+	r.Reason = SanitizeString(r.Reason)
+}
+
+// Sanitize normalizes the name and rating of a single sentiment
+func (r *RatingSentimentRequest) Sanitize() {
+	r.Name = SanitizeString(r.Name)
+	r.Rating = SanitizeString(r.Rating)
+}
+
+// Sanitize normalizes the rating of a sentiment update
+func (r *RatingSentimentUpdateRequest) Sanitize() {
+	r.Rating = SanitizeString(r.Rating)
+}
+
+// Sanitize normalizes the name of a single indicator
+func (r *NumericalIndicatorRequest) Sanitize() {
+	r.Name = SanitizeString(r.Name)
+}
@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterSortColumns are the columns the cluster filter endpoints can sort by
+var FilterSortColumns = []string{
+	"ticker", "action", "date", "company", "cluster", "target_to", "target_from", "target_delta",
+	"last_close", "rating_to", "rating_from", "final_score", "weighted_score",
+}
+
+// SortKey is one column of a sort_by list and its direction ("asc" or "desc")
+type SortKey struct {
+	Column string
+	Order  string
+}
+
+// ParseSortKeys parses a sort_by value: a comma-separated list of columns, each optionally followed
+// by asc or desc, e.g. "final_score desc, date desc, ticker asc". Columns without a direction use
+// defaultOrder. Every column must be in columns (when given) and appear at most once.
+func ParseSortKeys(sortBy, defaultOrder string, columns []string) ([]SortKey, error) {
+	defaultOrder = strings.ToLower(strings.TrimSpace(defaultOrder))
+	if defaultOrder == "" {
+		defaultOrder = "desc"
+	}
+
+	var keys []SortKey
+	seen := map[string]bool{}
+	for _, term := range strings.Split(sortBy, ",") {
+		fields := strings.Fields(term)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("sort_by must be a comma-separated list of \"column [asc|desc]\"")
+		}
+
+		key := SortKey{Column: strings.ToLower(fields[0]), Order: defaultOrder}
+		if len(fields) == 2 {
+			key.Order = strings.ToLower(fields[1])
+		}
+		if key.Order != "asc" && key.Order != "desc" {
+			return nil, fmt.Errorf("sort_by direction of %s must be asc or desc", key.Column)
+		}
+		if len(columns) > 0 && !containsColumn(columns, key.Column) {
+			return nil, fmt.Errorf("sort_by must be one of %v", columns)
+		}
+		if seen[key.Column] {
+			return nil, fmt.Errorf("sort_by lists %s more than once", key.Column)
+		}
+		seen[key.Column] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortKeysPattern is the JSON Schema pattern of a sort_by list over columns
+func sortKeysPattern(columns []string) string {
+	term := `\s*(` + strings.Join(columns, "|") + `)(\s+(asc|desc|ASC|DESC))?\s*`
+	return "^" + term + "(," + term + ")*$"
+}
+
+// containsColumn reports whether columns contains column
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
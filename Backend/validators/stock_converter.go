@@ -5,140 +5,157 @@ import (
 	"time"
 )
 
-// ToStockRequest converts a Stock model to StockRequest
-func (sr *StockRequest) ToStockRequest(stock *models.StockDataPoint) *StockRequest {
-	return &StockRequest{
-		ID:                  stock.ID,
+// stockBaseFrom builds the shared request fields from a Stock model
+func stockBaseFrom(stock *models.StockDataPoint) StockBase {
+	cluster := stock.Cluster
+	return StockBase{
 		Ticker:              stock.Ticker,
 		Company:             stock.Company,
 		Action:              stock.Action,
 		Date:                stock.Date,
-		Cluster:             stock.Cluster,
+		Cluster:             &cluster,
 		TargetTo:            stock.TargetTo,
 		TargetFrom:          stock.TargetFrom,
 		TargetDelta:         stock.TargetDelta,
 		LastClose:           stock.LastClose,
 		RatingTo:            stock.RatingTo,
 		RatingFrom:          stock.RatingFrom,
+		Brokerage:           stock.Brokerage,
 		RatingSentiments:    toRatingSentimentRequests(stock.RatingSentiments),
 		NumericalIndicators: toNumericalIndicatorRequests(stock.NumericalIndicators),
 	}
 }
 
-// ToStock converts a StockRequest to Stock model
-func (sr *StockRequest) ToStock() *models.StockDataPoint {
+// toStock converts the shared request fields to a Stock model with the given ID
+func (sb *StockBase) toStock(id uint) *models.StockDataPoint {
+	cluster := 0
+	if sb.Cluster != nil {
+		cluster = *sb.Cluster
+	}
 	return &models.StockDataPoint{
-		ID:                  sr.ID,
-		Ticker:              sr.Ticker,
-		Company:             sr.Company,
-		Action:              sr.Action,
-		Date:                sr.Date,
-		Cluster:             sr.Cluster,
-		TargetTo:            sr.TargetTo,
-		TargetFrom:          sr.TargetFrom,
-		TargetDelta:         sr.TargetDelta,
-		LastClose:           sr.LastClose,
-		RatingTo:            sr.RatingTo,
-		RatingFrom:          sr.RatingFrom,
-		RatingSentiments:    toRatingSentiments(sr.RatingSentiments),
-		NumericalIndicators: toNumericalIndicators(sr.NumericalIndicators),
+		ID:                  id,
+		Ticker:              sb.Ticker,
+		Company:             sb.Company,
+		Action:              sb.Action,
+		Date:                sb.Date,
+		Cluster:             cluster,
+		TargetTo:            sb.TargetTo,
+		TargetFrom:          sb.TargetFrom,
+		TargetDelta:         sb.TargetDelta,
+		LastClose:           sb.LastClose,
+		RatingTo:            sb.RatingTo,
+		RatingFrom:          sb.RatingFrom,
+		Brokerage:           sb.Brokerage,
+		RatingSentiments:    toRatingSentiments(sb.RatingSentiments),
+		NumericalIndicators: toNumericalIndicators(sb.NumericalIndicators),
 	}
 }
 
+// ToStockRequest converts a Stock model to StockRequest
+func (sr *StockRequest) ToStockRequest(stock *models.StockDataPoint) *StockRequest {
+	return &StockRequest{ID: stock.ID, StockBase: stockBaseFrom(stock)}
+}
+
+// ToStock converts a StockRequest to Stock model
+func (sr *StockRequest) ToStock() *models.StockDataPoint {
+	return sr.toStock(sr.ID)
+}
+
 // ToStockCreateRequest converts a Stock model to StockCreateRequest
 func (scr *StockCreateRequest) ToStockCreateRequest(stock *models.StockDataPoint) *StockCreateRequest {
-	return &StockCreateRequest{
-		Ticker:              stock.Ticker,
-		Company:             stock.Company,
-		Action:              stock.Action,
-		Date:                stock.Date,
-		Cluster:             stock.Cluster,
-		TargetTo:            stock.TargetTo,
-		TargetFrom:          stock.TargetFrom,
-		TargetDelta:         stock.TargetDelta,
-		LastClose:           stock.LastClose,
-		RatingTo:            stock.RatingTo,
-		RatingFrom:          stock.RatingFrom,
-		RatingSentiments:    toRatingSentimentRequests(stock.RatingSentiments),
-		NumericalIndicators: toNumericalIndicatorRequests(stock.NumericalIndicators),
-	}
+	return &StockCreateRequest{StockBase: stockBaseFrom(stock)}
 }
 
 // ToStock converts a StockCreateRequest to Stock model
 func (scr *StockCreateRequest) ToStock() *models.StockDataPoint {
-	return &models.StockDataPoint{
-		Ticker:              scr.Ticker,
-		Company:             scr.Company,
-		Action:              scr.Action,
-		Date:                scr.Date,
-		Cluster:             scr.Cluster,
-		TargetTo:            scr.TargetTo,
-		TargetFrom:          scr.TargetFrom,
-		TargetDelta:         scr.TargetDelta,
-		LastClose:           scr.LastClose,
-		RatingTo:            scr.RatingTo,
-		RatingFrom:          scr.RatingFrom,
-		RatingSentiments:    toRatingSentiments(scr.RatingSentiments),
-		NumericalIndicators: toNumericalIndicators(scr.NumericalIndicators),
-	}
-}
-
-// ToStockUpdateRequest converts a Stock model to StockUpdateRequest
+	return scr.toStock(0)
+}
+
+// ToStockUpdateRequest converts a Stock model to StockUpdateRequest with every field set
 func (sur *StockUpdateRequest) ToStockUpdateRequest(stock *models.StockDataPoint) *StockUpdateRequest {
 	return &StockUpdateRequest{
 		ID:                  stock.ID,
-		Ticker:              stock.Ticker,
-		Company:             stock.Company,
-		Action:              stock.Action,
-		Date:                stock.Date,
-		Cluster:             stock.Cluster,
-		TargetTo:            stock.TargetTo,
-		TargetFrom:          stock.TargetFrom,
-		TargetDelta:         stock.TargetDelta,
-		LastClose:           stock.LastClose,
-		RatingTo:            stock.RatingTo,
-		RatingFrom:          stock.RatingFrom,
+		Ticker:              &stock.Ticker,
+		Company:             &stock.Company,
+		Action:              &stock.Action,
+		Date:                &stock.Date,
+		Cluster:             &stock.Cluster,
+		TargetTo:            &stock.TargetTo,
+		TargetFrom:          &stock.TargetFrom,
+		TargetDelta:         &stock.TargetDelta,
+		LastClose:           &stock.LastClose,
+		RatingTo:            &stock.RatingTo,
+		RatingFrom:          &stock.RatingFrom,
+		Brokerage:           &stock.Brokerage,
 		RatingSentiments:    toRatingSentimentRequests(stock.RatingSentiments),
 		NumericalIndicators: toNumericalIndicatorRequests(stock.NumericalIndicators),
 	}
 }
 
-// ToStock converts a StockUpdateRequest to Stock model
-func (sur *StockUpdateRequest) ToStock() *models.StockDataPoint {
-	return &models.StockDataPoint{
-		ID:                  sur.ID,
-		Ticker:              sur.Ticker,
-		Company:             sur.Company,
-		Action:              sur.Action,
-		Date:                sur.Date,
-		Cluster:             sur.Cluster,
-		TargetTo:            sur.TargetTo,
-		TargetFrom:          sur.TargetFrom,
-		TargetDelta:         sur.TargetDelta,
-		LastClose:           sur.LastClose,
-		RatingTo:            sur.RatingTo,
-		RatingFrom:          sur.RatingFrom,
-		RatingSentiments:    toRatingSentiments(sur.RatingSentiments),
-		NumericalIndicators: toNumericalIndicators(sur.NumericalIndicators),
+// ApplyTo copies the fields present in the update request onto an existing Stock model.
+// Nil fields are left untouched; sentiments and indicators are merged by name so that
+// existing rows keep their IDs and new names are appended.
+func (sur *StockUpdateRequest) ApplyTo(stock *models.StockDataPoint) *models.StockDataPoint {
+	if sur.Ticker != nil {
+		stock.Ticker = *sur.Ticker
+	}
+	if sur.Company != nil {
+		stock.Company = *sur.Company
+	}
+	if sur.Action != nil {
+		stock.Action = *sur.Action
+	}
+	if sur.Date != nil {
+		stock.Date = *sur.Date
+	}
+	if sur.Cluster != nil {
+		stock.Cluster = *sur.Cluster
+	}
+	if sur.TargetTo != nil {
+		stock.TargetTo = *sur.TargetTo
+	}
+	if sur.TargetFrom != nil {
+		stock.TargetFrom = *sur.TargetFrom
+	}
+	if sur.TargetDelta != nil {
+		stock.TargetDelta = *sur.TargetDelta
 	}
+	if sur.LastClose != nil {
+		stock.LastClose = *sur.LastClose
+	}
+	if sur.RatingTo != nil {
+		stock.RatingTo = *sur.RatingTo
+	}
+	if sur.RatingFrom != nil {
+		stock.RatingFrom = *sur.RatingFrom
+	}
+	if sur.Brokerage != nil {
+		stock.Brokerage = *sur.Brokerage
+	}
+	if sur.RatingSentiments != nil {
+		stock.RatingSentiments = mergeRatingSentiments(stock.RatingSentiments, sur.RatingSentiments)
+	}
+	if sur.NumericalIndicators != nil {
+		stock.NumericalIndicators = mergeNumericalIndicators(stock.NumericalIndicators, sur.NumericalIndicators)
+	}
+	return stock
 }
 
 // NewStockCreateRequest creates a new StockCreateRequest with default values
 func NewStockCreateRequest(ticker, company string) *StockCreateRequest {
-	return &StockCreateRequest{
+	return &StockCreateRequest{StockBase: StockBase{
 		Ticker:  ticker,
 		Company: company,
 		Date:    time.Now(),
-	}
+	}}
 }
 
-// NewStockUpdateRequest creates a new StockUpdateRequest
+// NewStockUpdateRequest creates a new StockUpdateRequest that sets ticker and company
 func NewStockUpdateRequest(id uint, ticker, company string) *StockUpdateRequest {
 	return &StockUpdateRequest{
 		ID:      id,
-		Ticker:  ticker,
-		Company: company,
-		Date:    time.Now(),
+		Ticker:  &ticker,
+		Company: &company,
 	}
 }
 
@@ -204,3 +221,42 @@ func toNumericalIndicatorRequests(modelsIn []models.NumericalIndicator) []Numeri
 	}
 	return out
 }
+
+// mergeRatingSentiments updates existing sentiments by name and appends new ones
+func mergeRatingSentiments(existing []models.RatingSentiment, reqs []RatingSentimentRequest) []models.RatingSentiment {
+	for _, incoming := range toRatingSentiments(reqs) {
+		found := false
+		for i := range existing {
+			if existing[i].Name == incoming.Name {
+				existing[i].Rating = incoming.Rating
+				existing[i].RatingScore = incoming.RatingScore
+				existing[i].NormRatingScore = incoming.NormRatingScore
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, incoming)
+		}
+	}
+	return existing
+}
+
+// mergeNumericalIndicators updates existing indicators by name and appends new ones
+func mergeNumericalIndicators(existing []models.NumericalIndicator, reqs []NumericalIndicatorRequest) []models.NumericalIndicator {
+	for _, incoming := range toNumericalIndicators(reqs) {
+		found := false
+		for i := range existing {
+			if existing[i].Name == incoming.Name {
+				existing[i].Value = incoming.Value
+				existing[i].NormValue = incoming.NormValue
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, incoming)
+		}
+	}
+	return existing
+}
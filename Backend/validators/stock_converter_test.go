@@ -0,0 +1,122 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"dataextractor/models"
+)
+
+// sampleStock returns a fully populated model used by the conversion tests
+func sampleStock() *models.StockDataPoint {
+	return &models.StockDataPoint{
+		ID:          7,
+		Ticker:      "AAPL",
+		Company:     "Apple Inc.",
+		Action:      "upgraded by",
+		Date:        time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Cluster:     0,
+		TargetTo:    210,
+		TargetFrom:  190,
+		TargetDelta: 20,
+		LastClose:   185.5,
+		RatingTo:    "Buy",
+		RatingFrom:  "Neutral",
+		RatingSentiments: []models.RatingSentiment{
+			{Name: "action", Rating: "upgraded by", RatingScore: 1, NormRatingScore: 0.8},
+		},
+		NumericalIndicators: []models.NumericalIndicator{
+			{Name: "atr", Value: 3.2, NormValue: 0.4},
+		},
+	}
+}
+
+// TestStockRequestRoundTrip checks that model -> request -> model conversions preserve every field
+func TestStockRequestRoundTrip(t *testing.T) {
+	stock := sampleStock()
+
+	testCases := []struct {
+		name   string
+		wantID uint
+		got    *models.StockDataPoint
+	}{
+		{
+			name:   "StockRequest keeps ID",
+			wantID: stock.ID,
+			got:    (&StockRequest{}).ToStockRequest(stock).ToStock(),
+		},
+		{
+			name:   "StockCreateRequest drops ID",
+			wantID: 0,
+			got:    (&StockCreateRequest{}).ToStockCreateRequest(stock).ToStock(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := *sampleStock()
+			want.ID = tc.wantID
+			if !reflect.DeepEqual(*tc.got, want) {
+				t.Errorf("round trip mismatch:\n got: %+v\nwant: %+v", *tc.got, want)
+			}
+		})
+	}
+}
+
+// TestStockUpdateRequestApplyTo checks that only fields present in the update are applied
+func TestStockUpdateRequestApplyTo(t *testing.T) {
+	stock := sampleStock()
+	stock.RatingSentiments[0].ID = 11
+
+	company := "Apple"
+	cluster := 0
+	request := &StockUpdateRequest{
+		ID:      stock.ID,
+		Company: &company,
+		Cluster: &cluster,
+		RatingSentiments: []RatingSentimentRequest{
+			{Name: "action", Rating: "downgraded by", RatingScore: -1, NormRatingScore: 0.1},
+			{Name: "rating_to", Rating: "Sell", RatingScore: -2, NormRatingScore: 0},
+		},
+	}
+	request.ApplyTo(stock)
+
+	if stock.Company != company {
+		t.Errorf("Company = %q, want %q", stock.Company, company)
+	}
+	if stock.Ticker != "AAPL" || stock.TargetTo != 210 {
+		t.Errorf("unset fields were modified: ticker=%q target_to=%v", stock.Ticker, stock.TargetTo)
+	}
+	if len(stock.RatingSentiments) != 2 {
+		t.Fatalf("expected 2 sentiments after merge, got %d", len(stock.RatingSentiments))
+	}
+	if stock.RatingSentiments[0].ID != 11 || stock.RatingSentiments[0].Rating != "downgraded by" {
+		t.Errorf("existing sentiment not updated in place: %+v", stock.RatingSentiments[0])
+	}
+	if len(stock.NumericalIndicators) != 1 {
+		t.Errorf("indicators should be untouched when omitted, got %d", len(stock.NumericalIndicators))
+	}
+}
+
+// TestValidateRequestSanitizes checks that string fields are normalized before validation
+func TestValidateRequestSanitizes(t *testing.T) {
+	request := NewStockCreateRequest(" AAPL ", "Apple  Inc. ")
+	request.Date = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := NewStockValidator().ValidateRequest(request); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if request.Ticker != "AAPL" || request.Company != "Apple Inc." {
+		t.Errorf("fields not sanitized: ticker=%q company=%q", request.Ticker, request.Company)
+	}
+}
+
+// TestSanitizeTags checks tag normalization and de-duplication
+func TestSanitizeTags(t *testing.T) {
+	got := SanitizeTags([]string{" Earnings Week ", "review", "REVIEW", "  "})
+	want := []string{"earnings-week", "review"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SanitizeTags = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package validators
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -21,57 +24,140 @@ type NumericalIndicatorRequest struct {
 	NormValue float64 `json:"norm_value" validate:"required"`
 }
 
-// StockRequest represents the request structure for stock operations with validation
-type StockRequest struct {
-	ID                  uint                        `json:"id" validate:"omitempty,min=1"`
+// RatingSentimentUpdateRequest replaces the values of a sentiment addressed by name
+type RatingSentimentUpdateRequest struct {
+	Rating          string  `json:"rating" validate:"required,min=1,max=50"`
+	RatingScore     float64 `json:"rating_score" validate:"required"`
+	NormRatingScore float64 `json:"norm_rating_score" validate:"required"`
+}
+
+// NumericalIndicatorUpdateRequest replaces the values of an indicator addressed by name
+type NumericalIndicatorUpdateRequest struct {
+	Value     float64 `json:"value" validate:"required"`
+	NormValue float64 `json:"norm_value" validate:"required"`
+}
+
+// StockBase holds the stock fields shared by the full and create request shapes.
+// Adding a stock field here (plus its pointer form in StockUpdateRequest) exposes it everywhere.
+type StockBase struct {
 	Ticker              string                      `json:"ticker" validate:"required,min=1,max=20,alphanum"`
 	Company             string                      `json:"company" validate:"required,min=1,max=100"`
-	Action              string                      `json:"action" validate:"omitempty,max=100"`
+	Action              string                      `json:"action" validate:"omitempty,max=100,action_enum"`
 	Date                time.Time                   `json:"date" validate:"required"`
-	Cluster             int                         `json:"cluster" validate:"required"`
+	Cluster             *int                        `json:"cluster,omitempty" validate:"omitnil,min=-1"`
 	TargetTo            float64                     `json:"target_to" validate:"omitempty"`
 	TargetFrom          float64                     `json:"target_from" validate:"omitempty"`
 	TargetDelta         float64                     `json:"target_delta" validate:"omitempty"`
 	LastClose           float64                     `json:"last_close" validate:"omitempty"`
-	RatingTo            string                      `json:"rating_to" validate:"omitempty,max=50"`
-	RatingFrom          string                      `json:"rating_from" validate:"omitempty,max=50"`
+	RatingTo            string                      `json:"rating_to" validate:"omitempty,max=50,rating_enum"`
+	RatingFrom          string                      `json:"rating_from" validate:"omitempty,max=50,rating_enum"`
+	Brokerage           string                      `json:"brokerage" validate:"omitempty,max=100"`
 	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments" validate:"dive"`
 	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators" validate:"dive"`
 }
 
+// StockRequest represents the request structure for stock operations with validation
+type StockRequest struct {
+	ID uint `json:"id" validate:"omitempty,min=1"`
+	StockBase
+}
+
 // StockCreateRequest represents the request structure for creating a new stock
 type StockCreateRequest struct {
-	Ticker              string                      `json:"ticker" validate:"required,min=1,max=20,alphanum"`
-	Company             string                      `json:"company" validate:"required,min=1,max=100"`
-	Action              string                      `json:"action" validate:"omitempty,max=100"`
-	Date                time.Time                   `json:"date" validate:"required"`
-	Cluster             int                         `json:"cluster" validate:"required"`
-	TargetTo            float64                     `json:"target_to" validate:"omitempty"`
-	TargetFrom          float64                     `json:"target_from" validate:"omitempty"`
-	TargetDelta         float64                     `json:"target_delta" validate:"omitempty"`
-	LastClose           float64                     `json:"last_close" validate:"omitempty"`
-	RatingTo            string                      `json:"rating_to" validate:"omitempty,max=50"`
-	RatingFrom          string                      `json:"rating_from" validate:"omitempty,max=50"`
-	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments" validate:"dive"`
-	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators" validate:"dive"`
+	StockBase
 }
 
-// StockUpdateRequest represents the request structure for updating a stock
+// StockUpdateRequest represents the request structure for updating a stock.
+// Optional fields are pointers so that an omitted field (nil) is distinguishable from a zero value;
+// only non-nil fields are applied to the existing record.
 type StockUpdateRequest struct {
 	ID                  uint                        `json:"id" validate:"required,min=1"`
-	Ticker              string                      `json:"ticker" validate:"required,min=1,max=20,alphanum"`
-	Company             string                      `json:"company" validate:"required,min=1,max=100"`
-	Action              string                      `json:"action" validate:"omitempty,max=100"`
-	Date                time.Time                   `json:"date" validate:"required"`
-	Cluster             int                         `json:"cluster" validate:"required"`
-	TargetTo            float64                     `json:"target_to" validate:"omitempty"`
-	TargetFrom          float64                     `json:"target_from" validate:"omitempty"`
-	TargetDelta         float64                     `json:"target_delta" validate:"omitempty"`
-	LastClose           float64                     `json:"last_close" validate:"omitempty"`
-	RatingTo            string                      `json:"rating_to" validate:"omitempty,max=50"`
-	RatingFrom          string                      `json:"rating_from" validate:"omitempty,max=50"`
-	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments" validate:"dive"`
-	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators" validate:"dive"`
+	Ticker              *string                     `json:"ticker,omitempty" validate:"omitnil,min=1,max=20,alphanum"`
+	Company             *string                     `json:"company,omitempty" validate:"omitnil,min=1,max=100"`
+	Action              *string                     `json:"action,omitempty" validate:"omitnil,max=100,action_enum"`
+	Date                *time.Time                  `json:"date,omitempty" validate:"omitnil"`
+	Cluster             *int                        `json:"cluster,omitempty" validate:"omitnil,min=-1"`
+	TargetTo            *float64                    `json:"target_to,omitempty" validate:"omitnil"`
+	TargetFrom          *float64                    `json:"target_from,omitempty" validate:"omitnil"`
+	TargetDelta         *float64                    `json:"target_delta,omitempty" validate:"omitnil"`
+	LastClose           *float64                    `json:"last_close,omitempty" validate:"omitnil"`
+	RatingTo            *string                     `json:"rating_to,omitempty" validate:"omitnil,max=50,rating_enum"`
+	RatingFrom          *string                     `json:"rating_from,omitempty" validate:"omitnil,max=50,rating_enum"`
+	Brokerage           *string                     `json:"brokerage,omitempty" validate:"omitnil,max=100"`
+	RatingSentiments    []RatingSentimentRequest    `json:"rating_sentiments,omitempty" validate:"omitempty,dive"`
+	NumericalIndicators []NumericalIndicatorRequest `json:"numerical_indicators,omitempty" validate:"omitempty,dive"`
+}
+
+// TagRequest lists the tags to attach to a stock
+type TagRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,min=1,max=50"`
+}
+
+// NoteRequest captures the content of a stock note. Author is only used for unauthenticated
+// callers; authenticated writes are attributed to the caller.
+type NoteRequest struct {
+	Author string `json:"author" validate:"omitempty,max=100"`
+	Body   string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// RatingRubricRequest maps a rating or action term to the scores given to sentiments carrying it
+type RatingRubricRequest struct {
+	Kind      string   `json:"kind" validate:"required,oneof=rating action"`
+	Term      string   `json:"term" validate:"required,min=1,max=100"`
+	Score     *float64 `json:"score" validate:"required"`
+	NormScore *float64 `json:"norm_score" validate:"required"`
+}
+
+// PreferencesRequest replaces the caller's dashboard defaults
+type PreferencesRequest struct {
+	DefaultCluster   *int            `json:"default_cluster" validate:"omitnil,min=-1"`
+	NumericalWeights []WeightRequest `json:"numerical_weights" validate:"omitempty,dive"`
+	RatingWeights    []WeightRequest `json:"rating_weights" validate:"omitempty,dive"`
+}
+
+// WeightProfileRequest is the dashboard defaults of one user in a scoring configuration document
+type WeightProfileRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1,max=100"`
+	PreferencesRequest
+}
+
+// ScoringIndicators lists the indicator and sentiment names an environment can weight
+type ScoringIndicators struct {
+	Numerical []string `json:"numerical"`
+	Rating    []string `json:"rating"`
+}
+
+// ScoringConfigRequest is a scoring configuration document, as exported by GET /scoring-config,
+// to import into this environment
+type ScoringConfigRequest struct {
+	Version        int                    `json:"version" validate:"required,eq=1"`
+	Indicators     ScoringIndicators      `json:"indicators"`
+	RatingRubric   []RatingRubricRequest  `json:"rating_rubric" validate:"omitempty,max=1000,dive"`
+	WeightProfiles []WeightProfileRequest `json:"weight_profiles" validate:"omitempty,max=1000,dive"`
+}
+
+// ClusterAssignmentRequest overrides the cluster of a single stock
+type ClusterAssignmentRequest struct {
+	Cluster *int   `json:"cluster" validate:"required,min=-1"`
+	Reason  string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// BulkClusterAssignmentRequest moves a list of tickers to a cluster
+type BulkClusterAssignmentRequest struct {
+	Tickers []string `json:"tickers" validate:"required,min=1,max=500,dive,required,max=20,alphanum"`
+	Cluster *int     `json:"cluster" validate:"required,min=-1"`
+	Reason  string   `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// NotificationTestRequest selects the channel a test notification is sent on; empty means all
+type NotificationTestRequest struct {
+	Channel string `json:"channel" validate:"omitempty,oneof=email slack"`
+}
+
+// WebhookSubscriptionRequest registers a callback URL for a set of events
+type WebhookSubscriptionRequest struct {
+	URL    string   `json:"url" validate:"required,max=2048,url,startswith=http"`
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=extraction.completed extraction.failed"`
 }
 
 // StockExtractRequest represents the request structure for data extraction
@@ -82,17 +168,57 @@ type StockExtractRequest struct {
 // StockValidator handles validation for stock-related requests
 type StockValidator struct {
 	validator *validator.Validate
+	enums     *EnumRegistry
 }
 
-// NewStockValidator creates a new StockValidator instance
+// NewStockValidator creates a new StockValidator instance with unrestricted enumerations
 func NewStockValidator() *StockValidator {
+	return NewStockValidatorWithEnums(NewEnumRegistry())
+}
+
+// NewStockValidatorWithEnums creates a StockValidator that checks action/rating fields against enums
+func NewStockValidatorWithEnums(enums *EnumRegistry) *StockValidator {
+	v := validator.New()
+	v.RegisterValidation("action_enum", func(fl validator.FieldLevel) bool {
+		return enums.IsAllowed(EnumAction, fl.Field().String())
+	})
+	v.RegisterValidation("rating_enum", func(fl validator.FieldLevel) bool {
+		return enums.IsAllowed(EnumRating, fl.Field().String())
+	})
+	v.RegisterValidation("sort_keys", func(fl validator.FieldLevel) bool {
+		_, err := ParseSortKeys(fl.Field().String(), "", FilterSortColumns)
+		return err == nil
+	})
 	return &StockValidator{
-		validator: validator.New(),
+		validator: v,
+		enums:     enums,
 	}
 }
 
-// ValidateRequest validates any request struct using the validator
+// Enums returns the enumeration registry used by the validator
+func (sv *StockValidator) Enums() *EnumRegistry {
+	return sv.enums
+}
+
+// ValidateEnums checks action and rating values against the registered enumerations
+func (sv *StockValidator) ValidateEnums(action, ratingTo, ratingFrom string) error {
+	if !sv.enums.IsAllowed(EnumAction, action) {
+		return fmt.Errorf("invalid action %q: allowed values are %v", action, sv.enums.Allowed(EnumAction))
+	}
+	if !sv.enums.IsAllowed(EnumRating, ratingTo) {
+		return fmt.Errorf("invalid rating_to %q: allowed values are %v", ratingTo, sv.enums.Allowed(EnumRating))
+	}
+	if !sv.enums.IsAllowed(EnumRating, ratingFrom) {
+		return fmt.Errorf("invalid rating_from %q: allowed values are %v", ratingFrom, sv.enums.Allowed(EnumRating))
+	}
+	return nil
+}
+
+// ValidateRequest sanitizes (when supported) and validates any request struct using the validator
 func (sv *StockValidator) ValidateRequest(request interface{}) error {
+	if s, ok := request.(Sanitizer); ok {
+		s.Sanitize()
+	}
 	return sv.validator.Struct(request)
 }
 
@@ -106,7 +232,144 @@ func (sv *StockValidator) ValidateCompany(company string) error {
 	return sv.validator.Var(company, "required,min=1,max=100")
 }
 
+// ValidateUUID validates a UUID string
+func (sv *StockValidator) ValidateUUID(uuid string) error {
+	return sv.validator.Var(uuid, "required,uuid")
+}
+
 // ValidateID validates an ID
 func (sv *StockValidator) ValidateID(id uint) error {
 	return sv.validator.Var(id, "required,min=1")
 }
+
+// WeightRequest captures a weight for an indicator/sentiment name
+type WeightRequest struct {
+	IndicatorName string  `json:"indicator_name" validate:"required"`
+	Weight        float64 `json:"weight"`
+}
+
+// FilterRequest represents the grouped/paginated/weighted cluster filter parameters.
+// It binds from the query string (weights as URL-encoded JSON arrays) or from a JSON body.
+type FilterRequest struct {
+	GroupingColumn   string          `form:"grouping_column" json:"grouping_column" validate:"omitempty,oneof=none action rating_to rating_from"`
+	GroupingValue    string          `form:"grouping_value" json:"grouping_value" validate:"omitempty,max=100"`
+	SortBy           string          `form:"sort_by" json:"sort_by" validate:"omitempty,max=200,sort_keys"`
+	Order            string          `form:"order" json:"order" validate:"omitempty,oneof=asc desc"`
+	Page             int             `form:"page" json:"page" validate:"omitempty,min=1"`
+	PerPage          int             `form:"per_page" json:"per_page" validate:"omitempty,min=1"`
+	Tags             []string        `form:"tags" json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Relations        string          `form:"relations" json:"relations" validate:"omitempty,oneof=full scoring none"`
+	Aggregate        bool            `form:"aggregate" json:"aggregate"`
+	Contributions    bool            `form:"contributions" json:"contributions"`
+	NumericalWeights []WeightRequest `form:"-" json:"numerical_weights" validate:"omitempty,dive"`
+	RatingWeights    []WeightRequest `form:"-" json:"rating_weights" validate:"omitempty,dive"`
+
+	// Inclusive bounds on the numeric columns; unset bounds are open
+	MinFinalScore  *float64 `form:"min_final_score" json:"min_final_score,omitempty" validate:"omitnil"`
+	MaxFinalScore  *float64 `form:"max_final_score" json:"max_final_score,omitempty" validate:"omitnil"`
+	MinTargetTo    *float64 `form:"min_target_to" json:"min_target_to,omitempty" validate:"omitnil"`
+	MaxTargetTo    *float64 `form:"max_target_to" json:"max_target_to,omitempty" validate:"omitnil"`
+	MinTargetFrom  *float64 `form:"min_target_from" json:"min_target_from,omitempty" validate:"omitnil"`
+	MaxTargetFrom  *float64 `form:"max_target_from" json:"max_target_from,omitempty" validate:"omitnil"`
+	MinTargetDelta *float64 `form:"min_target_delta" json:"min_target_delta,omitempty" validate:"omitnil"`
+	MaxTargetDelta *float64 `form:"max_target_delta" json:"max_target_delta,omitempty" validate:"omitnil"`
+	MinLastClose   *float64 `form:"min_last_close" json:"min_last_close,omitempty" validate:"omitnil"`
+	MaxLastClose   *float64 `form:"max_last_close" json:"max_last_close,omitempty" validate:"omitnil"`
+
+	// Raw query-string forms of the weight arrays
+	NumericalWeightsJSON string `form:"numerical_weights" json:"-" swaggerignore:"true"`
+	RatingWeightsJSON    string `form:"rating_weights" json:"-" swaggerignore:"true"`
+}
+
+// ParseQueryWeights decodes the URL-encoded JSON weight arrays bound from the query string
+func (fr *FilterRequest) ParseQueryWeights() error {
+	if fr.NumericalWeightsJSON != "" {
+		if err := json.Unmarshal([]byte(fr.NumericalWeightsJSON), &fr.NumericalWeights); err != nil {
+			return fmt.Errorf("numerical_weights must be a JSON array of {indicator_name, weight}: %w", err)
+		}
+	}
+	if fr.RatingWeightsJSON != "" {
+		if err := json.Unmarshal([]byte(fr.RatingWeightsJSON), &fr.RatingWeights); err != nil {
+			return fmt.Errorf("rating_weights must be a JSON array of {indicator_name, weight}: %w", err)
+		}
+	}
+	return nil
+}
+
+// SortsByWeightedScore reports whether weighted_score is one of the sort keys
+func (fr *FilterRequest) SortsByWeightedScore() bool {
+	keys, err := ParseSortKeys(fr.SortBy, fr.Order, nil)
+	if err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if key.Column == "weighted_score" {
+			return true
+		}
+	}
+	return false
+}
+
+// RangeBound is an inclusive bound on a numeric column; a nil Min or Max leaves that side open
+type RangeBound struct {
+	Column string
+	Min    *float64
+	Max    *float64
+}
+
+// RangeBounds returns the bounded columns of the filter, rejecting a column whose min exceeds its max
+func (fr *FilterRequest) RangeBounds() ([]RangeBound, error) {
+	all := []RangeBound{
+		{Column: "final_score", Min: fr.MinFinalScore, Max: fr.MaxFinalScore},
+		{Column: "target_to", Min: fr.MinTargetTo, Max: fr.MaxTargetTo},
+		{Column: "target_from", Min: fr.MinTargetFrom, Max: fr.MaxTargetFrom},
+		{Column: "target_delta", Min: fr.MinTargetDelta, Max: fr.MaxTargetDelta},
+		{Column: "last_close", Min: fr.MinLastClose, Max: fr.MaxLastClose},
+	}
+
+	var bounds []RangeBound
+	for _, b := range all {
+		if b.Min == nil && b.Max == nil {
+			continue
+		}
+		if b.Min != nil && b.Max != nil && *b.Min > *b.Max {
+			return nil, fmt.Errorf("min_%[1]s must not exceed max_%[1]s", b.Column)
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds, nil
+}
+
+// ApplyDefaults fills unset filter parameters with their defaults. grouping_column and order are
+// lowercased, so "None" and "DESC" are accepted as none and desc.
+func (fr *FilterRequest) ApplyDefaults() {
+	fr.GroupingColumn = strings.ToLower(strings.TrimSpace(fr.GroupingColumn))
+	if fr.GroupingColumn == "" {
+		fr.GroupingColumn = GroupingNone
+	}
+	if fr.SortBy == "" {
+		fr.SortBy = "date"
+	}
+	if fr.Relations == "" {
+		fr.Relations = "full"
+	}
+	fr.Order = strings.ToLower(strings.TrimSpace(fr.Order))
+	if fr.Order == "" {
+		fr.Order = OrderDesc
+	}
+	if fr.Page == 0 {
+		fr.Page = 1
+	}
+	if fr.PerPage == 0 {
+		fr.PerPage = 20
+	}
+}
+
+// ValidateEnums checks grouping_column and order against their allowed values, so a mismatch is
+// reported with the values the caller can use
+func (fr *FilterRequest) ValidateEnums() error {
+	if err := checkEnum("grouping_column", fr.GroupingColumn, GroupingColumns); err != nil {
+		return err
+	}
+	return checkEnum("order", fr.Order, SortOrders)
+}
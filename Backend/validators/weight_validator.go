@@ -0,0 +1,43 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+)
+
+// WeightRules configures how indicator/sentiment weights are validated
+type WeightRules struct {
+	MinWeight float64
+	MaxWeight float64
+}
+
+// ValidateWeight checks that a weight is finite, within the configured range, and references a known name
+func (sv *StockValidator) ValidateWeight(name string, weight float64, isKnown func(string) bool, rules WeightRules) error {
+	if name == "" {
+		return fmt.Errorf("indicator_name is required")
+	}
+	if !isKnown(name) {
+		return fmt.Errorf("unknown indicator_name %q", name)
+	}
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("weight for %q must be a finite number", name)
+	}
+	if weight < rules.MinWeight || weight > rules.MaxWeight {
+		return fmt.Errorf("weight for %q must be between %g and %g, got %g", name, rules.MinWeight, rules.MaxWeight, weight)
+	}
+	return nil
+}
+
+// NormalizeWeights scales weights in place so that they sum to 1; a zero total is left unchanged
+func NormalizeWeights(weights ...*float64) {
+	var total float64
+	for _, w := range weights {
+		total += *w
+	}
+	if total == 0 {
+		return
+	}
+	for _, w := range weights {
+		*w /= total
+	}
+}
@@ -0,0 +1,208 @@
+// Package webhooks delivers signed event callbacks to subscriber URLs and verifies them on the
+// receiving side. Every delivery is signed with the subscription's secret over its timestamp, a
+// random nonce and the raw body, so a consumer can check that the callback comes from this server,
+// was not altered, and is not a replay of an earlier delivery.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Delivery headers
+const (
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	NonceHeader     = "X-Webhook-Nonce"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// signatureVersion prefixes the signature so the scheme can change without breaking consumers
+const signatureVersion = "v1="
+
+// secretPrefix marks subscription secrets so they are recognizable in configuration
+const secretPrefix = "whsec_"
+
+// Verification errors
+var (
+	ErrMissingSignature = errors.New("webhook signature headers are missing")
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	ErrStaleTimestamp   = errors.New("webhook timestamp is outside the replay window")
+	ErrReplayed         = errors.New("webhook nonce was already used")
+)
+
+// Envelope is the JSON body of a delivery
+type Envelope struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// VerificationScheme describes how consumers verify deliveries; it is returned with the
+// subscriptions so the rules travel with the secret
+type VerificationScheme struct {
+	Algorithm        string `json:"algorithm"`
+	SignatureHeader  string `json:"signature_header"`
+	TimestampHeader  string `json:"timestamp_header"`
+	NonceHeader      string `json:"nonce_header"`
+	SignedPayload    string `json:"signed_payload"`
+	ToleranceSeconds int    `json:"tolerance_seconds"`
+	Instructions     string `json:"instructions"`
+}
+
+// Describe returns the verification scheme for deliveries accepted within tolerance of their timestamp
+func Describe(tolerance time.Duration) VerificationScheme {
+	return VerificationScheme{
+		Algorithm:        "HMAC-SHA256",
+		SignatureHeader:  SignatureHeader,
+		TimestampHeader:  TimestampHeader,
+		NonceHeader:      NonceHeader,
+		SignedPayload:    "<timestamp>.<nonce>.<raw body>",
+		ToleranceSeconds: int(tolerance.Seconds()),
+		Instructions: fmt.Sprintf("Compute the hex HMAC-SHA256 of the signed payload with the subscription secret and compare it, in constant time, "+
+			"with the %s header after its %q prefix. Reject deliveries whose %s (Unix seconds) is more than %d seconds from your clock, "+
+			"and remember each %s for that long to reject replays.",
+			SignatureHeader, signatureVersion, TimestampHeader, int(tolerance.Seconds()), NonceHeader),
+	}
+}
+
+// NewSecret returns a random subscription secret
+func NewSecret() (string, error) {
+	random, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	return secretPrefix + random, nil
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Sign returns the signature header value of body delivered at timestamp (Unix seconds) with nonce
+func Sign(secret string, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s.", timestamp, nonce)
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery and that its timestamp is within tolerance of now.
+// With guard set, the nonce is also checked against the deliveries already accepted, so a
+// captured delivery cannot be replayed inside the window.
+func Verify(secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration, guard *ReplayGuard) error {
+	signature := header.Get(SignatureHeader)
+	nonce := header.Get(NonceHeader)
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if signature == "" || nonce == "" || err != nil {
+		return ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, nonce, body))) {
+		return ErrInvalidSignature
+	}
+	sentAt := time.Unix(timestamp, 0)
+	if now.Sub(sentAt) > tolerance || sentAt.Sub(now) > tolerance {
+		return ErrStaleTimestamp
+	}
+	if guard != nil && !guard.accept(nonce, now) {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// ReplayGuard remembers the nonces of accepted deliveries for the replay window
+type ReplayGuard struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[string]time.Time
+}
+
+// NewReplayGuard creates a guard remembering nonces for window; use twice the verification
+// tolerance, since a timestamp may be that far in either direction
+func NewReplayGuard(window time.Duration) *ReplayGuard {
+	return &ReplayGuard{window: window, seen: map[string]time.Time{}}
+}
+
+// accept records nonce and reports whether it was unseen within the window
+func (g *ReplayGuard) accept(nonce string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for seen, at := range g.seen {
+		if now.Sub(at) > g.window {
+			delete(g.seen, seen)
+		}
+	}
+	if _, ok := g.seen[nonce]; ok {
+		return false
+	}
+	g.seen[nonce] = now
+	return true
+}
+
+// Sender posts signed deliveries
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a sender whose deliveries time out after timeout
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{client: &http.Client{Timeout: timeout}}
+}
+
+// Deliver posts event with data to url, signed with secret. Any 2xx answer is a success.
+func (s *Sender) Deliver(ctx context.Context, url, secret, event string, data interface{}) error {
+	id, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(Envelope{ID: id, Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	nonce, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(NonceHeader, nonce)
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, nonce, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		answer, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook %s returned status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(answer)))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestDeliverVerify checks that a delivery verifies with its secret and only once within the window
+func TestDeliverVerify(t *testing.T) {
+	const secret = "whsec_test"
+	var (
+		header http.Header
+		body   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	if err := NewSender(time.Second).Deliver(context.Background(), server.URL, secret, "extraction.completed", map[string]int{"id": 7}); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if got := header.Get(EventHeader); got != "extraction.completed" {
+		t.Errorf("%s = %q, want extraction.completed", EventHeader, got)
+	}
+
+	guard := NewReplayGuard(10 * time.Minute)
+	now := time.Now()
+	if err := Verify(secret, header, body, now, 5*time.Minute, guard); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if err := Verify(secret, header, body, now, 5*time.Minute, guard); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replayed Verify() error = %v, want ErrReplayed", err)
+	}
+	if err := Verify("whsec_other", header, body, now, 5*time.Minute, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret Verify() error = %v, want ErrInvalidSignature", err)
+	}
+	if err := Verify(secret, header, append(body, ' '), now, 5*time.Minute, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("altered body Verify() error = %v, want ErrInvalidSignature", err)
+	}
+	if err := Verify(secret, header, body, now.Add(6*time.Minute), 5*time.Minute, nil); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("late Verify() error = %v, want ErrStaleTimestamp", err)
+	}
+	if err := Verify(secret, http.Header{}, body, now, 5*time.Minute, nil); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("unsigned Verify() error = %v, want ErrMissingSignature", err)
+	}
+}
+
+// TestSignCoversTimestamp checks that moving the timestamp invalidates the signature
+func TestSignCoversTimestamp(t *testing.T) {
+	body := []byte(`{"event":"x"}`)
+	timestamp := time.Now().Unix()
+	header := http.Header{}
+	header.Set(TimestampHeader, strconv.FormatInt(timestamp+1, 10))
+	header.Set(NonceHeader, "n")
+	header.Set(SignatureHeader, Sign("s", timestamp, "n", body))
+	if err := Verify("s", header, body, time.Now(), time.Minute, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+// TestDeliverStatus checks that non-2xx answers are reported
+func TestDeliverStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	if err := NewSender(time.Second).Deliver(context.Background(), server.URL, "s", "e", nil); err == nil {
+		t.Error("Deliver() error = nil, want status 410")
+	}
+}
@@ -5,82 +5,32 @@ import type {
   IndicatorWeight,
   FilteredStocksResponse,
   UniqueValuesResponse,
-  ClusterStatsResponse,
+  GroupAggregatesResponse,
+  DataMeta,
 } from '@/types/stock'
+import { ApiClient } from '@/services/generated/apiClient'
 
 // Base API URL - configure this in your .env file or update here
 const API_BASE_URL = import.meta.env.VITE_API_BASE_URL || 'http://localhost:8887'
 
+// Adapts the generated client (src/services/generated, regenerated from the OpenAPI document) to
+// the response types used by the views
 class ApiService {
-  private baseUrl: string
+  private client: ApiClient
 
   constructor(baseUrl: string) {
-    this.baseUrl = baseUrl
+    this.client = new ApiClient({ baseUrl })
   }
 
-  // Build query string from params
-  private buildQuery(params: Record<string, string | number | undefined | null>): string {
-    const search = new URLSearchParams()
-    Object.entries(params).forEach(([key, value]) => {
-      if (value !== undefined && value !== null && value !== '') {
-        search.set(key, String(value))
-      }
-    })
-    const qs = search.toString()
-    return qs ? `?${qs}` : ''
+  // Weights travel in the query string as JSON arrays
+  private encodeWeights(weights?: IndicatorWeight[]): string | undefined {
+    return weights && weights.length > 0 ? JSON.stringify(weights) : undefined
   }
 
-  // Build query string with JSON array encoding for weights
-  private buildFilterQuery(params: {
-    grouping_column?: string
-    grouping_value?: string
-    sort_by?: string
-    order?: 'asc' | 'desc'
-    page?: number
-    per_page?: number
-    numerical_weights?: IndicatorWeight[]
-    rating_weights?: IndicatorWeight[]
-  }): string {
-    const search = new URLSearchParams()
-
-    if (params.grouping_column) {
-      search.set('grouping_column', params.grouping_column)
-    }
-
-    if (params.grouping_value) {
-      search.set('grouping_value', params.grouping_value)
-    }
-
-    if (params.sort_by) {
-      search.set('sort_by', params.sort_by)
-    }
-
-    if (params.order) {
-      search.set('order', params.order)
-    }
-
-    if (params.page !== undefined) {
-      search.set('page', String(params.page))
-    }
-
-    if (params.per_page !== undefined) {
-      search.set('per_page', String(params.per_page))
-    }
-
-    if (params.numerical_weights && params.numerical_weights.length > 0) {
-      search.set('numerical_weights', JSON.stringify(params.numerical_weights))
-    }
-
-    if (params.rating_weights && params.rating_weights.length > 0) {
-      search.set('rating_weights', JSON.stringify(params.rating_weights))
-    }
-
-    const qs = search.toString()
-    return qs ? `?${qs}` : ''
-  }
-
-  // Paginated stocks with optional filters and sorting
-  async getStocksPaginated(options: {
+  // Paginated stocks. The stocks endpoint returns every row and ignores paging and filter
+  // parameters, so the options are currently unused; clustered views use the filter endpoint.
+  // eslint-disable-next-line @typescript-eslint/no-unused-vars
+  async getStocksPaginated(_options: {
     page: number
     perPage: number
     sortBy?: string
@@ -90,77 +40,60 @@ class ApiService {
     cluster?: number
     ticker?: string
   }): Promise<StocksResponse> {
-    const { page, perPage, sortBy, order, action, company, cluster, ticker } = options
-    const query = this.buildQuery({
-      page,
-      per_page: perPage,
-      sort_by: sortBy,
-      order,
-      action,
-      company,
-      cluster,
-      ticker,
-    })
-    // Use base endpoint with query params; backend may ignore unknown params gracefully
-    return this.request<StocksResponse>(`/api/v1/stocks${query}`)
-  }
-
-  private async request<T>(endpoint: string): Promise<T> {
-    const url = `${this.baseUrl}${endpoint}`
-    const response = await fetch(url)
-
-    if (!response.ok) {
-      throw new Error(`API request failed: ${response.statusText}`)
-    }
-
-    return response.json()
+    return (await this.client.getStocks()) as unknown as StocksResponse
   }
 
   // Get all stocks
   async getAllStocks(): Promise<StocksResponse> {
-    return this.request<StocksResponse>('/api/v1/stocks')
+    return (await this.client.getStocks()) as unknown as StocksResponse
   }
 
   // Get stocks by action
   async getStocksByAction(action: string): Promise<StocksResponse> {
-    const encodedAction = encodeURIComponent(action)
-    return this.request<StocksResponse>(`/api/v1/stocks/action/${encodedAction}`)
+    return (await this.client.getStocksActionByAction({ action })) as unknown as StocksResponse
   }
 
   // Get unique actions
   async getActions(): Promise<StocksListResponse> {
-    return this.request<StocksListResponse>('/api/v1/stocks/actions')
+    return (await this.client.getStocksActions()) as unknown as StocksListResponse
   }
 
   // Get stocks by cluster
   async getStocksByCluster(cluster: number): Promise<StocksResponse> {
-    return this.request<StocksResponse>(`/api/v1/stocks/cluster/${cluster}`)
+    return (await this.client.getStocksClusterByCluster({ cluster })) as unknown as StocksResponse
   }
 
   // Get unique clusters
   async getClusters(): Promise<StocksListResponse> {
-    return this.request<StocksListResponse>('/api/v1/stocks/clusters')
+    return (await this.client.getStocksClusters()) as unknown as StocksListResponse
+  }
+
+  // Get when the data was last refreshed and whether it is stale
+  async getDataMeta(): Promise<DataMeta> {
+    const response = await this.client.getStocksMeta()
+    return response.data as DataMeta
   }
 
   // Get unique companies
   async getCompanies(): Promise<StocksListResponse> {
-    return this.request<StocksListResponse>('/api/v1/stocks/companies')
+    return (await this.client.getStocksCompanies()) as unknown as StocksListResponse
   }
 
   // Get stocks by company
   async getStocksByCompany(company: string): Promise<StocksResponse> {
-    const encodedCompany = encodeURIComponent(company)
-    return this.request<StocksResponse>(`/api/v1/stocks/company/${encodedCompany}`)
+    return (await this.client.getStocksCompanyByCompany({ company })) as unknown as StocksResponse
   }
 
   // Get stock by ticker
   async getStockByTicker(ticker: string): Promise<Stock> {
-    return this.request<Stock>(`/api/v1/stocks/ticker/${ticker}`)
+    const response = await this.client.getStocksTickerByTicker({ ticker })
+    return response.data as Stock
   }
 
-  // Get stock by ID
-  async getStockById(id: number): Promise<Stock> {
-    return this.request<Stock>(`/api/v1/stocks/${id}`)
+  // Get stock by UUID
+  async getStockById(uuid: string): Promise<Stock> {
+    const response = await this.client.getStocksById({ id: uuid })
+    return response.data as Stock
   }
 
   // Get filtered stocks by cluster with grouping, sorting, pagination, and weights
@@ -174,23 +107,34 @@ class ApiService {
     per_page?: number
     numerical_weights?: IndicatorWeight[]
     rating_weights?: IndicatorWeight[]
+    contributions?: boolean
   }): Promise<FilteredStocksResponse> {
-    const { cluster, ...queryParams } = options
-    const query = this.buildFilterQuery(queryParams)
-    return this.request<FilteredStocksResponse>(`/api/v1/stocks/cluster/${cluster}/filter${query}`)
+    const { numerical_weights, rating_weights, ...params } = options
+    const response = await this.client.getStocksClusterByClusterFilter({
+      ...params,
+      numerical_weights: this.encodeWeights(numerical_weights),
+      rating_weights: this.encodeWeights(rating_weights),
+    })
+    return response as unknown as FilteredStocksResponse
   }
 
-  // Get unique values for a grouping column within a cluster
-  async getUniqueValues(cluster: number, groupingColumn: string): Promise<UniqueValuesResponse> {
-    const encodedColumn = encodeURIComponent(groupingColumn)
-    return this.request<UniqueValuesResponse>(
-      `/api/v1/stocks/cluster/${cluster}/unique/${encodedColumn}`,
-    )
+  // Get one summary (count, average scores) per value of a grouping column within a cluster
+  async getGroupAggregates(cluster: number, groupingColumn: string): Promise<GroupAggregatesResponse> {
+    const response = await this.client.getStocksClusterByClusterFilter({
+      cluster,
+      grouping_column: groupingColumn,
+      aggregate: true,
+    })
+    return response as unknown as GroupAggregatesResponse
   }
 
-  // Get cluster statistics
-  async getClusterStats(): Promise<ClusterStatsResponse> {
-    return this.request<ClusterStatsResponse>('/api/v1/clusters/stats')
+  // Get unique values for a grouping column within a cluster
+  async getUniqueValues(cluster: number, groupingColumn: string): Promise<UniqueValuesResponse> {
+    const response = await this.client.getStocksClusterByClusterUniqueByColumnName({
+      cluster,
+      column_name: groupingColumn,
+    })
+    return response as unknown as UniqueValuesResponse
   }
 }
 
@@ -0,0 +1,1633 @@
+// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.
+// Regenerate with `npm run generate:client` (or `go generate` in Backend).
+
+export interface BatchCreateResult {
+  created?: number
+  failed?: number
+  results?: BatchItemResult[]
+}
+
+export interface BatchItemResult {
+  data?: StockDataPoint
+  error?: string
+  index?: number
+  success?: boolean
+}
+
+export interface BulkClusterAssignmentRequest {
+  cluster: number
+  reason: string
+  tickers: string[]
+}
+
+export interface ClusterAssignmentRequest {
+  cluster: number
+  reason: string
+}
+
+export interface DataMeta {
+  data_as_of?: string
+  dataset_version?: number
+  last_extracted_at?: string
+  last_imported_at?: string
+  stale?: boolean
+  stale_after_hours?: number
+  staleness_hours?: number
+  total_rows?: number
+}
+
+export interface DatabaseStats {
+  cluster_counts?: Record<string, unknown>
+  total_records?: number
+  unique_companies?: number
+  unique_tickers?: number
+}
+
+export interface DefaultWeightProfile {
+  default_weight?: number
+  weights?: Record<string, unknown>
+}
+
+export interface FilterRequest {
+  aggregate?: boolean
+  contributions?: boolean
+  grouping_column?: 'none' | 'action' | 'rating_to' | 'rating_from'
+  grouping_value?: string
+  max_final_score?: number
+  max_last_close?: number
+  max_target_delta?: number
+  max_target_from?: number
+  max_target_to?: number
+  min_final_score?: number
+  min_last_close?: number
+  min_target_delta?: number
+  min_target_from?: number
+  min_target_to?: number
+  numerical_weights?: WeightRequest[]
+  order?: 'asc' | 'desc'
+  page?: number
+  per_page?: number
+  rating_weights?: WeightRequest[]
+  relations?: 'full' | 'scoring' | 'none'
+  sort_by?: string
+  tags?: string[]
+}
+
+export interface GraphQLRequest {
+  operationName?: string
+  query?: string
+  variables?: Record<string, unknown>
+}
+
+export interface Note {
+  author?: string
+  body?: string
+  created_at?: string
+  id?: number
+  updated_at?: string
+}
+
+export interface NoteRequest {
+  author?: string
+  body: string
+}
+
+export interface NotificationTestRequest {
+  channel?: 'email' | 'slack'
+}
+
+export interface NumericalIndicator {
+  created_at?: string
+  created_by?: string
+  name?: string
+  norm_value?: number
+  updated_at?: string
+  updated_by?: string
+  uuid?: string
+  value?: number
+}
+
+export interface NumericalIndicatorRequest {
+  name: string
+  norm_value: number
+  value: number
+}
+
+export interface NumericalIndicatorUpdateRequest {
+  norm_value: number
+  value: number
+}
+
+export interface PreferencesRequest {
+  default_cluster?: number
+  numerical_weights?: WeightRequest[]
+  rating_weights?: WeightRequest[]
+}
+
+export interface RatingRubricRequest {
+  kind: 'rating' | 'action'
+  norm_score: number
+  score: number
+  term: string
+}
+
+export interface RatingSentiment {
+  created_at?: string
+  created_by?: string
+  name?: string
+  norm_rating_score?: number
+  rating?: string
+  rating_score?: number
+  updated_at?: string
+  updated_by?: string
+  uuid?: string
+}
+
+export interface RatingSentimentRequest {
+  name: string
+  norm_rating_score: number
+  rating: string
+  rating_score: number
+}
+
+export interface RatingSentimentUpdateRequest {
+  norm_rating_score: number
+  rating: string
+  rating_score: number
+}
+
+export interface ScoringConfigDocument {
+  default_weights?: DefaultWeightProfile
+  exported_at?: string
+  indicators?: ScoringIndicators
+  rating_rubric?: RatingRubricRequest[]
+  version?: number
+  weight_profiles?: WeightProfileRequest[]
+}
+
+export interface ScoringConfigRequest {
+  indicators?: ScoringIndicators
+  rating_rubric?: RatingRubricRequest[]
+  version: number
+  weight_profiles?: WeightProfileRequest[]
+}
+
+export interface ScoringIndicators {
+  numerical?: string[]
+  rating?: string[]
+}
+
+export interface StockCreateRequest {
+  action?: string
+  brokerage?: string
+  cluster?: number
+  company: string
+  date: string
+  last_close?: number
+  numerical_indicators?: NumericalIndicatorRequest[]
+  rating_from?: string
+  rating_sentiments?: RatingSentimentRequest[]
+  rating_to?: string
+  target_delta?: number
+  target_from?: number
+  target_to?: number
+  ticker: string
+}
+
+export interface StockDataPoint {
+  action?: string
+  brokerage?: string
+  cluster?: number
+  company?: string
+  contributions?: Record<string, unknown>
+  created_at?: string
+  created_by?: string
+  dataset_version_id?: number
+  date?: string
+  final_score?: number
+  last_close?: number
+  notes?: Note[]
+  numerical_indicators?: NumericalIndicator[]
+  rating_from?: string
+  rating_sentiments?: RatingSentiment[]
+  rating_to?: string
+  tags?: Tag[]
+  target_delta?: number
+  target_from?: number
+  target_to?: number
+  ticker?: string
+  updated_at?: string
+  updated_by?: string
+  uuid?: string
+  weighted_score?: number
+}
+
+export interface StockExtractRequest {
+  max_pages: number
+}
+
+export interface StockUpdateRequest {
+  action?: string
+  brokerage?: string
+  cluster?: number
+  company?: string
+  date?: string
+  id: number
+  last_close?: number
+  numerical_indicators?: NumericalIndicatorRequest[]
+  rating_from?: string
+  rating_sentiments?: RatingSentimentRequest[]
+  rating_to?: string
+  target_delta?: number
+  target_from?: number
+  target_to?: number
+  ticker?: string
+}
+
+export interface Tag {
+  created_at?: string
+  id?: number
+  name?: string
+}
+
+export interface TagRequest {
+  tags: string[]
+}
+
+export interface TickerStats {
+  count?: number
+  earliest_time?: string
+  latest_time?: string
+  ticker?: string
+}
+
+export interface WebhookSubscriptionRequest {
+  events: string[]
+  url: string
+}
+
+export interface WeightProfileRequest {
+  default_cluster?: number
+  numerical_weights?: WeightRequest[]
+  rating_weights?: WeightRequest[]
+  user_id: string
+}
+
+export interface WeightRequest {
+  indicator_name: string
+  weight?: number
+}
+
+export interface GetAdminUsageParams {
+  /** Number of days to report, 1-90 (default: 7) */
+  days?: number
+  /** Busiest endpoints listed per client, 1-50 (default: 5) */
+  top?: number
+}
+
+export interface PostArchiveParams {
+  /** Archive records dated before this day (YYYY-MM-DD) */
+  before?: string
+  /** Archive records of this cluster */
+  cluster?: number
+  /** Archive records last written by this dataset version */
+  dataset?: number
+  /** Archive records last written by a dataset version older than this one */
+  dataset_before?: number
+}
+
+export interface GetArchiveByTickerParams {
+  /** Stock ticker symbol */
+  ticker: string
+}
+
+export interface PostDatasetsByVersionRollbackParams {
+  /** Dataset version ID to roll back to */
+  version: number
+}
+
+export interface PostExportsParams {
+  /** Export format: csv | xlsx | ndjson (default: csv) */
+  format?: string
+}
+
+export interface GetExportsByIdParams {
+  /** Export job ID */
+  id: number
+}
+
+export interface GetExportsByIdDownloadParams {
+  /** Export job ID */
+  id: number
+  /** Link expiry (unix seconds) */
+  expires: number
+  /** Link signature */
+  signature: string
+}
+
+export interface GetGraphqlParams {
+  /** GraphQL query */
+  query: string
+  /** JSON-encoded query variables */
+  variables?: string
+  /** Operation to run when the query defines several */
+  operationName?: string
+}
+
+export interface PostGraphqlParams {
+  body: GraphQLRequest
+}
+
+export interface GetJobsByIdParams {
+  /** Job ID */
+  id: number
+}
+
+export interface PutMePreferencesParams {
+  body: PreferencesRequest
+}
+
+export interface PostNotificationsTestParams {
+  body?: NotificationTestRequest
+}
+
+export interface GetRatingRubricParams {
+  /** Only entries of this kind (rating or action) */
+  kind?: string
+}
+
+export interface PostRatingRubricParams {
+  body: RatingRubricRequest
+}
+
+export interface PutRatingRubricByIdParams {
+  /** Rubric entry ID */
+  id: number
+  body: RatingRubricRequest
+}
+
+export interface DeleteRatingRubricByIdParams {
+  /** Rubric entry ID */
+  id: number
+}
+
+export interface PostScoringConfigImportParams {
+  /** Delete rubric entries and weight profiles missing from the document (default: false) */
+  replace?: boolean
+  body: ScoringConfigRequest
+}
+
+export interface GetSearchParams {
+  /** Search text */
+  q: string
+  /** Maximum number of results, 1-50 (default: 10) */
+  limit?: number
+}
+
+export interface PostStocksParams {
+  body: StockCreateRequest
+}
+
+export interface GetStocksActionByActionParams {
+  /** Action value */
+  action: string
+  /** Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+  /** Page number (default: 1) */
+  page?: number
+  /** Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it) */
+  per_page?: number
+  /** Only include data points last written by this dataset version */
+  dataset?: number
+}
+
+export interface GetStocksAnalyticsDispersionParams {
+  /** Restrict to one cluster (default: all clusters) */
+  cluster?: number
+}
+
+export interface GetStocksAnalyticsHeatmapParams {
+  /** Column crossed with cluster: action | rating_to (default: action) */
+  dimension?: string
+}
+
+export interface PostStocksBatchParams {
+  body: StockCreateRequest[]
+}
+
+export interface PutStocksClusterParams {
+  body: BulkClusterAssignmentRequest
+}
+
+export interface GetStocksClusterByClusterParams {
+  /** Cluster id */
+  cluster: number
+  /** Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+  /** Page number (default: 1) */
+  page?: number
+  /** Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it) */
+  per_page?: number
+  /** Only include data points last written by this dataset version */
+  dataset?: number
+}
+
+export interface GetStocksClusterByClusterFilterParams {
+  /** Cluster id */
+  cluster: number
+  /** Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected). Note: company and date are excluded. */
+  grouping_column?: string
+  /** Grouping value to filter by (required if grouping_column is not none) */
+  grouping_value?: string
+  /** Sort by column: ticker | action | date | company | target_to | target_from | rating_to | rating_from | final_score | weighted_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+  /** Page number (default: 1) */
+  page?: number
+  /** Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it) */
+  per_page?: number
+  /** JSON array of numerical weights: [{\ */
+  numerical_weights?: string
+  /** JSON array of rating weights: [{\ */
+  rating_weights?: string
+  /** Only include stocks carrying any of these tags */
+  tags?: string[]
+  /** Only include stocks with final_score at least this value */
+  min_final_score?: number
+  /** Only include stocks with final_score at most this value */
+  max_final_score?: number
+  /** Only include stocks with target_to at least this value */
+  min_target_to?: number
+  /** Only include stocks with target_to at most this value */
+  max_target_to?: number
+  /** Only include stocks with target_from at least this value */
+  min_target_from?: number
+  /** Only include stocks with target_from at most this value */
+  max_target_from?: number
+  /** Only include stocks with target_delta at least this value */
+  min_target_delta?: number
+  /** Only include stocks with target_delta at most this value */
+  max_target_delta?: number
+  /** Only include stocks with last_close at least this value */
+  min_last_close?: number
+  /** Only include stocks with last_close at most this value */
+  max_last_close?: number
+  /** Child rows loaded per stock: full | scoring (only name and normalized score/value) | none (default: full) */
+  relations?: string
+  /** With weights, add a contributions object to each row mapping every weighted name to weight x normalized value; the values add up to weighted_score (default: false) */
+  contributions?: boolean
+  /** Return one record per grouping value (group_value, count, avg_final_score, avg_weighted_score) instead of rows; requires grouping_column (default: false) */
+  aggregate?: boolean
+  /** Admin only: return the EXPLAIN ANALYZE plan of the page query (data.sql, data.plan) instead of rows; not combinable with aggregate (default: false) */
+  explain?: boolean
+}
+
+export interface PostStocksClusterByClusterFilterParams {
+  /** Cluster id */
+  cluster: number
+  /** Admin only: return the EXPLAIN ANALYZE plan of the page query instead of rows (default: false) */
+  explain?: boolean
+  body: FilterRequest
+}
+
+export interface GetStocksClusterByClusterFilterExportParams {
+  /** Cluster id */
+  cluster: number
+  /** Export format: csv | xlsx (default: csv) */
+  format?: string
+  /** Grouping column: none | action | rating_to | rating_from (default: none; other values are rejected) */
+  grouping_column?: string
+  /** Grouping value to filter by (required if grouping_column is not none) */
+  grouping_value?: string
+  /** Sort by column; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+  /** JSON array of numerical weights: [{\ */
+  numerical_weights?: string
+  /** JSON array of rating weights: [{\ */
+  rating_weights?: string
+  /** Only include stocks carrying any of these tags */
+  tags?: string[]
+  /** Only include stocks with final_score at least this value */
+  min_final_score?: number
+  /** Only include stocks with final_score at most this value */
+  max_final_score?: number
+  /** Only include stocks with target_to at least this value */
+  min_target_to?: number
+  /** Only include stocks with target_to at most this value */
+  max_target_to?: number
+  /** Only include stocks with target_from at least this value */
+  min_target_from?: number
+  /** Only include stocks with target_from at most this value */
+  max_target_from?: number
+  /** Only include stocks with target_delta at least this value */
+  min_target_delta?: number
+  /** Only include stocks with target_delta at most this value */
+  max_target_delta?: number
+  /** Only include stocks with last_close at least this value */
+  min_last_close?: number
+  /** Only include stocks with last_close at most this value */
+  max_last_close?: number
+}
+
+export interface PostStocksClusterByClusterFilterExportParams {
+  /** Cluster id */
+  cluster: number
+  /** Export format: csv | xlsx (default: csv) */
+  format?: string
+  body: FilterRequest
+}
+
+export interface GetStocksClusterByClusterUniqueByColumnNameParams {
+  /** Cluster id */
+  cluster: number
+  /** Column name: action | rating_to | rating_from */
+  column_name: string
+}
+
+export interface GetStocksCompanyByCompanyParams {
+  /** Company name */
+  company: string
+  /** Sort by column: ticker | action | date | company | cluster | target_to | target_from | target_delta | last_close | rating_to | rating_from | final_score; a comma-separated list of column [asc|desc] keys (e.g. final_score desc, date desc, ticker asc) sorts by each in turn, keys without a direction use order (default: date) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+  /** Page number (default: 1) */
+  page?: number
+  /** Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it) */
+  per_page?: number
+  /** Only include data points last written by this dataset version */
+  dataset?: number
+}
+
+export interface GetStocksExportParams {
+  /** Export format: csv | xlsx | ndjson (default: csv) */
+  format?: string
+  /** Only export stocks of this cluster */
+  cluster?: number
+  /** Only export stocks of this dataset version */
+  dataset?: number
+  /** Only export stocks recorded on or after this date (YYYY-MM-DD) */
+  from?: string
+  /** Only export stocks recorded on or before this date (YYYY-MM-DD) */
+  to?: string
+}
+
+export interface PostStocksExtractParams {
+  body: StockExtractRequest
+}
+
+export interface GetStocksExtractPagesParams {
+  /** Only include entries with this status: success | error | retried */
+  status?: string
+  /** Page number (default: 1) */
+  page?: number
+  /** Items per page (default: 20, max: 200 unless SERVER_MAX_PER_PAGE overrides it) */
+  per_page?: number
+  /** Sort by column: recorded_at | page_number; a comma-separated list of column [asc|desc] keys (e.g. page_number asc, recorded_at desc) sorts by each in turn, keys without a direction use order (default: recorded_at) */
+  sort_by?: string
+  /** Sort order: asc | desc (default: desc) */
+  order?: string
+}
+
+export interface GetStocksExtractByJobIdEventsParams {
+  /** Extraction job ID */
+  job_id: number
+}
+
+export interface PostStocksImportParams {
+  /** CSV file */
+  file: Blob
+  /** Import even if this exact file was imported before (default: false) */
+  force?: boolean
+}
+
+export interface PostStocksImportEnrichedParams {
+  /** Import even if this exact file was imported before (default: false) */
+  force?: boolean
+}
+
+export interface GetStocksMoversParams {
+  /** Metric to rank by: target_delta | final_score (default: target_delta) */
+  metric?: string
+  /** up (largest positive) | down (largest negative) (default: up) */
+  direction?: string
+  /** Earliest date (YYYY-MM-DD, inclusive) */
+  from?: string
+  /** Latest date (YYYY-MM-DD, inclusive) */
+  to?: string
+  /** Number of stocks to return, 1-100 (default: 20) */
+  limit?: number
+}
+
+export interface DeleteStocksOrphansParams {
+  /** Also delete the stocks without sentiments or without indicators (default: false) */
+  incomplete?: boolean
+  /** Only count the rows and issue a confirmation token (default: false) */
+  dry_run?: boolean
+  /** Token from the dry run (required unless dry_run=true) */
+  xConfirmationToken?: string
+}
+
+export interface DeleteStocksPurgeParams {
+  /** Cluster id */
+  cluster?: number
+  /** Dataset version ID */
+  dataset?: number
+  /** Earliest record date, inclusive (YYYY-MM-DD) */
+  from?: string
+  /** Latest record date, inclusive (YYYY-MM-DD) */
+  to?: string
+  /** Only count the matching rows and issue a confirmation token (default: false) */
+  dry_run?: boolean
+  /** Token from the dry run (required unless dry_run=true) */
+  xConfirmationToken?: string
+}
+
+export interface GetStocksStatsByTickerParams {
+  /** Stock ticker symbol */
+  ticker: string
+}
+
+export interface DeleteStocksTablesParams {
+  /** Only count the rows and issue a confirmation token (default: false) */
+  dry_run?: boolean
+  /** Token from the dry run (required unless dry_run=true) */
+  xConfirmationToken?: string
+}
+
+export interface GetStocksTickerByTickerParams {
+  /** Stock ticker symbol */
+  ticker: string
+}
+
+export interface GetStocksTickerByTickerConsensusParams {
+  /** Stock ticker symbol */
+  ticker: string
+}
+
+export interface GetStocksTickerByTickerHistoryParams {
+  /** Stock ticker symbol */
+  ticker: string
+  /** Earliest date (YYYY-MM-DD, inclusive) */
+  from?: string
+  /** Latest date (YYYY-MM-DD, inclusive) */
+  to?: string
+}
+
+export interface GetStocksTickerByTickerMovingAveragesParams {
+  /** Stock ticker symbol */
+  ticker: string
+  /** Comma-separated indicator names (default: all indicators) */
+  indicators?: string
+}
+
+export interface GetStocksTickerByTickerSimilarParams {
+  /** Stock ticker symbol */
+  ticker: string
+  /** Number of neighbors (default: 10, max: 100) */
+  limit?: number
+}
+
+export interface GetStocksByIdParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Related resources to embed: notes */
+  include?: string
+}
+
+export interface PutStocksByIdParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** ETag the stock must still have */
+  ifMatch?: string
+  /** HTTP date the stock must not have been modified after */
+  ifUnmodifiedSince?: string
+  body: StockUpdateRequest
+}
+
+export interface DeleteStocksByIdParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** ETag the stock must still have */
+  ifMatch?: string
+  /** HTTP date the stock must not have been modified after */
+  ifUnmodifiedSince?: string
+}
+
+export interface PutStocksByIdClusterParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  body: ClusterAssignmentRequest
+}
+
+export interface GetStocksByIdClusterHistoryParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+}
+
+export interface GetStocksByIdIndicatorsParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+}
+
+export interface PostStocksByIdIndicatorsParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  body: NumericalIndicatorRequest
+}
+
+export interface PutStocksByIdIndicatorsByNameParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Indicator name */
+  name: string
+  body: NumericalIndicatorUpdateRequest
+}
+
+export interface DeleteStocksByIdIndicatorsByNameParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Indicator name */
+  name: string
+}
+
+export interface GetStocksByIdNotesParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+}
+
+export interface PostStocksByIdNotesParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  body: NoteRequest
+}
+
+export interface PutStocksByIdNotesByNoteIdParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Note ID */
+  note_id: number
+  body: NoteRequest
+}
+
+export interface DeleteStocksByIdNotesByNoteIdParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Note ID */
+  note_id: number
+}
+
+export interface GetStocksByIdPercentileParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Cluster to rank against (default: the stock's own cluster) */
+  cluster?: number
+}
+
+export interface GetStocksByIdSentimentsParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+}
+
+export interface PostStocksByIdSentimentsParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  body: RatingSentimentRequest
+}
+
+export interface PutStocksByIdSentimentsByNameParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Sentiment name */
+  name: string
+  body: RatingSentimentUpdateRequest
+}
+
+export interface DeleteStocksByIdSentimentsByNameParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Sentiment name */
+  name: string
+}
+
+export interface PostStocksByIdTagsParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  body: TagRequest
+}
+
+export interface DeleteStocksByIdTagsByTagParams {
+  /** Stock UUID (or numeric ID during the transition period) */
+  id: string
+  /** Tag name */
+  tag: string
+}
+
+export interface PostWebhooksParams {
+  body: WebhookSubscriptionRequest
+}
+
+export interface DeleteWebhooksByIdParams {
+  /** Subscription ID */
+  id: number
+}
+
+/** JSON response envelope ({ data, count, message, ... }) */
+export type ApiResponse = Record<string, unknown>
+
+/** Error response of the API; body is the { error, details } envelope when the server sent one */
+export class ApiError extends Error {
+  readonly status: number
+  readonly body: unknown
+
+  constructor(status: number, statusText: string, body: unknown) {
+    super(`API request failed: ${status} ${statusText}`)
+    this.name = 'ApiError'
+    this.status = status
+    this.body = body
+  }
+}
+
+export interface ApiClientOptions {
+  /** Backend origin, e.g. http://localhost:8887 */
+  baseUrl: string
+  /** Headers sent with every request (e.g. Authorization or X-API-Key credentials, X-Actor) */
+  headers?: Record<string, string>
+  fetch?: typeof fetch
+}
+
+type QueryValue = string | number | boolean | string[] | undefined | null
+
+interface RequestOptions {
+  query?: Record<string, QueryValue>
+  headers?: Record<string, string | undefined>
+  body?: unknown
+  /** multipart/form-data fields (file uploads); sent instead of body */
+  form?: Record<string, Blob | string | undefined>
+  binary?: boolean
+}
+
+export class ApiClient {
+  private readonly baseUrl: string
+  private readonly headers: Record<string, string>
+  private readonly fetchFn: typeof fetch
+
+  constructor(options: ApiClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, '')
+    this.headers = options.headers ?? {}
+    this.fetchFn = options.fetch ?? globalThis.fetch.bind(globalThis)
+  }
+
+  private async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const search = new URLSearchParams()
+    for (const [key, value] of Object.entries(options.query ?? {})) {
+      if (value === undefined || value === null || value === '') {
+        continue
+      }
+      if (Array.isArray(value)) {
+        value.forEach((item) => search.append(key, item))
+      } else {
+        search.set(key, String(value))
+      }
+    }
+    const headers: Record<string, string> = { ...this.headers }
+    for (const [key, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined) {
+        headers[key] = value
+      }
+    }
+    let body: BodyInit | undefined
+    if (options.form !== undefined) {
+      // fetch sets the multipart Content-Type with its boundary
+      const form = new FormData()
+      for (const [key, value] of Object.entries(options.form)) {
+        if (value !== undefined) {
+          form.append(key, value)
+        }
+      }
+      body = form
+    } else if (options.body !== undefined) {
+      headers['Content-Type'] = 'application/json'
+      body = JSON.stringify(options.body)
+    }
+
+    const qs = search.toString()
+    const response = await this.fetchFn(`${this.baseUrl}${path}${qs ? `?${qs}` : ''}`, {
+      method,
+      headers,
+      body,
+    })
+    if (!response.ok) {
+      const body = await response.json().catch(() => undefined)
+      throw new ApiError(response.status, response.statusText, body)
+    }
+    if (options.binary) {
+      return (await response.blob()) as T
+    }
+    if (response.status === 204) {
+      return undefined as T
+    }
+    return (await response.json()) as T
+  }
+
+  /** Get database diagnostics (GET /api/v1/admin/diagnostics) */
+  getAdminDiagnostics(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/admin/diagnostics')
+  }
+
+  /** Get API usage per client (GET /api/v1/admin/usage) */
+  getAdminUsage(params: GetAdminUsageParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/admin/usage', {
+      query: {
+        days: params.days,
+        top: params.top,
+      },
+    })
+  }
+
+  /** Archive old data points (POST /api/v1/archive) */
+  postArchive(params: PostArchiveParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/archive', {
+      query: {
+        before: params.before,
+        cluster: params.cluster,
+        dataset: params.dataset,
+        dataset_before: params.dataset_before,
+      },
+    })
+  }
+
+  /** Get the archived records of a ticker (GET /api/v1/archive/{ticker}) */
+  getArchiveByTicker(params: GetArchiveByTickerParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/archive/${encodeURIComponent(String(params.ticker))}`)
+  }
+
+  /** List dataset versions (GET /api/v1/datasets) */
+  getDatasets(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/datasets')
+  }
+
+  /** Roll back to a dataset version (POST /api/v1/datasets/{version}/rollback) */
+  postDatasetsByVersionRollback(params: PostDatasetsByVersionRollbackParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/datasets/${encodeURIComponent(String(params.version))}/rollback`)
+  }
+
+  /** Start an export job (POST /api/v1/exports) */
+  postExports(params: PostExportsParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/exports', {
+      query: { format: params.format },
+    })
+  }
+
+  /** Get an export job (GET /api/v1/exports/{id}) */
+  getExportsById(params: GetExportsByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/exports/${encodeURIComponent(String(params.id))}`)
+  }
+
+  /** Download an export file (GET /api/v1/exports/{id}/download) */
+  getExportsByIdDownload(params: GetExportsByIdDownloadParams): Promise<Blob> {
+    return this.request<Blob>('GET', `/api/v1/exports/${encodeURIComponent(String(params.id))}/download`, {
+      query: {
+        expires: params.expires,
+        signature: params.signature,
+      },
+      binary: true,
+    })
+  }
+
+  /** Run a GraphQL query (GET /api/v1/graphql) */
+  getGraphql(params: GetGraphqlParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/graphql', {
+      query: {
+        query: params.query,
+        variables: params.variables,
+        operationName: params.operationName,
+      },
+    })
+  }
+
+  /** Run a GraphQL query (POST /api/v1/graphql) */
+  postGraphql(params: PostGraphqlParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/graphql', {
+      body: params.body,
+    })
+  }
+
+  /** Get a background job (GET /api/v1/jobs/{id}) */
+  getJobsById(params: GetJobsByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/jobs/${encodeURIComponent(String(params.id))}`)
+  }
+
+  /** Get my dashboard preferences (GET /api/v1/me/preferences) */
+  getMePreferences(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/me/preferences')
+  }
+
+  /** Save my dashboard preferences (PUT /api/v1/me/preferences) */
+  putMePreferences(params: PutMePreferencesParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', '/api/v1/me/preferences', {
+      body: params.body,
+    })
+  }
+
+  /** Send a test notification (POST /api/v1/notifications/test) */
+  postNotificationsTest(params: PostNotificationsTestParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/notifications/test', {
+      body: params.body,
+    })
+  }
+
+  /** Get the OpenAPI document (GET /api/v1/openapi.json) */
+  getOpenapiJson(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/openapi.json')
+  }
+
+  /** List the rating rubric (GET /api/v1/rating-rubric) */
+  getRatingRubric(params: GetRatingRubricParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/rating-rubric', {
+      query: { kind: params.kind },
+    })
+  }
+
+  /** Add a rubric term (POST /api/v1/rating-rubric) */
+  postRatingRubric(params: PostRatingRubricParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/rating-rubric', {
+      body: params.body,
+    })
+  }
+
+  /** Edit a rubric term (PUT /api/v1/rating-rubric/{id}) */
+  putRatingRubricById(params: PutRatingRubricByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/rating-rubric/${encodeURIComponent(String(params.id))}`, {
+      body: params.body,
+    })
+  }
+
+  /** Delete a rubric term (DELETE /api/v1/rating-rubric/{id}) */
+  deleteRatingRubricById(params: DeleteRatingRubricByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/rating-rubric/${encodeURIComponent(String(params.id))}`)
+  }
+
+  /** Get JSON Schemas for request bodies (GET /api/v1/schema) */
+  getSchema(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/schema')
+  }
+
+  /** Export the scoring configuration (GET /api/v1/scoring-config) */
+  getScoringConfig(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/scoring-config')
+  }
+
+  /** Import a scoring configuration (POST /api/v1/scoring-config/import) */
+  postScoringConfigImport(params: PostScoringConfigImportParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/scoring-config/import', {
+      query: { replace: params.replace },
+      body: params.body,
+    })
+  }
+
+  /** Search tickers and companies (GET /api/v1/search) */
+  getSearch(params: GetSearchParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/search', {
+      query: {
+        q: params.q,
+        limit: params.limit,
+      },
+    })
+  }
+
+  /** Get all stocks (GET /api/v1/stocks) */
+  getStocks(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks')
+  }
+
+  /** Create a new stock (POST /api/v1/stocks) */
+  postStocks(params: PostStocksParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks', {
+      body: params.body,
+    })
+  }
+
+  /** Get stocks by action (GET /api/v1/stocks/action/{action}) */
+  getStocksActionByAction(params: GetStocksActionByActionParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/action/${encodeURIComponent(String(params.action))}`, {
+      query: {
+        sort_by: params.sort_by,
+        order: params.order,
+        page: params.page,
+        per_page: params.per_page,
+        dataset: params.dataset,
+      },
+    })
+  }
+
+  /** Get unique actions (GET /api/v1/stocks/actions) */
+  getStocksActions(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/actions')
+  }
+
+  /** Get per-cluster dispersion statistics (GET /api/v1/stocks/analytics/dispersion) */
+  getStocksAnalyticsDispersion(params: GetStocksAnalyticsDispersionParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/analytics/dispersion', {
+      query: { cluster: params.cluster },
+    })
+  }
+
+  /** Get the cluster heatmap (GET /api/v1/stocks/analytics/heatmap) */
+  getStocksAnalyticsHeatmap(params: GetStocksAnalyticsHeatmapParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/analytics/heatmap', {
+      query: { dimension: params.dimension },
+    })
+  }
+
+  /** Create stocks in bulk (POST /api/v1/stocks/batch) */
+  postStocksBatch(params: PostStocksBatchParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/batch', {
+      body: params.body,
+    })
+  }
+
+  /** Move tickers to a cluster (PUT /api/v1/stocks/cluster) */
+  putStocksCluster(params: PutStocksClusterParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', '/api/v1/stocks/cluster', {
+      body: params.body,
+    })
+  }
+
+  /** Get stocks by cluster (GET /api/v1/stocks/cluster/{cluster}) */
+  getStocksClusterByCluster(params: GetStocksClusterByClusterParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}`, {
+      query: {
+        sort_by: params.sort_by,
+        order: params.order,
+        page: params.page,
+        per_page: params.per_page,
+        dataset: params.dataset,
+      },
+    })
+  }
+
+  /** Filter stocks by cluster with grouping, pagination, sorting, and weighted scoring (GET /api/v1/stocks/cluster/{cluster}/filter) */
+  getStocksClusterByClusterFilter(params: GetStocksClusterByClusterFilterParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}/filter`, {
+      query: {
+        grouping_column: params.grouping_column,
+        grouping_value: params.grouping_value,
+        sort_by: params.sort_by,
+        order: params.order,
+        page: params.page,
+        per_page: params.per_page,
+        numerical_weights: params.numerical_weights,
+        rating_weights: params.rating_weights,
+        tags: params.tags,
+        min_final_score: params.min_final_score,
+        max_final_score: params.max_final_score,
+        min_target_to: params.min_target_to,
+        max_target_to: params.max_target_to,
+        min_target_from: params.min_target_from,
+        max_target_from: params.max_target_from,
+        min_target_delta: params.min_target_delta,
+        max_target_delta: params.max_target_delta,
+        min_last_close: params.min_last_close,
+        max_last_close: params.max_last_close,
+        relations: params.relations,
+        contributions: params.contributions,
+        aggregate: params.aggregate,
+        explain: params.explain,
+      },
+    })
+  }
+
+  /** Filter stocks by cluster (JSON body variant) (POST /api/v1/stocks/cluster/{cluster}/filter) */
+  postStocksClusterByClusterFilter(params: PostStocksClusterByClusterFilterParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}/filter`, {
+      query: { explain: params.explain },
+      body: params.body,
+    })
+  }
+
+  /** Export the filtered result set (GET /api/v1/stocks/cluster/{cluster}/filter/export) */
+  getStocksClusterByClusterFilterExport(params: GetStocksClusterByClusterFilterExportParams): Promise<Blob> {
+    return this.request<Blob>('GET', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}/filter/export`, {
+      query: {
+        format: params.format,
+        grouping_column: params.grouping_column,
+        grouping_value: params.grouping_value,
+        sort_by: params.sort_by,
+        order: params.order,
+        numerical_weights: params.numerical_weights,
+        rating_weights: params.rating_weights,
+        tags: params.tags,
+        min_final_score: params.min_final_score,
+        max_final_score: params.max_final_score,
+        min_target_to: params.min_target_to,
+        max_target_to: params.max_target_to,
+        min_target_from: params.min_target_from,
+        max_target_from: params.max_target_from,
+        min_target_delta: params.min_target_delta,
+        max_target_delta: params.max_target_delta,
+        min_last_close: params.min_last_close,
+        max_last_close: params.max_last_close,
+      },
+      binary: true,
+    })
+  }
+
+  /** Export the filtered result set (JSON body variant) (POST /api/v1/stocks/cluster/{cluster}/filter/export) */
+  postStocksClusterByClusterFilterExport(params: PostStocksClusterByClusterFilterExportParams): Promise<Blob> {
+    return this.request<Blob>('POST', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}/filter/export`, {
+      query: { format: params.format },
+      body: params.body,
+      binary: true,
+    })
+  }
+
+  /** Get unique values for a specified column filtered by cluster (GET /api/v1/stocks/cluster/{cluster}/unique/{column_name}) */
+  getStocksClusterByClusterUniqueByColumnName(params: GetStocksClusterByClusterUniqueByColumnNameParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/cluster/${encodeURIComponent(String(params.cluster))}/unique/${encodeURIComponent(String(params.column_name))}`)
+  }
+
+  /** Get unique clusters (GET /api/v1/stocks/clusters) */
+  getStocksClusters(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/clusters')
+  }
+
+  /** Get cluster centroids (GET /api/v1/stocks/clusters/centroids) */
+  getStocksClustersCentroids(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/clusters/centroids')
+  }
+
+  /** Recompute cluster centroids (POST /api/v1/stocks/clusters/centroids) */
+  postStocksClustersCentroids(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/clusters/centroids')
+  }
+
+  /** Get unique companies (GET /api/v1/stocks/companies) */
+  getStocksCompanies(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/companies')
+  }
+
+  /** Get stocks by company (GET /api/v1/stocks/company/{company}) */
+  getStocksCompanyByCompany(params: GetStocksCompanyByCompanyParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/company/${encodeURIComponent(String(params.company))}`, {
+      query: {
+        sort_by: params.sort_by,
+        order: params.order,
+        page: params.page,
+        per_page: params.per_page,
+        dataset: params.dataset,
+      },
+    })
+  }
+
+  /** Get database statistics (GET /api/v1/stocks/database/stats) */
+  getStocksDatabaseStats(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/database/stats')
+  }
+
+  /** Get the data dictionary (GET /api/v1/stocks/dictionary) */
+  getStocksDictionary(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/dictionary')
+  }
+
+  /** Get allowed action and rating values (GET /api/v1/stocks/enums) */
+  getStocksEnums(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/enums')
+  }
+
+  /** Export every stock (GET /api/v1/stocks/export) */
+  getStocksExport(params: GetStocksExportParams = {}): Promise<Blob> {
+    return this.request<Blob>('GET', '/api/v1/stocks/export', {
+      query: {
+        format: params.format,
+        cluster: params.cluster,
+        dataset: params.dataset,
+        from: params.from,
+        to: params.to,
+      },
+      binary: true,
+    })
+  }
+
+  /** Extract data from API (POST /api/v1/stocks/extract) */
+  postStocksExtract(params: PostStocksExtractParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/extract', {
+      body: params.body,
+    })
+  }
+
+  /** Get the daily upstream request budget (GET /api/v1/stocks/extract/budget) */
+  getStocksExtractBudget(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/extract/budget')
+  }
+
+  /** List extraction page-key history (GET /api/v1/stocks/extract/pages) */
+  getStocksExtractPages(params: GetStocksExtractPagesParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/extract/pages', {
+      query: {
+        status: params.status,
+        page: params.page,
+        per_page: params.per_page,
+        sort_by: params.sort_by,
+        order: params.order,
+      },
+    })
+  }
+
+  /** Replay failed extraction pages (POST /api/v1/stocks/extract/retry-failed) */
+  postStocksExtractRetryFailed(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/extract/retry-failed')
+  }
+
+  /** Stream the progress of an extraction job (GET /api/v1/stocks/extract/{job_id}/events) */
+  getStocksExtractByJobIdEvents(params: GetStocksExtractByJobIdEventsParams): Promise<Blob> {
+    return this.request<Blob>('GET', `/api/v1/stocks/extract/${encodeURIComponent(String(params.job_id))}/events`, {
+      binary: true,
+    })
+  }
+
+  /** Import stock data from an uploaded CSV (POST /api/v1/stocks/import) */
+  postStocksImport(params: PostStocksImportParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/import', {
+      query: { force: params.force },
+      form: { file: params.file },
+    })
+  }
+
+  /** Import enriched stock data from default CSV (POST /api/v1/stocks/import-enriched) */
+  postStocksImportEnriched(params: PostStocksImportEnrichedParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/stocks/import-enriched', {
+      query: { force: params.force },
+    })
+  }
+
+  /** Check referential integrity (GET /api/v1/stocks/integrity) */
+  getStocksIntegrity(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/integrity')
+  }
+
+  /** Get data freshness (GET /api/v1/stocks/meta) */
+  getStocksMeta(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/meta')
+  }
+
+  /** Get the top movers (GET /api/v1/stocks/movers) */
+  getStocksMovers(params: GetStocksMoversParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/movers', {
+      query: {
+        metric: params.metric,
+        direction: params.direction,
+        from: params.from,
+        to: params.to,
+        limit: params.limit,
+      },
+    })
+  }
+
+  /** Delete orphaned sentiments and indicators (DELETE /api/v1/stocks/orphans) */
+  deleteStocksOrphans(params: DeleteStocksOrphansParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', '/api/v1/stocks/orphans', {
+      query: {
+        incomplete: params.incomplete,
+        dry_run: params.dry_run,
+      },
+      headers: { 'X-Confirmation-Token': params.xConfirmationToken },
+    })
+  }
+
+  /** Delete the stocks in a cluster, dataset version or date range (DELETE /api/v1/stocks/purge) */
+  deleteStocksPurge(params: DeleteStocksPurgeParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', '/api/v1/stocks/purge', {
+      query: {
+        cluster: params.cluster,
+        dataset: params.dataset,
+        from: params.from,
+        to: params.to,
+        dry_run: params.dry_run,
+      },
+      headers: { 'X-Confirmation-Token': params.xConfirmationToken },
+    })
+  }
+
+  /** Get stock statistics by ticker (GET /api/v1/stocks/stats/{ticker}) */
+  getStocksStatsByTicker(params: GetStocksStatsByTickerParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/stats/${encodeURIComponent(String(params.ticker))}`)
+  }
+
+  /** Empty all tables (DELETE /api/v1/stocks/tables) */
+  deleteStocksTables(params: DeleteStocksTablesParams = {}): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', '/api/v1/stocks/tables', {
+      query: { dry_run: params.dry_run },
+      headers: { 'X-Confirmation-Token': params.xConfirmationToken },
+    })
+  }
+
+  /** Get tags (GET /api/v1/stocks/tags) */
+  getStocksTags(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/stocks/tags')
+  }
+
+  /** Get stock by ticker (GET /api/v1/stocks/ticker/{ticker}) */
+  getStocksTickerByTicker(params: GetStocksTickerByTickerParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/ticker/${encodeURIComponent(String(params.ticker))}`)
+  }
+
+  /** Get the brokerage consensus for a ticker (GET /api/v1/stocks/ticker/{ticker}/consensus) */
+  getStocksTickerByTickerConsensus(params: GetStocksTickerByTickerConsensusParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/ticker/${encodeURIComponent(String(params.ticker))}/consensus`)
+  }
+
+  /** Get a ticker's score history (GET /api/v1/stocks/ticker/{ticker}/history) */
+  getStocksTickerByTickerHistory(params: GetStocksTickerByTickerHistoryParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/ticker/${encodeURIComponent(String(params.ticker))}/history`, {
+      query: {
+        from: params.from,
+        to: params.to,
+      },
+    })
+  }
+
+  /** Get rolling means of a ticker's indicators (GET /api/v1/stocks/ticker/{ticker}/moving-averages) */
+  getStocksTickerByTickerMovingAverages(params: GetStocksTickerByTickerMovingAveragesParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/ticker/${encodeURIComponent(String(params.ticker))}/moving-averages`, {
+      query: { indicators: params.indicators },
+    })
+  }
+
+  /** Find comparable stocks (GET /api/v1/stocks/ticker/{ticker}/similar) */
+  getStocksTickerByTickerSimilar(params: GetStocksTickerByTickerSimilarParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/ticker/${encodeURIComponent(String(params.ticker))}/similar`, {
+      query: { limit: params.limit },
+    })
+  }
+
+  /** Get stock by ID (GET /api/v1/stocks/{id}) */
+  getStocksById(params: GetStocksByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}`, {
+      query: { include: params.include },
+    })
+  }
+
+  /** Update stock by ID (PUT /api/v1/stocks/{id}) */
+  putStocksById(params: PutStocksByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/stocks/${encodeURIComponent(String(params.id))}`, {
+      headers: {
+        'If-Match': params.ifMatch,
+        'If-Unmodified-Since': params.ifUnmodifiedSince,
+      },
+      body: params.body,
+    })
+  }
+
+  /** Delete stock by ID (DELETE /api/v1/stocks/{id}) */
+  deleteStocksById(params: DeleteStocksByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/stocks/${encodeURIComponent(String(params.id))}`, {
+      headers: {
+        'If-Match': params.ifMatch,
+        'If-Unmodified-Since': params.ifUnmodifiedSince,
+      },
+    })
+  }
+
+  /** Override a stock's cluster (PUT /api/v1/stocks/{id}/cluster) */
+  putStocksByIdCluster(params: PutStocksByIdClusterParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/cluster`, {
+      body: params.body,
+    })
+  }
+
+  /** Get a stock's cluster override history (GET /api/v1/stocks/{id}/cluster/history) */
+  getStocksByIdClusterHistory(params: GetStocksByIdClusterHistoryParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/cluster/history`)
+  }
+
+  /** List stock indicators (GET /api/v1/stocks/{id}/indicators) */
+  getStocksByIdIndicators(params: GetStocksByIdIndicatorsParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/indicators`)
+  }
+
+  /** Add a stock indicator (POST /api/v1/stocks/{id}/indicators) */
+  postStocksByIdIndicators(params: PostStocksByIdIndicatorsParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/indicators`, {
+      body: params.body,
+    })
+  }
+
+  /** Correct a stock indicator (PUT /api/v1/stocks/{id}/indicators/{name}) */
+  putStocksByIdIndicatorsByName(params: PutStocksByIdIndicatorsByNameParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/indicators/${encodeURIComponent(String(params.name))}`, {
+      body: params.body,
+    })
+  }
+
+  /** Delete a stock indicator (DELETE /api/v1/stocks/{id}/indicators/{name}) */
+  deleteStocksByIdIndicatorsByName(params: DeleteStocksByIdIndicatorsByNameParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/indicators/${encodeURIComponent(String(params.name))}`)
+  }
+
+  /** List stock notes (GET /api/v1/stocks/{id}/notes) */
+  getStocksByIdNotes(params: GetStocksByIdNotesParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/notes`)
+  }
+
+  /** Add a stock note (POST /api/v1/stocks/{id}/notes) */
+  postStocksByIdNotes(params: PostStocksByIdNotesParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/notes`, {
+      body: params.body,
+    })
+  }
+
+  /** Edit a stock note (PUT /api/v1/stocks/{id}/notes/{note_id}) */
+  putStocksByIdNotesByNoteId(params: PutStocksByIdNotesByNoteIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/notes/${encodeURIComponent(String(params.note_id))}`, {
+      body: params.body,
+    })
+  }
+
+  /** Delete a stock note (DELETE /api/v1/stocks/{id}/notes/{note_id}) */
+  deleteStocksByIdNotesByNoteId(params: DeleteStocksByIdNotesByNoteIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/notes/${encodeURIComponent(String(params.note_id))}`)
+  }
+
+  /** Get a stock's percentile ranks (GET /api/v1/stocks/{id}/percentile) */
+  getStocksByIdPercentile(params: GetStocksByIdPercentileParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/percentile`, {
+      query: { cluster: params.cluster },
+    })
+  }
+
+  /** List stock sentiments (GET /api/v1/stocks/{id}/sentiments) */
+  getStocksByIdSentiments(params: GetStocksByIdSentimentsParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/sentiments`)
+  }
+
+  /** Add a stock sentiment (POST /api/v1/stocks/{id}/sentiments) */
+  postStocksByIdSentiments(params: PostStocksByIdSentimentsParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/sentiments`, {
+      body: params.body,
+    })
+  }
+
+  /** Correct a stock sentiment (PUT /api/v1/stocks/{id}/sentiments/{name}) */
+  putStocksByIdSentimentsByName(params: PutStocksByIdSentimentsByNameParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('PUT', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/sentiments/${encodeURIComponent(String(params.name))}`, {
+      body: params.body,
+    })
+  }
+
+  /** Delete a stock sentiment (DELETE /api/v1/stocks/{id}/sentiments/{name}) */
+  deleteStocksByIdSentimentsByName(params: DeleteStocksByIdSentimentsByNameParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/sentiments/${encodeURIComponent(String(params.name))}`)
+  }
+
+  /** Tag a stock (POST /api/v1/stocks/{id}/tags) */
+  postStocksByIdTags(params: PostStocksByIdTagsParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/tags`, {
+      body: params.body,
+    })
+  }
+
+  /** Untag a stock (DELETE /api/v1/stocks/{id}/tags/{tag}) */
+  deleteStocksByIdTagsByTag(params: DeleteStocksByIdTagsByTagParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/stocks/${encodeURIComponent(String(params.id))}/tags/${encodeURIComponent(String(params.tag))}`)
+  }
+
+  /** List webhook subscriptions (GET /api/v1/webhooks) */
+  getWebhooks(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/webhooks')
+  }
+
+  /** Subscribe a URL to webhook events (POST /api/v1/webhooks) */
+  postWebhooks(params: PostWebhooksParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('POST', '/api/v1/webhooks', {
+      body: params.body,
+    })
+  }
+
+  /** Delete a webhook subscription (DELETE /api/v1/webhooks/{id}) */
+  deleteWebhooksById(params: DeleteWebhooksByIdParams): Promise<ApiResponse> {
+    return this.request<ApiResponse>('DELETE', `/api/v1/webhooks/${encodeURIComponent(String(params.id))}`)
+  }
+
+  /** Stream stock changes over a WebSocket (GET /api/v1/ws) */
+  getWs(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/api/v1/ws')
+  }
+
+  /** Health check (GET /health) */
+  getHealth(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/health')
+  }
+
+  /** Readiness check (GET /health/ready) */
+  getHealthReady(): Promise<ApiResponse> {
+    return this.request<ApiResponse>('GET', '/health/ready')
+  }
+}
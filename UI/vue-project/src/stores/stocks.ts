@@ -1,7 +1,13 @@
 import { ref, computed } from 'vue'
 import { defineStore } from 'pinia'
 import { apiService } from '@/services/api'
-import type { Stock, IndicatorWeight, UniqueValuesResponse } from '@/types/stock'
+import type {
+  Stock,
+  IndicatorWeight,
+  UniqueValuesResponse,
+  GroupAggregate,
+  DataMeta,
+} from '@/types/stock'
 
 export const useStocksStore = defineStore('stocks', () => {
   // State
@@ -25,6 +31,12 @@ export const useStocksStore = defineStore('stocks', () => {
   const uniqueValuesLoading = ref(false)
   const uniqueValuesError = ref<string | null>(null)
 
+  // Per-group summaries, keyed by grouping value
+  const groupAggregates = ref<Record<string, GroupAggregate>>({})
+
+  // Data freshness ("data as of ...")
+  const dataMeta = ref<DataMeta | null>(null)
+
   // Indicator weights state
   const weights = ref<{
     numerical: Record<string, number>
@@ -130,11 +142,11 @@ export const useStocksStore = defineStore('stocks', () => {
     }
   }
 
-  async function fetchStockById(id: number) {
+  async function fetchStockById(uuid: string) {
     loading.value = true
     error.value = null
     try {
-      selectedStock.value = await apiService.getStockById(id)
+      selectedStock.value = await apiService.getStockById(uuid)
     } catch (err) {
       error.value = err instanceof Error ? err.message : 'Failed to fetch stock'
       selectedStock.value = null
@@ -242,6 +254,28 @@ export const useStocksStore = defineStore('stocks', () => {
     }
   }
 
+  // Fetch per-group summaries for a grouping column within a cluster; the groups still render
+  // without them if the request fails
+  async function fetchGroupAggregates(cluster: number, groupingColumn: string) {
+    try {
+      const response = await apiService.getGroupAggregates(cluster, groupingColumn)
+      groupAggregates.value = Object.fromEntries(
+        response.data.map((aggregate) => [aggregate.group_value, aggregate]),
+      )
+    } catch {
+      groupAggregates.value = {}
+    }
+  }
+
+  // Fetch when the data was last refreshed; the freshness chip is hidden if the request fails
+  async function fetchDataMeta() {
+    try {
+      dataMeta.value = await apiService.getDataMeta()
+    } catch {
+      dataMeta.value = null
+    }
+  }
+
   return {
     // State
     stocks,
@@ -257,12 +291,15 @@ export const useStocksStore = defineStore('stocks', () => {
     uniqueValues,
     uniqueValuesLoading,
     uniqueValuesError,
+    groupAggregates,
+    dataMeta,
     weights,
     // Computed
     hasStocks,
     hasSelectedStock,
     // Actions
     fetchAllStocks,
+    fetchDataMeta,
     fetchStocksByAction,
     fetchStocksByCluster,
     fetchFilteredStocksByCluster,
@@ -273,6 +310,7 @@ export const useStocksStore = defineStore('stocks', () => {
     fetchClusters,
     fetchCompanies,
     fetchUniqueValues,
+    fetchGroupAggregates,
     setSelectedStock,
     clearFilters,
     updateWeight,
@@ -1,6 +1,5 @@
 export interface RatingSentiment {
-  id: number
-  stock_data_point_id: number
+  uuid: string
   name: string
   rating: string
   rating_score: number
@@ -10,8 +9,7 @@ export interface RatingSentiment {
 }
 
 export interface NumericalIndicator {
-  id: number
-  stock_data_point_id: number
+  uuid: string
   name: string
   value: number
   norm_value: number
@@ -20,7 +18,7 @@ export interface NumericalIndicator {
 }
 
 export interface Stock {
-  id: number
+  uuid: string
   ticker: string
   action: string
   date: string
@@ -33,6 +31,7 @@ export interface Stock {
   rating_to?: string
   rating_from?: string
   weighted_score?: number
+  contributions?: Record<string, number>
   created_at: string
   updated_at: string
   rating_sentiments: RatingSentiment[]
@@ -46,13 +45,17 @@ export interface StocksResponse {
 
 export interface FilteredStocksResponse {
   data: Stock[]
+  count?: number
   grouping_column?: string
   grouping_value?: string
+  has_next?: boolean
   order?: string
   page?: number
   per_page?: number
   sort_by?: string
   total_count: number
+  total_pages?: number
+  weights_hash?: string
 }
 
 export interface StocksListResponse {
@@ -71,6 +74,32 @@ export interface UniqueValuesResponse {
   values: string[]
 }
 
+export interface GroupAggregate {
+  group_value: string
+  count: number
+  avg_final_score: number | null
+  avg_weighted_score?: number | null
+}
+
+export interface GroupAggregatesResponse {
+  data: GroupAggregate[]
+  count: number
+  grouping_column: string
+  weights_hash?: string
+}
+
+// When the data was last imported or extracted and whether it is older than the server threshold
+export interface DataMeta {
+  last_imported_at: string | null
+  last_extracted_at: string | null
+  data_as_of: string | null
+  dataset_version: number | null
+  total_rows: number
+  staleness_hours: number | null
+  stale_after_hours: number
+  stale: boolean
+}
+
 export interface SilhouetteStats {
   mean: number
   min: number